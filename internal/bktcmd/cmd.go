@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/alessandro308/bitbucket-cli/internal/build"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/alias"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/extension"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/factory"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/root"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
 )
 
 // Main initialises CLI dependencies and executes the root command.
@@ -38,25 +42,57 @@ func Main() int {
 	}
 	rootCmd.SetContext(ctx)
 
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
-		var exitErr *cmdutil.ExitError
-		if errors.As(err, &exitErr) {
-			if exitErr.Msg != "" {
-				_, _ = fmt.Fprintln(ios.ErrOut, exitErr.Msg)
-			}
-			return exitErr.Code
+	args := os.Args[1:]
+	if cfg, err := f.ResolveConfig(); err == nil && len(cfg.Aliases) > 0 {
+		expanded, script, err := alias.Expand(rootCmd, cfg.Aliases, args)
+		if err != nil {
+			_, _ = fmt.Fprintf(ios.ErrOut, "Error: %v\n", err)
+			return 1
 		}
-		// ErrPending: checks still pending (e.g., timeout hit) - exit code 8
-		if errors.Is(err, cmdutil.ErrPending) {
-			return 8
+		if script != "" {
+			return exitCode(ios.ErrOut, f.ExecutableName, alias.RunShell(rootCmd, f, args[0], script, expanded))
 		}
-		// ErrSilent: failure without message - exit code 1
-		if errors.Is(err, cmdutil.ErrSilent) {
-			return 1
+		args = expanded
+	}
+	if handled, err := extension.Dispatch(rootCmd, f, args); handled {
+		return exitCode(ios.ErrOut, f.ExecutableName, err)
+	}
+	rootCmd.SetArgs(cmdutil.ExpandJSONFieldArgs(args))
+
+	err = rootCmd.ExecuteContext(ctx)
+	_ = f.StopPager()
+	return exitCode(ios.ErrOut, f.ExecutableName, err)
+}
+
+// exitCode reports err and maps it to the process exit status.
+func exitCode(errOut io.Writer, executable string, err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *cmdutil.ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.Msg != "" {
+			_, _ = fmt.Fprintln(errOut, exitErr.Msg)
 		}
-		_, _ = fmt.Fprintf(ios.ErrOut, "Error: %v\n", err)
+		return exitErr.Code
+	}
+	// ErrPending: checks still pending (e.g., timeout hit) - exit code 8
+	if errors.Is(err, cmdutil.ErrPending) {
+		return 8
+	}
+	// ErrSilent: failure without message - exit code 1
+	if errors.Is(err, cmdutil.ErrSilent) {
 		return 1
 	}
-
-	return 0
+	// Deadlines surfacing outside the HTTP client arrive as a bare
+	// "context deadline exceeded"; report them in the same terms.
+	if errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, httpx.ErrTimeout) {
+		err = errors.New("timed out waiting for Bitbucket")
+	}
+	_, _ = fmt.Fprintf(errOut, "Error: %v\n", err)
+	if hint := cmdutil.ErrorHint(err, executable); hint != "" {
+		_, _ = fmt.Fprintf(errOut, "Hint: %s\n", hint)
+	}
+	return 1
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -17,17 +18,69 @@ var (
 	ErrContextNotFound = errors.New("context not found")
 	// ErrHostNotFound is returned when a requested host entry is missing.
 	ErrHostNotFound = errors.New("host not found")
+	// ErrProfileNotFound is returned when a requested credential profile is missing.
+	ErrProfileNotFound = errors.New("profile not found")
 )
 
+// DefaultProfile names the identity stored directly on a Host.
+const DefaultProfile = "default"
+
 // Config models persisted CLI state.
 type Config struct {
 	Version       int                 `yaml:"version"`
 	ActiveContext string              `yaml:"active_context,omitempty"`
 	Contexts      map[string]*Context `yaml:"contexts,omitempty"`
 	Hosts         map[string]*Host    `yaml:"hosts,omitempty"`
+	// Aliases maps a command shorthand to its expansion, e.g. "co" to
+	// "pr checkout". Expansions starting with "!" run through the shell.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+	// Timeout is the default deadline for a command's API calls, as a Go
+	// duration (e.g. "45s"); the global --timeout flag overrides it.
+	Timeout string `yaml:"timeout,omitempty"`
+	// Pager set to "never" turns off paging of long list, view and diff
+	// output; the pager program itself comes from BKT_PAGER or PAGER.
+	Pager string `yaml:"pager,omitempty"`
+	// Workspace and Project are fallbacks for contexts that name neither a
+	// Cloud workspace nor a Data Center project key.
+	Workspace string `yaml:"workspace,omitempty"`
+	Project   string `yaml:"project,omitempty"`
+	// MergeStrategy is the default --strategy for "pr merge".
+	MergeStrategy string `yaml:"merge_strategy,omitempty"`
+	// Editor is the program used to compose text; BKT_EDITOR overrides it
+	// and it overrides GIT_EDITOR, VISUAL and EDITOR.
+	Editor string `yaml:"editor,omitempty"`
+	// Jira configures Jira issue key detection in "pr create".
+	Jira *Jira `yaml:"jira,omitempty"`
 
 	path string
 	mu   sync.RWMutex
+
+	// system and repo hold the settings read from the system-wide file and
+	// the repository's .bkt.yml; see LookupSetting.
+	system     map[string]string
+	repo       map[string]string
+	systemPath string
+	repoPath   string
+	// warnings collects problems with the layered files that did not stop
+	// the config from loading; see Warnings.
+	warnings []string
+}
+
+// Jira controls how "pr create" finds Jira issue keys in the source branch
+// and commit messages.
+type Jira struct {
+	// Projects lists the Jira project keys to look for, e.g. PROJ and OPS.
+	// It takes precedence over KeyPattern.
+	Projects []string `yaml:"projects,omitempty"`
+	// KeyPattern is a regular expression matching issue keys; it defaults
+	// to upper-case keys such as PROJ-123. Without Projects or KeyPattern
+	// only the branch name is searched.
+	KeyPattern string `yaml:"key_pattern,omitempty"`
+	// BaseURL (e.g. https://acme.atlassian.net) turns detected keys into
+	// links in the pull request description.
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Disabled turns detection off; keys passed with --jira still apply.
+	Disabled bool `yaml:"disabled,omitempty"`
 }
 
 // Context captures user-scoped defaults that reference a host.
@@ -36,6 +89,8 @@ type Context struct {
 	ProjectKey  string `yaml:"project_key,omitempty"`
 	Workspace   string `yaml:"workspace,omitempty"`
 	DefaultRepo string `yaml:"default_repo,omitempty"`
+	// Profile pins the context to a named credential profile on its host.
+	Profile string `yaml:"profile,omitempty"`
 }
 
 // Host stores connection and credential details for a Bitbucket instance.
@@ -45,8 +100,83 @@ type Host struct {
 	Username           string `yaml:"username,omitempty"`
 	Token              string `yaml:"token,omitempty"`
 	AllowInsecureStore bool   `yaml:"allow_insecure_store,omitempty"`
+	GitProtocol        string `yaml:"git_protocol,omitempty"` // https | ssh
+	AuthMethod         string `yaml:"auth_method,omitempty"`  // "" (token) | oauth
+	OAuthClientID      string `yaml:"oauth_client_id,omitempty"`
+
+	// Proxy, CACert and InsecureSkipVerify configure how the host is reached
+	// from networks with an outbound proxy or TLS interception.
+	Proxy              string `yaml:"proxy,omitempty"`
+	CACert             string `yaml:"ca_cert,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+
+	// Profiles holds additional named identities for the same host. The
+	// fields above form the "default" profile.
+	Profiles      map[string]*Profile `yaml:"profiles,omitempty"`
+	ActiveProfile string              `yaml:"active_profile,omitempty"`
+
+	// Profile records which identity a resolved Host carries; it is set by
+	// WithProfile and never persisted.
+	Profile string `yaml:"-"`
+}
+
+// Profile is a named identity for a host. Its secret is stored in the keychain
+// separately from the host's default token.
+type Profile struct {
+	Username      string `yaml:"username,omitempty"`
+	AuthMethod    string `yaml:"auth_method,omitempty"`
+	OAuthClientID string `yaml:"oauth_client_id,omitempty"`
 }
 
+// WithProfile returns a copy of the host carrying the named identity. An empty
+// name selects the host's active profile; "default" selects the identity
+// stored on the host itself.
+func (h *Host) WithProfile(name string) (*Host, error) {
+	if name == "" {
+		name = h.ActiveProfile
+	}
+
+	resolved := *h
+	if name == "" || name == DefaultProfile {
+		resolved.Profile = ""
+		return &resolved, nil
+	}
+
+	p, ok := h.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+	}
+	resolved.Username = p.Username
+	resolved.AuthMethod = p.AuthMethod
+	resolved.OAuthClientID = p.OAuthClientID
+	resolved.Token = ""
+	resolved.Profile = name
+	return &resolved, nil
+}
+
+// ProfileNames lists the host's profiles, including "default" when the host
+// itself carries an identity, in sorted order.
+func (h *Host) ProfileNames() []string {
+	var names []string
+	if h.Username != "" || len(h.Profiles) == 0 {
+		names = append(names, DefaultProfile)
+	}
+	for name := range h.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AuthMethodOAuth marks hosts authenticated through the OAuth 2.0 flow; their
+// Token holds encoded OAuth credentials rather than a raw secret.
+const AuthMethodOAuth = "oauth"
+
+// AuthMethodBearer marks a host whose Token is a raw access token sent as a
+// bearer token without refreshing, such as the OAuth access token bkt passes
+// to extensions. It is only set from BKT_AUTH_METHOD, never stored.
+const AuthMethodBearer = "bearer"
+
 // MarshalYAML strips the token field so credentials are never written to disk.
 func (h *Host) MarshalYAML() (any, error) {
 	if h == nil {
@@ -73,6 +203,10 @@ func Load() (*Config, error) {
 		path:     path,
 	}
 
+	if err := cfg.loadLayers(); err != nil {
+		return nil, err
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -242,6 +376,38 @@ func (c *Config) DeleteHost(key string) {
 	delete(c.Hosts, key)
 }
 
+// SetAlias upserts a command alias.
+func (c *Config) SetAlias(name, expansion string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Aliases == nil {
+		c.Aliases = make(map[string]string)
+	}
+	c.Aliases[name] = expansion
+}
+
+// Alias returns the expansion for a command alias.
+func (c *Config) Alias(name string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	expansion, ok := c.Aliases[name]
+	return expansion, ok
+}
+
+// DeleteAlias removes a command alias and reports whether it existed.
+func (c *Config) DeleteAlias(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.Aliases[name]; !ok {
+		return false
+	}
+	delete(c.Aliases, name)
+	return true
+}
+
 func resolvePath() (string, error) {
 	base := os.Getenv("BKT_CONFIG_DIR")
 	if base == "" {
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CredentialStore persists host secrets outside config.yml. It is satisfied by
+// internal/secret's keyring store, which falls back to its encrypted file
+// backend when insecure storage is allowed. Get returns an error wrapping
+// os.ErrNotExist when the key is absent.
+type CredentialStore interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// LegacyTokenHosts lists hosts whose token was read from config.yml. Older
+// releases wrote tokens there; they must be moved to a CredentialStore because
+// Save never writes them back.
+func (c *Config) LegacyTokenHosts() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var keys []string
+	for key, host := range c.Hosts {
+		if host != nil && host.Token != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MigrateCredentials moves tokens found in config.yml into store under the
+// identifier returned by key, then rewrites the config without them. It must
+// run straight after Load, before any token is resolved into a Host. Because
+// Save strips every token, the config is only rewritten once all of them are
+// safely stored.
+func (c *Config) MigrateCredentials(store CredentialStore, key func(hostKey string) string) ([]string, error) {
+	hostKeys := c.LegacyTokenHosts()
+	if len(hostKeys) == 0 {
+		return nil, nil
+	}
+
+	for _, hostKey := range hostKeys {
+		c.mu.RLock()
+		token := c.Hosts[hostKey].Token
+		c.mu.RUnlock()
+
+		if err := store.Set(key(hostKey), token); err != nil {
+			return nil, fmt.Errorf("migrate credentials for %s: %w", hostKey, err)
+		}
+	}
+
+	if err := c.Save(); err != nil {
+		return nil, err
+	}
+	return hostKeys, nil
+}
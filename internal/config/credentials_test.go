@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type memoryStore map[string]string
+
+func (m memoryStore) Get(key string) (string, error) {
+	value, ok := m[key]
+	if !ok {
+		return "", os.ErrNotExist
+	}
+	return value, nil
+}
+
+func (m memoryStore) Set(key, value string) error {
+	m[key] = value
+	return nil
+}
+
+func (m memoryStore) Delete(key string) error {
+	delete(m, key)
+	return nil
+}
+
+type failingStore struct{ CredentialStore }
+
+func (failingStore) Set(key, value string) error { return errors.New("keyring locked") }
+
+func TestMigrateCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("BKT_CONFIG_DIR", dir)
+
+	legacy := `version: 1
+hosts:
+  bitbucket.example.com:
+    kind: dc
+    base_url: https://bitbucket.example.com
+    username: alice
+    token: legacy-token
+`
+	configPath := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(configPath, []byte(legacy), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// A failing store must leave config.yml untouched.
+	if _, err := cfg.MigrateCredentials(failingStore{}, func(k string) string { return k }); err == nil {
+		t.Fatal("expected migration error")
+	}
+	if data, _ := os.ReadFile(configPath); !strings.Contains(string(data), "legacy-token") {
+		t.Fatal("token was removed from config.yml despite failed migration")
+	}
+
+	store := memoryStore{}
+	migrated, err := cfg.MigrateCredentials(store, func(k string) string { return "host/" + k + "/token" })
+	if err != nil {
+		t.Fatalf("MigrateCredentials: %v", err)
+	}
+	if len(migrated) != 1 || migrated[0] != "bitbucket.example.com" {
+		t.Fatalf("migrated = %v", migrated)
+	}
+	if got, _ := store.Get("host/bitbucket.example.com/token"); got != "legacy-token" {
+		t.Fatalf("stored token = %q", got)
+	}
+	if data, _ := os.ReadFile(configPath); strings.Contains(string(data), "legacy-token") {
+		t.Fatalf("config.yml still contains the token:\n%s", data)
+	}
+}
@@ -0,0 +1,343 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layer names the file a setting was read from. Later layers win: a value in
+// the repository's .bkt.yml overrides the user's config.yml, which overrides
+// the system-wide file.
+type Layer string
+
+const (
+	LayerDefault Layer = "default"
+	LayerSystem  Layer = "system"
+	LayerUser    Layer = "user"
+	LayerRepo    Layer = "repo"
+)
+
+// RepoConfigName is the per-repository settings file, looked up from the
+// working directory up to the root of the git work tree.
+const RepoConfigName = ".bkt.yml"
+
+// Setting describes a key managed by `bkt config`.
+type Setting struct {
+	Key         string
+	Description string
+	// Allowed lists the accepted values; any value is accepted when empty.
+	Allowed []string
+	// UserOnly keeps the key out of the system and repository files. A
+	// cloned repository must not be able to pick a program bkt runs.
+	UserOnly bool
+	validate func(string) error
+}
+
+var settings = []Setting{
+	{Key: "workspace", Description: "Bitbucket Cloud workspace used when neither the context nor the git remote names one"},
+	{Key: "project", Description: "Data Center project key used when neither the context nor the git remote names one"},
+	{Key: "merge_strategy", Description: "Default merge strategy for pr merge, e.g. squash or no-ff"},
+	{Key: "editor", Description: "Program used to compose pull request descriptions and comments", UserOnly: true},
+	{Key: "pager", Description: "Paging of long list, view and diff output", Allowed: []string{"auto", "never"}},
+	{Key: "timeout", Description: "Default deadline for a command's API calls, e.g. 45s", validate: func(v string) error {
+		if d, err := time.ParseDuration(v); err != nil || d < 0 {
+			return fmt.Errorf("use a duration such as 30s or 2m (0 disables)")
+		}
+		return nil
+	}},
+}
+
+// Settings returns the keys managed by `bkt config` in display order.
+func Settings() []Setting {
+	return slices.Clone(settings)
+}
+
+// FindSetting looks up a setting by key.
+func FindSetting(key string) (Setting, bool) {
+	for _, s := range settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Validate reports whether value is acceptable for the setting. An empty
+// value, which clears the key, is always accepted.
+func (s Setting) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(s.Allowed) > 0 && !slices.Contains(s.Allowed, value) {
+		return fmt.Errorf("invalid %s %q; use one of %s", s.Key, value, strings.Join(s.Allowed, ", "))
+	}
+	if s.validate != nil {
+		if err := s.validate(value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", s.Key, value, err)
+		}
+	}
+	return nil
+}
+
+// userSetting returns the config.yml field backing key.
+func (c *Config) userSetting(key string) *string {
+	switch key {
+	case "workspace":
+		return &c.Workspace
+	case "project":
+		return &c.Project
+	case "merge_strategy":
+		return &c.MergeStrategy
+	case "editor":
+		return &c.Editor
+	case "pager":
+		return &c.Pager
+	case "timeout":
+		return &c.Timeout
+	}
+	return nil
+}
+
+// Setting returns the effective value of key, or "" when no layer sets it.
+func (c *Config) Setting(key string) string {
+	value, _ := c.LookupSetting(key)
+	return value
+}
+
+// LookupSetting returns the effective value of key together with the layer
+// that supplied it.
+func (c *Config) LookupSetting(key string) (string, Layer) {
+	s, ok := FindSetting(key)
+	if !ok {
+		return "", LayerDefault
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !s.UserOnly {
+		if value := c.repo[key]; value != "" {
+			return value, LayerRepo
+		}
+	}
+	if field := c.userSetting(key); field != nil && strings.TrimSpace(*field) != "" {
+		return strings.TrimSpace(*field), LayerUser
+	}
+	if !s.UserOnly {
+		if value := c.system[key]; value != "" {
+			return value, LayerSystem
+		}
+	}
+	return "", LayerDefault
+}
+
+// SetSetting stores value for key in the user's config.yml; an empty value
+// clears it. Callers persist the change with Save.
+func (c *Config) SetSetting(key, value string) error {
+	s, ok := FindSetting(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	value = strings.TrimSpace(value)
+	if err := s.Validate(value); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.userSetting(key) = value
+	return nil
+}
+
+// LayerPath returns the file backing a layer, or "" when there is none. The
+// repository file is reported only when it exists.
+func (c *Config) LayerPath(layer Layer) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch layer {
+	case LayerSystem:
+		return c.systemPath
+	case LayerUser:
+		return c.path
+	case LayerRepo:
+		return c.repoPath
+	}
+	return ""
+}
+
+// systemConfigPath locates the machine-wide settings file.
+var systemConfigPath = func() string {
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("ProgramData"); dir != "" {
+			return filepath.Join(dir, "bkt", "config.yml")
+		}
+		return ""
+	}
+	return "/etc/bkt/config.yml"
+}
+
+// Warnings returns the problems found while reading the system and
+// repository settings files, such as an unreadable system file or an invalid
+// value, which were skipped rather than failing the load.
+func (c *Config) Warnings() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.warnings)
+}
+
+// loadLayers reads the system file and the .bkt.yml of the repository
+// containing the working directory. The system file is managed outside the
+// user's control, so a broken one is only reported through Warnings.
+func (c *Config) loadLayers() error {
+	c.systemPath = systemConfigPath()
+	system, warnings, err := readSettingsFile(c.systemPath)
+	if err != nil {
+		c.warnings = append(c.warnings, fmt.Sprintf("ignoring system config: %v", err))
+	}
+	c.system = system
+	c.warnings = append(c.warnings, warnings...)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	if path, found := RepoConfigPath(wd); found {
+		repo, warnings, err := readSettingsFile(path)
+		if err != nil {
+			return err
+		}
+		c.repo = repo
+		c.warnings = append(c.warnings, warnings...)
+		c.repoPath = path
+	}
+	return nil
+}
+
+// readSettingsFile decodes the known, non user-only keys of a settings file.
+// A missing file yields no values; invalid values are skipped and reported
+// as warnings.
+func readSettingsFile(path string) (map[string]string, []string, error) {
+	if path == "" {
+		return nil, nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	var warnings []string
+	for key, v := range raw {
+		s, ok := FindSetting(key)
+		if !ok || s.UserOnly || v == nil {
+			continue
+		}
+		value := strings.TrimSpace(fmt.Sprint(v))
+		if err := s.Validate(value); err != nil {
+			warnings = append(warnings, fmt.Sprintf("ignoring %s in %s: %v", key, path, err))
+			continue
+		}
+		values[key] = value
+	}
+	slices.Sort(warnings)
+	return values, warnings, nil
+}
+
+// RepoConfigPath walks up from dir looking for .bkt.yml, stopping at the
+// root of the git work tree. It returns the file found, or where the file
+// belongs in the work tree with found set to false. Outside a git work tree
+// it returns "", false even if a .bkt.yml exists in some parent directory.
+func RepoConfigPath(dir string) (path string, found bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	nearest := ""
+	for {
+		candidate := filepath.Join(dir, RepoConfigName)
+		if nearest == "" {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				nearest = candidate
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			if nearest != "" {
+				return nearest, true
+			}
+			return candidate, false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// SetRepoSetting writes value for key to the .bkt.yml of the repository
+// containing dir, keeping the file's other keys; an empty value removes the
+// key. It returns the file written.
+func SetRepoSetting(dir, key, value string) (string, error) {
+	s, ok := FindSetting(key)
+	if !ok {
+		return "", fmt.Errorf("unknown config key %q", key)
+	}
+	if s.UserOnly {
+		return "", fmt.Errorf("%s can only be set in the user config", key)
+	}
+	value = strings.TrimSpace(value)
+	if err := s.Validate(value); err != nil {
+		return "", err
+	}
+
+	path, _ := RepoConfigPath(dir)
+	if path == "" {
+		return "", fmt.Errorf("not inside a git work tree; %s lives at the repository root", RepoConfigName)
+	}
+
+	raw := map[string]any{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return "", fmt.Errorf("decode %s: %w", path, err)
+		}
+		if raw == nil {
+			raw = map[string]any{}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if value == "" {
+		delete(raw, key)
+	} else {
+		raw[key] = value
+	}
+
+	out, err := yaml.Marshal(raw)
+	if err != nil {
+		return "", fmt.Errorf("encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLayeredSettings(t *testing.T) {
+	root := t.TempDir()
+
+	systemPath := filepath.Join(root, "system.yml")
+	writeFile(t, systemPath, "workspace: acme\nmerge_strategy: no-ff\npager: never\neditor: evil\n")
+	prev := systemConfigPath
+	systemConfigPath = func() string { return systemPath }
+	t.Cleanup(func() { systemConfigPath = prev })
+
+	configDir := filepath.Join(root, "user")
+	t.Setenv("BKT_CONFIG_DIR", configDir)
+	writeFile(t, filepath.Join(configDir, "config.yml"), "version: 1\nmerge_strategy: squash\neditor: hx\n")
+
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(repo, RepoConfigName), "merge_strategy: ff-only\neditor: rm -rf\nunknown: 1\n")
+	sub := filepath.Join(repo, "cmd", "tool")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	cases := []struct {
+		key   string
+		value string
+		layer Layer
+	}{
+		{"merge_strategy", "ff-only", LayerRepo},
+		{"editor", "hx", LayerUser},
+		{"workspace", "acme", LayerSystem},
+		{"pager", "never", LayerSystem},
+		{"timeout", "", LayerDefault},
+		{"unknown", "", LayerDefault},
+	}
+	for _, tc := range cases {
+		value, layer := cfg.LookupSetting(tc.key)
+		if value != tc.value || layer != tc.layer {
+			t.Errorf("LookupSetting(%q) = %q, %s; want %q, %s", tc.key, value, layer, tc.value, tc.layer)
+		}
+	}
+	if got := cfg.LayerPath(LayerRepo); got != filepath.Join(repo, RepoConfigName) {
+		t.Errorf("repo layer path = %q", got)
+	}
+}
+
+func TestSetSettingValidates(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.SetSetting("pager", "sometimes"); err == nil || !strings.Contains(err.Error(), "auto, never") {
+		t.Fatalf("SetSetting pager = %v", err)
+	}
+	if err := cfg.SetSetting("timeout", "soon"); err == nil {
+		t.Fatal("expected invalid timeout error")
+	}
+	if err := cfg.SetSetting("colour", "on"); err == nil {
+		t.Fatal("expected unknown key error")
+	}
+	if err := cfg.SetSetting("timeout", "45s"); err != nil {
+		t.Fatalf("SetSetting timeout: %v", err)
+	}
+	if cfg.Timeout != "45s" || cfg.Setting("timeout") != "45s" {
+		t.Fatalf("timeout = %q", cfg.Timeout)
+	}
+	if err := cfg.SetSetting("timeout", ""); err != nil || cfg.Setting("timeout") != "" {
+		t.Fatalf("clearing timeout: %v, %q", err, cfg.Timeout)
+	}
+}
+
+func TestSetRepoSettingKeepsOtherKeys(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(repo, "docs")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(repo, RepoConfigName)
+	writeFile(t, path, "workspace: acme\n")
+
+	got, err := SetRepoSetting(sub, "merge_strategy", "squash")
+	if err != nil {
+		t.Fatalf("SetRepoSetting: %v", err)
+	}
+	if got != path {
+		t.Fatalf("wrote %q, want %q", got, path)
+	}
+	values, _, err := readSettingsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["workspace"] != "acme" || values["merge_strategy"] != "squash" {
+		t.Fatalf("unexpected values %v", values)
+	}
+
+	if _, err := SetRepoSetting(sub, "editor", "vim"); err == nil {
+		t.Fatal("expected editor to be rejected in the repository file")
+	}
+	if _, err := SetRepoSetting(t.TempDir(), "workspace", "acme"); err == nil {
+		t.Fatal("expected error outside a git work tree")
+	}
+}
+
+func TestRepoConfigPathRequiresGitWorkTree(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, RepoConfigName), "workspace: stray\n")
+	dir := filepath.Join(root, "not-a-repo")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if path, found := RepoConfigPath(dir); path != "" || found {
+		t.Fatalf("RepoConfigPath outside a work tree = %q, %v", path, found)
+	}
+
+	// A .bkt.yml above the work tree root belongs to another directory.
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if path, found := RepoConfigPath(repo); path != filepath.Join(repo, RepoConfigName) || found {
+		t.Fatalf("RepoConfigPath in a work tree = %q, %v", path, found)
+	}
+}
+
+func TestLoadWarnsAboutBrokenSystemFileAndInvalidValues(t *testing.T) {
+	root := t.TempDir()
+	systemPath := filepath.Join(root, "system.yml")
+	writeFile(t, systemPath, "workspace: [unterminated\n")
+	prev := systemConfigPath
+	systemConfigPath = func() string { return systemPath }
+	t.Cleanup(func() { systemConfigPath = prev })
+	t.Setenv("BKT_CONFIG_DIR", filepath.Join(root, "user"))
+
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(repo, RepoConfigName), "pager: sometimes\ntimeout: soon\nworkspace: acme\n")
+	t.Chdir(repo)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	warnings := strings.Join(cfg.Warnings(), "\n")
+	for _, want := range []string{"ignoring system config", "ignoring pager", "ignoring timeout"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings missing %q:\n%s", want, warnings)
+		}
+	}
+	if value, layer := cfg.LookupSetting("pager"); value != "" || layer != LayerDefault {
+		t.Errorf("invalid pager was applied: %q from %s", value, layer)
+	}
+	if got := cfg.Setting("workspace"); got != "acme" {
+		t.Errorf("workspace = %q", got)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,355 @@
+// Package oauth implements the Bitbucket Cloud OAuth 2.0 authorization-code
+// flow with PKCE, receiving the authorization code on a localhost callback,
+// and refreshes expired access tokens.
+package oauth
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AuthorizeURL is the Bitbucket Cloud authorization endpoint.
+	AuthorizeURL = "https://bitbucket.org/site/oauth2/authorize"
+	// TokenURL is the Bitbucket Cloud token endpoint.
+	TokenURL = "https://bitbucket.org/site/oauth2/access_token"
+	// DefaultCallbackPort is the localhost port the login flow listens on. The
+	// OAuth consumer's callback URL must be http://localhost:<port>/callback.
+	DefaultCallbackPort = 8746
+
+	callbackPath = "/callback"
+	expirySkew   = time.Minute
+)
+
+// Config identifies an OAuth consumer and the endpoints it talks to.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// AuthorizeURL and TokenURL default to the Bitbucket Cloud endpoints.
+	AuthorizeURL string
+	TokenURL     string
+	HTTPClient   *http.Client
+}
+
+// Token is an OAuth access token with its refresh token.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scopes       string    `json:"scopes,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the access token is present and not about to expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(expirySkew).Before(t.Expiry)
+}
+
+// Credentials is what gets persisted for an OAuth host: the consumer secret
+// needed to refresh, plus the current token.
+type Credentials struct {
+	ClientSecret string `json:"client_secret,omitempty"`
+	Token        Token  `json:"token"`
+}
+
+// Encode serialises credentials for the secret store.
+func (c Credentials) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DecodeCredentials parses credentials produced by Encode.
+func DecodeCredentials(raw string) (*Credentials, error) {
+	var creds Credentials
+	if err := json.Unmarshal([]byte(raw), &creds); err != nil {
+		return nil, fmt.Errorf("decode oauth credentials: %w", err)
+	}
+	if creds.Token.AccessToken == "" {
+		return nil, errors.New("stored oauth credentials have no access token")
+	}
+	return &creds, nil
+}
+
+// LoginOptions controls the interactive authorization flow.
+type LoginOptions struct {
+	// Port is the localhost callback port; 0 selects DefaultCallbackPort.
+	Port int
+	// OpenURL is called with the authorization URL, typically to launch a
+	// browser. A failure is not fatal; the URL is also passed to Notify.
+	OpenURL func(string) error
+	// Notify receives the authorization URL before waiting for the callback.
+	// An error aborts the login.
+	Notify func(authURL string) error
+}
+
+// Login runs the authorization-code flow: it listens on localhost, sends the
+// user to the authorization page and exchanges the returned code for a token.
+// It returns when the callback arrives or ctx is done.
+func Login(ctx context.Context, cfg Config, opts LoginOptions) (*Token, error) {
+	if cfg.ClientID == "" {
+		return nil, errors.New("oauth client ID is required")
+	}
+
+	port := opts.Port
+	if port == 0 {
+		port = DefaultCallbackPort
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("listen for oauth callback: %w", err)
+	}
+	defer listener.Close()
+
+	redirectURI := fmt.Sprintf("http://localhost:%d%s", listener.Addr().(*net.TCPAddr).Port, callbackPath)
+
+	state, err := randomString(24)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString(48)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := authorizationURL(cfg, redirectURI, state, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	var once sync.Once
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		// Requests that do not carry this login's state, or carry neither a
+		// code nor an error, are not the authorization server's redirect;
+		// reject them and keep waiting for the real one.
+		if q.Get("state") != state {
+			http.Error(w, "oauth callback state mismatch", http.StatusBadRequest)
+			return
+		}
+		res := result{code: q.Get("code")}
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("authorization denied: %s", cmp.Or(q.Get("error_description"), q.Get("error")))
+		case res.code == "":
+			http.Error(w, "oauth callback did not include a code", http.StatusBadRequest)
+			return
+		}
+
+		// The result reaches the terminal whether or not the browser gets
+		// the page, so a failed write only loses the courtesy message.
+		if res.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, "<p>Authentication failed: %s</p>", html.EscapeString(res.err.Error()))
+		} else {
+			_, _ = fmt.Fprint(w, "<p>Authentication complete. You can close this window and return to the terminal.</p>")
+		}
+		once.Do(func() { results <- res })
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if opts.Notify != nil {
+		if err := opts.Notify(authURL); err != nil {
+			return nil, err
+		}
+	}
+	if opts.OpenURL != nil {
+		_ = opts.OpenURL(authURL)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for oauth callback: %w", ctx.Err())
+	case res := <-results:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return Exchange(ctx, cfg, res.code, redirectURI, verifier)
+	}
+}
+
+// Exchange trades an authorization code for a token.
+func Exchange(ctx context.Context, cfg Config, code, redirectURI, verifier string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	if redirectURI != "" {
+		form.Set("redirect_uri", redirectURI)
+	}
+	if verifier != "" {
+		form.Set("code_verifier", verifier)
+	}
+	return requestToken(ctx, cfg, form)
+}
+
+// Refresh obtains a new access token using a refresh token.
+func Refresh(ctx context.Context, cfg Config, refreshToken string) (*Token, error) {
+	if refreshToken == "" {
+		return nil, errors.New("no refresh token available; run `bkt auth login` again")
+	}
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	return requestToken(ctx, cfg, form)
+}
+
+func requestToken(ctx context.Context, cfg Config, form url.Values) (*Token, error) {
+	// Confidential consumers authenticate with HTTP basic auth; public ones
+	// identify themselves in the form and rely on PKCE.
+	if cfg.ClientSecret == "" {
+		form.Set("client_id", cfg.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cmp.Or(cfg.TokenURL, TokenURL), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cfg.ClientSecret != "" {
+		req.SetBasicAuth(cfg.ClientID, cfg.ClientSecret)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request oauth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		TokenType        string `json:"token_type"`
+		Scopes           string `json:"scopes"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%s: decode oauth token response: %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK || payload.AccessToken == "" {
+		msg := cmp.Or(payload.ErrorDescription, payload.Error, "no access token returned")
+		return nil, fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+
+	tok := &Token{
+		AccessToken:  payload.AccessToken,
+		RefreshToken: payload.RefreshToken,
+		TokenType:    payload.TokenType,
+		Scopes:       payload.Scopes,
+	}
+	if payload.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+	return tok, nil
+}
+
+// Refresher hands out a valid access token, refreshing it when it expires.
+// It satisfies httpx.TokenSource.
+type Refresher struct {
+	cfg       Config
+	onRefresh func(*Token) error
+
+	mu    sync.Mutex
+	token Token
+}
+
+// NewRefresher wraps tok. onRefresh, when non-nil, is called with every newly
+// issued token so it can be persisted; Bitbucket rotates refresh tokens.
+func NewRefresher(cfg Config, tok Token, onRefresh func(*Token) error) *Refresher {
+	return &Refresher{cfg: cfg, token: tok, onRefresh: onRefresh}
+}
+
+// AccessToken returns a valid access token.
+func (r *Refresher) AccessToken(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.token.Valid() {
+		return r.token.AccessToken, nil
+	}
+
+	tok, err := Refresh(ctx, r.cfg, r.token.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("refresh oauth token: %w", err)
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = r.token.RefreshToken
+	}
+	r.token = *tok
+
+	if r.onRefresh != nil {
+		if err := r.onRefresh(tok); err != nil {
+			return "", fmt.Errorf("store refreshed oauth token: %w", err)
+		}
+	}
+	return tok.AccessToken, nil
+}
+
+func authorizationURL(cfg Config, redirectURI, state, verifier string) (string, error) {
+	u, err := url.Parse(cmp.Or(cfg.AuthorizeURL, AuthorizeURL))
+	if err != nil {
+		return "", fmt.Errorf("parse authorize URL: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	q := u.Query()
+	q.Set("client_id", cfg.ClientID)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", redirectURI)
+	q.Set("state", state)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,234 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestLoginExchangesCodeWithPKCE(t *testing.T) {
+	var challenge string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "client" || pass != "secret" {
+			t.Errorf("expected client basic auth, got %q/%q", user, pass)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "authorization_code" {
+			t.Errorf("grant_type = %q", got)
+		}
+		if got := r.PostForm.Get("code"); got != "the-code" {
+			t.Errorf("code = %q", got)
+		}
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if got := base64.RawURLEncoding.EncodeToString(sum[:]); got != challenge {
+			t.Errorf("code_verifier does not match challenge")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access-1",
+			"refresh_token": "refresh-1",
+			"token_type":    "bearer",
+			"scopes":        "repository pullrequest",
+			"expires_in":    7200,
+		})
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	cfg := Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		AuthorizeURL: "https://bitbucket.example/authorize",
+		TokenURL:     tokenServer.URL,
+	}
+
+	// Simulate the browser: follow the authorization URL straight back to the
+	// callback with a code.
+	open := func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		q := u.Query()
+		if q.Get("code_challenge_method") != "S256" || q.Get("client_id") != "client" {
+			t.Errorf("unexpected authorize query: %s", u.RawQuery)
+		}
+		challenge = q.Get("code_challenge")
+
+		callback := q.Get("redirect_uri") + "?code=the-code&state=" + url.QueryEscape(q.Get("state"))
+		go func() {
+			resp, err := http.Get(callback)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tok, err := Login(ctx, cfg, LoginOptions{Port: freePort(t), OpenURL: open})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if tok.AccessToken != "access-1" || tok.RefreshToken != "refresh-1" {
+		t.Fatalf("unexpected token: %+v", tok)
+	}
+	if !tok.Valid() {
+		t.Fatalf("expected token to be valid until %s", tok.Expiry)
+	}
+}
+
+func TestLoginIgnoresForgedCallbacks(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		if got := r.PostForm.Get("code"); got != "real" {
+			t.Errorf("code = %q, want the one from the valid callback", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "access-1", "expires_in": 3600})
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	open := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		q := u.Query()
+		callback := q.Get("redirect_uri")
+		go func() {
+			// Forged and incomplete requests are rejected without ending the
+			// login; the real redirect that follows still completes it.
+			for _, query := range []string{
+				"?code=x&state=forged",
+				"?error=access_denied&state=forged",
+				"?state=" + url.QueryEscape(q.Get("state")),
+			} {
+				resp, err := http.Get(callback + query)
+				if err != nil {
+					t.Errorf("GET %s: %v", query, err)
+					return
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusBadRequest {
+					t.Errorf("GET %s: status %d, want 400", query, resp.StatusCode)
+				}
+			}
+			resp, err := http.Get(callback + "?code=real&state=" + url.QueryEscape(q.Get("state")))
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tok, err := Login(ctx, Config{ClientID: "client", TokenURL: tokenServer.URL}, LoginOptions{Port: freePort(t), OpenURL: open})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if tok.AccessToken != "access-1" {
+		t.Fatalf("unexpected token: %+v", tok)
+	}
+}
+
+func TestLoginReportsDeniedAuthorization(t *testing.T) {
+	open := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		q := u.Query()
+		go func() {
+			resp, err := http.Get(q.Get("redirect_uri") + "?error=access_denied&error_description=nope&state=" + url.QueryEscape(q.Get("state")))
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := Login(ctx, Config{ClientID: "client", TokenURL: "http://127.0.0.1:0"}, LoginOptions{Port: freePort(t), OpenURL: open})
+	if err == nil || err.Error() != "authorization denied: nope" {
+		t.Fatalf("expected denied authorization, got %v", err)
+	}
+}
+
+func TestRefresherRefreshesExpiredToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "old-refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "new-access",
+			"refresh_token": "new-refresh",
+			"expires_in":    7200,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	var saved *Token
+	r := NewRefresher(
+		Config{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL},
+		Token{AccessToken: "stale", RefreshToken: "old-refresh", Expiry: time.Now().Add(-time.Minute)},
+		func(tok *Token) error {
+			saved = tok
+			return nil
+		},
+	)
+
+	got, err := r.AccessToken(context.Background())
+	if err != nil {
+		t.Fatalf("AccessToken: %v", err)
+	}
+	if got != "new-access" {
+		t.Fatalf("access token = %q, want new-access", got)
+	}
+	if saved == nil || saved.RefreshToken != "new-refresh" {
+		t.Fatalf("expected rotated token to be persisted, got %+v", saved)
+	}
+
+	// A second call reuses the fresh token without hitting the server.
+	server.Close()
+	if got, err := r.AccessToken(context.Background()); err != nil || got != "new-access" {
+		t.Fatalf("cached AccessToken = %q, %v", got, err)
+	}
+}
+
+func TestCredentialsRoundTrip(t *testing.T) {
+	encoded, err := Credentials{ClientSecret: "s", Token: Token{AccessToken: "a", RefreshToken: "r"}}.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	creds, err := DecodeCredentials(encoded)
+	if err != nil {
+		t.Fatalf("DecodeCredentials: %v", err)
+	}
+	if creds.ClientSecret != "s" || creds.Token.AccessToken != "a" || creds.Token.RefreshToken != "r" {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	if _, err := DecodeCredentials("plain-api-token"); err == nil {
+		t.Fatal("expected error decoding a non-OAuth token")
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	server := httptest.NewServer(http.NotFoundHandler())
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+	server.Close()
+	return port
+}
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 )
@@ -23,10 +24,25 @@ type Locator struct {
 	Workspace  string
 	ProjectKey string
 	RepoSlug   string
+	// Remote is the git remote name the locator was read from.
+	Remote string
 }
 
 // Detect attempts to infer the locator from git remotes.
 func Detect(repoPath string) (Locator, error) {
+	locators, err := DetectAll(repoPath)
+	if err != nil {
+		return Locator{}, err
+	}
+	return locators[0], nil
+}
+
+// DetectAll returns a locator for every git remote that looks like a
+// Bitbucket repository, ordered origin first, then upstream, then the
+// remaining remotes by name. Callers that know which hosts are configured
+// should pick the first matching locator rather than assume origin is
+// Bitbucket (it may well point at another forge).
+func DetectAll(repoPath string) ([]Locator, error) {
 	repoPath = strings.TrimSpace(repoPath)
 	if repoPath == "" {
 		repoPath = "."
@@ -34,60 +50,43 @@ func Detect(repoPath string) (Locator, error) {
 
 	remotes, err := listGitRemotes(repoPath)
 	if err != nil {
-		return Locator{}, err
+		return nil, err
 	}
 	if len(remotes) == 0 {
-		return Locator{}, ErrNoGitRemote
+		return nil, ErrNoGitRemote
 	}
 
-	var tried []string
-	appendIfMissing := func(values []string, candidate string) []string {
-		for _, v := range values {
-			if v == candidate {
-				return values
-			}
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		if name != "origin" && name != "upstream" {
+			names = append(names, name)
 		}
-		return append(values, candidate)
 	}
-
-	var urls []string
-	for _, name := range []string{"origin", "upstream"} {
-		if candidates, ok := remotes[name]; ok {
-			for _, candidate := range candidates {
-				urls = appendIfMissing(urls, candidate)
+	sort.Strings(names)
+	names = append([]string{"origin", "upstream"}, names...)
+
+	seen := make(map[string]bool)
+	var locators []Locator
+	for _, name := range names {
+		for _, raw := range remotes[name] {
+			if seen[raw] {
+				continue
 			}
-			tried = append(tried, name)
-		}
-	}
+			seen[raw] = true
 
-	for name, candidates := range remotes {
-		skipped := false
-		for _, t := range tried {
-			if t == name {
-				skipped = true
-				break
+			loc, err := parseLocator(raw)
+			if err != nil || loc.RepoSlug == "" {
+				continue
 			}
-		}
-		if skipped {
-			continue
-		}
-		for _, candidate := range candidates {
-			urls = appendIfMissing(urls, candidate)
+			loc.Remote = name
+			locators = append(locators, loc)
 		}
 	}
 
-	for _, raw := range urls {
-		loc, err := parseLocator(raw)
-		if err != nil {
-			continue
-		}
-		if loc.RepoSlug == "" {
-			continue
-		}
-		return loc, nil
+	if len(locators) == 0 {
+		return nil, ErrNoGitRemote
 	}
-
-	return Locator{}, ErrNoGitRemote
+	return locators, nil
 }
 
 func listGitRemotes(repoPath string) (map[string][]string, error) {
@@ -22,6 +22,7 @@ const (
 	envTimeout       = "BKT_KEYRING_TIMEOUT"
 	envBackend       = "KEYRING_BACKEND"
 	envFileDir       = "KEYRING_FILE_DIR"
+	envConfigDir     = "BKT_CONFIG_DIR"
 )
 
 const (
@@ -287,6 +288,15 @@ func TokenKey(hostKey string) string {
 	return fmt.Sprintf("host/%s/token", hostKey)
 }
 
+// ProfileTokenKey returns the keyring identifier for a named profile's token.
+// The empty and "default" profiles share the host's TokenKey.
+func ProfileTokenKey(hostKey, profile string) string {
+	if profile == "" || profile == "default" {
+		return TokenKey(hostKey)
+	}
+	return fmt.Sprintf("host/%s/profiles/%s/token", hostKey, profile)
+}
+
 // IsNoKeyringError reports whether the error indicates that no native keyring
 // backend is available on the system.
 func IsNoKeyringError(err error) bool {
@@ -385,7 +395,9 @@ func configureFileBackend(cfg *keyring.Config, opts openOptions) error {
 
 	dir := opts.fileDir
 	if dir == "" {
-		if userDir, err := os.UserConfigDir(); err == nil {
+		if configDir := strings.TrimSpace(os.Getenv(envConfigDir)); configDir != "" {
+			dir = filepath.Join(configDir, "secrets")
+		} else if userDir, err := os.UserConfigDir(); err == nil {
 			dir = filepath.Join(userDir, serviceName, "secrets")
 		}
 	}
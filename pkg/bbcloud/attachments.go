@@ -105,6 +105,41 @@ func (c *Client) UploadIssueAttachment(ctx context.Context, workspace, repoSlug
 	return &attachments[0], nil
 }
 
+// UploadIssueAttachmentFrom streams content produced by open to an issue as
+// filename. Pass size -1 when the length is unknown. open is called again on
+// retries.
+func (c *Client) UploadIssueAttachmentFrom(ctx context.Context, workspace, repoSlug string, issueID int, filename string, size int64, open func() (io.ReadCloser, error)) (*IssueAttachment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+
+	req, err := c.http.NewStreamingMultipartRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/issues/%d/attachments",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		issueID,
+	), nil, []httpx.StreamFile{{
+		FieldName: "files",
+		FileName:  filename,
+		Size:      size,
+		Open:      open,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []IssueAttachment
+	if err := c.http.Do(req, &attachments); err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, fmt.Errorf("upload succeeded but no attachment returned")
+	}
+	return &attachments[0], nil
+}
+
 // DownloadIssueAttachment downloads an attachment from an issue to the provided writer.
 // The API returns a 302 redirect which the http.Client follows automatically.
 func (c *Client) DownloadIssueAttachment(ctx context.Context, workspace, repoSlug string, issueID int, filename string, w io.Writer) error {
@@ -0,0 +1,11 @@
+package bbcloud
+
+import "strings"
+
+// BBQLString quotes s as a string literal for a Bitbucket query language
+// (BBQL) filter, escaping backslashes and double quotes.
+func BBQLString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
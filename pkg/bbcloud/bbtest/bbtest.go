@@ -0,0 +1,225 @@
+// Package bbtest provides a fake Bitbucket Cloud server for testing code
+// built on the bbcloud client.
+//
+// Routes use http.ServeMux patterns, so they may name a method and capture
+// path segments:
+//
+//	srv := bbtest.NewServer(t)
+//	srv.JSON("GET /repositories/{workspace}/{repo}", http.StatusOK, `{"slug":"api"}`)
+//	srv.Paginate("GET /repositories/acme", repos)
+//	client := srv.Client()
+//
+// Every request is recorded, and a request matching no route fails the test.
+package bbtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+)
+
+// DefaultPageLen is the page size Paginate uses when the request names none,
+// matching Bitbucket Cloud's own default.
+const DefaultPageLen = 10
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	// Pattern is the route that handled the request, or "" when none did.
+	Pattern string
+	Path    string
+	Query   url.Values
+	Header  http.Header
+	Body    []byte
+}
+
+// DecodeJSON unmarshals the request body into v.
+func (r Request) DecodeJSON(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Server is a fake Bitbucket Cloud API. Its URL serves as the client's base
+// URL.
+type Server struct {
+	*httptest.Server
+
+	t   testing.TB
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	requests []Request
+}
+
+// NewServer starts a server that is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{t: t, mux: http.NewServeMux()}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	_, pattern := s.mux.Handler(r)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method:  r.Method,
+		Pattern: pattern,
+		Path:    r.URL.Path,
+		Query:   r.URL.Query(),
+		Header:  r.Header.Clone(),
+		Body:    body,
+	})
+	s.mu.Unlock()
+
+	if pattern == "" {
+		s.t.Errorf("bbtest: unexpected request %s %s", r.Method, r.URL.RequestURI())
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Handle registers handler for pattern, an http.ServeMux pattern such as
+// "POST /repositories/{workspace}/{repo}/pullrequests". Each pattern may be
+// registered once.
+func (s *Server) Handle(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// JSON answers pattern with status and body. A string or []byte body is sent
+// as is; anything else is encoded as JSON.
+func (s *Server) JSON(pattern string, status int, body any) {
+	data, err := encode(body)
+	if err != nil {
+		s.t.Fatalf("bbtest: encode response for %s: %v", pattern, err)
+	}
+	s.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	})
+}
+
+// Error answers pattern with status and a Bitbucket error envelope carrying
+// message, which the client reports as the APIError message.
+func (s *Server) Error(pattern string, status int, message string) {
+	s.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status, message)
+	})
+}
+
+// Paginate serves values, a slice, as a paginated listing: the page and
+// pagelen query parameters select the page, and every page but the last
+// carries an absolute next link, as Bitbucket's do.
+func (s *Server) Paginate(pattern string, values any) {
+	s.PaginateBy(pattern, 0, values)
+}
+
+// PaginateBy is like Paginate but splits values into pages of pageLen
+// whatever the request asks for, to exercise paging through a short list.
+func (s *Server) PaginateBy(pattern string, pageLen int, values any) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		s.t.Fatalf("bbtest: encode values for %s: %v", pattern, err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		s.t.Fatalf("bbtest: values for %s must be a slice: %v", pattern, err)
+	}
+
+	s.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pageLen := pageLen
+		if pageLen <= 0 {
+			pageLen = DefaultPageLen
+			if n, err := strconv.Atoi(query.Get("pagelen")); err == nil && n > 0 {
+				pageLen = n
+			}
+		}
+		page := 1
+		if n, err := strconv.Atoi(query.Get("page")); err == nil && n > 0 {
+			page = n
+		}
+
+		start := min((page-1)*pageLen, len(items))
+		end := min(start+pageLen, len(items))
+		resp := map[string]any{
+			"page":    page,
+			"pagelen": pageLen,
+			"size":    len(items),
+			"values":  items[start:end],
+		}
+		if end < len(items) {
+			query.Set("page", strconv.Itoa(page+1))
+			resp["next"] = s.URL + r.URL.Path + "?" + query.Encode()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsFor returns the requests handled by the route registered as
+// pattern.
+func (s *Server) RequestsFor(pattern string) []Request {
+	var out []Request
+	for _, r := range s.Requests() {
+		if r.Pattern == pattern {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Client returns a bbcloud client talking to the server.
+func (s *Server) Client() *bbcloud.Client {
+	s.t.Helper()
+	client, err := bbcloud.New(bbcloud.Options{BaseURL: s.URL, Username: "user", Token: "token"})
+	if err != nil {
+		s.t.Fatalf("bbtest: new client: %v", err)
+	}
+	return client
+}
+
+func encode(body any) ([]byte, error) {
+	switch b := body.(type) {
+	case string:
+		return []byte(b), nil
+	case []byte:
+		return b, nil
+	case nil:
+		return nil, nil
+	default:
+		return json.Marshal(b)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":  "error",
+		"error": map[string]string{"message": message},
+	})
+}
@@ -0,0 +1,134 @@
+package bbtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+)
+
+func TestPaginateFollowsNextLinks(t *testing.T) {
+	srv := NewServer(t)
+
+	var watchers []bbcloud.Account
+	for i := range 5 {
+		watchers = append(watchers, bbcloud.Account{UUID: fmt.Sprintf("{%d}", i)})
+	}
+	const pattern = "GET /repositories/{workspace}/{repo}/watchers"
+	srv.PaginateBy(pattern, 2, watchers)
+
+	got, err := srv.Client().ListRepositoryWatchers(context.Background(), "acme", "api", 0)
+	if err != nil {
+		t.Fatalf("ListRepositoryWatchers: %v", err)
+	}
+	if len(got) != 5 || got[4].UUID != "{4}" {
+		t.Fatalf("watchers = %+v", got)
+	}
+
+	requests := srv.RequestsFor(pattern)
+	if len(requests) != 3 {
+		t.Fatalf("requests = %d, want 3 pages", len(requests))
+	}
+	if page := requests[2].Query.Get("page"); page != "3" {
+		t.Fatalf("last page requested = %q", page)
+	}
+	if requests[0].Path != "/repositories/acme/api/watchers" {
+		t.Fatalf("path = %q", requests[0].Path)
+	}
+}
+
+func TestPaginateHonoursPageLen(t *testing.T) {
+	srv := NewServer(t)
+	srv.Paginate("GET /items", []int{1, 2, 3})
+
+	resp, err := http.Get(srv.URL + "/items?pagelen=2&page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var page struct {
+		Values []int  `json:"values"`
+		Next   string `json:"next"`
+		Size   int    `json:"size"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Values) != 1 || page.Values[0] != 3 || page.Next != "" || page.Size != 3 {
+		t.Fatalf("page = %+v", page)
+	}
+}
+
+func TestErrorAndRecording(t *testing.T) {
+	srv := NewServer(t)
+	srv.Error("GET /repositories/{workspace}/{repo}/watchers", http.StatusForbidden, "Your credentials lack one or more required privilege scopes.")
+	srv.Handle("POST /echo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := srv.Client().ListRepositoryWatchers(context.Background(), "acme", "api", 0)
+	var apiErr *bbcloud.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || !strings.Contains(apiErr.Message, "privilege scopes") {
+		t.Fatalf("error = %v", err)
+	}
+	if !errors.Is(err, bbcloud.ErrPermission) {
+		t.Fatalf("error %v is not ErrPermission", err)
+	}
+
+	resp, err := http.Post(srv.URL+"/echo", "application/json", strings.NewReader(`{"name":"x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("requests = %+v", requests)
+	}
+	var body struct{ Name string }
+	if err := requests[1].DecodeJSON(&body); err != nil || body.Name != "x" {
+		t.Fatalf("body = %+v, %v", body, err)
+	}
+	if got := requests[0].Header.Get("Authorization"); !strings.HasPrefix(got, "Basic ") {
+		t.Fatalf("Authorization = %q", got)
+	}
+}
+
+// recorder captures the failures a Server reports.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestUnexpectedRequestFailsTest(t *testing.T) {
+	rec := &recorder{TB: t}
+	srv := NewServer(rec)
+	srv.JSON("GET /user", http.StatusOK, map[string]string{"uuid": "{me}"})
+
+	_, err := srv.Client().ListRepositoryWatchers(context.Background(), "acme", "api", 0)
+	if !errors.Is(err, bbcloud.ErrNotFound) {
+		t.Fatalf("error = %v, want not found", err)
+	}
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "GET /repositories/acme/api/watchers") {
+		t.Fatalf("reported %q", rec.errors)
+	}
+
+	// A route registered for another method does not match either.
+	resp, err := http.Post(srv.URL+"/user", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(rec.errors) != 2 {
+		t.Fatalf("reported %q", rec.errors)
+	}
+}
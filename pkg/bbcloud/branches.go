@@ -3,6 +3,7 @@ package bbcloud
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 )
@@ -28,16 +29,17 @@ type BranchListOptions struct {
 	Limit  int
 }
 
-// branchListPage wraps paginated branch responses.
-type branchListPage struct {
-	Values []Branch `json:"values"`
-	Next   string   `json:"next"`
+// ListBranches lists repository branches, returning at most opts.Limit
+// entries when it is positive.
+func (c *Client) ListBranches(ctx context.Context, workspace, repoSlug string, opts BranchListOptions) ([]Branch, error) {
+	return collect(c.ListBranchesIter(ctx, workspace, repoSlug, opts), opts.Limit)
 }
 
-// ListBranches lists repository branches.
-func (c *Client) ListBranches(ctx context.Context, workspace, repoSlug string, opts BranchListOptions) ([]Branch, error) {
+// ListBranchesIter lazily iterates over repository branches; opts.Limit only
+// sizes the pages.
+func (c *Client) ListBranchesIter(ctx context.Context, workspace, repoSlug string, opts BranchListOptions) iter.Seq2[Branch, error] {
 	if workspace == "" || repoSlug == "" {
-		return nil, fmt.Errorf("workspace and repository slug are required")
+		return failed[Branch](fmt.Errorf("workspace and repository slug are required"))
 	}
 
 	pageLen := opts.Limit
@@ -56,24 +58,125 @@ func (c *Client) ListBranches(ctx context.Context, workspace, repoSlug string, o
 		url.PathEscape(repoSlug),
 		strings.Join(params, "&"),
 	)
+	return paginate[Branch](ctx, c, path)
+}
+
+// CreateBranch creates a branch pointing at target, which may be a commit
+// hash or the name of an existing branch or tag. The API only accepts a
+// full commit hash, so anything else is resolved to one first.
+func (c *Client) CreateBranch(ctx context.Context, workspace, repoSlug, name, target string) (*Branch, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(name) == "" || strings.TrimSpace(target) == "" {
+		return nil, fmt.Errorf("branch name and target are required")
+	}
+
+	hash := target
+	if !isFullCommitHash(target) {
+		commit, err := c.GetCommit(ctx, workspace, repoSlug, target)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s: %w", target, err)
+		}
+		hash = commit.Hash
+	}
+
+	body := map[string]any{
+		"name": name,
+		"target": map[string]any{
+			"hash": hash,
+		},
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/refs/branches",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch Branch
+	if err := c.http.Do(req, &branch); err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
 
-	var branches []Branch
+// isFullCommitHash reports whether s is a 40-character hexadecimal commit
+// hash.
+func isFullCommitHash(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteBranch removes a branch from the repository.
+func (c *Client) DeleteBranch(ctx context.Context, workspace, repoSlug, name string) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("branch name is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/repositories/%s/%s/refs/branches/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(name),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+// CountCommits counts commits reachable from include but not from exclude,
+// stopping once max commits have been seen (max <= 0 counts everything).
+// The boolean result reports whether the count was truncated.
+func (c *Client) CountCommits(ctx context.Context, workspace, repoSlug, include, exclude string, max int) (int, bool, error) {
+	if workspace == "" || repoSlug == "" {
+		return 0, false, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	params := url.Values{}
+	params.Set("include", include)
+	params.Set("exclude", exclude)
+	params.Set("pagelen", "100")
+	params.Set("fields", "values.hash,next")
+
+	path := fmt.Sprintf("/repositories/%s/%s/commits?%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		params.Encode(),
+	)
+
+	count := 0
 	for path != "" {
 		req, err := c.http.NewRequest(ctx, "GET", path, nil)
 		if err != nil {
-			return nil, err
+			return 0, false, err
 		}
 
-		var page branchListPage
+		var page struct {
+			Values []struct {
+				Hash string `json:"hash"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
 		if err := c.http.Do(req, &page); err != nil {
-			return nil, err
+			return 0, false, err
 		}
 
-		branches = append(branches, page.Values...)
-
-		if opts.Limit > 0 && len(branches) >= opts.Limit {
-			branches = branches[:opts.Limit]
-			break
+		count += len(page.Values)
+		if max > 0 && count >= max {
+			return max, page.Next != "" || count > max, nil
 		}
 
 		if page.Next == "" {
@@ -81,10 +184,10 @@ func (c *Client) ListBranches(ctx context.Context, workspace, repoSlug string, o
 		}
 		nextURL, err := url.Parse(page.Next)
 		if err != nil {
-			return nil, err
+			return 0, false, err
 		}
 		path = nextURL.RequestURI()
 	}
 
-	return branches, nil
+	return count, false, nil
 }
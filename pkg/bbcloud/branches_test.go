@@ -0,0 +1,112 @@
+package bbcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateAndDeleteBranch(t *testing.T) {
+	const mainHash = "0123456789abcdef0123456789abcdef01234567"
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			if r.URL.Path != "/repositories/work/repo/commit/main" {
+				t.Fatalf("unexpected lookup %s", r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"hash":"` + mainHash + `"}`))
+			return
+		}
+		if r.Method == http.MethodPost {
+			var body struct {
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Name != "feature/x" || body.Target.Hash != mainHash {
+				t.Fatalf("unexpected body %+v", body)
+			}
+			_, _ = w.Write([]byte(`{"name":"feature/x","target":{"hash":"abc123"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	branch, err := client.CreateBranch(context.Background(), "work", "repo", "feature/x", "main")
+	if err != nil || branch.Target.Hash != "abc123" {
+		t.Fatalf("CreateBranch = %+v, %v", branch, err)
+	}
+	if err := client.DeleteBranch(context.Background(), "work", "repo", "feature/x"); err != nil {
+		t.Fatalf("DeleteBranch: %v", err)
+	}
+
+	if requests[0] != "GET /repositories/work/repo/commit/main" {
+		t.Fatalf("expected the branch to be resolved first, got %q", requests[0])
+	}
+	if requests[2] != "DELETE /repositories/work/repo/refs/branches/feature%2Fx" {
+		t.Fatalf("unexpected delete request %q", requests[2])
+	}
+}
+
+func TestCreateBranchFromFullHashSkipsLookup(t *testing.T) {
+	const hash = "0123456789abcdef0123456789abcdef01234567"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"hotfix","target":{"hash":"` + hash + `"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := client.CreateBranch(context.Background(), "work", "repo", "hotfix", hash); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+}
+
+func TestCountCommitsStopsAtMax(t *testing.T) {
+	var server *httptest.Server
+	pages := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		if r.URL.Query().Get("include") != "feature" || r.URL.Query().Get("exclude") != "main" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"values":[{"hash":"a"},{"hash":"b"},{"hash":"c"}],"next":"%s/repositories/work/repo/commits?include=feature&exclude=main&page=%d"}`, server.URL, pages+1)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	count, capped, err := client.CountCommits(context.Background(), "work", "repo", "feature", "main", 5)
+	if err != nil {
+		t.Fatalf("CountCommits: %v", err)
+	}
+	if count != 5 || !capped || pages != 2 {
+		t.Fatalf("count=%d capped=%v pages=%d", count, capped, pages)
+	}
+}
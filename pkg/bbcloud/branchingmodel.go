@@ -0,0 +1,157 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// BranchingModelBranch describes the development or production branch of a
+// branching model.
+type BranchingModelBranch struct {
+	Name          string `json:"name,omitempty"`
+	UseMainBranch bool   `json:"use_mainbranch"`
+	Enabled       *bool  `json:"enabled,omitempty"`
+	Branch        *struct {
+		Name string `json:"name"`
+	} `json:"branch,omitempty"`
+}
+
+// BranchName returns the branch the model resolves to, falling back to the
+// configured name when the branch does not exist yet.
+func (b *BranchingModelBranch) BranchName() string {
+	if b == nil {
+		return ""
+	}
+	if b.Branch != nil && b.Branch.Name != "" {
+		return b.Branch.Name
+	}
+	return b.Name
+}
+
+// BranchType maps a branch kind (feature, bugfix, release, hotfix) to its prefix.
+type BranchType struct {
+	Kind    string `json:"kind"`
+	Prefix  string `json:"prefix,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// BranchingModel is the effective branching model of a repository.
+type BranchingModel struct {
+	Development *BranchingModelBranch `json:"development,omitempty"`
+	Production  *BranchingModelBranch `json:"production,omitempty"`
+	BranchTypes []BranchType          `json:"branch_types,omitempty"`
+}
+
+// GetBranchingModel returns the effective branching model for a repository,
+// including settings inherited from its project.
+func (c *Client) GetBranchingModel(ctx context.Context, workspace, repoSlug string) (*BranchingModel, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/repositories/%s/%s/branching-model",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var model BranchingModel
+	if err := c.http.Do(req, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// UpdateBranchingModelInput describes branching model changes. Nil fields are
+// left unchanged.
+type UpdateBranchingModelInput struct {
+	// Development names the development branch; an empty string selects the
+	// repository's main branch.
+	Development *string
+	// Production names the production branch; an empty string selects the
+	// repository's main branch.
+	Production *string
+	// ProductionEnabled toggles whether the model has a production branch.
+	ProductionEnabled *bool
+	// Prefixes sets branch prefixes by kind. An empty prefix disables the kind.
+	Prefixes map[string]string
+}
+
+// UpdateBranchingModel changes the repository's branching model settings.
+func (c *Client) UpdateBranchingModel(ctx context.Context, workspace, repoSlug string, in UpdateBranchingModelInput) (*BranchingModel, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	body := map[string]any{}
+	if in.Development != nil {
+		body["development"] = modelBranchSetting(*in.Development, nil)
+	}
+	if in.Production != nil || in.ProductionEnabled != nil {
+		name := ""
+		if in.Production != nil {
+			name = *in.Production
+		}
+		enabled := in.ProductionEnabled
+		if enabled == nil {
+			on := true
+			enabled = &on
+		}
+		body["production"] = modelBranchSetting(name, enabled)
+	}
+	if len(in.Prefixes) > 0 {
+		kinds := make([]string, 0, len(in.Prefixes))
+		for kind := range in.Prefixes {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		types := make([]map[string]any, 0, len(kinds))
+		for _, kind := range kinds {
+			prefix := in.Prefixes[kind]
+			entry := map[string]any{
+				"kind":    kind,
+				"enabled": prefix != "",
+			}
+			if prefix != "" {
+				entry["prefix"] = prefix
+			}
+			types = append(types, entry)
+		}
+		body["branch_types"] = types
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("no branching model changes specified")
+	}
+
+	req, err := c.http.NewRequest(ctx, "PUT", fmt.Sprintf("/repositories/%s/%s/branching-model/settings",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var model BranchingModel
+	if err := c.http.Do(req, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+func modelBranchSetting(name string, enabled *bool) map[string]any {
+	setting := map[string]any{
+		"use_mainbranch": name == "",
+	}
+	if name != "" {
+		setting["name"] = name
+	}
+	if enabled != nil {
+		setting["enabled"] = *enabled
+	}
+	return setting
+}
@@ -0,0 +1,65 @@
+package bbcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateBranchingModelBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/repositories/work/repo/branching-model/settings" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Development map[string]any   `json:"development"`
+			Production  map[string]any   `json:"production"`
+			BranchTypes []map[string]any `json:"branch_types"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.Development["name"] != "develop" || body.Development["use_mainbranch"] != false {
+			t.Fatalf("unexpected development %v", body.Development)
+		}
+		if body.Production["use_mainbranch"] != true || body.Production["enabled"] != true {
+			t.Fatalf("unexpected production %v", body.Production)
+		}
+		if len(body.BranchTypes) != 2 || body.BranchTypes[0]["kind"] != "feature" || body.BranchTypes[1]["enabled"] != false {
+			t.Fatalf("unexpected branch types %v", body.BranchTypes)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	development, production := "develop", ""
+	_, err = client.UpdateBranchingModel(context.Background(), "work", "repo", UpdateBranchingModelInput{
+		Development: &development,
+		Production:  &production,
+		Prefixes:    map[string]string{"feature": "feat/", "hotfix": ""},
+	})
+	if err != nil {
+		t.Fatalf("UpdateBranchingModel: %v", err)
+	}
+}
+
+func TestBranchingModelBranchName(t *testing.T) {
+	var model BranchingModel
+	if err := json.Unmarshal([]byte(`{"development":{"name":"develop","use_mainbranch":true,"branch":{"name":"main"}}}`), &model); err != nil {
+		t.Fatal(err)
+	}
+	if got := model.Development.BranchName(); got != "main" {
+		t.Fatalf("BranchName = %q", got)
+	}
+	if got := model.Production.BranchName(); got != "" {
+		t.Fatalf("nil production BranchName = %q", got)
+	}
+}
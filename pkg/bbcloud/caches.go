@@ -0,0 +1,84 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// PipelineCache is a dependency cache saved by a pipeline step.
+type PipelineCache struct {
+	UUID          string `json:"uuid"`
+	Name          string `json:"name"`
+	Path          string `json:"path"`
+	KeyHash       string `json:"key_hash,omitempty"`
+	PipelineUUID  string `json:"pipeline_uuid,omitempty"`
+	StepUUID      string `json:"step_uuid,omitempty"`
+	FileSizeBytes int64  `json:"file_size_bytes"`
+	CreatedOn     string `json:"created_on,omitempty"`
+}
+
+// ListPipelineCaches lists the pipeline caches of a repository. A cache
+// defined with a key has one entry per key hash, all sharing its name.
+func (c *Client) ListPipelineCaches(ctx context.Context, workspace, repoSlug string) ([]PipelineCache, error) {
+	return collect(c.ListPipelineCachesIter(ctx, workspace, repoSlug), 0)
+}
+
+// ListPipelineCachesIter lazily iterates over the pipeline caches of a
+// repository, fetching a page at a time as the loop advances.
+func (c *Client) ListPipelineCachesIter(ctx context.Context, workspace, repoSlug string) iter.Seq2[PipelineCache, error] {
+	if workspace == "" || repoSlug == "" {
+		return failed[PipelineCache](fmt.Errorf("workspace and repository slug are required"))
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines-config/caches?pagelen=100",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	)
+	return paginate[PipelineCache](ctx, c, path)
+}
+
+// DeletePipelineCache deletes a single pipeline cache by UUID.
+func (c *Client) DeletePipelineCache(ctx context.Context, workspace, repoSlug, cacheUUID string) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.Trim(cacheUUID, "{} ") == "" {
+		return fmt.Errorf("cache UUID is required")
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines-config/caches/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(normalizeUUID(strings.TrimSpace(cacheUUID))),
+	)
+	req, err := c.http.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+// DeletePipelineCachesByName deletes every cache entry with the given name,
+// such as "node" or a custom cache from bitbucket-pipelines.yml.
+func (c *Client) DeletePipelineCachesByName(ctx context.Context, workspace, repoSlug, name string) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("cache name is required")
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines-config/caches?name=%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.QueryEscape(name),
+	)
+	req, err := c.http.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
@@ -0,0 +1,73 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListPipelineCachesFollowsPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/work/repo/pipelines-config/caches" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{c2}","name":"pip","path":"~/.cache/pip","file_size_bytes":2048}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"uuid":"{c1}","name":"node","path":"node_modules","file_size_bytes":1024,"created_on":"2026-01-02T03:04:05Z"}],"next":"` + server.URL + `/repositories/work/repo/pipelines-config/caches?page=2"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	caches, err := client.ListPipelineCaches(context.Background(), "work", "repo")
+	if err != nil {
+		t.Fatalf("ListPipelineCaches: %v", err)
+	}
+	if len(caches) != 2 || caches[0].Name != "node" || caches[0].FileSizeBytes != 1024 || caches[1].UUID != "{c2}" {
+		t.Fatalf("unexpected caches: %+v", caches)
+	}
+}
+
+func TestDeletePipelineCaches(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := client.DeletePipelineCache(ctx, "work", "repo", "c1"); err != nil {
+		t.Fatalf("DeletePipelineCache: %v", err)
+	}
+	if err := client.DeletePipelineCachesByName(ctx, "work", "repo", "my cache"); err != nil {
+		t.Fatalf("DeletePipelineCachesByName: %v", err)
+	}
+	if err := client.DeletePipelineCachesByName(ctx, "work", "repo", " "); err == nil {
+		t.Fatal("expected error for empty cache name")
+	}
+
+	want := []string{
+		"/repositories/work/repo/pipelines-config/caches/{c1}?",
+		"/repositories/work/repo/pipelines-config/caches?name=my+cache",
+	}
+	if len(requests) != len(want) || requests[0] != want[0] || requests[1] != want[1] {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+}
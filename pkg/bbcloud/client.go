@@ -3,8 +3,12 @@ package bbcloud
 import (
 	"context"
 	"fmt"
+	"iter"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
 	"github.com/alessandro308/bitbucket-cli/pkg/types"
@@ -12,12 +16,26 @@ import (
 
 // Options configure the Bitbucket Cloud client.
 type Options struct {
-	BaseURL     string
-	Username    string
-	Token       string
+	BaseURL  string
+	Username string
+	Token    string
+	// TokenSource supplies OAuth bearer tokens and overrides Username/Token.
+	TokenSource httpx.TokenSource
 	Workspace   string
-	EnableCache bool
-	Retry       httpx.RetryPolicy
+	// Proxy, CACertFile and InsecureSkipVerify configure the network path
+	// (see httpx.Options).
+	Proxy              string
+	CACertFile         string
+	InsecureSkipVerify bool
+	EnableCache        bool
+	// CacheDir persists ETag-validated responses on disk, keyed by
+	// CacheIdentity (see httpx.Options).
+	CacheDir      string
+	CacheIdentity string
+	// Timeout bounds each request that has no context deadline of its own;
+	// zero keeps the httpx default.
+	Timeout time.Duration
+	Retry   httpx.RetryPolicy
 }
 
 // Client wraps Bitbucket Cloud REST endpoints.
@@ -37,12 +55,19 @@ func New(opts Options) (*Client, error) {
 	}
 
 	httpClient, err := httpx.New(httpx.Options{
-		BaseURL:     opts.BaseURL,
-		Username:    opts.Username,
-		Password:    opts.Token,
-		UserAgent:   "bkt-cli",
-		EnableCache: opts.EnableCache,
-		Retry:       opts.Retry,
+		BaseURL:            opts.BaseURL,
+		Username:           opts.Username,
+		Password:           opts.Token,
+		TokenSource:        opts.TokenSource,
+		UserAgent:          "bkt-cli",
+		Timeout:            opts.Timeout,
+		Proxy:              opts.Proxy,
+		CACertFile:         opts.CACertFile,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+		EnableCache:        opts.EnableCache,
+		CacheDir:           opts.CacheDir,
+		CacheIdentity:      opts.CacheIdentity,
+		Retry:              opts.Retry,
 	})
 	if err != nil {
 		return nil, err
@@ -72,14 +97,53 @@ func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
 	return &user, nil
 }
 
+// CurrentUserScopes retrieves the authenticated user along with the scopes
+// granted to the credential, as advertised in the X-OAuth-Scopes response
+// header. Scopes is nil when Bitbucket does not report them, which is the
+// case for unscoped credentials.
+func (c *Client) CurrentUserScopes(ctx context.Context) (*User, []string, error) {
+	req, err := c.http.NewRequest(ctx, "GET", "/user", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var header http.Header
+	req = httpx.CaptureHeaders(req, &header)
+
+	var user User
+	if err := c.http.Do(req, &user); err != nil {
+		return nil, nil, err
+	}
+	return &user, parseScopes(header.Get("X-OAuth-Scopes")), nil
+}
+
+func parseScopes(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	return fields
+}
+
 // Repository identifies a Bitbucket Cloud repository.
 type Repository struct {
-	UUID      string `json:"uuid"`
-	Name      string `json:"name"`
-	Slug      string `json:"slug"`
-	SCM       string `json:"scm"`
-	IsPrivate bool   `json:"is_private"`
-	Links     struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	SCM         string `json:"scm"`
+	IsPrivate   bool   `json:"is_private"`
+	Description string `json:"description,omitempty"`
+	UpdatedOn   string `json:"updated_on,omitempty"`
+	CreatedOn   string `json:"created_on,omitempty"`
+	Language    string `json:"language,omitempty"`
+	ForkPolicy  string `json:"fork_policy,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	MainBranch  *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch,omitempty"`
+	Links struct {
 		Clone []struct {
 			Href string `json:"href"`
 			Name string `json:"name"`
@@ -190,61 +254,53 @@ func (c *Client) ListPipelines(ctx context.Context, workspace, repoSlug string,
 	return pipelines, nil
 }
 
-// RepositoryListPage encapsulates paginated repository responses.
-type repositoryListPage struct {
-	Values []Repository `json:"values"`
-	Next   string       `json:"next"`
+// ListRepositoriesOptions configures workspace repository listings.
+type ListRepositoriesOptions struct {
+	// Role restricts results to repositories where the caller holds the role
+	// (member, contributor, admin, owner).
+	Role string
+	// Query is a raw BBQL expression, e.g. name ~ "api".
+	Query string
+	// Sort orders results by a field, e.g. "-updated_on".
+	Sort  string
+	Limit int
+}
+
+// ListRepositories enumerates repositories for the workspace, returning at
+// most opts.Limit entries when it is positive.
+func (c *Client) ListRepositories(ctx context.Context, workspace string, opts ListRepositoriesOptions) ([]Repository, error) {
+	return collect(c.ListRepositoriesIter(ctx, workspace, opts), opts.Limit)
 }
 
-// ListRepositories enumerates repositories for the workspace.
-func (c *Client) ListRepositories(ctx context.Context, workspace string, limit int) ([]Repository, error) {
+// ListRepositoriesIter lazily iterates over the repositories of a workspace;
+// opts.Limit only sizes the pages.
+func (c *Client) ListRepositoriesIter(ctx context.Context, workspace string, opts ListRepositoriesOptions) iter.Seq2[Repository, error] {
 	if workspace == "" {
-		return nil, fmt.Errorf("workspace is required")
+		return failed[Repository](fmt.Errorf("workspace is required"))
 	}
 
-	pageLen := limit
+	pageLen := opts.Limit
 	if pageLen <= 0 || pageLen > 100 {
 		pageLen = 20
 	}
 
-	path := fmt.Sprintf("/repositories/%s?pagelen=%d",
-		url.PathEscape(workspace),
-		pageLen,
-	)
-
-	var repos []Repository
-
-	for path != "" {
-		req, err := c.http.NewRequest(ctx, "GET", path, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var page repositoryListPage
-		if err := c.http.Do(req, &page); err != nil {
-			return nil, err
-		}
-
-		repos = append(repos, page.Values...)
-
-		if limit > 0 && len(repos) >= limit {
-			repos = repos[:limit]
-			break
-		}
-
-		if page.Next == "" {
-			break
-		}
-
-		// Bitbucket returns absolute URLs for next; reuse as-is.
-		pathURL, err := url.Parse(page.Next)
-		if err != nil {
-			return nil, err
-		}
-		path = pathURL.RequestURI()
+	var params []string
+	params = append(params, fmt.Sprintf("pagelen=%d", pageLen))
+	if role := strings.TrimSpace(opts.Role); role != "" {
+		params = append(params, "role="+url.QueryEscape(strings.ToLower(role)))
+	}
+	if query := strings.TrimSpace(opts.Query); query != "" {
+		params = append(params, "q="+url.QueryEscape(query))
+	}
+	if sort := strings.TrimSpace(opts.Sort); sort != "" {
+		params = append(params, "sort="+url.QueryEscape(sort))
 	}
 
-	return repos, nil
+	path := fmt.Sprintf("/repositories/%s?%s",
+		url.PathEscape(workspace),
+		strings.Join(params, "&"),
+	)
+	return paginate[Repository](ctx, c, path)
 }
 
 // GetRepository retrieves repository details.
@@ -320,6 +376,58 @@ func (c *Client) CreateRepository(ctx context.Context, workspace string, input C
 	return &repo, nil
 }
 
+// UpdateRepositoryInput describes mutable repository settings. Nil fields are
+// left unchanged.
+type UpdateRepositoryInput struct {
+	Name          *string
+	Description   *string
+	DefaultBranch *string
+	ForkPolicy    *string // allow_forks, no_public_forks, no_forks
+	IsPrivate     *bool
+}
+
+// UpdateRepository changes repository settings.
+func (c *Client) UpdateRepository(ctx context.Context, workspace, repoSlug string, input UpdateRepositoryInput) (*Repository, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	body := make(map[string]any)
+	if input.Name != nil {
+		body["name"] = *input.Name
+	}
+	if input.Description != nil {
+		body["description"] = *input.Description
+	}
+	if input.DefaultBranch != nil {
+		body["mainbranch"] = map[string]any{
+			"type": "branch",
+			"name": *input.DefaultBranch,
+		}
+	}
+	if input.ForkPolicy != nil {
+		body["fork_policy"] = *input.ForkPolicy
+	}
+	if input.IsPrivate != nil {
+		body["is_private"] = *input.IsPrivate
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	)
+	req, err := c.http.NewRequest(ctx, "PUT", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if err := c.http.Do(req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
 // TriggerPipelineInput configures a pipeline run.
 type TriggerPipelineInput struct {
 	Ref       string
@@ -452,6 +560,10 @@ type PipelineLog struct {
 // Type alias to shared types.CommitStatus for backward compatibility.
 type CommitStatus = types.CommitStatus
 
+// BuildStatusInput is the body CreateCommitStatus posts to a commit's
+// statuses/build endpoint.
+type BuildStatusInput = types.BuildStatusInput
+
 // GetPipelineLogs fetches logs for a pipeline step.
 func (c *Client) GetPipelineLogs(ctx context.Context, workspace, repoSlug, pipelineUUID, stepUUID string) ([]byte, error) {
 	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps/%s/log",
@@ -560,7 +672,7 @@ func (c *Client) ListWorkspacePullRequests(ctx context.Context, workspace, usern
 			return nil, err
 		}
 
-		var page pullRequestListPage
+		var page listPage[PullRequest]
 		if err := c.http.Do(req, &page); err != nil {
 			return nil, err
 		}
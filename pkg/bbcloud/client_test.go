@@ -340,3 +340,118 @@ func TestNormalizeUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestListRepositoriesAppliesFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/work" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("role") != "admin" {
+			t.Fatalf("expected role=admin, got %q", query.Get("role"))
+		}
+		if query.Get("q") != `name ~ "api"` {
+			t.Fatalf("unexpected q %q", query.Get("q"))
+		}
+		if query.Get("sort") != "-updated_on" {
+			t.Fatalf("unexpected sort %q", query.Get("sort"))
+		}
+		if query.Get("pagelen") != "2" {
+			t.Fatalf("unexpected pagelen %q", query.Get("pagelen"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(listPage[Repository]{
+			Values: []Repository{{Slug: "api"}, {Slug: "api-docs"}, {Slug: "api-old"}},
+			Next:   "https://example.invalid/should-not-follow",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	repos, err := client.ListRepositories(context.Background(), "work", ListRepositoriesOptions{
+		Role:  "Admin",
+		Query: `name ~ "api"`,
+		Sort:  "-updated_on",
+		Limit: 2,
+	})
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 2 || repos[1].Slug != "api-docs" {
+		t.Fatalf("unexpected repositories: %+v", repos)
+	}
+}
+
+func TestUpdateRepositorySendsOnlyChangedFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/repositories/work/repo" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body) != 2 {
+			t.Fatalf("expected only description and mainbranch, got %v", body)
+		}
+		if body["description"] != "" {
+			t.Fatalf("expected description to be cleared, got %v", body["description"])
+		}
+		main, _ := body["mainbranch"].(map[string]any)
+		if main["name"] != "develop" {
+			t.Fatalf("unexpected mainbranch %v", body["mainbranch"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","mainbranch":{"name":"develop"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	description, branch := "", "develop"
+	repo, err := client.UpdateRepository(context.Background(), "work", "repo", UpdateRepositoryInput{
+		Description:   &description,
+		DefaultBranch: &branch,
+	})
+	if err != nil {
+		t.Fatalf("UpdateRepository: %v", err)
+	}
+	if repo.MainBranch == nil || repo.MainBranch.Name != "develop" {
+		t.Fatalf("unexpected repository: %+v", repo)
+	}
+}
+
+func TestCurrentUserScopesReadsHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-OAuth-Scopes", "repository, pullrequest:write account")
+		_, _ = w.Write([]byte(`{"username":"alice","display_name":"Alice"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	user, scopes, err := client.CurrentUserScopes(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentUserScopes: %v", err)
+	}
+	if user.Username != "alice" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+	if strings.Join(scopes, ",") != "account,pullrequest:write,repository" {
+		t.Fatalf("unexpected scopes %v", scopes)
+	}
+}
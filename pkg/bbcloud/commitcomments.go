@@ -0,0 +1,113 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CommitComment represents a comment on a commit, optionally anchored to a
+// file line.
+type CommitComment struct {
+	PullRequestComment
+	Inline *struct {
+		Path string `json:"path"`
+		From *int   `json:"from,omitempty"`
+		To   *int   `json:"to,omitempty"`
+	} `json:"inline,omitempty"`
+	Deleted bool `json:"deleted"`
+}
+
+type commitCommentListPage struct {
+	Values []CommitComment `json:"values"`
+	Next   string          `json:"next"`
+}
+
+// ListCommitComments lists comments on a commit, oldest first.
+func (c *Client) ListCommitComments(ctx context.Context, workspace, repoSlug, hash string, limit int) ([]CommitComment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if hash == "" {
+		return nil, fmt.Errorf("commit SHA is required")
+	}
+
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 30
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/comments?pagelen=%d",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(hash),
+		pageLen,
+	)
+
+	var comments []CommitComment
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page commitCommentListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page.Values...)
+
+		if limit > 0 && len(comments) >= limit {
+			comments = comments[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return comments, nil
+}
+
+// CreateCommitComment comments on a commit. Set FilePath and Line in the
+// options for an inline comment, exactly as for pull request comments.
+func (c *Client) CreateCommitComment(ctx context.Context, workspace, repoSlug, hash string, opts CommentPullRequestOptions) (*CommitComment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if hash == "" {
+		return nil, fmt.Errorf("commit SHA is required")
+	}
+	if strings.TrimSpace(opts.Text) == "" {
+		return nil, fmt.Errorf("comment text is required")
+	}
+
+	payload, err := commentPayload(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/commit/%s/comments",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(hash),
+	), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment CommitComment
+	if err := c.http.Do(req, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
@@ -0,0 +1,162 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Commit represents a Bitbucket Cloud commit.
+type Commit struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+	Date    string `json:"date"`
+	Author  struct {
+		Raw  string   `json:"raw"`
+		User *Account `json:"user,omitempty"`
+	} `json:"author"`
+	Parents []struct {
+		Hash string `json:"hash"`
+	} `json:"parents,omitempty"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// CommitListOptions configure commit listings.
+type CommitListOptions struct {
+	// Include lists the refs or hashes to walk from; empty means the main branch.
+	Include []string
+	// Exclude lists refs or hashes whose ancestors are omitted.
+	Exclude []string
+	// Path restricts results to commits touching the given file or directory.
+	Path  string
+	Limit int
+}
+
+type commitListPage struct {
+	Values []Commit `json:"values"`
+	Next   string   `json:"next"`
+}
+
+// ListCommits lists commits, newest first.
+func (c *Client) ListCommits(ctx context.Context, workspace, repoSlug string, opts CommitListOptions) ([]Commit, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	pageLen := opts.Limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	params := url.Values{}
+	params.Set("pagelen", fmt.Sprintf("%d", pageLen))
+	for _, ref := range opts.Include {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			params.Add("include", ref)
+		}
+	}
+	for _, ref := range opts.Exclude {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			params.Add("exclude", ref)
+		}
+	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/commits?%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		params.Encode(),
+	)
+
+	var commits []Commit
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page commitListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, page.Values...)
+
+		if opts.Limit > 0 && len(commits) >= opts.Limit {
+			commits = commits[:opts.Limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return commits, nil
+}
+
+// GetCommit fetches a single commit by hash or ref.
+func (c *Client) GetCommit(ctx context.Context, workspace, repoSlug, revision string) (*Commit, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(revision) == "" {
+		return nil, fmt.Errorf("commit is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/repositories/%s/%s/commit/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(revision),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var commit Commit
+	if err := c.http.Do(req, &commit); err != nil {
+		return nil, err
+	}
+	return &commit, nil
+}
+
+// CreateCommitStatus publishes a build status for a commit. Reusing the key of
+// an existing status updates it in place.
+func (c *Client) CreateCommitStatus(ctx context.Context, workspace, repoSlug, hash string, in BuildStatusInput) (*CommitStatus, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if hash == "" {
+		return nil, fmt.Errorf("commit SHA is required")
+	}
+	if in.Key == "" || in.State == "" || in.URL == "" {
+		return nil, fmt.Errorf("build status key, state and url are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses/build",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(hash),
+	), in)
+	if err != nil {
+		return nil, err
+	}
+
+	var status CommitStatus
+	if err := c.http.Do(req, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
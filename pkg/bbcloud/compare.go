@@ -0,0 +1,144 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
+)
+
+// DiffStatPath is one side of a changed file.
+type DiffStatPath struct {
+	Path string `json:"path"`
+}
+
+// DiffStatEntry summarises the changes to a single file.
+type DiffStatEntry struct {
+	// Status is added, removed, modified, renamed or merge conflict.
+	Status       string        `json:"status"`
+	LinesAdded   int           `json:"lines_added"`
+	LinesRemoved int           `json:"lines_removed"`
+	Old          *DiffStatPath `json:"old,omitempty"`
+	New          *DiffStatPath `json:"new,omitempty"`
+}
+
+// Path returns the file's path after the change, or before it for removed
+// files.
+func (e DiffStatEntry) Path() string {
+	if e.New != nil {
+		return e.New.Path
+	}
+	if e.Old != nil {
+		return e.Old.Path
+	}
+	return ""
+}
+
+// Comparison is the result of comparing two refs: the commits reachable from
+// Head but not Base, and the files they change since the refs diverged.
+type Comparison struct {
+	Base    string   `json:"base"`
+	Head    string   `json:"head"`
+	Commits []Commit `json:"commits"`
+	// CommitsTruncated reports that Head has more commits over Base than the
+	// limit CompareRefs was given.
+	CommitsTruncated bool            `json:"commits_truncated"`
+	Files            []DiffStatEntry `json:"files"`
+}
+
+// ParseCompareSpec splits a git-style range, base..head or base...head, into
+// its refs. Both forms compare head against its merge base with base, as a
+// pull request from head into base would.
+func ParseCompareSpec(spec string) (base, head string, err error) {
+	sep := ".."
+	if strings.Contains(spec, "...") {
+		sep = "..."
+	}
+	base, head, found := strings.Cut(strings.TrimSpace(spec), sep)
+	if !found || base == "" || head == "" {
+		return "", "", fmt.Errorf("invalid range %q: expected <base>..<head>", spec)
+	}
+	return base, head, nil
+}
+
+// compareSpec converts a git-style range to Bitbucket's diff spec, which names
+// the head first.
+func compareSpec(spec string) (base, head, escaped string, err error) {
+	base, head, err = ParseCompareSpec(spec)
+	if err != nil {
+		return "", "", "", err
+	}
+	return base, head, url.PathEscape(head) + ".." + url.PathEscape(base), nil
+}
+
+// CompareRefs lists the commits and file changes between the refs of spec, a
+// git-style range such as main..release/1.4 or a pair of commit hashes. At
+// most limit commits are listed when limit is positive; all files are.
+func (c *Client) CompareRefs(ctx context.Context, workspace, repoSlug, spec string, limit int) (*Comparison, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	base, head, escaped, err := compareSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra commit tells whether the list was cut short.
+	fetch := 0
+	if limit > 0 {
+		fetch = limit + 1
+	}
+	commits, err := c.ListCommits(ctx, workspace, repoSlug, CommitListOptions{
+		Include: []string{head},
+		Exclude: []string{base},
+		Limit:   fetch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	truncated := limit > 0 && len(commits) > limit
+	if truncated {
+		commits = commits[:limit]
+	}
+
+	files, err := collect(paginate[DiffStatEntry](ctx, c, fmt.Sprintf("/repositories/%s/%s/diffstat/%s?pagelen=100",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		escaped,
+	)), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comparison{Base: base, Head: head, Commits: commits, CommitsTruncated: truncated, Files: files}, nil
+}
+
+// CompareDiff streams the unified diff between the refs of spec, a git-style
+// range as accepted by CompareRefs, into w.
+func (c *Client) CompareDiff(ctx context.Context, workspace, repoSlug, spec string, w io.Writer) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+	_, _, escaped, err := compareSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/repositories/%s/%s/diff/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		escaped,
+	), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	return c.http.Do(httpx.Unbounded(req), w)
+}
@@ -0,0 +1,81 @@
+package bbcloud
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseCompareSpec(t *testing.T) {
+	cases := []struct {
+		spec, base, head string
+	}{
+		{"main..release/1.4", "main", "release/1.4"},
+		{"v1.3.0...v1.4.0", "v1.3.0", "v1.4.0"},
+		{"3a8b42..9ff173", "3a8b42", "9ff173"},
+	}
+	for _, tc := range cases {
+		base, head, err := ParseCompareSpec(tc.spec)
+		if err != nil || base != tc.base || head != tc.head {
+			t.Errorf("ParseCompareSpec(%q) = %q, %q, %v", tc.spec, base, head, err)
+		}
+	}
+	for _, spec := range []string{"main", "main..", "..main", ""} {
+		if _, _, err := ParseCompareSpec(spec); err == nil {
+			t.Errorf("ParseCompareSpec(%q) succeeded", spec)
+		}
+	}
+}
+
+func TestCompareRefsListsCommitsAndFiles(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/repositories/work/repo/commits":
+			query := r.URL.Query()
+			if query.Get("include") != "release/1.4" || query.Get("exclude") != "main" {
+				t.Errorf("commit query = %v", query)
+			}
+			_, _ = w.Write([]byte(`{"values":[{"hash":"9ff173","message":"Bump version"},{"hash":"3a8b42","message":"Fix login"}]}`))
+		case "/repositories/work/repo/diffstat/release%2F1.4..main":
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`{"values":[{"status":"removed","lines_removed":4,"old":{"path":"old.txt"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"values":[{"status":"modified","lines_added":3,"lines_removed":1,"old":{"path":"VERSION"},"new":{"path":"VERSION"}}],"next":"` + server.URL + `/repositories/work/repo/diffstat/release%2F1.4..main?page=2"}`))
+		case "/repositories/work/repo/diff/release%2F1.4..main":
+			_, _ = w.Write([]byte("diff --git a/VERSION b/VERSION\n"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	cmp, err := client.CompareRefs(context.Background(), "work", "repo", "main..release/1.4", 0)
+	if err != nil {
+		t.Fatalf("CompareRefs: %v", err)
+	}
+	if cmp.Base != "main" || cmp.Head != "release/1.4" || len(cmp.Commits) != 2 {
+		t.Fatalf("comparison = %+v", cmp)
+	}
+	if len(cmp.Files) != 2 || cmp.Files[0].Path() != "VERSION" || cmp.Files[1].Path() != "old.txt" {
+		t.Fatalf("files = %+v", cmp.Files)
+	}
+
+	var buf bytes.Buffer
+	if err := client.CompareDiff(context.Background(), "work", "repo", "main...release/1.4", &buf); err != nil {
+		t.Fatalf("CompareDiff: %v", err)
+	}
+	if buf.String() != "diff --git a/VERSION b/VERSION\n" {
+		t.Fatalf("diff = %q", buf.String())
+	}
+}
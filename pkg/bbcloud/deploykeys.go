@@ -0,0 +1,120 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DeployKey is a read-only SSH key granting access to a single repository.
+type DeployKey struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedOn string `json:"created_on,omitempty"`
+	LastUsed  string `json:"last_used,omitempty"`
+}
+
+type deployKeyListPage struct {
+	Values []DeployKey `json:"values"`
+	Next   string      `json:"next"`
+}
+
+// ListDeployKeys lists the deploy keys registered on a repository.
+func (c *Client) ListDeployKeys(ctx context.Context, workspace, repoSlug string, limit int) ([]DeployKey, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/deploy-keys?pagelen=%d",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		pageLen,
+	)
+
+	var keys []DeployKey
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page deployKeyListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, page.Values...)
+
+		if limit > 0 && len(keys) >= limit {
+			keys = keys[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return keys, nil
+}
+
+// AddDeployKey registers a public SSH key as a deploy key on the repository.
+func (c *Client) AddDeployKey(ctx context.Context, workspace, repoSlug, label, key string) (*DeployKey, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("public key is required")
+	}
+
+	body := map[string]any{
+		"key":   key,
+		"label": label,
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/deploy-keys",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployKey DeployKey
+	if err := c.http.Do(req, &deployKey); err != nil {
+		return nil, err
+	}
+	return &deployKey, nil
+}
+
+// DeleteDeployKey removes a deploy key from the repository.
+func (c *Client) DeleteDeployKey(ctx context.Context, workspace, repoSlug string, id int) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/repositories/%s/%s/deploy-keys/%d",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		id,
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
@@ -0,0 +1,67 @@
+package bbcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeployKeyRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"values":[{"id":7,"key":"ssh-ed25519 AAAA","label":"ci"}]}`))
+		case http.MethodPost:
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body["key"] != "ssh-ed25519 AAAA deploy@host" || body["label"] != "deploy" {
+				t.Fatalf("unexpected body: %v", body)
+			}
+			_, _ = w.Write([]byte(`{"id":8,"key":"ssh-ed25519 AAAA","label":"deploy"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	keys, err := client.ListDeployKeys(ctx, "work", "repo", 0)
+	if err != nil || len(keys) != 1 || keys[0].ID != 7 {
+		t.Fatalf("ListDeployKeys = %+v, %v", keys, err)
+	}
+
+	key, err := client.AddDeployKey(ctx, "work", "repo", "deploy", "ssh-ed25519 AAAA deploy@host\n")
+	if err != nil || key.ID != 8 {
+		t.Fatalf("AddDeployKey = %+v, %v", key, err)
+	}
+
+	if err := client.DeleteDeployKey(ctx, "work", "repo", 8); err != nil {
+		t.Fatalf("DeleteDeployKey: %v", err)
+	}
+
+	want := []string{
+		"GET /repositories/work/repo/deploy-keys",
+		"POST /repositories/work/repo/deploy-keys",
+		"DELETE /repositories/work/repo/deploy-keys/8",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v", requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Fatalf("request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
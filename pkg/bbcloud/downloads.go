@@ -0,0 +1,151 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
+)
+
+// Download is a file in a repository's Downloads section.
+type Download struct {
+	Name      string   `json:"name"`
+	Size      int64    `json:"size"`
+	Downloads int      `json:"downloads"`
+	CreatedOn string   `json:"created_on"`
+	User      *Account `json:"user,omitempty"`
+	Links     struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+type downloadListPage struct {
+	Values []Download `json:"values"`
+	Next   string     `json:"next"`
+}
+
+// ListDownloads lists the files in a repository's Downloads section, newest first.
+func (c *Client) ListDownloads(ctx context.Context, workspace, repoSlug string, limit int) ([]Download, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/downloads?pagelen=%d",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		pageLen,
+	)
+
+	var downloads []Download
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page downloadListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		downloads = append(downloads, page.Values...)
+
+		if limit > 0 && len(downloads) >= limit {
+			downloads = downloads[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return downloads, nil
+}
+
+// UploadDownload uploads a local file to the repository's Downloads section.
+// The file is streamed rather than buffered, so release artifacts of any size
+// can be uploaded; an existing download with the same name is replaced.
+func (c *Client) UploadDownload(ctx context.Context, workspace, repoSlug, filePath string) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", filePath)
+	}
+
+	return c.UploadDownloadFrom(ctx, workspace, repoSlug, filepath.Base(filePath), info.Size(), func() (io.ReadCloser, error) {
+		return os.Open(filePath)
+	})
+}
+
+// UploadDownloadFrom uploads content produced by open under the given name.
+// Pass size -1 when the length is unknown. open is called again on retries.
+func (c *Client) UploadDownloadFrom(ctx context.Context, workspace, repoSlug, name string, size int64, open func() (io.ReadCloser, error)) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if name == "" {
+		return fmt.Errorf("file name is required")
+	}
+
+	req, err := c.http.NewStreamingMultipartRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/downloads",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	), nil, []httpx.StreamFile{{
+		FieldName: "files",
+		FileName:  name,
+		Size:      size,
+		Open:      open,
+	}})
+	if err != nil {
+		return err
+	}
+
+	return c.http.Do(req, nil)
+}
+
+// GetDownload streams a file from the repository's Downloads section to w.
+// The API redirects to the file storage, which the HTTP client follows.
+func (c *Client) GetDownload(ctx context.Context, workspace, repoSlug, name string, w io.Writer) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if name == "" {
+		return fmt.Errorf("file name is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/repositories/%s/%s/downloads/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(name),
+	), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	return c.http.Do(httpx.Unbounded(req), w)
+}
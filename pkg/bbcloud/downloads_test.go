@@ -0,0 +1,68 @@
+package bbcloud
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUploadDownloadStreamsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app-1.0.tar.gz")
+	if err := os.WriteFile(path, []byte("release"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repositories/work/repo/downloads" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		f, header, err := r.FormFile("files")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		data, _ := io.ReadAll(f)
+		if header.Filename != "app-1.0.tar.gz" || string(data) != "release" {
+			t.Fatalf("unexpected upload %q: %q", header.Filename, data)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.UploadDownload(context.Background(), "work", "repo", path); err != nil {
+		t.Fatalf("UploadDownload: %v", err)
+	}
+}
+
+func TestGetDownloadFollowsRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repositories/work/repo/downloads/app.zip", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/storage/app.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/app.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("zip-bytes"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.GetDownload(context.Background(), "work", "repo", "app.zip", &buf); err != nil {
+		t.Fatalf("GetDownload: %v", err)
+	}
+	if buf.String() != "zip-bytes" {
+		t.Fatalf("unexpected content %q", buf.String())
+	}
+}
@@ -0,0 +1,20 @@
+package bbcloud
+
+import "github.com/alessandro308/bitbucket-cli/pkg/httpx"
+
+// APIError is returned for non-2xx responses; use errors.As to inspect the
+// status code and the error details reported by Bitbucket.
+type APIError = httpx.APIError
+
+// TimeoutError is returned when a request exceeds its deadline.
+type TimeoutError = httpx.TimeoutError
+
+// Sentinel errors for common failure kinds, matched with errors.Is.
+var (
+	ErrUnauthorized = httpx.ErrUnauthorized
+	ErrPermission   = httpx.ErrPermission
+	ErrNotFound     = httpx.ErrNotFound
+	ErrConflict     = httpx.ErrConflict
+	ErrRateLimited  = httpx.ErrRateLimited
+	ErrTimeout      = httpx.ErrTimeout
+)
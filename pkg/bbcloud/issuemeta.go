@@ -0,0 +1,60 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// The issue tracker's milestones, versions and components are configured in
+// the repository settings; the API only exposes them for reading.
+
+// ListIssueMilestones lists the milestones defined for a repository's issue
+// tracker.
+func (c *Client) ListIssueMilestones(ctx context.Context, workspace, repoSlug string) ([]IssueMilestone, error) {
+	return collect(c.ListIssueMilestonesIter(ctx, workspace, repoSlug), 0)
+}
+
+// ListIssueMilestonesIter lazily iterates over the milestones of a
+// repository's issue tracker.
+func (c *Client) ListIssueMilestonesIter(ctx context.Context, workspace, repoSlug string) iter.Seq2[IssueMilestone, error] {
+	return listIssueMeta[IssueMilestone](ctx, c, workspace, repoSlug, "milestones")
+}
+
+// ListIssueVersions lists the versions defined for a repository's issue
+// tracker.
+func (c *Client) ListIssueVersions(ctx context.Context, workspace, repoSlug string) ([]IssueVersion, error) {
+	return collect(c.ListIssueVersionsIter(ctx, workspace, repoSlug), 0)
+}
+
+// ListIssueVersionsIter lazily iterates over the versions of a repository's
+// issue tracker.
+func (c *Client) ListIssueVersionsIter(ctx context.Context, workspace, repoSlug string) iter.Seq2[IssueVersion, error] {
+	return listIssueMeta[IssueVersion](ctx, c, workspace, repoSlug, "versions")
+}
+
+// ListIssueComponents lists the components defined for a repository's issue
+// tracker.
+func (c *Client) ListIssueComponents(ctx context.Context, workspace, repoSlug string) ([]IssueComponent, error) {
+	return collect(c.ListIssueComponentsIter(ctx, workspace, repoSlug), 0)
+}
+
+// ListIssueComponentsIter lazily iterates over the components of a
+// repository's issue tracker.
+func (c *Client) ListIssueComponentsIter(ctx context.Context, workspace, repoSlug string) iter.Seq2[IssueComponent, error] {
+	return listIssueMeta[IssueComponent](ctx, c, workspace, repoSlug, "components")
+}
+
+func listIssueMeta[T any](ctx context.Context, c *Client, workspace, repoSlug, collection string) iter.Seq2[T, error] {
+	if workspace == "" || repoSlug == "" {
+		return failed[T](fmt.Errorf("workspace and repository slug are required"))
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/%s?pagelen=100",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		collection,
+	)
+	return paginate[T](ctx, c, path)
+}
@@ -0,0 +1,64 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListIssueMetadata(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repositories/work/repo/milestones":
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`{"values":[{"id":2,"name":"M2"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"values":[{"id":1,"name":"M1"}],"next":"` + server.URL + `/repositories/work/repo/milestones?page=2"}`))
+		case "/repositories/work/repo/versions":
+			_, _ = w.Write([]byte(`{"values":[{"id":7,"name":"1.0"}]}`))
+		case "/repositories/work/repo/components":
+			_, _ = w.Write([]byte(`{"values":[{"id":3,"name":"api"},{"id":4,"name":"ui"}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	milestones, err := client.ListIssueMilestones(ctx, "work", "repo")
+	if err != nil {
+		t.Fatalf("ListIssueMilestones: %v", err)
+	}
+	if len(milestones) != 2 || milestones[0].Name != "M1" || milestones[1].ID != 2 {
+		t.Fatalf("unexpected milestones: %+v", milestones)
+	}
+
+	versions, err := client.ListIssueVersions(ctx, "work", "repo")
+	if err != nil {
+		t.Fatalf("ListIssueVersions: %v", err)
+	}
+	if len(versions) != 1 || versions[0].Name != "1.0" {
+		t.Fatalf("unexpected versions: %+v", versions)
+	}
+
+	components, err := client.ListIssueComponents(ctx, "work", "repo")
+	if err != nil {
+		t.Fatalf("ListIssueComponents: %v", err)
+	}
+	if len(components) != 2 || components[1].Name != "ui" {
+		t.Fatalf("unexpected components: %+v", components)
+	}
+
+	if _, err := client.ListIssueComponents(ctx, "", "repo"); err == nil {
+		t.Fatal("expected error without workspace")
+	}
+}
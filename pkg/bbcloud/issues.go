@@ -497,3 +497,73 @@ func (c *Client) CreateIssueComment(ctx context.Context, workspace, repoSlug str
 	}
 	return &comment, nil
 }
+
+// IssueChangeInput describes a transition recorded through the issue changes
+// endpoint. Empty or nil fields are left unchanged.
+type IssueChangeInput struct {
+	// State is the new state, e.g. "open", "resolved" or "closed".
+	State string
+	// AssigneeAccountID reassigns the issue; an empty string unassigns it.
+	AssigneeAccountID *string
+	// Component sets the component by name; an empty string clears it.
+	Component *string
+	// Message is an optional comment posted alongside the change.
+	Message string
+}
+
+// IssueChange is a recorded change to an issue's attributes.
+type IssueChange struct {
+	ID      int                       `json:"id"`
+	Changes map[string]map[string]any `json:"changes"`
+	Message *struct {
+		Raw string `json:"raw"`
+	} `json:"message,omitempty"`
+	CreatedOn string   `json:"created_on"`
+	User      *Account `json:"user"`
+	Issue     *Issue   `json:"issue"`
+}
+
+// ChangeIssueState transitions an issue (for example new → open → resolved)
+// and optionally reassigns it, sets its component and leaves a comment, all
+// recorded as a single entry in the issue history.
+func (c *Client) ChangeIssueState(ctx context.Context, workspace, repoSlug string, issueID int, input IssueChangeInput) (*IssueChange, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	changes := make(map[string]any)
+	if state := strings.TrimSpace(input.State); state != "" {
+		changes["state"] = map[string]any{"new": state}
+	}
+	if input.AssigneeAccountID != nil {
+		changes["assignee_account_id"] = map[string]any{"new": *input.AssigneeAccountID}
+	}
+	if input.Component != nil {
+		changes["component"] = map[string]any{"new": *input.Component}
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no issue changes specified")
+	}
+
+	payload := map[string]any{"changes": changes}
+	if strings.TrimSpace(input.Message) != "" {
+		payload["message"] = map[string]any{"raw": input.Message}
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/issues/%d/changes",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		issueID,
+	)
+
+	req, err := c.http.NewRequest(ctx, "POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var change IssueChange
+	if err := c.http.Do(req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
@@ -295,3 +295,57 @@ func TestListIssuesRespectsLimit(t *testing.T) {
 		t.Errorf("expected 1 request (limit satisfied), got %d", requestCount)
 	}
 }
+
+func TestChangeIssueStatePayload(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repositories/ws/repo/issues/42/changes" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":9,"changes":{"state":{"old":"open","new":"resolved"}},"issue":{"id":42,"title":"Crash"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	unassign := ""
+	change, err := client.ChangeIssueState(context.Background(), "ws", "repo", 42, IssueChangeInput{
+		State:             "resolved",
+		AssigneeAccountID: &unassign,
+		Message:           "Fixed",
+	})
+	if err != nil {
+		t.Fatalf("ChangeIssueState: %v", err)
+	}
+	if change.Issue == nil || change.Issue.Title != "Crash" || change.Changes["state"]["new"] != "resolved" {
+		t.Fatalf("unexpected change %+v", change)
+	}
+
+	changes, _ := body["changes"].(map[string]any)
+	state, _ := changes["state"].(map[string]any)
+	assignee, _ := changes["assignee_account_id"].(map[string]any)
+	message, _ := body["message"].(map[string]any)
+	if state["new"] != "resolved" || assignee["new"] != "" || message["raw"] != "Fixed" {
+		t.Fatalf("unexpected payload %+v", body)
+	}
+	if _, ok := changes["component"]; ok {
+		t.Fatalf("component should be omitted when unset: %+v", changes)
+	}
+}
+
+func TestChangeIssueStateRequiresChanges(t *testing.T) {
+	client, err := New(Options{BaseURL: "http://example.invalid"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := client.ChangeIssueState(context.Background(), "ws", "repo", 1, IssueChangeInput{Message: "hi"}); err == nil {
+		t.Fatal("expected error without changes")
+	}
+}
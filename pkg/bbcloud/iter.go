@@ -0,0 +1,76 @@
+package bbcloud
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// listPage is the envelope of paginated Bitbucket Cloud listings.
+type listPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// paginate lazily walks a paginated listing starting at path, following the
+// next links one page at a time. Iteration stops at the first error, which is
+// yielded with a zero value; breaking out of the loop stops further requests.
+func paginate[T any](ctx context.Context, c *Client, path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for path != "" {
+			req, err := c.http.NewRequest(ctx, "GET", path, nil)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var page listPage[T]
+			if err := c.http.Do(req, &page); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, v := range page.Values {
+				if !yield(v, nil) {
+					return
+				}
+			}
+
+			if page.Next == "" {
+				return
+			}
+			// Bitbucket returns absolute URLs for next; keep them relative
+			// to the client's base URL.
+			nextURL, err := url.Parse(page.Next)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			path = nextURL.RequestURI()
+		}
+	}
+}
+
+// failed returns an iterator yielding only err.
+func failed[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// collect gathers up to limit items from seq (all of them when limit <= 0).
+func collect[T any](seq iter.Seq2[T, error], limit int) ([]T, error) {
+	var out []T
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,312 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WorkspacePermission is a user's role in a workspace: owner, collaborator
+// or member.
+type WorkspacePermission struct {
+	Permission string  `json:"permission"`
+	User       Account `json:"user"`
+}
+
+// RepositoryPermission is a user's effective access to a repository: admin,
+// write or read.
+type RepositoryPermission struct {
+	Permission string  `json:"permission"`
+	User       Account `json:"user"`
+	Repository struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// PermissionListOptions filter permission listings.
+type PermissionListOptions struct {
+	// Permissions keeps only entries with one of these roles.
+	Permissions []string
+	Limit       int
+}
+
+type workspacePermissionPage struct {
+	Values []WorkspacePermission `json:"values"`
+	Next   string                `json:"next"`
+}
+
+type repositoryPermissionPage struct {
+	Values []RepositoryPermission `json:"values"`
+	Next   string                 `json:"next"`
+}
+
+// ListWorkspacePermissions lists workspace members together with their role.
+func (c *Client) ListWorkspacePermissions(ctx context.Context, workspace string, opts PermissionListOptions) ([]WorkspacePermission, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	path := fmt.Sprintf("/workspaces/%s/permissions?%s",
+		url.PathEscape(workspace),
+		permissionParams(opts, "user.display_name").Encode(),
+	)
+
+	var perms []WorkspacePermission
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page workspacePermissionPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		perms = append(perms, page.Values...)
+
+		if opts.Limit > 0 && len(perms) >= opts.Limit {
+			perms = perms[:opts.Limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return perms, nil
+}
+
+// ListRepositoryPermissions lists effective user permissions for every
+// repository in the workspace, or only repoSlug when it is non-empty.
+func (c *Client) ListRepositoryPermissions(ctx context.Context, workspace, repoSlug string, opts PermissionListOptions) ([]RepositoryPermission, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	path := fmt.Sprintf("/workspaces/%s/permissions/repositories", url.PathEscape(workspace))
+	if repoSlug != "" {
+		path += "/" + url.PathEscape(repoSlug)
+	}
+	path += "?" + permissionParams(opts, "repository.name").Encode()
+
+	var perms []RepositoryPermission
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page repositoryPermissionPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		perms = append(perms, page.Values...)
+
+		if opts.Limit > 0 && len(perms) >= opts.Limit {
+			perms = perms[:opts.Limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return perms, nil
+}
+
+func permissionParams(opts PermissionListOptions, sort string) url.Values {
+	pageLen := opts.Limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	params := url.Values{}
+	params.Set("pagelen", fmt.Sprintf("%d", pageLen))
+	params.Set("sort", sort)
+
+	var clauses []string
+	for _, p := range opts.Permissions {
+		if p = strings.TrimSpace(p); p != "" {
+			clauses = append(clauses, fmt.Sprintf("permission=\"%s\"", p))
+		}
+	}
+	if len(clauses) > 0 {
+		params.Set("q", strings.Join(clauses, " OR "))
+	}
+	return params
+}
+
+// RepoUserPermission is an explicit user permission on a repository.
+type RepoUserPermission struct {
+	Permission string  `json:"permission"`
+	User       Account `json:"user"`
+}
+
+// RepoGroupPermission is an explicit group permission on a repository.
+type RepoGroupPermission struct {
+	Permission string `json:"permission"`
+	Group      struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	} `json:"group"`
+}
+
+type repoUserPermissionPage struct {
+	Values []RepoUserPermission `json:"values"`
+	Next   string               `json:"next"`
+}
+
+type repoGroupPermissionPage struct {
+	Values []RepoGroupPermission `json:"values"`
+	Next   string                `json:"next"`
+}
+
+// ListRepoUserPermissions lists explicit user permissions on a repository.
+func (c *Client) ListRepoUserPermissions(ctx context.Context, workspace, repoSlug string) ([]RepoUserPermission, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	path := repoPermissionsPath(workspace, repoSlug, "users", "") + "?pagelen=100"
+
+	var perms []RepoUserPermission
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page repoUserPermissionPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		perms = append(perms, page.Values...)
+
+		if page.Next == "" {
+			break
+		}
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return perms, nil
+}
+
+// ListRepoGroupPermissions lists explicit group permissions on a repository.
+func (c *Client) ListRepoGroupPermissions(ctx context.Context, workspace, repoSlug string) ([]RepoGroupPermission, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	path := repoPermissionsPath(workspace, repoSlug, "groups", "") + "?pagelen=100"
+
+	var perms []RepoGroupPermission
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page repoGroupPermissionPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		perms = append(perms, page.Values...)
+
+		if page.Next == "" {
+			break
+		}
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return perms, nil
+}
+
+// GrantRepoUserPermission grants or updates a user's permission (read, write
+// or admin) on a repository. user is an account ID or UUID.
+func (c *Client) GrantRepoUserPermission(ctx context.Context, workspace, repoSlug, user, permission string) error {
+	if workspace == "" || repoSlug == "" || user == "" || permission == "" {
+		return fmt.Errorf("workspace, repository slug, user, and permission are required")
+	}
+	return c.putRepoPermission(ctx, repoPermissionsPath(workspace, repoSlug, "users", user), permission)
+}
+
+// GrantRepoGroupPermission grants or updates a group's permission (read,
+// write or admin) on a repository.
+func (c *Client) GrantRepoGroupPermission(ctx context.Context, workspace, repoSlug, group, permission string) error {
+	if workspace == "" || repoSlug == "" || group == "" || permission == "" {
+		return fmt.Errorf("workspace, repository slug, group, and permission are required")
+	}
+	return c.putRepoPermission(ctx, repoPermissionsPath(workspace, repoSlug, "groups", group), permission)
+}
+
+// RevokeRepoUserPermission removes a user's explicit repository permission.
+func (c *Client) RevokeRepoUserPermission(ctx context.Context, workspace, repoSlug, user string) error {
+	if workspace == "" || repoSlug == "" || user == "" {
+		return fmt.Errorf("workspace, repository slug, and user are required")
+	}
+	return c.deleteRepoPermission(ctx, repoPermissionsPath(workspace, repoSlug, "users", user))
+}
+
+// RevokeRepoGroupPermission removes a group's explicit repository permission.
+func (c *Client) RevokeRepoGroupPermission(ctx context.Context, workspace, repoSlug, group string) error {
+	if workspace == "" || repoSlug == "" || group == "" {
+		return fmt.Errorf("workspace, repository slug, and group are required")
+	}
+	return c.deleteRepoPermission(ctx, repoPermissionsPath(workspace, repoSlug, "groups", group))
+}
+
+func (c *Client) putRepoPermission(ctx context.Context, path, permission string) error {
+	req, err := c.http.NewRequest(ctx, "PUT", path, map[string]any{
+		"permission": strings.ToLower(permission),
+	})
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+func (c *Client) deleteRepoPermission(ctx context.Context, path string) error {
+	req, err := c.http.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+func repoPermissionsPath(workspace, repoSlug, kind, subject string) string {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		kind,
+	)
+	if subject != "" {
+		path += "/" + url.PathEscape(subject)
+	}
+	return path
+}
@@ -0,0 +1,61 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListWorkspacePermissionsFiltersRoles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/acme/permissions" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query().Get("q"); q != `permission="owner" OR permission="collaborator"` {
+			t.Fatalf("unexpected q %q", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"permission":"owner","user":{"display_name":"Ada","account_id":"1"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	perms, err := client.ListWorkspacePermissions(context.Background(), "acme", PermissionListOptions{
+		Permissions: []string{"owner", "collaborator"},
+	})
+	if err != nil {
+		t.Fatalf("ListWorkspacePermissions: %v", err)
+	}
+	if len(perms) != 1 || perms[0].User.DisplayName != "Ada" || perms[0].Permission != "owner" {
+		t.Fatalf("unexpected permissions %+v", perms)
+	}
+}
+
+func TestListRepositoryPermissionsForSingleRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/acme/permissions/repositories/api" || r.URL.Query().Get("q") != "" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"permission":"write","user":{"display_name":"Bob"},"repository":{"name":"api","full_name":"acme/api"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	perms, err := client.ListRepositoryPermissions(context.Background(), "acme", "api", PermissionListOptions{})
+	if err != nil {
+		t.Fatalf("ListRepositoryPermissions: %v", err)
+	}
+	if len(perms) != 1 || perms[0].Repository.FullName != "acme/api" || perms[0].Permission != "write" {
+		t.Fatalf("unexpected permissions %+v", perms)
+	}
+}
@@ -0,0 +1,215 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Project groups repositories within a workspace.
+type Project struct {
+	UUID        string `json:"uuid"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IsPrivate   bool   `json:"is_private"`
+	CreatedOn   string `json:"created_on,omitempty"`
+	UpdatedOn   string `json:"updated_on,omitempty"`
+	Links       struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type projectListPage struct {
+	Values []Project `json:"values"`
+	Next   string    `json:"next"`
+}
+
+// ListProjects enumerates the projects in a workspace.
+func (c *Client) ListProjects(ctx context.Context, workspace string, limit int) ([]Project, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	path := fmt.Sprintf("/workspaces/%s/projects?pagelen=%d&sort=key",
+		url.PathEscape(workspace),
+		pageLen,
+	)
+
+	var projects []Project
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page projectListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, page.Values...)
+
+		if limit > 0 && len(projects) >= limit {
+			projects = projects[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return projects, nil
+}
+
+// GetProject fetches a project by key.
+func (c *Client) GetProject(ctx context.Context, workspace, key string) (*Project, error) {
+	if workspace == "" || key == "" {
+		return nil, fmt.Errorf("workspace and project key are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", projectPath(workspace, key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := c.http.Do(req, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// CreateProjectInput describes a project to create.
+type CreateProjectInput struct {
+	Key         string
+	Name        string
+	Description string
+	IsPrivate   bool
+}
+
+// CreateProject creates a project in the workspace.
+func (c *Client) CreateProject(ctx context.Context, workspace string, input CreateProjectInput) (*Project, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+	if input.Key == "" || input.Name == "" {
+		return nil, fmt.Errorf("project key and name are required")
+	}
+
+	body := map[string]any{
+		"key":        strings.ToUpper(input.Key),
+		"name":       input.Name,
+		"is_private": input.IsPrivate,
+	}
+	if input.Description != "" {
+		body["description"] = input.Description
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/workspaces/%s/projects", url.PathEscape(workspace)), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := c.http.Do(req, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// UpdateProjectInput describes mutable project settings. Nil fields are left
+// unchanged.
+type UpdateProjectInput struct {
+	Name        *string
+	Description *string
+	IsPrivate   *bool
+}
+
+// UpdateProject changes project settings.
+func (c *Client) UpdateProject(ctx context.Context, workspace, key string, input UpdateProjectInput) (*Project, error) {
+	if workspace == "" || key == "" {
+		return nil, fmt.Errorf("workspace and project key are required")
+	}
+
+	// The API replaces the project on PUT and rejects bodies without a name,
+	// so unchanged fields are carried over from the current project.
+	current, err := c.GetProject(ctx, workspace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"key":         current.Key,
+		"name":        current.Name,
+		"description": current.Description,
+		"is_private":  current.IsPrivate,
+	}
+	if input.Name != nil {
+		body["name"] = *input.Name
+	}
+	if input.Description != nil {
+		body["description"] = *input.Description
+	}
+	if input.IsPrivate != nil {
+		body["is_private"] = *input.IsPrivate
+	}
+
+	req, err := c.http.NewRequest(ctx, "PUT", projectPath(workspace, key), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := c.http.Do(req, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// DeleteProject removes an empty project.
+func (c *Client) DeleteProject(ctx context.Context, workspace, key string) error {
+	if workspace == "" || key == "" {
+		return fmt.Errorf("workspace and project key are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", projectPath(workspace, key), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+// ListProjectRepositories lists the repositories that belong to a project.
+func (c *Client) ListProjectRepositories(ctx context.Context, workspace, key string, limit int) ([]Repository, error) {
+	if key == "" {
+		return nil, fmt.Errorf("project key is required")
+	}
+	return c.ListRepositories(ctx, workspace, ListRepositoriesOptions{
+		Query: fmt.Sprintf("project.key=\"%s\"", strings.ToUpper(key)),
+		Sort:  "slug",
+		Limit: limit,
+	})
+}
+
+func projectPath(workspace, key string) string {
+	return fmt.Sprintf("/workspaces/%s/projects/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(strings.ToUpper(key)),
+	)
+}
@@ -0,0 +1,72 @@
+package bbcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateProjectPreservesUnchangedFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/acme/projects/PLAT" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"key":"PLAT","name":"Platform","description":"infra","is_private":true}`))
+		case http.MethodPut:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body["name"] != "Platform" || body["description"] != "Shared infrastructure" || body["is_private"] != true {
+				t.Fatalf("unexpected body %+v", body)
+			}
+			_ = json.NewEncoder(w).Encode(body)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	desc := "Shared infrastructure"
+	project, err := client.UpdateProject(context.Background(), "acme", "plat", UpdateProjectInput{Description: &desc})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if project.Description != desc {
+		t.Fatalf("unexpected project %+v", project)
+	}
+}
+
+func TestListProjectRepositoriesFiltersByKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/acme" || r.URL.Query().Get("q") != `project.key="PLAT"` {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"slug":"api"},{"slug":"web"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	repos, err := client.ListProjectRepositories(context.Background(), "acme", "plat", 0)
+	if err != nil {
+		t.Fatalf("ListProjectRepositories: %v", err)
+	}
+	if len(repos) != 2 || repos[1].Slug != "web" {
+		t.Fatalf("unexpected repos %+v", repos)
+	}
+}
@@ -3,8 +3,12 @@ package bbcloud
 import (
 	"context"
 	"fmt"
+	"io"
+	"iter"
 	"net/url"
 	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
 )
 
 // PullRequest models a Bitbucket Cloud pull request.
@@ -43,6 +47,18 @@ type PullRequest struct {
 	Summary struct {
 		Raw string `json:"raw"`
 	} `json:"summary"`
+	Participants []PullRequestParticipant `json:"participants,omitempty"`
+}
+
+// PullRequestParticipant describes a reviewer or participant on a pull request.
+// Role is REVIEWER or PARTICIPANT; State is "approved", "changes_requested"
+// or empty while the review is pending.
+type PullRequestParticipant struct {
+	User           Account `json:"user"`
+	Role           string  `json:"role"`
+	Approved       bool    `json:"approved"`
+	State          string  `json:"state"`
+	ParticipatedOn string  `json:"participated_on,omitempty"`
 }
 
 // PullRequestListOptions configure PR listings.
@@ -50,17 +66,32 @@ type PullRequestListOptions struct {
 	State string
 	Limit int
 	Mine  string
+	// ReviewRequested keeps pull requests that list this user as a reviewer.
+	// ReviewedBy keeps pull requests the user has taken part in, by
+	// approving, requesting changes or commenting. Both accept a UUID in
+	// braces or an account ID.
+	ReviewRequested string
+	ReviewedBy      string
+	// Query is an additional BBQL filter, e.g. `reviewers.uuid="{...}"`,
+	// combined with the other filters using AND.
+	Query string
+	// Fields adjusts the partial response, e.g. "+values.participants" to
+	// include participants which the list endpoint omits by default.
+	Fields string
 }
 
-type pullRequestListPage struct {
-	Values []PullRequest `json:"values"`
-	Next   string        `json:"next"`
+// ListPullRequests lists pull requests for a repository, returning at most
+// opts.Limit entries when it is positive.
+func (c *Client) ListPullRequests(ctx context.Context, workspace, repoSlug string, opts PullRequestListOptions) ([]PullRequest, error) {
+	return collect(c.ListPullRequestsIter(ctx, workspace, repoSlug, opts), opts.Limit)
 }
 
-// ListPullRequests lists pull requests for a repository.
-func (c *Client) ListPullRequests(ctx context.Context, workspace, repoSlug string, opts PullRequestListOptions) ([]PullRequest, error) {
+// ListPullRequestsIter lazily iterates over the pull requests of a
+// repository, fetching a page at a time as the loop advances. opts.Limit only
+// sizes the pages; stop early by breaking out of the loop.
+func (c *Client) ListPullRequestsIter(ctx context.Context, workspace, repoSlug string, opts PullRequestListOptions) iter.Seq2[PullRequest, error] {
 	if workspace == "" || repoSlug == "" {
-		return nil, fmt.Errorf("workspace and repository slug are required")
+		return failed[PullRequest](fmt.Errorf("workspace and repository slug are required"))
 	}
 
 	pageLen := opts.Limit
@@ -73,8 +104,29 @@ func (c *Client) ListPullRequests(ctx context.Context, workspace, repoSlug strin
 	if state := strings.TrimSpace(opts.State); state != "" && !strings.EqualFold(state, "all") {
 		params = append(params, "state="+url.QueryEscape(strings.ToUpper(state)))
 	}
+	var filters []string
 	if opts.Mine != "" {
-		params = append(params, "q="+url.QueryEscape(fmt.Sprintf("author.username=\"%s\"", opts.Mine)))
+		filters = append(filters, "author.username="+BBQLString(opts.Mine))
+	}
+	if user := strings.TrimSpace(opts.ReviewRequested); user != "" {
+		filters = append(filters, userFilter("reviewers", user))
+	}
+	if user := strings.TrimSpace(opts.ReviewedBy); user != "" {
+		filters = append(filters, userFilter("participants", user))
+	}
+	if query := strings.TrimSpace(opts.Query); query != "" {
+		filters = append(filters, query)
+	}
+	if len(filters) > 1 {
+		for i, filter := range filters {
+			filters[i] = "(" + filter + ")"
+		}
+	}
+	if len(filters) > 0 {
+		params = append(params, "q="+url.QueryEscape(strings.Join(filters, " AND ")))
+	}
+	if fields := strings.TrimSpace(opts.Fields); fields != "" {
+		params = append(params, "fields="+url.QueryEscape(fields))
 	}
 
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests?%s",
@@ -82,37 +134,16 @@ func (c *Client) ListPullRequests(ctx context.Context, workspace, repoSlug strin
 		url.PathEscape(repoSlug),
 		strings.Join(params, "&"),
 	)
+	return paginate[PullRequest](ctx, c, path)
+}
 
-	var prs []PullRequest
-	for path != "" {
-		req, err := c.http.NewRequest(ctx, "GET", path, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var page pullRequestListPage
-		if err := c.http.Do(req, &page); err != nil {
-			return nil, err
-		}
-
-		prs = append(prs, page.Values...)
-
-		if opts.Limit > 0 && len(prs) >= opts.Limit {
-			prs = prs[:opts.Limit]
-			break
-		}
-
-		if page.Next == "" {
-			break
-		}
-		nextURL, err := url.Parse(page.Next)
-		if err != nil {
-			return nil, err
-		}
-		path = nextURL.RequestURI()
+// userFilter matches field (reviewers or participants) against a user
+// identified by UUID, when braced, or by account ID otherwise.
+func userFilter(field, user string) string {
+	if strings.HasPrefix(user, "{") {
+		return field + ".uuid=" + BBQLString(user)
 	}
-
-	return prs, nil
+	return field + ".account_id=" + BBQLString(user)
 }
 
 // GetPullRequest fetches a pull request by ID.
@@ -138,6 +169,31 @@ func (c *Client) GetPullRequest(ctx context.Context, workspace, repoSlug string,
 	return &pr, nil
 }
 
+// GetPullRequestPatch streams the pull request's commits into w as a series
+// of mbox-formatted patches, one per commit, suitable for git am or git apply.
+func (c *Client) GetPullRequestPatch(ctx context.Context, workspace, repoSlug string, id int, w io.Writer) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+
+	// Bitbucket redirects to the patch of the source..destination range; the
+	// client follows the redirect.
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/patch",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		id,
+	), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	return c.http.Do(httpx.Unbounded(req), w)
+}
+
 // CreatePullRequestInput configures PR creation.
 type CreatePullRequestInput struct {
 	Title       string
@@ -270,16 +326,9 @@ type CommentPullRequestOptions struct {
 	LineFrom int    // Optional: starting line for range comment (requires FilePath and Line)
 }
 
-// CommentPullRequest creates a comment on a pull request.
-// For inline comments on specific file lines, set FilePath and Line in the options.
-func (c *Client) CommentPullRequest(ctx context.Context, workspace, repoSlug string, id int, opts CommentPullRequestOptions) (*PullRequestComment, error) {
-	if workspace == "" || repoSlug == "" {
-		return nil, fmt.Errorf("workspace and repository slug are required")
-	}
-	if strings.TrimSpace(opts.Text) == "" {
-		return nil, fmt.Errorf("comment text is required")
-	}
-
+// commentPayload builds the request body shared by pull request and commit
+// comments, including the inline location when a file path is set.
+func commentPayload(opts CommentPullRequestOptions) (map[string]any, error) {
 	payload := map[string]any{
 		"content": map[string]any{
 			"raw": opts.Text,
@@ -308,6 +357,24 @@ func (c *Client) CommentPullRequest(ctx context.Context, workspace, repoSlug str
 		payload["inline"] = inline
 	}
 
+	return payload, nil
+}
+
+// CommentPullRequest creates a comment on a pull request.
+// For inline comments on specific file lines, set FilePath and Line in the options.
+func (c *Client) CommentPullRequest(ctx context.Context, workspace, repoSlug string, id int, opts CommentPullRequestOptions) (*PullRequestComment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(opts.Text) == "" {
+		return nil, fmt.Errorf("comment text is required")
+	}
+
+	payload, err := commentPayload(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments",
 		url.PathEscape(workspace),
 		url.PathEscape(repoSlug),
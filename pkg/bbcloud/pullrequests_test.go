@@ -1,6 +1,7 @@
 package bbcloud
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -209,3 +210,102 @@ func TestCommentPullRequestPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestListPullRequestsIterFetchesPagesLazily(t *testing.T) {
+	var requests int
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "":
+			_ = json.NewEncoder(w).Encode(listPage[PullRequest]{
+				Values: []PullRequest{{ID: 1}, {ID: 2}},
+				Next:   server.URL + "/repositories/ws/repo/pullrequests?page=2",
+			})
+		case "2":
+			_ = json.NewEncoder(w).Encode(listPage[PullRequest]{Values: []PullRequest{{ID: 3}}})
+		default:
+			t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	for pr, err := range client.ListPullRequestsIter(ctx, "ws", "repo", PullRequestListOptions{}) {
+		if err != nil {
+			t.Fatalf("iterate: %v", err)
+		}
+		if pr.ID != 1 {
+			t.Fatalf("unexpected first pull request %d", pr.ID)
+		}
+		break
+	}
+	if requests != 1 {
+		t.Fatalf("breaking after the first item must not fetch more pages, got %d requests", requests)
+	}
+
+	var ids []int
+	for pr, err := range client.ListPullRequestsIter(ctx, "ws", "repo", PullRequestListOptions{}) {
+		if err != nil {
+			t.Fatalf("iterate: %v", err)
+		}
+		ids = append(ids, pr.ID)
+	}
+	if len(ids) != 3 || ids[2] != 3 {
+		t.Fatalf("expected all pages to be walked, got %v", ids)
+	}
+
+	for _, err := range client.ListPullRequestsIter(ctx, "", "repo", PullRequestListOptions{}) {
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+	}
+}
+
+func TestGetPullRequestPatchFollowsRedirect(t *testing.T) {
+	const patch = "From 1a2b3c Mon Sep 17 00:00:00 2001\nSubject: [PATCH] Fix typo\n\n---\n"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/work/repo/pullrequests/7/patch":
+			http.Redirect(w, r, server.URL+"/repositories/work/repo/patch/work/repo:1a2b3c%0Dmain?from_pullrequest_id=7", http.StatusFound)
+		case "/repositories/work/repo/patch/work/repo:1a2b3c\rmain":
+			if r.Header.Get("Authorization") == "" {
+				t.Error("authorization dropped on redirect")
+			}
+			_, _ = w.Write([]byte(patch))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL, Username: "user", Token: "token"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.GetPullRequestPatch(context.Background(), "work", "repo", 7, &buf); err != nil {
+		t.Fatalf("GetPullRequestPatch: %v", err)
+	}
+	if buf.String() != patch {
+		t.Fatalf("patch = %q", buf.String())
+	}
+}
+
+func TestUserFilterEscapesInput(t *testing.T) {
+	if got := userFilter("reviewers", "{abc}"); got != `reviewers.uuid="{abc}"` {
+		t.Fatalf("uuid filter = %s", got)
+	}
+	if got := userFilter("participants", `x" OR state="MERGED`); got != `participants.account_id="x\" OR state=\"MERGED"` {
+		t.Fatalf("account filter = %s", got)
+	}
+}
@@ -0,0 +1,118 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type accountListPage struct {
+	Values []Account `json:"values"`
+	Next   string    `json:"next"`
+}
+
+// ListDefaultReviewers lists the default reviewers configured on a repository.
+func (c *Client) ListDefaultReviewers(ctx context.Context, workspace, repoSlug string, limit int) ([]Account, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/default-reviewers?pagelen=%d",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		pageLen,
+	)
+
+	var reviewers []Account
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page accountListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		reviewers = append(reviewers, page.Values...)
+
+		if limit > 0 && len(reviewers) >= limit {
+			reviewers = reviewers[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return reviewers, nil
+}
+
+// AddDefaultReviewer adds a user to the repository's default reviewers. The
+// user may be given as an account UUID or Atlassian account ID.
+func (c *Client) AddDefaultReviewer(ctx context.Context, workspace, repoSlug, user string) (*Account, error) {
+	path, err := defaultReviewerPath(workspace, repoSlug, user)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.http.NewRequest(ctx, "PUT", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err := c.http.Do(req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// RemoveDefaultReviewer removes a user from the repository's default reviewers.
+func (c *Client) RemoveDefaultReviewer(ctx context.Context, workspace, repoSlug, user string) error {
+	path, err := defaultReviewerPath(workspace, repoSlug, user)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.http.Do(req, nil)
+}
+
+func defaultReviewerPath(workspace, repoSlug, user string) (string, error) {
+	if workspace == "" || repoSlug == "" {
+		return "", fmt.Errorf("workspace and repository slug are required")
+	}
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return "", fmt.Errorf("user is required")
+	}
+	// Bare UUIDs must be wrapped in braces for the path parameter.
+	if strings.Count(user, "-") == 4 && !strings.Contains(user, ":") {
+		user = normalizeUUID(user)
+	}
+
+	return fmt.Sprintf("/repositories/%s/%s/default-reviewers/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(user),
+	), nil
+}
@@ -0,0 +1,69 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDefaultReviewerPaths(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{u1}","display_name":"Alice"}]}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"uuid":"{u2}","display_name":"Bob"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	reviewers, err := client.ListDefaultReviewers(ctx, "work", "repo", 0)
+	if err != nil || len(reviewers) != 1 || reviewers[0].DisplayName != "Alice" {
+		t.Fatalf("ListDefaultReviewers = %+v, %v", reviewers, err)
+	}
+
+	account, err := client.AddDefaultReviewer(ctx, "work", "repo", "6f1c5a8e-0d2b-4c1e-9a3f-1b2c3d4e5f60")
+	if err != nil || account.DisplayName != "Bob" {
+		t.Fatalf("AddDefaultReviewer = %+v, %v", account, err)
+	}
+
+	if err := client.RemoveDefaultReviewer(ctx, "work", "repo", "557058:abcd"); err != nil {
+		t.Fatalf("RemoveDefaultReviewer: %v", err)
+	}
+
+	want := []string{
+		"GET /repositories/work/repo/default-reviewers",
+		"PUT /repositories/work/repo/default-reviewers/%7B6f1c5a8e-0d2b-4c1e-9a3f-1b2c3d4e5f60%7D",
+		"DELETE /repositories/work/repo/default-reviewers/557058:abcd",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v", requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Fatalf("request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestDefaultReviewerRequiresUser(t *testing.T) {
+	client, err := New(Options{BaseURL: "https://api.bitbucket.org/2.0"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.RemoveDefaultReviewer(context.Background(), "work", "repo", " "); err == nil {
+		t.Fatal("expected error for empty user")
+	}
+}
@@ -0,0 +1,96 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// CodeSearchResult is one file matched by a code search.
+type CodeSearchResult struct {
+	ContentMatchCount int                 `json:"content_match_count"`
+	ContentMatches    []CodeSearchMatch   `json:"content_matches"`
+	PathMatches       []CodeSearchSegment `json:"path_matches"`
+	File              struct {
+		Path   string `json:"path"`
+		Commit struct {
+			Hash       string `json:"hash"`
+			Repository struct {
+				Slug     string `json:"slug"`
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+		} `json:"commit"`
+		Links struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+	} `json:"file"`
+}
+
+// CodeSearchMatch groups the lines around one content match.
+type CodeSearchMatch struct {
+	Lines []CodeSearchLine `json:"lines"`
+}
+
+// CodeSearchLine is a line of a matched file, split into segments so the
+// matching parts can be highlighted.
+type CodeSearchLine struct {
+	Line     int                 `json:"line"`
+	Segments []CodeSearchSegment `json:"segments"`
+}
+
+// Text returns the line with its segments joined.
+func (l CodeSearchLine) Text() string {
+	var b strings.Builder
+	for _, s := range l.Segments {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// Matched reports whether any segment of the line matched the query.
+func (l CodeSearchLine) Matched() bool {
+	for _, s := range l.Segments {
+		if s.Match {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeSearchSegment is a run of text that either matched the query or not.
+type CodeSearchSegment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+// SearchCode searches the code of every repository in a workspace. The query
+// uses Bitbucket's search syntax, including modifiers such as repo:, lang:,
+// ext: and path:. All pages are fetched; use SearchCodeIter to stop early.
+func (c *Client) SearchCode(ctx context.Context, workspace, query string) ([]CodeSearchResult, error) {
+	return collect(c.SearchCodeIter(ctx, workspace, query), 0)
+}
+
+// SearchCodeIter lazily iterates over code search results, fetching a page at
+// a time as the loop advances.
+func (c *Client) SearchCodeIter(ctx context.Context, workspace, query string) iter.Seq2[CodeSearchResult, error] {
+	if workspace == "" {
+		return failed[CodeSearchResult](fmt.Errorf("workspace is required"))
+	}
+	if strings.TrimSpace(query) == "" {
+		return failed[CodeSearchResult](fmt.Errorf("search query is required"))
+	}
+
+	params := url.Values{}
+	params.Set("search_query", query)
+	params.Set("pagelen", "50")
+	// The repository is not included by default, which leaves results from
+	// different repositories indistinguishable.
+	params.Set("fields", "+values.file.commit.repository.full_name,+values.file.commit.repository.slug")
+
+	path := fmt.Sprintf("/workspaces/%s/search/code?%s", url.PathEscape(workspace), params.Encode())
+	return paginate[CodeSearchResult](ctx, c, path)
+}
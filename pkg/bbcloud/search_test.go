@@ -0,0 +1,57 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchCodeFollowsPagesAndRequestsRepository(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/acme/search/code" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"values":[{"file":{"path":"b.go"}}]}`))
+			return
+		}
+		if got := r.URL.Query().Get("search_query"); got != "TODO lang:go" {
+			t.Fatalf("unexpected search_query %q", got)
+		}
+		if got := r.URL.Query().Get("fields"); got == "" {
+			t.Fatalf("expected fields to include the repository")
+		}
+		_, _ = w.Write([]byte(`{"values":[{"content_match_count":1,"content_matches":[{"lines":[{"line":3,"segments":[{"text":"// "},{"text":"TODO","match":true},{"text":" retry"}]}]}],"file":{"path":"a.go","commit":{"repository":{"full_name":"acme/api"}}}}],"next":"` + server.URL + `/workspaces/acme/search/code?page=2"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	results, err := client.SearchCode(context.Background(), "acme", "TODO lang:go")
+	if err != nil {
+		t.Fatalf("SearchCode: %v", err)
+	}
+	if len(results) != 2 || results[0].File.Commit.Repository.FullName != "acme/api" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	line := results[0].ContentMatches[0].Lines[0]
+	if line.Text() != "// TODO retry" || !line.Matched() {
+		t.Fatalf("unexpected line %+v", line)
+	}
+}
+
+func TestSearchCodeRequiresQuery(t *testing.T) {
+	client, err := New(Options{BaseURL: "http://127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := client.SearchCode(context.Background(), "acme", "  "); err == nil {
+		t.Fatal("expected an error for an empty query")
+	}
+}
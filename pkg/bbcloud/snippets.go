@@ -0,0 +1,226 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
+)
+
+// Snippet is a standalone collection of files shared from a workspace, the
+// Bitbucket equivalent of a gist.
+type Snippet struct {
+	ID        string                 `json:"id"`
+	Title     string                 `json:"title"`
+	IsPrivate bool                   `json:"is_private"`
+	Owner     *Account               `json:"owner,omitempty"`
+	Creator   *Account               `json:"creator,omitempty"`
+	CreatedOn string                 `json:"created_on,omitempty"`
+	UpdatedOn string                 `json:"updated_on,omitempty"`
+	Files     map[string]SnippetFile `json:"files,omitempty"`
+	Links     struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// SnippetFile links to the raw content of a file in a snippet.
+type SnippetFile struct {
+	Links struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// FileNames returns the snippet's file names in sorted order.
+func (s *Snippet) FileNames() []string {
+	names := make([]string, 0, len(s.Files))
+	for name := range s.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SnippetListOptions filters snippet listings.
+type SnippetListOptions struct {
+	// Role restricts results to snippets the user is owner, contributor or member of.
+	Role  string
+	Limit int
+}
+
+type snippetListPage struct {
+	Values []Snippet `json:"values"`
+	Next   string    `json:"next"`
+}
+
+// ListSnippets lists the snippets in a workspace.
+func (c *Client) ListSnippets(ctx context.Context, workspace string, opts SnippetListOptions) ([]Snippet, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	pageLen := opts.Limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 50
+	}
+
+	params := url.Values{}
+	params.Set("pagelen", fmt.Sprintf("%d", pageLen))
+	if role := strings.TrimSpace(opts.Role); role != "" {
+		params.Set("role", role)
+	}
+
+	path := fmt.Sprintf("/snippets/%s?%s", url.PathEscape(workspace), params.Encode())
+
+	var snippets []Snippet
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page snippetListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		snippets = append(snippets, page.Values...)
+
+		if opts.Limit > 0 && len(snippets) >= opts.Limit {
+			snippets = snippets[:opts.Limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return snippets, nil
+}
+
+// GetSnippet fetches a snippet's metadata, including its file list.
+func (c *Client) GetSnippet(ctx context.Context, workspace, id string) (*Snippet, error) {
+	if workspace == "" || id == "" {
+		return nil, fmt.Errorf("workspace and snippet id are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/snippets/%s/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(id),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippet Snippet
+	if err := c.http.Do(req, &snippet); err != nil {
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+// SnippetFileInput is a file to include in a new snippet.
+type SnippetFileInput struct {
+	Name   string
+	Reader io.Reader
+}
+
+// CreateSnippetInput describes a new snippet.
+type CreateSnippetInput struct {
+	Title   string
+	Private bool
+	Files   []SnippetFileInput
+}
+
+// CreateSnippet creates a snippet in the workspace from one or more files.
+func (c *Client) CreateSnippet(ctx context.Context, workspace string, in CreateSnippetInput) (*Snippet, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+	if len(in.Files) == 0 {
+		return nil, fmt.Errorf("at least one file is required")
+	}
+
+	fields := map[string]string{
+		"is_private": fmt.Sprintf("%t", in.Private),
+	}
+	if title := strings.TrimSpace(in.Title); title != "" {
+		fields["title"] = title
+	}
+
+	files := make([]httpx.MultipartFile, 0, len(in.Files))
+	for _, f := range in.Files {
+		if f.Name == "" {
+			return nil, fmt.Errorf("snippet file name is required")
+		}
+		files = append(files, httpx.MultipartFile{
+			FieldName: "file",
+			FileName:  f.Name,
+			Reader:    f.Reader,
+		})
+	}
+
+	req, err := c.http.NewMultipartFormRequest(ctx, "POST", fmt.Sprintf("/snippets/%s", url.PathEscape(workspace)), fields, files)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippet Snippet
+	if err := c.http.Do(req, &snippet); err != nil {
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+// GetSnippetFile writes the raw content of one file in a snippet to w.
+func (c *Client) GetSnippetFile(ctx context.Context, workspace, id, filename string, w io.Writer) error {
+	if workspace == "" || id == "" {
+		return fmt.Errorf("workspace and snippet id are required")
+	}
+	if filename == "" {
+		return fmt.Errorf("filename is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/snippets/%s/%s/files/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(id),
+		url.PathEscape(filename),
+	), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	return c.http.Do(req, w)
+}
+
+// DeleteSnippet deletes a snippet.
+func (c *Client) DeleteSnippet(ctx context.Context, workspace, id string) error {
+	if workspace == "" || id == "" {
+		return fmt.Errorf("workspace and snippet id are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/snippets/%s/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(id),
+	), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.http.Do(req, nil)
+}
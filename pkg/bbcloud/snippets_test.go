@@ -0,0 +1,63 @@
+package bbcloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateSnippetMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/snippets/work" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		if r.FormValue("title") != "Example" || r.FormValue("is_private") != "false" {
+			t.Fatalf("unexpected fields %v", r.MultipartForm.Value)
+		}
+		files := r.MultipartForm.File["file"]
+		if len(files) != 2 || files[0].Filename != "a.go" || files[1].Filename != "b.go" {
+			t.Fatalf("unexpected files %+v", files)
+		}
+		f, _ := files[1].Open()
+		data, _ := io.ReadAll(f)
+		if string(data) != "package b" {
+			t.Fatalf("unexpected content %q", data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"kypj9","title":"Example","links":{"html":{"href":"https://bitbucket.org/work/workspace/snippets/kypj9"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	snippet, err := client.CreateSnippet(context.Background(), "work", CreateSnippetInput{
+		Title: "Example",
+		Files: []SnippetFileInput{
+			{Name: "a.go", Reader: strings.NewReader("package a")},
+			{Name: "b.go", Reader: strings.NewReader("package b")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateSnippet: %v", err)
+	}
+	if snippet.ID != "kypj9" || !strings.HasSuffix(snippet.Links.HTML.Href, "/snippets/kypj9") {
+		t.Fatalf("unexpected snippet %+v", snippet)
+	}
+}
+
+func TestSnippetFileNamesSorted(t *testing.T) {
+	s := Snippet{Files: map[string]SnippetFile{"z.txt": {}, "a.txt": {}}}
+	if got := s.FileNames(); len(got) != 2 || got[0] != "a.txt" || got[1] != "z.txt" {
+		t.Fatalf("unexpected names %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
+)
+
+// GetFileContent streams the raw content of path at ref (a branch, tag or
+// commit hash) into w.
+func (c *Client) GetFileContent(ctx context.Context, workspace, repoSlug, ref, path string, w io.Writer) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if ref == "" {
+		return fmt.Errorf("ref is required")
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return fmt.Errorf("file path is required")
+	}
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/repositories/%s/%s/src/%s/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(ref),
+		escapePath(path),
+	), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	return c.http.Do(httpx.Unbounded(req), w)
+}
+
+// escapePath escapes each segment of a repository path so directory
+// separators are preserved in the URL.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// TreeEntry is a file, directory or submodule link in a repository tree.
+type TreeEntry struct {
+	Path       string   `json:"path"`
+	Type       string   `json:"type"`
+	Size       int64    `json:"size,omitempty"`
+	MimeType   string   `json:"mimetype,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+	Commit     struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+// IsDir reports whether the entry is a directory.
+func (e TreeEntry) IsDir() bool {
+	return e.Type == "commit_directory"
+}
+
+// ListDirectoryOptions controls tree listing.
+type ListDirectoryOptions struct {
+	// Recursive includes the contents of subdirectories.
+	Recursive bool
+	Limit     int
+}
+
+// recursiveTreeDepth is the max_depth sent for recursive listings, deep
+// enough to reach every directory of any practical repository.
+const recursiveTreeDepth = 100
+
+// ListDirectory lists the entries of the directory at path (the repository
+// root when empty) at ref. Recursive listings are served by the src
+// endpoint's max_depth parameter in a single paginated walk.
+func (c *Client) ListDirectory(ctx context.Context, workspace, repoSlug, ref, path string, opts ListDirectoryOptions) ([]TreeEntry, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if ref == "" {
+		return nil, fmt.Errorf("ref is required")
+	}
+
+	pageLen := opts.Limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	// The trailing slash asks for a directory listing rather than raw content.
+	reqPath := fmt.Sprintf("/repositories/%s/%s/src/%s/",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(ref),
+	)
+	if dir := strings.Trim(path, "/"); dir != "" {
+		reqPath += escapePath(dir) + "/"
+	}
+	reqPath += fmt.Sprintf("?pagelen=%d", pageLen)
+	if opts.Recursive {
+		reqPath += fmt.Sprintf("&max_depth=%d", recursiveTreeDepth)
+	}
+
+	return collect(paginate[TreeEntry](ctx, c, reqPath), opts.Limit)
+}
@@ -0,0 +1,32 @@
+package bbcloud
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetFileContentEscapesRefAndPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/repositories/work/repo/src/feature%2Fx/docs/my%20notes.md" {
+			t.Fatalf("unexpected path %s", r.URL.EscapedPath())
+		}
+		_, _ = w.Write([]byte("notes"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.GetFileContent(context.Background(), "work", "repo", "feature/x", "/docs/my notes.md", &buf); err != nil {
+		t.Fatalf("GetFileContent: %v", err)
+	}
+	if buf.String() != "notes" {
+		t.Fatalf("unexpected content %q", buf.String())
+	}
+}
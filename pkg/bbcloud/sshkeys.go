@@ -0,0 +1,119 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SSHKey is a public SSH key registered on a user account.
+type SSHKey struct {
+	UUID      string `json:"uuid"`
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedOn string `json:"created_on,omitempty"`
+	LastUsed  string `json:"last_used,omitempty"`
+}
+
+type sshKeyListPage struct {
+	Values []SSHKey `json:"values"`
+	Next   string   `json:"next"`
+}
+
+// ListSSHKeys lists the SSH keys registered for a user, identified by account
+// UUID or Atlassian account ID.
+func (c *Client) ListSSHKeys(ctx context.Context, user string, limit int) ([]SSHKey, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	path := fmt.Sprintf("/users/%s/ssh-keys?pagelen=%d", url.PathEscape(user), pageLen)
+
+	var keys []SSHKey
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page sshKeyListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, page.Values...)
+
+		if limit > 0 && len(keys) >= limit {
+			keys = keys[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return keys, nil
+}
+
+// AddSSHKey registers a public SSH key on the user's account.
+func (c *Client) AddSSHKey(ctx context.Context, user, label, key string) (*SSHKey, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("public key is required")
+	}
+
+	body := map[string]any{
+		"key":   key,
+		"label": label,
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/users/%s/ssh-keys", url.PathEscape(user)), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sshKey SSHKey
+	if err := c.http.Do(req, &sshKey); err != nil {
+		return nil, err
+	}
+	return &sshKey, nil
+}
+
+// DeleteSSHKey removes an SSH key, identified by UUID, from the user's account.
+func (c *Client) DeleteSSHKey(ctx context.Context, user, keyUUID string) error {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return fmt.Errorf("user is required")
+	}
+	if strings.Trim(strings.TrimSpace(keyUUID), "{}") == "" {
+		return fmt.Errorf("ssh key uuid is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/users/%s/ssh-keys/%s",
+		url.PathEscape(user),
+		url.PathEscape(normalizeUUID(strings.TrimSpace(keyUUID))),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
@@ -0,0 +1,62 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSSHKeyRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{k1}","key":"ssh-ed25519 AAAA","label":"laptop"}]}`))
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"uuid":"{k2}","key":"ssh-ed25519 BBBB","label":"ci"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	keys, err := client.ListSSHKeys(ctx, "{u1}", 0)
+	if err != nil || len(keys) != 1 || keys[0].Label != "laptop" {
+		t.Fatalf("ListSSHKeys = %+v, %v", keys, err)
+	}
+
+	key, err := client.AddSSHKey(ctx, "{u1}", "ci", "ssh-ed25519 BBBB")
+	if err != nil || key.UUID != "{k2}" {
+		t.Fatalf("AddSSHKey = %+v, %v", key, err)
+	}
+
+	if err := client.DeleteSSHKey(ctx, "{u1}", "k2"); err != nil {
+		t.Fatalf("DeleteSSHKey: %v", err)
+	}
+	if err := client.DeleteSSHKey(ctx, "{u1}", "{}"); err == nil {
+		t.Fatal("expected error for empty key uuid")
+	}
+
+	want := []string{
+		"GET /users/%7Bu1%7D/ssh-keys",
+		"POST /users/%7Bu1%7D/ssh-keys",
+		"DELETE /users/%7Bu1%7D/ssh-keys/%7Bk2%7D",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v", requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Fatalf("request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
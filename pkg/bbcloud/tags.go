@@ -0,0 +1,159 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Tag represents a Bitbucket Cloud tag.
+type Tag struct {
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Target  struct {
+		Hash string `json:"hash"`
+		Date string `json:"date,omitempty"`
+	} `json:"target"`
+	Tagger *struct {
+		Raw string `json:"raw"`
+	} `json:"tagger,omitempty"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// TagListOptions configure tag listings.
+type TagListOptions struct {
+	Filter string
+	Sort   string
+	Limit  int
+}
+
+type tagListPage struct {
+	Values []Tag  `json:"values"`
+	Next   string `json:"next"`
+}
+
+// ListTags lists repository tags.
+func (c *Client) ListTags(ctx context.Context, workspace, repoSlug string, opts TagListOptions) ([]Tag, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+
+	pageLen := opts.Limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	params := url.Values{}
+	params.Set("pagelen", fmt.Sprintf("%d", pageLen))
+	if strings.TrimSpace(opts.Filter) != "" {
+		params.Set("q", fmt.Sprintf("name ~ \"%s\"", opts.Filter))
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/refs/tags?%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		params.Encode(),
+	)
+
+	var tags []Tag
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page tagListPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, page.Values...)
+
+		if opts.Limit > 0 && len(tags) >= opts.Limit {
+			tags = tags[:opts.Limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return tags, nil
+}
+
+// CreateTagInput describes a tag to create. Target may be a commit hash or a
+// branch name; a non-empty Message creates an annotated tag.
+type CreateTagInput struct {
+	Name    string
+	Target  string
+	Message string
+}
+
+// CreateTag creates a tag in the repository.
+func (c *Client) CreateTag(ctx context.Context, workspace, repoSlug string, in CreateTagInput) (*Tag, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(in.Name) == "" || strings.TrimSpace(in.Target) == "" {
+		return nil, fmt.Errorf("tag name and target are required")
+	}
+
+	body := map[string]any{
+		"name": in.Name,
+		"target": map[string]any{
+			"hash": in.Target,
+		},
+	}
+	if in.Message != "" {
+		body["message"] = in.Message
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/repositories/%s/%s/refs/tags",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	if err := c.http.Do(req, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// DeleteTag removes a tag from the repository.
+func (c *Client) DeleteTag(ctx context.Context, workspace, repoSlug, name string) error {
+	if workspace == "" || repoSlug == "" {
+		return fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("tag name is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/repositories/%s/%s/refs/tags/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(name),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
@@ -0,0 +1,53 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListTagsQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("q") != `name ~ "v1"` || q.Get("sort") != "-target.date" || q.Get("pagelen") != "2" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"v1.1"},{"name":"v1.0"}],"next":"http://example.invalid/next"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tags, err := client.ListTags(context.Background(), "work", "repo", TagListOptions{Filter: "v1", Sort: "-target.date", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Name != "v1.1" {
+		t.Fatalf("unexpected tags %+v", tags)
+	}
+}
+
+func TestDeleteTagEscapesName(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.DeleteTag(context.Background(), "work", "repo", "release/1.0"); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	if path != "DELETE /repositories/work/repo/refs/tags/release%2F1.0" {
+		t.Fatalf("unexpected request %q", path)
+	}
+}
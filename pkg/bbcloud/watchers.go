@@ -0,0 +1,42 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// ListRepositoryWatchers lists the accounts watching a repository.
+func (c *Client) ListRepositoryWatchers(ctx context.Context, workspace, repoSlug string, limit int) ([]Account, error) {
+	return collect(c.ListRepositoryWatchersIter(ctx, workspace, repoSlug), limit)
+}
+
+// ListRepositoryWatchersIter lazily iterates over the accounts watching a
+// repository, fetching a page at a time as the loop advances.
+func (c *Client) ListRepositoryWatchersIter(ctx context.Context, workspace, repoSlug string) iter.Seq2[Account, error] {
+	if workspace == "" || repoSlug == "" {
+		return failed[Account](fmt.Errorf("workspace and repository slug are required"))
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/watchers?pagelen=100",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	)
+	return paginate[Account](ctx, c, path)
+}
+
+// IsWatchingRepository reports whether the account with the given UUID is
+// among the repository's watchers. It stops paging once the account is found.
+func (c *Client) IsWatchingRepository(ctx context.Context, workspace, repoSlug, accountUUID string) (bool, error) {
+	want := normalizeUUID(accountUUID)
+	for account, err := range c.ListRepositoryWatchersIter(ctx, workspace, repoSlug) {
+		if err != nil {
+			return false, err
+		}
+		if normalizeUUID(account.UUID) == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
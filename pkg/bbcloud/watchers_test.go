@@ -0,0 +1,52 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsWatchingRepositoryStopsAtMatch(t *testing.T) {
+	var pages []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/work/repo/watchers" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json")
+		switch page {
+		case "":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{u1}"}],"next":"` + server.URL + `/repositories/work/repo/watchers?page=2"}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{me}"}],"next":"` + server.URL + `/repositories/work/repo/watchers?page=3"}`))
+		default:
+			t.Fatalf("requested page %s after a match", page)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	watching, err := client.IsWatchingRepository(context.Background(), "work", "repo", "me")
+	if err != nil {
+		t.Fatalf("IsWatchingRepository: %v", err)
+	}
+	if !watching || len(pages) != 2 {
+		t.Fatalf("watching = %v after pages %v", watching, pages)
+	}
+
+	pages = nil
+	watchers, err := client.ListRepositoryWatchers(context.Background(), "work", "repo", 1)
+	if err != nil {
+		t.Fatalf("ListRepositoryWatchers: %v", err)
+	}
+	if len(watchers) != 1 || watchers[0].UUID != "{u1}" {
+		t.Fatalf("unexpected watchers %+v", watchers)
+	}
+}
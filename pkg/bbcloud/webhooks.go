@@ -14,6 +14,9 @@ type Webhook struct {
 	URL         string   `json:"url"`
 	Events      []string `json:"events"`
 	Active      bool     `json:"active"`
+	// SecretSet reports whether deliveries are signed. The secret itself is
+	// never returned by the API.
+	SecretSet bool `json:"secret_set,omitempty"`
 }
 
 // WebhookInput configures webhook creation.
@@ -22,6 +25,9 @@ type WebhookInput struct {
 	URL         string
 	Events      []string
 	Active      bool
+	// Secret, when set, makes Bitbucket sign each delivery with an
+	// HMAC-SHA256 of the body in the X-Hub-Signature header.
+	Secret string
 }
 
 // ListWebhooks enumerates repository webhooks.
@@ -59,6 +65,9 @@ func (c *Client) CreateWebhook(ctx context.Context, workspace, repoSlug string,
 		"events":      input.Events,
 		"active":      input.Active,
 	}
+	if input.Secret != "" {
+		body["secret"] = input.Secret
+	}
 
 	path := fmt.Sprintf("/repositories/%s/%s/hooks",
 		url.PathEscape(workspace),
@@ -77,6 +86,95 @@ func (c *Client) CreateWebhook(ctx context.Context, workspace, repoSlug string,
 	return &hook, nil
 }
 
+// GetWebhook fetches a webhook by uuid.
+func (c *Client) GetWebhook(ctx context.Context, workspace, repoSlug, uuid string) (*Webhook, error) {
+	path, err := webhookPath(workspace, repoSlug, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.http.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook Webhook
+	if err := c.http.Do(req, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// UpdateWebhookInput describes webhook changes. Nil fields are left unchanged.
+type UpdateWebhookInput struct {
+	Description *string
+	URL         *string
+	Events      []string
+	Active      *bool
+	// Secret replaces the signing secret; an empty string removes it.
+	Secret *string
+}
+
+// UpdateWebhook modifies an existing webhook. The current configuration is
+// fetched first because the API replaces the whole subscription.
+func (c *Client) UpdateWebhook(ctx context.Context, workspace, repoSlug, uuid string, input UpdateWebhookInput) (*Webhook, error) {
+	current, err := c.GetWebhook(ctx, workspace, repoSlug, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Description != nil {
+		current.Description = *input.Description
+	}
+	if input.URL != nil {
+		current.URL = *input.URL
+	}
+	if input.Events != nil {
+		current.Events = input.Events
+	}
+	if input.Active != nil {
+		current.Active = *input.Active
+	}
+
+	body := map[string]any{
+		"description": current.Description,
+		"url":         current.URL,
+		"events":      current.Events,
+		"active":      current.Active,
+	}
+	if input.Secret != nil {
+		body["secret"] = *input.Secret
+	}
+
+	path, err := webhookPath(workspace, repoSlug, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.http.NewRequest(ctx, "PUT", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook Webhook
+	if err := c.http.Do(req, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+func webhookPath(workspace, repoSlug, uuid string) (string, error) {
+	if workspace == "" || repoSlug == "" {
+		return "", fmt.Errorf("workspace and repository slug are required")
+	}
+	if strings.Trim(uuid, "{} ") == "" {
+		return "", fmt.Errorf("webhook uuid is required")
+	}
+	return fmt.Sprintf("/repositories/%s/%s/hooks/%s",
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+		url.PathEscape(normalizeUUID(strings.TrimSpace(uuid))),
+	), nil
+}
+
 // DeleteWebhook removes a webhook by uuid.
 func (c *Client) DeleteWebhook(ctx context.Context, workspace, repoSlug, uuid string) error {
 	path := fmt.Sprintf("/repositories/%s/%s/hooks/%s",
@@ -0,0 +1,64 @@
+package bbcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateWebhookPreservesUnchangedFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/work/repo/hooks/{abc}" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(Webhook{
+				UUID:        "{abc}",
+				Description: "CI",
+				URL:         "https://old.example.com",
+				Events:      []string{"repo:push"},
+				Active:      true,
+			})
+		case http.MethodPut:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body["description"] != "CI" || body["url"] != "https://new.example.com" || body["active"] != false {
+				t.Fatalf("unexpected body: %v", body)
+			}
+			if events, _ := body["events"].([]any); len(events) != 1 || events[0] != "repo:push" {
+				t.Fatalf("events should be preserved, got %v", body["events"])
+			}
+			if body["secret"] != "rotated" {
+				t.Fatalf("secret not sent, got %v", body["secret"])
+			}
+			_ = json.NewEncoder(w).Encode(body)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	newURL, active, secret := "https://new.example.com", false, "rotated"
+	hook, err := client.UpdateWebhook(context.Background(), "work", "repo", "abc", UpdateWebhookInput{
+		URL:    &newURL,
+		Active: &active,
+		Secret: &secret,
+	})
+	if err != nil {
+		t.Fatalf("UpdateWebhook: %v", err)
+	}
+	if hook.URL != newURL || hook.Active {
+		t.Fatalf("unexpected webhook: %+v", hook)
+	}
+}
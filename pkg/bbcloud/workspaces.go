@@ -0,0 +1,99 @@
+package bbcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Workspace describes a Bitbucket Cloud workspace.
+type Workspace struct {
+	UUID      string `json:"uuid"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	IsPrivate bool   `json:"is_private"`
+	CreatedOn string `json:"created_on,omitempty"`
+	// Permission is the authenticated user's role (owner, collaborator or
+	// member). It is only populated by ListWorkspaces.
+	Permission string `json:"permission,omitempty"`
+	Links      struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+		Avatar struct {
+			Href string `json:"href"`
+		} `json:"avatar"`
+	} `json:"links"`
+}
+
+type workspaceAccessPage struct {
+	Values []struct {
+		Permission string    `json:"permission"`
+		Workspace  Workspace `json:"workspace"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// ListWorkspaces lists the workspaces the authenticated user can access.
+func (c *Client) ListWorkspaces(ctx context.Context, limit int) ([]Workspace, error) {
+	pageLen := limit
+	if pageLen <= 0 || pageLen > 100 {
+		pageLen = 100
+	}
+
+	path := fmt.Sprintf("/user/permissions/workspaces?pagelen=%d&sort=workspace.slug", pageLen)
+
+	var workspaces []Workspace
+	for path != "" {
+		req, err := c.http.NewRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page workspaceAccessPage
+		if err := c.http.Do(req, &page); err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Values {
+			ws := v.Workspace
+			ws.Permission = v.Permission
+			workspaces = append(workspaces, ws)
+		}
+
+		if limit > 0 && len(workspaces) >= limit {
+			workspaces = workspaces[:limit]
+			break
+		}
+
+		if page.Next == "" {
+			break
+		}
+
+		nextURL, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, err
+		}
+		path = nextURL.RequestURI()
+	}
+
+	return workspaces, nil
+}
+
+// GetWorkspace fetches a workspace by slug or UUID.
+func (c *Client) GetWorkspace(ctx context.Context, workspace string) (*Workspace, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/workspaces/%s", url.PathEscape(workspace)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws Workspace
+	if err := c.http.Do(req, &ws); err != nil {
+		return nil, err
+	}
+	return &ws, nil
+}
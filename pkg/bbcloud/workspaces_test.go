@@ -0,0 +1,37 @@
+package bbcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListWorkspacesFollowsPagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/permissions/workspaces" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"values":[{"permission":"member","workspace":{"slug":"beta","name":"Beta"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"permission":"owner","workspace":{"slug":"alpha","name":"Alpha"}}],"next":"` + server.URL + `/user/permissions/workspaces?page=2"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	workspaces, err := client.ListWorkspaces(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ListWorkspaces: %v", err)
+	}
+	if len(workspaces) != 2 || workspaces[0].Slug != "alpha" || workspaces[0].Permission != "owner" || workspaces[1].Permission != "member" {
+		t.Fatalf("unexpected workspaces %+v", workspaces)
+	}
+}
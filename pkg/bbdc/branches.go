@@ -2,7 +2,9 @@ package bbdc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 )
@@ -14,58 +16,69 @@ type Branch struct {
 	Type         string `json:"type"`
 	LatestCommit string `json:"latestCommit"`
 	IsDefault    bool   `json:"isDefault"`
+
+	Metadata map[string]json.RawMessage `json:"metadata,omitempty"`
+}
+
+const aheadBehindMetadataKey = "com.atlassian.bitbucket.server.bitbucket-branch:ahead-behind-metadata-provider"
+
+// AheadBehind reports how many commits the branch is ahead of and behind the
+// default branch. It is only populated when the branch was listed with Details.
+func (b Branch) AheadBehind() (ahead, behind int, ok bool) {
+	raw, found := b.Metadata[aheadBehindMetadataKey]
+	if !found {
+		return 0, 0, false
+	}
+	var counts struct {
+		Ahead  int `json:"ahead"`
+		Behind int `json:"behind"`
+	}
+	if err := json.Unmarshal(raw, &counts); err != nil {
+		return 0, 0, false
+	}
+	return counts.Ahead, counts.Behind, true
 }
 
 // BranchListOptions filters branch listing.
 type BranchListOptions struct {
 	Filter string
 	Limit  int
+	// Details requests branch metadata such as ahead/behind counts.
+	Details bool
 }
 
 // ListBranches retrieves branches for a repository.
 func (c *Client) ListBranches(ctx context.Context, projectKey, repoSlug string, opts BranchListOptions) ([]Branch, error) {
+	return collect(c.listBranches(ctx, projectKey, repoSlug, opts, opts.Limit), opts.Limit)
+}
+
+// ListBranchesIter lazily iterates over the branches of a repository;
+// opts.Limit is ignored.
+func (c *Client) ListBranchesIter(ctx context.Context, projectKey, repoSlug string, opts BranchListOptions) iter.Seq2[Branch, error] {
+	return c.listBranches(ctx, projectKey, repoSlug, opts, 0)
+}
+
+func (c *Client) listBranches(ctx context.Context, projectKey, repoSlug string, opts BranchListOptions, limit int) iter.Seq2[Branch, error] {
 	if projectKey == "" || repoSlug == "" {
-		return nil, fmt.Errorf("project key and repository slug are required")
+		return failed[Branch](fmt.Errorf("project key and repository slug are required"))
 	}
 
-	query := fmt.Sprintf("limit=%d", valueOrPositive(opts.Limit, 25))
+	var params []string
 	if opts.Filter != "" {
-		query += "&filterText=" + url.QueryEscape(opts.Filter)
+		params = append(params, "filterText="+url.QueryEscape(opts.Filter))
 	}
-
-	start := 0
-	var branches []Branch
-
-	for {
-		u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/branches?%s&start=%d",
-			url.PathEscape(projectKey),
-			url.PathEscape(repoSlug),
-			query,
-			start,
-		)
-		req, err := c.http.NewRequest(ctx, "GET", u, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var resp paged[Branch]
-		if err := c.http.Do(req, &resp); err != nil {
-			return nil, err
-		}
-
-		branches = append(branches, resp.Values...)
-
-		if resp.IsLastPage || len(resp.Values) == 0 || (opts.Limit > 0 && len(branches) >= opts.Limit) {
-			if opts.Limit > 0 && len(branches) > opts.Limit {
-				branches = branches[:opts.Limit]
-			}
-			break
-		}
-
-		start = resp.NextPageStart
+	if opts.Details {
+		params = append(params, "details=true")
 	}
 
-	return branches, nil
+	path := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/branches",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+	)
+	if len(params) > 0 {
+		path += "?" + strings.Join(params, "&")
+	}
+	return paginate[Branch](ctx, c, path, limit)
 }
 
 // CreateBranchInput describes branch creation payload.
@@ -166,3 +179,38 @@ func valueOrPositive(value, fallback int) int {
 	}
 	return fallback
 }
+
+// BranchModelType maps a branch type (FEATURE, BUGFIX, RELEASE, HOTFIX) to its prefix.
+type BranchModelType struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Prefix      string `json:"prefix"`
+}
+
+// BranchModel is the repository branching model configured in Bitbucket Data Center.
+type BranchModel struct {
+	Development *Branch           `json:"development,omitempty"`
+	Production  *Branch           `json:"production,omitempty"`
+	Types       []BranchModelType `json:"types,omitempty"`
+}
+
+// GetBranchModel fetches the repository branching model.
+func (c *Client) GetBranchModel(ctx context.Context, projectKey, repoSlug string) (*BranchModel, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/rest/branch-utils/1.0/projects/%s/repos/%s/branchmodel",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var model BranchModel
+	if err := c.http.Do(req, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
@@ -3,8 +3,10 @@ package bbdc
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
 	"github.com/alessandro308/bitbucket-cli/pkg/types"
@@ -12,11 +14,25 @@ import (
 
 // Options configure the Bitbucket Data Center client.
 type Options struct {
-	BaseURL     string
-	Username    string
-	Token       string
-	EnableCache bool
-	Retry       httpx.RetryPolicy
+	BaseURL  string
+	Username string
+	Token    string
+	// TokenSource supplies bearer tokens and overrides Username/Token.
+	TokenSource httpx.TokenSource
+	// Proxy, CACertFile and InsecureSkipVerify configure the network path
+	// (see httpx.Options).
+	Proxy              string
+	CACertFile         string
+	InsecureSkipVerify bool
+	EnableCache        bool
+	// CacheDir persists ETag-validated responses on disk, keyed by
+	// CacheIdentity (see httpx.Options).
+	CacheDir      string
+	CacheIdentity string
+	// Timeout bounds each request that has no context deadline of its own;
+	// zero keeps the httpx default.
+	Timeout time.Duration
+	Retry   httpx.RetryPolicy
 }
 
 // Client wraps Bitbucket Data Center REST endpoints.
@@ -36,12 +52,19 @@ func New(opts Options) (*Client, error) {
 	}
 
 	httpClient, err := httpx.New(httpx.Options{
-		BaseURL:     opts.BaseURL,
-		Username:    opts.Username,
-		Password:    opts.Token,
-		UserAgent:   "bkt-cli",
-		EnableCache: opts.EnableCache,
-		Retry:       opts.Retry,
+		BaseURL:            opts.BaseURL,
+		Username:           opts.Username,
+		Password:           opts.Token,
+		TokenSource:        opts.TokenSource,
+		UserAgent:          "bkt-cli",
+		Timeout:            opts.Timeout,
+		Proxy:              opts.Proxy,
+		CACertFile:         opts.CACertFile,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+		EnableCache:        opts.EnableCache,
+		CacheDir:           opts.CacheDir,
+		CacheIdentity:      opts.CacheIdentity,
+		Retry:              opts.Retry,
 	})
 	if err != nil {
 		return nil, err
@@ -68,6 +91,10 @@ type Repository struct {
 	ID            int      `json:"id"`
 	Project       *Project `json:"project"`
 	DefaultBranch string   `json:"defaultBranch,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	Forkable      bool     `json:"forkable"`
+	Public        bool     `json:"public"`
+	State         string   `json:"state,omitempty"`
 	Links         struct {
 		Self []struct {
 			Href string `json:"href"`
@@ -125,6 +152,10 @@ type Ref struct {
 // Type alias to shared types.CommitStatus for backward compatibility.
 type CommitStatus = types.CommitStatus
 
+// BuildStatusInput is the body CreateCommitStatus posts to the
+// build-status REST API, which keys statuses by commit only.
+type BuildStatusInput = types.BuildStatusInput
+
 type paged[T any] struct {
 	Size          int  `json:"size"`
 	Limit         int  `json:"limit"`
@@ -149,54 +180,20 @@ func (c *Client) CurrentUser(ctx context.Context, userSlug string) (*User, error
 
 // ListRepositories enumerates repositories for a project, handling pagination.
 func (c *Client) ListRepositories(ctx context.Context, projectKey string, limit int) ([]Repository, error) {
-	if projectKey == "" {
-		return nil, fmt.Errorf("project key is required")
-	}
-
-	const defaultPageSize = 25
-
-	var (
-		start = 0
-		found []Repository
-	)
-
-	for {
-		pageSize := defaultPageSize
-		if limit > 0 {
-			remaining := limit - len(found)
-			if remaining <= 0 {
-				break
-			}
-			if remaining < pageSize {
-				pageSize = remaining
-			}
-		}
-
-		u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos?limit=%d&start=%d", url.PathEscape(projectKey), pageSize, start)
-		req, err := c.http.NewRequest(ctx, "GET", u, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var resp paged[Repository]
-		if err := c.http.Do(req, &resp); err != nil {
-			return nil, err
-		}
-
-		found = append(found, resp.Values...)
+	return collect(c.listRepositories(ctx, projectKey, limit), limit)
+}
 
-		if limit > 0 && len(found) >= limit {
-			found = found[:limit]
-			break
-		}
+// ListRepositoriesIter lazily iterates over the repositories of a project,
+// fetching a page at a time as the loop advances.
+func (c *Client) ListRepositoriesIter(ctx context.Context, projectKey string) iter.Seq2[Repository, error] {
+	return c.listRepositories(ctx, projectKey, 0)
+}
 
-		if resp.IsLastPage || len(resp.Values) == 0 {
-			break
-		}
-		start = resp.NextPageStart
+func (c *Client) listRepositories(ctx context.Context, projectKey string, limit int) iter.Seq2[Repository, error] {
+	if projectKey == "" {
+		return failed[Repository](fmt.Errorf("project key is required"))
 	}
-
-	return found, nil
+	return paginate[Repository](ctx, c, fmt.Sprintf("/rest/api/1.0/projects/%s/repos", url.PathEscape(projectKey)), limit)
 }
 
 // GetRepository fetches details for a repository.
@@ -238,63 +235,29 @@ func (c *Client) GetPullRequest(ctx context.Context, projectKey, repoSlug string
 
 // ListPullRequests lists pull requests for a repository.
 func (c *Client) ListPullRequests(ctx context.Context, projectKey, repoSlug, state string, limit int) ([]PullRequest, error) {
-	if projectKey == "" || repoSlug == "" {
-		return nil, fmt.Errorf("project key and repository slug are required")
-	}
-
-	const defaultPageSize = 25
-
-	var (
-		start = 0
-		all   []PullRequest
-	)
-
-	for {
-		pageSize := defaultPageSize
-		if limit > 0 {
-			remaining := limit - len(all)
-			if remaining <= 0 {
-				break
-			}
-			if remaining < pageSize {
-				pageSize = remaining
-			}
-		}
-
-		params := []string{fmt.Sprintf("limit=%d", pageSize)}
-		if state != "" {
-			params = append(params, "state="+url.QueryEscape(strings.ToUpper(state)))
-		}
-
-		u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests?%s&start=%d",
-			url.PathEscape(projectKey),
-			url.PathEscape(repoSlug),
-			strings.Join(params, "&"),
-			start,
-		)
-		req, err := c.http.NewRequest(ctx, "GET", u, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var resp paged[PullRequest]
-		if err := c.http.Do(req, &resp); err != nil {
-			return nil, err
-		}
+	return collect(c.listPullRequests(ctx, projectKey, repoSlug, state, limit), limit)
+}
 
-		all = append(all, resp.Values...)
+// ListPullRequestsIter lazily iterates over the pull requests of a
+// repository in the given state, fetching a page at a time as the loop
+// advances; stop early by breaking out of the loop.
+func (c *Client) ListPullRequestsIter(ctx context.Context, projectKey, repoSlug, state string) iter.Seq2[PullRequest, error] {
+	return c.listPullRequests(ctx, projectKey, repoSlug, state, 0)
+}
 
-		if resp.IsLastPage || len(resp.Values) == 0 {
-			break
-		}
-		start = resp.NextPageStart
+func (c *Client) listPullRequests(ctx context.Context, projectKey, repoSlug, state string, limit int) iter.Seq2[PullRequest, error] {
+	if projectKey == "" || repoSlug == "" {
+		return failed[PullRequest](fmt.Errorf("project key and repository slug are required"))
 	}
 
-	if limit > 0 && len(all) > limit {
-		all = all[:limit]
+	path := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+	)
+	if state != "" {
+		path += "?state=" + url.QueryEscape(strings.ToUpper(state))
 	}
-
-	return all, nil
+	return paginate[PullRequest](ctx, c, path, limit)
 }
 
 // CommitStatuses returns build statuses for a commit.
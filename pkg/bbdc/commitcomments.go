@@ -0,0 +1,122 @@
+package bbdc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CommitComment represents a comment on a commit.
+type CommitComment struct {
+	ID          int    `json:"id"`
+	Version     int    `json:"version"`
+	Text        string `json:"text"`
+	Author      User   `json:"author"`
+	CreatedDate int64  `json:"createdDate"`
+	Anchor      *struct {
+		Path string `json:"path"`
+		Line int    `json:"line,omitempty"`
+	} `json:"anchor,omitempty"`
+}
+
+// CommitCommentOptions configures commit comment creation.
+type CommitCommentOptions struct {
+	Text     string
+	FilePath string // Optional: file path for an inline comment
+	Line     int    // Optional: line in the new version of the file (requires FilePath)
+}
+
+// ListCommitComments lists comments on a commit. Data Center scopes commit
+// comments by file, so path selects the file whose comments are returned.
+func (c *Client) ListCommitComments(ctx context.Context, projectKey, repoSlug, commitID, path string, limit int) ([]CommitComment, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+	if commitID == "" {
+		return nil, fmt.Errorf("commit is required")
+	}
+
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", valueOrPositive(limit, 25)))
+	if path != "" {
+		params.Set("path", path)
+	}
+
+	start := 0
+	var comments []CommitComment
+
+	for {
+		u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits/%s/comments?%s&start=%d",
+			url.PathEscape(projectKey),
+			url.PathEscape(repoSlug),
+			url.PathEscape(commitID),
+			params.Encode(),
+			start,
+		)
+		req, err := c.http.NewRequest(ctx, "GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp paged[CommitComment]
+		if err := c.http.Do(req, &resp); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, resp.Values...)
+
+		if resp.IsLastPage || len(resp.Values) == 0 || (limit > 0 && len(comments) >= limit) {
+			if limit > 0 && len(comments) > limit {
+				comments = comments[:limit]
+			}
+			break
+		}
+
+		start = resp.NextPageStart
+	}
+
+	return comments, nil
+}
+
+// CreateCommitComment comments on a commit, anchored to a file line when
+// FilePath is set.
+func (c *Client) CreateCommitComment(ctx context.Context, projectKey, repoSlug, commitID string, opts CommitCommentOptions) (*CommitComment, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+	if commitID == "" {
+		return nil, fmt.Errorf("commit is required")
+	}
+	if strings.TrimSpace(opts.Text) == "" {
+		return nil, fmt.Errorf("comment text is required")
+	}
+
+	body := map[string]any{"text": opts.Text}
+	if opts.FilePath != "" {
+		anchor := map[string]any{
+			"path":     opts.FilePath,
+			"fileType": "TO",
+		}
+		if opts.Line > 0 {
+			anchor["line"] = opts.Line
+			anchor["lineType"] = "ADDED"
+		}
+		body["anchor"] = anchor
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits/%s/comments",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		url.PathEscape(commitID),
+	), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment CommitComment
+	if err := c.http.Do(req, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
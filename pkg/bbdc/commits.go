@@ -0,0 +1,130 @@
+package bbdc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Commit represents a repository commit.
+type Commit struct {
+	ID              string `json:"id"`
+	DisplayID       string `json:"displayId"`
+	Message         string `json:"message"`
+	AuthorTimestamp int64  `json:"authorTimestamp"`
+	Author          struct {
+		Name         string `json:"name"`
+		EmailAddress string `json:"emailAddress"`
+		DisplayName  string `json:"displayName,omitempty"`
+	} `json:"author"`
+	Parents []struct {
+		ID        string `json:"id"`
+		DisplayID string `json:"displayId"`
+	} `json:"parents,omitempty"`
+}
+
+// CommitListOptions filters commit listing.
+type CommitListOptions struct {
+	// Until is the ref or commit to walk from; empty means the default branch.
+	Until string
+	// Since excludes commits reachable from this ref or commit.
+	Since string
+	// Path restricts results to commits touching the given file or directory.
+	Path  string
+	Limit int
+}
+
+// ListCommits retrieves commits for a repository, newest first.
+func (c *Client) ListCommits(ctx context.Context, projectKey, repoSlug string, opts CommitListOptions) ([]Commit, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", valueOrPositive(opts.Limit, 25)))
+	if opts.Until != "" {
+		params.Set("until", opts.Until)
+	}
+	if opts.Since != "" {
+		params.Set("since", opts.Since)
+	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+
+	start := 0
+	var commits []Commit
+
+	for {
+		u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?%s&start=%d",
+			url.PathEscape(projectKey),
+			url.PathEscape(repoSlug),
+			params.Encode(),
+			start,
+		)
+		req, err := c.http.NewRequest(ctx, "GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp paged[Commit]
+		if err := c.http.Do(req, &resp); err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, resp.Values...)
+
+		if resp.IsLastPage || len(resp.Values) == 0 || (opts.Limit > 0 && len(commits) >= opts.Limit) {
+			if opts.Limit > 0 && len(commits) > opts.Limit {
+				commits = commits[:opts.Limit]
+			}
+			break
+		}
+
+		start = resp.NextPageStart
+	}
+
+	return commits, nil
+}
+
+// GetCommit fetches a single commit by hash or ref.
+func (c *Client) GetCommit(ctx context.Context, projectKey, repoSlug, commitID string) (*Commit, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+	if commitID == "" {
+		return nil, fmt.Errorf("commit is required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits/%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		url.PathEscape(commitID),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var commit Commit
+	if err := c.http.Do(req, &commit); err != nil {
+		return nil, err
+	}
+	return &commit, nil
+}
+
+// CreateCommitStatus publishes a build status for a commit. The commit must be
+// a full SHA; reusing the key of an existing status replaces it.
+func (c *Client) CreateCommitStatus(ctx context.Context, sha string, in BuildStatusInput) error {
+	if sha == "" {
+		return fmt.Errorf("commit SHA is required")
+	}
+	if in.Key == "" || in.State == "" || in.URL == "" {
+		return fmt.Errorf("build status key, state and url are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/rest/build-status/1.0/commits/%s", url.PathEscape(sha)), in)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
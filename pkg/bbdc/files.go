@@ -0,0 +1,159 @@
+package bbdc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
+)
+
+// GetFileContent streams the raw content of path into w. ref selects the
+// branch, tag or commit; when empty the repository's default branch is used.
+func (c *Client) GetFileContent(ctx context.Context, projectKey, repoSlug, ref, path string, w io.Writer) error {
+	if projectKey == "" || repoSlug == "" {
+		return fmt.Errorf("project key and repository slug are required")
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return fmt.Errorf("file path is required")
+	}
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+
+	u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/raw/%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		escapeRefPath(path),
+	)
+	if ref != "" {
+		u += "?at=" + url.QueryEscape(ref)
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	return c.http.Do(httpx.Unbounded(req), w)
+}
+
+// TreeEntry is a file, directory or submodule in a repository tree. Path is
+// relative to the repository root.
+type TreeEntry struct {
+	Path      string `json:"path"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size,omitempty"`
+	ContentID string `json:"contentId,omitempty"`
+}
+
+// IsDir reports whether the entry is a directory.
+func (e TreeEntry) IsDir() bool {
+	return e.Type == "DIRECTORY"
+}
+
+// ListDirectoryOptions controls tree listing.
+type ListDirectoryOptions struct {
+	// Recursive lists every file below the directory. Data Center only
+	// reports file paths in this mode, so directories and sizes are omitted.
+	Recursive bool
+	Limit     int
+}
+
+// ListDirectory lists the entries of the directory at path (the repository
+// root when empty). ref selects the branch, tag or commit; when empty the
+// default branch is used.
+func (c *Client) ListDirectory(ctx context.Context, projectKey, repoSlug, ref, path string, opts ListDirectoryOptions) ([]TreeEntry, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	dir := strings.Trim(path, "/")
+	endpoint := "browse"
+	if opts.Recursive {
+		endpoint = "files"
+	}
+
+	u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		endpoint,
+	)
+	if dir != "" {
+		u += "/" + escapeRefPath(dir)
+	}
+
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", valueOrPositive(opts.Limit, 500)))
+	if ref != "" {
+		params.Set("at", ref)
+	}
+
+	var entries []TreeEntry
+	start := 0
+	for {
+		req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("%s?%s&start=%d", u, params.Encode(), start), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page paged[TreeEntry]
+		if opts.Recursive {
+			var files paged[string]
+			if err := c.http.Do(req, &files); err != nil {
+				return nil, err
+			}
+			page = paged[TreeEntry]{IsLastPage: files.IsLastPage, NextPageStart: files.NextPageStart}
+			for _, file := range files.Values {
+				page.Values = append(page.Values, TreeEntry{Path: joinTreePath(dir, file), Type: "FILE"})
+			}
+		} else {
+			var resp struct {
+				Children paged[struct {
+					Path struct {
+						ToString string `json:"toString"`
+					} `json:"path"`
+					Type      string `json:"type"`
+					Size      int64  `json:"size"`
+					ContentID string `json:"contentId"`
+				}] `json:"children"`
+			}
+			if err := c.http.Do(req, &resp); err != nil {
+				return nil, err
+			}
+			page = paged[TreeEntry]{IsLastPage: resp.Children.IsLastPage, NextPageStart: resp.Children.NextPageStart}
+			for _, child := range resp.Children.Values {
+				page.Values = append(page.Values, TreeEntry{
+					Path:      joinTreePath(dir, child.Path.ToString),
+					Type:      child.Type,
+					Size:      child.Size,
+					ContentID: child.ContentID,
+				})
+			}
+		}
+
+		entries = append(entries, page.Values...)
+
+		if page.IsLastPage || len(page.Values) == 0 || (opts.Limit > 0 && len(entries) >= opts.Limit) {
+			if opts.Limit > 0 && len(entries) > opts.Limit {
+				entries = entries[:opts.Limit]
+			}
+			break
+		}
+		start = page.NextPageStart
+	}
+
+	return entries, nil
+}
+
+// joinTreePath prefixes a path reported relative to dir with dir itself.
+func joinTreePath(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
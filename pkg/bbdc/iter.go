@@ -0,0 +1,90 @@
+package bbdc
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// maxPageSize is the largest page Data Center serves by default; the server
+// caps larger limit parameters to its page.max settings.
+const maxPageSize = 1000
+
+// paginate lazily walks a paginated Data Center listing at path, following
+// nextPageStart one page at a time. Each page asks for as many items as are
+// still wanted, up to maxPageSize; when limit is positive at most limit items
+// are yielded. Iteration stops at the first error, which is yielded with a
+// zero value.
+func paginate[T any](ctx context.Context, c *Client, path string, limit int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+
+		start, yielded := 0, 0
+		for {
+			pageSize := maxPageSize
+			if limit > 0 {
+				remaining := limit - yielded
+				if remaining <= 0 {
+					return
+				}
+				pageSize = min(pageSize, remaining)
+			}
+
+			req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("%s%slimit=%d&start=%d", path, sep, pageSize, start), nil)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var resp paged[T]
+			if err := c.http.Do(req, &resp); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, v := range resp.Values {
+				if limit > 0 && yielded >= limit {
+					return
+				}
+				if !yield(v, nil) {
+					return
+				}
+				yielded++
+			}
+
+			if resp.IsLastPage || len(resp.Values) == 0 {
+				return
+			}
+			start = resp.NextPageStart
+		}
+	}
+}
+
+// failed returns an iterator yielding only err.
+func failed[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// collect gathers the items of seq into a slice, stopping after limit items
+// when limit is positive.
+func collect[T any](seq iter.Seq2[T, error], limit int) ([]T, error) {
+	var out []T
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
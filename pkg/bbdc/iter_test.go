@@ -0,0 +1,170 @@
+package bbdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// pagedServer serves total numbered items from path with Data Center's
+// limit/start pagination and records the query of every request.
+func pagedServer(t *testing.T, path string, total int) (*Client, func() []string) {
+	t.Helper()
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := min(start+limit, total)
+		values := []map[string]any{}
+		for i := start; i < end; i++ {
+			values = append(values, map[string]any{"displayId": fmt.Sprintf("b%d", i+1), "slug": fmt.Sprintf("r%d", i+1)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"values":        values,
+			"isLastPage":    end >= total,
+			"nextPageStart": end,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL, Username: "u", Token: "t"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+func TestListBranchesRequestsRemainingItemsPerPage(t *testing.T) {
+	client, queries := pagedServer(t, "/rest/api/1.0/projects/PROJ/repos/repo/branches", 200)
+
+	branches, err := client.ListBranches(context.Background(), "PROJ", "repo", BranchListOptions{Limit: 60})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if len(branches) != 60 || branches[59].DisplayID != "b60" {
+		t.Fatalf("got %d branches", len(branches))
+	}
+	if got := queries(); len(got) != 1 || got[0] != "limit=60&start=0" {
+		t.Fatalf("queries = %v", got)
+	}
+}
+
+func TestListBranchesIterFollowsPages(t *testing.T) {
+	client, queries := pagedServer(t, "/rest/api/1.0/projects/PROJ/repos/repo/branches", 2*maxPageSize+5)
+
+	count := 0
+	for branch, err := range client.ListBranchesIter(context.Background(), "PROJ", "repo", BranchListOptions{Filter: "b"}) {
+		if err != nil {
+			t.Fatalf("iter: %v", err)
+		}
+		count++
+		if branch.DisplayID != fmt.Sprintf("b%d", count) {
+			t.Fatalf("branch %d = %s", count, branch.DisplayID)
+		}
+	}
+	if count != 2*maxPageSize+5 {
+		t.Fatalf("iterated %d branches", count)
+	}
+	want := []string{
+		fmt.Sprintf("filterText=b&limit=%d&start=0", maxPageSize),
+		fmt.Sprintf("filterText=b&limit=%d&start=%d", maxPageSize, maxPageSize),
+		fmt.Sprintf("filterText=b&limit=%d&start=%d", maxPageSize, 2*maxPageSize),
+	}
+	if got := queries(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("queries = %v, want %v", got, want)
+	}
+}
+
+func TestListRepositoriesIterStopsWhenLoopBreaks(t *testing.T) {
+	client, queries := pagedServer(t, "/rest/api/1.0/projects/PROJ/repos", 3*maxPageSize)
+
+	var slugs []string
+	for repo, err := range client.ListRepositoriesIter(context.Background(), "PROJ") {
+		if err != nil {
+			t.Fatalf("iter: %v", err)
+		}
+		slugs = append(slugs, repo.Slug)
+		if len(slugs) == 3 {
+			break
+		}
+	}
+	if fmt.Sprint(slugs) != "[r1 r2 r3]" {
+		t.Fatalf("slugs = %v", slugs)
+	}
+	if got := queries(); len(got) != 1 {
+		t.Fatalf("expected a single page request, got %v", got)
+	}
+}
+
+func TestListPullRequestsIterYieldsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "OPEN" {
+			t.Errorf("state = %q", r.URL.Query().Get("state"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Repository repo does not exist."}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL, Username: "u", Token: "t"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	calls := 0
+	for _, err := range client.ListPullRequestsIter(context.Background(), "PROJ", "repo", "open") {
+		calls++
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("yielded %d times", calls)
+	}
+
+	for _, err := range client.ListPullRequestsIter(context.Background(), "", "repo", "open") {
+		if err == nil {
+			t.Fatal("expected a validation error")
+		}
+	}
+}
+
+func TestCollectStopsAtLimit(t *testing.T) {
+	seq := func(yield func(int, error) bool) {
+		for i := range 10 {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+	got, err := collect(seq, 3)
+	if err != nil || fmt.Sprint(got) != "[0 1 2]" {
+		t.Fatalf("collect = %v, %v", got, err)
+	}
+	if all, _ := collect(seq, 0); len(all) != 10 {
+		t.Fatalf("collect without limit = %v", all)
+	}
+}
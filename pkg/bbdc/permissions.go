@@ -124,3 +124,80 @@ func (c *Client) RevokeProjectPermission(ctx context.Context, projectKey, userna
 	}
 	return c.http.Do(req, nil)
 }
+
+// GroupPermission represents a permission granted to a group.
+type GroupPermission struct {
+	Group struct {
+		Name string `json:"name"`
+	} `json:"group"`
+	Permission string `json:"permission"`
+}
+
+// ListRepoGroupPermissions returns repository group permissions.
+func (c *Client) ListRepoGroupPermissions(ctx context.Context, projectKey, repoSlug string, limit int) ([]GroupPermission, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	path := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/permissions/groups",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug))
+	pageLimit := valueOrPositive(limit, 100)
+	start := 0
+	var out []GroupPermission
+
+	for {
+		u := fmt.Sprintf("%s?limit=%d&start=%d", path, pageLimit, start)
+		req, err := c.http.NewRequest(ctx, "GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		var resp paged[GroupPermission]
+		if err := c.http.Do(req, &resp); err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Values...)
+		if resp.IsLastPage || len(resp.Values) == 0 || (limit > 0 && len(out) >= limit) {
+			if limit > 0 && len(out) > limit {
+				out = out[:limit]
+			}
+			break
+		}
+		start = resp.NextPageStart
+	}
+	return out, nil
+}
+
+// GrantRepoGroupPermission assigns a permission to a group for a repository.
+func (c *Client) GrantRepoGroupPermission(ctx context.Context, projectKey, repoSlug, group, permission string) error {
+	if projectKey == "" || repoSlug == "" || group == "" || permission == "" {
+		return fmt.Errorf("project, repo, group, and permission are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "PUT", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/permissions/groups?name=%s&permission=%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		url.QueryEscape(group),
+		url.QueryEscape(strings.ToUpper(permission)),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+// RevokeRepoGroupPermission removes a repository permission for a group.
+func (c *Client) RevokeRepoGroupPermission(ctx context.Context, projectKey, repoSlug, group string) error {
+	if projectKey == "" || repoSlug == "" || group == "" {
+		return fmt.Errorf("project, repo, and group are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/permissions/groups?name=%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		url.QueryEscape(group),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
@@ -10,7 +10,9 @@ import (
 
 // PullRequestReviewer represents a reviewer assignment.
 type PullRequestReviewer struct {
-	User User `json:"user"`
+	User     User   `json:"user"`
+	Status   string `json:"status,omitempty"`
+	Approved bool   `json:"approved,omitempty"`
 }
 
 // PullRequestParticipant wraps a reviewer/participant entry.
@@ -53,6 +53,124 @@ func (c *Client) CreateRepository(ctx context.Context, projectKey string, in Cre
 	return &repo, nil
 }
 
+// UpdateRepositoryInput describes mutable repository settings. Nil fields are
+// left unchanged.
+type UpdateRepositoryInput struct {
+	Name          *string
+	Description   *string
+	Forkable      *bool
+	Public        *bool
+	DefaultBranch *string
+}
+
+// UpdateRepository changes repository settings. The default branch is stored
+// separately and is updated through the repository settings endpoint.
+func (c *Client) UpdateRepository(ctx context.Context, projectKey, repoSlug string, in UpdateRepositoryInput) (*Repository, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	body := make(map[string]any)
+	if in.Name != nil {
+		body["name"] = *in.Name
+	}
+	if in.Description != nil {
+		body["description"] = *in.Description
+	}
+	if in.Forkable != nil {
+		body["forkable"] = *in.Forkable
+	}
+	if in.Public != nil {
+		body["public"] = *in.Public
+	}
+
+	var repo *Repository
+	if len(body) > 0 {
+		req, err := c.http.NewRequest(ctx, "PUT", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
+			url.PathEscape(projectKey),
+			url.PathEscape(repoSlug),
+		), body)
+		if err != nil {
+			return nil, err
+		}
+
+		var updated Repository
+		if err := c.http.Do(req, &updated); err != nil {
+			return nil, err
+		}
+		repo = &updated
+		// Renaming a repository changes its slug.
+		repoSlug = valueOrDefault(updated.Slug, repoSlug)
+	}
+
+	if in.DefaultBranch != nil {
+		if err := c.SetDefaultBranch(ctx, projectKey, repoSlug, *in.DefaultBranch); err != nil {
+			return nil, err
+		}
+	}
+
+	if repo == nil {
+		fetched, err := c.GetRepository(ctx, projectKey, repoSlug)
+		if err != nil {
+			return nil, err
+		}
+		repo = fetched
+	}
+	if in.DefaultBranch != nil {
+		repo.DefaultBranch = *in.DefaultBranch
+	}
+
+	return repo, nil
+}
+
+// GetDefaultBranch returns the repository's default branch.
+func (c *Client) GetDefaultBranch(ctx context.Context, projectKey, repoSlug string) (*Branch, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/branches/default",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch Branch
+	if err := c.http.Do(req, &branch); err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// WatchRepository subscribes the authenticated user to notifications for
+// the repository's pull requests and commits.
+func (c *Client) WatchRepository(ctx context.Context, projectKey, repoSlug string) error {
+	return c.setRepositoryWatch(ctx, projectKey, repoSlug, "POST")
+}
+
+// UnwatchRepository removes the authenticated user from the repository's
+// watchers.
+func (c *Client) UnwatchRepository(ctx context.Context, projectKey, repoSlug string) error {
+	return c.setRepositoryWatch(ctx, projectKey, repoSlug, "DELETE")
+}
+
+func (c *Client) setRepositoryWatch(ctx context.Context, projectKey, repoSlug, method string) error {
+	if projectKey == "" || repoSlug == "" {
+		return fmt.Errorf("project key and repository slug are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, method, fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/watch",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
 func valueOrDefault(value, fallback string) string {
 	if value != "" {
 		return value
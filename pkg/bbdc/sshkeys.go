@@ -0,0 +1,105 @@
+package bbdc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SSHKey is a public SSH key registered for a user.
+type SSHKey struct {
+	ID    int    `json:"id"`
+	Text  string `json:"text"`
+	Label string `json:"label,omitempty"`
+}
+
+// ListSSHKeys lists SSH keys for the given user slug, or for the authenticated
+// user when userSlug is empty.
+func (c *Client) ListSSHKeys(ctx context.Context, userSlug string, limit int) ([]SSHKey, error) {
+	const defaultPageSize = 25
+
+	var (
+		start = 0
+		found []SSHKey
+	)
+
+	for {
+		pageSize := defaultPageSize
+		if limit > 0 {
+			remaining := limit - len(found)
+			if remaining <= 0 {
+				break
+			}
+			if remaining < pageSize {
+				pageSize = remaining
+			}
+		}
+
+		q := url.Values{}
+		q.Set("limit", fmt.Sprintf("%d", pageSize))
+		q.Set("start", fmt.Sprintf("%d", start))
+		if userSlug != "" {
+			q.Set("user", userSlug)
+		}
+
+		req, err := c.http.NewRequest(ctx, "GET", "/rest/ssh/1.0/keys?"+q.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp paged[SSHKey]
+		if err := c.http.Do(req, &resp); err != nil {
+			return nil, err
+		}
+
+		found = append(found, resp.Values...)
+
+		if limit > 0 && len(found) >= limit {
+			found = found[:limit]
+			break
+		}
+
+		if resp.IsLastPage || len(resp.Values) == 0 {
+			break
+		}
+		start = resp.NextPageStart
+	}
+
+	return found, nil
+}
+
+// AddSSHKey registers a public SSH key for the authenticated user.
+func (c *Client) AddSSHKey(ctx context.Context, label, key string) (*SSHKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("public key is required")
+	}
+
+	body := map[string]any{
+		"text": key,
+	}
+	if label != "" {
+		body["label"] = label
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", "/rest/ssh/1.0/keys", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sshKey SSHKey
+	if err := c.http.Do(req, &sshKey); err != nil {
+		return nil, err
+	}
+	return &sshKey, nil
+}
+
+// DeleteSSHKey removes an SSH key by ID.
+func (c *Client) DeleteSSHKey(ctx context.Context, id int) error {
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/rest/ssh/1.0/keys/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
@@ -0,0 +1,136 @@
+package bbdc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Tag represents a repository tag.
+type Tag struct {
+	ID           string `json:"id"`
+	DisplayID    string `json:"displayId"`
+	Type         string `json:"type"`
+	LatestCommit string `json:"latestCommit"`
+	Hash         string `json:"hash,omitempty"`
+}
+
+// TagListOptions filters tag listing.
+type TagListOptions struct {
+	Filter string
+	Limit  int
+}
+
+// ListTags retrieves tags for a repository.
+func (c *Client) ListTags(ctx context.Context, projectKey, repoSlug string, opts TagListOptions) ([]Tag, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+
+	query := fmt.Sprintf("limit=%d&orderBy=MODIFICATION", valueOrPositive(opts.Limit, 25))
+	if opts.Filter != "" {
+		query += "&filterText=" + url.QueryEscape(opts.Filter)
+	}
+
+	start := 0
+	var tags []Tag
+
+	for {
+		u := fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/tags?%s&start=%d",
+			url.PathEscape(projectKey),
+			url.PathEscape(repoSlug),
+			query,
+			start,
+		)
+		req, err := c.http.NewRequest(ctx, "GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp paged[Tag]
+		if err := c.http.Do(req, &resp); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, resp.Values...)
+
+		if resp.IsLastPage || len(resp.Values) == 0 || (opts.Limit > 0 && len(tags) >= opts.Limit) {
+			if opts.Limit > 0 && len(tags) > opts.Limit {
+				tags = tags[:opts.Limit]
+			}
+			break
+		}
+
+		start = resp.NextPageStart
+	}
+
+	return tags, nil
+}
+
+// CreateTagInput describes tag creation payload. StartPoint may be a commit
+// hash or a ref; a non-empty Message creates an annotated tag.
+type CreateTagInput struct {
+	Name       string
+	StartPoint string
+	Message    string
+}
+
+// CreateTag creates a tag within the repository.
+func (c *Client) CreateTag(ctx context.Context, projectKey, repoSlug string, in CreateTagInput) (*Tag, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+	if in.Name == "" || in.StartPoint == "" {
+		return nil, fmt.Errorf("tag name and start point are required")
+	}
+
+	body := map[string]any{
+		"name":       strings.TrimPrefix(in.Name, "refs/tags/"),
+		"startPoint": in.StartPoint,
+	}
+	if in.Message != "" {
+		body["message"] = in.Message
+	}
+
+	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/tags",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+	), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	if err := c.http.Do(req, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// DeleteTag removes a tag from the repository.
+func (c *Client) DeleteTag(ctx context.Context, projectKey, repoSlug, name string) error {
+	if projectKey == "" || repoSlug == "" || name == "" {
+		return fmt.Errorf("project key, repository slug, and tag are required")
+	}
+
+	req, err := c.http.NewRequest(ctx, "DELETE", fmt.Sprintf("/rest/git/1.0/projects/%s/repos/%s/tags/%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(repoSlug),
+		escapeRefPath(strings.TrimPrefix(name, "refs/tags/")),
+	), nil)
+	if err != nil {
+		return err
+	}
+	return c.http.Do(req, nil)
+}
+
+// escapeRefPath escapes each segment of a ref name so hierarchical names such
+// as release/1.0 keep their slashes in the URL path.
+func escapeRefPath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -41,6 +41,9 @@ type CreateWebhookInput struct {
 	URL    string
 	Events []string
 	Active bool
+	// Secret, when set, makes Bitbucket sign each delivery with an
+	// HMAC-SHA256 of the body in the X-Hub-Signature header.
+	Secret string
 }
 
 // CreateWebhook registers a webhook for the repository.
@@ -58,6 +61,9 @@ func (c *Client) CreateWebhook(ctx context.Context, projectKey, repoSlug string,
 		"events": in.Events,
 		"active": in.Active,
 	}
+	if in.Secret != "" {
+		body["configuration"] = map[string]any{"secret": in.Secret}
+	}
 
 	req, err := c.http.NewRequest(ctx, "POST", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks",
 		url.PathEscape(projectKey), url.PathEscape(repoSlug)), body)
@@ -72,6 +78,88 @@ func (c *Client) CreateWebhook(ctx context.Context, projectKey, repoSlug string,
 	return &hook, nil
 }
 
+// GetWebhook fetches a webhook by ID.
+func (c *Client) GetWebhook(ctx context.Context, projectKey, repoSlug string, id int) (*Webhook, error) {
+	if projectKey == "" || repoSlug == "" {
+		return nil, fmt.Errorf("project key and repository slug are required")
+	}
+	req, err := c.http.NewRequest(ctx, "GET", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks/%d",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug), id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var hook Webhook
+	if err := c.http.Do(req, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// UpdateWebhookInput describes webhook changes. Nil fields are left unchanged.
+type UpdateWebhookInput struct {
+	Name   *string
+	URL    *string
+	Events []string
+	Active *bool
+	// Secret replaces the signing secret; an empty string removes it.
+	Secret *string
+}
+
+// UpdateWebhook modifies an existing webhook, preserving unspecified fields.
+func (c *Client) UpdateWebhook(ctx context.Context, projectKey, repoSlug string, id int, in UpdateWebhookInput) (*Webhook, error) {
+	current, err := c.GetWebhook(ctx, projectKey, repoSlug, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Name != nil {
+		current.Name = *in.Name
+	}
+	if in.URL != nil {
+		current.URL = *in.URL
+	}
+	if in.Events != nil {
+		current.Events = in.Events
+	}
+	if in.Active != nil {
+		current.Active = *in.Active
+	}
+
+	body := map[string]any{
+		"name":   current.Name,
+		"url":    current.URL,
+		"events": current.Events,
+		"active": current.Active,
+	}
+	if in.Secret != nil {
+		configuration := make(map[string]any, len(current.Configuration)+1)
+		for key, value := range current.Configuration {
+			configuration[key] = value
+		}
+		if *in.Secret == "" {
+			delete(configuration, "secret")
+		} else {
+			configuration["secret"] = *in.Secret
+		}
+		current.Configuration = configuration
+	}
+	if in.Secret != nil || len(current.Configuration) > 0 {
+		body["configuration"] = current.Configuration
+	}
+
+	req, err := c.http.NewRequest(ctx, "PUT", fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/webhooks/%d",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug), id), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook Webhook
+	if err := c.http.Do(req, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
 // DeleteWebhook removes a webhook by ID.
 func (c *Client) DeleteWebhook(ctx context.Context, projectKey, repoSlug string, id int) error {
 	if projectKey == "" || repoSlug == "" {
@@ -1,7 +1,6 @@
 package admin
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -65,7 +64,7 @@ func runSecretsRotate(cmd *cobra.Command, f *cmdutil.Factory) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	spinner := f.ProgressSpinner()
@@ -144,7 +143,7 @@ func runLoggingGet(cmd *cobra.Command, f *cmdutil.Factory) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 	defer cancel()
 
 	cfg, err := client.GetLoggingConfig(ctx)
@@ -181,7 +180,7 @@ func runLoggingSet(cmd *cobra.Command, f *cmdutil.Factory, opts *struct {
 	}
 	cfg.Async = opts.Async
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 	defer cancel()
 
 	if err := client.UpdateLoggingConfig(ctx, cfg); err != nil {
@@ -0,0 +1,338 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdAlias returns the alias management command tree.
+func NewCmdAlias(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Create shortcuts for bkt commands",
+		Long: `Aliases expand into full bkt commands before they run.
+
+The expansion may reference positional arguments as $1, $2, ...; arguments
+that are not referenced are appended. An expansion starting with "!" is run
+by sh with the arguments available as "$@", so aliases can compose bkt with
+other tools.`,
+	}
+
+	cmd.AddCommand(newSetCmd(f))
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newDeleteCmd(f))
+
+	return cmd
+}
+
+type setOptions struct {
+	Shell   bool
+	Clobber bool
+}
+
+func newSetCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &setOptions{}
+	cmd := &cobra.Command{
+		Use:   "set <name> <expansion>",
+		Short: "Create or update an alias",
+		Example: `  bkt alias set co 'pr checkout'
+  bkt alias set mine 'pr list --mine --state OPEN'
+  bkt alias set review 'pr view $1 --web'
+  bkt alias set open-prs --shell 'bkt pr list --json id,title | jq length'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(cmd, f, opts, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Shell, "shell", "s", false, "Run the expansion through sh (same as prefixing it with !)")
+	cmd.Flags().BoolVar(&opts.Clobber, "clobber", false, "Overwrite an existing alias")
+
+	return cmd
+}
+
+func runSet(cmd *cobra.Command, f *cmdutil.Factory, opts *setOptions, name, expansion string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, " \t\n") || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	if cmdutil.IsBuiltinCommand(cmd.Root(), name) {
+		return fmt.Errorf("%q is already a %s command", name, f.ExecutableName)
+	}
+
+	expansion = strings.TrimSpace(expansion)
+	if opts.Shell && !strings.HasPrefix(expansion, "!") {
+		expansion = "!" + expansion
+	}
+	if expansion == "" || expansion == "!" {
+		return fmt.Errorf("alias expansion cannot be empty")
+	}
+	if !strings.HasPrefix(expansion, "!") {
+		words, err := splitWords(expansion)
+		if err != nil {
+			return fmt.Errorf("parse expansion: %w", err)
+		}
+		if !cmdutil.IsBuiltinCommand(cmd.Root(), words[0]) {
+			return fmt.Errorf("expansion must start with a %s command, not %q; use --shell for other programs", f.ExecutableName, words[0])
+		}
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	existing, exists := cfg.Alias(name)
+	if exists && existing != expansion && !opts.Clobber {
+		return fmt.Errorf("alias %q already expands to %q; use --clobber to overwrite", name, existing)
+	}
+
+	cfg.SetAlias(name, expansion)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	verb := "Added"
+	if exists {
+		verb = "Updated"
+	}
+	_, err = fmt.Fprintf(ios.Out, "✓ %s alias %s → %s\n", verb, name, expansion)
+	return err
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List configured aliases",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f)
+		},
+	}
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type aliasSummary struct {
+		Name      string `json:"name"`
+		Expansion string `json:"expansion"`
+	}
+	summaries := make([]aliasSummary, 0, len(names))
+	for _, name := range names {
+		summaries = append(summaries, aliasSummary{Name: name, Expansion: cfg.Aliases[name]})
+	}
+
+	payload := map[string]any{"aliases": summaries}
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(summaries) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No aliases configured. Add one with `%s alias set`.\n", f.ExecutableName)
+			return err
+		}
+		for _, s := range summaries {
+			if _, err := fmt.Fprintf(ios.Out, "%s:\t%s\n", s.Name, s.Expansion); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete an alias",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd, f, args[0])
+		},
+	}
+}
+
+func runDelete(cmd *cobra.Command, f *cmdutil.Factory, name string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	expansion, ok := cfg.Alias(name)
+	if !ok {
+		return fmt.Errorf("no alias named %q", name)
+	}
+	cfg.DeleteAlias(name)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Deleted alias %s (was %s)\n", name, expansion)
+	return err
+}
+
+// Expand rewrites args when args[0] names an alias rather than a built-in
+// command. It returns the new argument list and, for shell aliases, the
+// script to run instead; args are returned unchanged when no alias applies.
+func Expand(root *cobra.Command, aliases map[string]string, args []string) (expanded []string, script string, err error) {
+	if len(args) == 0 || cmdutil.IsBuiltinCommand(root, args[0]) {
+		return args, "", nil
+	}
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, "", nil
+	}
+	rest := args[1:]
+
+	if shell, ok := strings.CutPrefix(expansion, "!"); ok {
+		return rest, shell, nil
+	}
+
+	words, err := splitWords(expansion)
+	if err != nil {
+		return nil, "", fmt.Errorf("alias %q: %w", args[0], err)
+	}
+
+	used := make(map[int]bool)
+	for i, word := range words {
+		words[i] = expandPositional(word, rest, used)
+	}
+	for i, arg := range rest {
+		if !used[i] {
+			words = append(words, arg)
+		}
+	}
+	return words, "", nil
+}
+
+// expandPositional replaces $N references in word with the matching argument.
+func expandPositional(word string, args []string, used map[int]bool) string {
+	var b strings.Builder
+	for i := 0; i < len(word); i++ {
+		if word[i] != '$' {
+			b.WriteByte(word[i])
+			continue
+		}
+		j := i + 1
+		for j < len(word) && word[j] >= '0' && word[j] <= '9' {
+			j++
+		}
+		n, err := strconv.Atoi(word[i+1 : j])
+		if err != nil || n < 1 || n > len(args) {
+			b.WriteByte(word[i])
+			continue
+		}
+		b.WriteString(args[n-1])
+		used[n-1] = true
+		i = j - 1
+	}
+	return b.String()
+}
+
+// RunShell executes a shell alias with args as its positional parameters and
+// maps a non-zero exit status to an ExitError.
+func RunShell(cmd *cobra.Command, f *cmdutil.Factory, name, script string, args []string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	shArgs := append([]string{"-c", script, name}, args...)
+	shell := exec.CommandContext(cmd.Context(), "sh", shArgs...)
+	shell.Stdin = ios.In
+	shell.Stdout = ios.Out
+	shell.Stderr = ios.ErrOut
+	shell.Env = os.Environ()
+
+	if err := shell.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &cmdutil.ExitError{Code: exitErr.ExitCode()}
+		}
+		return fmt.Errorf("run alias %q: %w", name, err)
+	}
+	return nil
+}
+
+// splitWords splits an expansion into arguments, honouring single quotes,
+// double quotes and backslash escapes.
+func splitWords(s string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty expansion")
+	}
+	return words, nil
+}
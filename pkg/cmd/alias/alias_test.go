@@ -0,0 +1,118 @@
+package alias
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestRoot(f *cmdutil.Factory) *cobra.Command {
+	root := &cobra.Command{Use: "bkt", SilenceErrors: true, SilenceUsage: true}
+	root.PersistentFlags().Bool("json", false, "")
+	pr := &cobra.Command{Use: "pr", Aliases: []string{"pull-request"}}
+	pr.AddCommand(&cobra.Command{Use: "checkout", RunE: func(*cobra.Command, []string) error { return nil }})
+	root.AddCommand(pr, NewCmdAlias(f))
+	return root
+}
+
+func TestExpand(t *testing.T) {
+	root := newTestRoot(&cmdutil.Factory{})
+	aliases := map[string]string{
+		"co":     "pr checkout",
+		"review": `pr view $1 --comment "looks good"`,
+		"pr":     "should never shadow a built-in",
+		"count":  "!bkt pr list --json | jq length",
+	}
+
+	cases := []struct {
+		args       []string
+		want       []string
+		wantScript string
+	}{
+		{[]string{"co", "42"}, []string{"pr", "checkout", "42"}, ""},
+		{[]string{"review", "7", "--web"}, []string{"pr", "view", "7", "--comment", "looks good", "--web"}, ""},
+		{[]string{"pr", "list"}, []string{"pr", "list"}, ""},
+		{[]string{"unknown"}, []string{"unknown"}, ""},
+		{[]string{"count", "x"}, []string{"x"}, "bkt pr list --json | jq length"},
+	}
+	for _, tc := range cases {
+		got, script, err := Expand(root, aliases, tc.args)
+		if err != nil {
+			t.Fatalf("Expand(%v): %v", tc.args, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) || script != tc.wantScript {
+			t.Errorf("Expand(%v) = %v, %q; want %v, %q", tc.args, got, script, tc.want, tc.wantScript)
+		}
+	}
+}
+
+func TestSplitWordsRejectsUnterminatedQuote(t *testing.T) {
+	if _, err := splitWords(`pr list --search "open`); err == nil {
+		t.Fatal("expected unterminated quote error")
+	}
+}
+
+func TestAliasSetListDelete(t *testing.T) {
+	t.Setenv("BKT_CONFIG_DIR", t.TempDir())
+
+	cfg := &config.Config{}
+	var stdout strings.Builder
+	f := &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stdout},
+		Config:         func() (*config.Config, error) { return cfg, nil },
+	}
+
+	run := func(args ...string) error {
+		stdout.Reset()
+		root := newTestRoot(f)
+		root.SetArgs(append([]string{"alias"}, args...))
+		return root.Execute()
+	}
+
+	if err := run("set", "co", "pr checkout"); err != nil {
+		t.Fatalf("alias set: %v", err)
+	}
+	if got, _ := cfg.Alias("co"); got != "pr checkout" {
+		t.Fatalf("alias co = %q", got)
+	}
+
+	if err := run("set", "co", "pr view"); err == nil || !strings.Contains(err.Error(), "--clobber") {
+		t.Fatalf("expected clobber error, got %v", err)
+	}
+	if err := run("set", "pr", "pr checkout"); err == nil || !strings.Contains(err.Error(), "already a bkt command") {
+		t.Fatalf("expected built-in error, got %v", err)
+	}
+	if err := run("set", "x", "git status"); err == nil || !strings.Contains(err.Error(), "--shell") {
+		t.Fatalf("expected non-bkt expansion error, got %v", err)
+	}
+	if err := run("set", "st", "--shell", "git status"); err != nil {
+		t.Fatalf("alias set --shell: %v", err)
+	}
+	if got, _ := cfg.Alias("st"); got != "!git status" {
+		t.Fatalf("alias st = %q", got)
+	}
+
+	if err := run("list"); err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if got := stdout.String(); got != "co:\tpr checkout\nst:\t!git status\n" {
+		t.Fatalf("unexpected list output %q", got)
+	}
+
+	if err := run("delete", "co"); err != nil {
+		t.Fatalf("alias delete: %v", err)
+	}
+	if _, ok := cfg.Alias("co"); ok {
+		t.Fatal("alias co still present")
+	}
+	if err := run("delete", "co"); err == nil {
+		t.Fatal("expected error deleting missing alias")
+	}
+}
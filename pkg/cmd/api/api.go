@@ -4,21 +4,30 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/alessandro308/bitbucket-cli/internal/config"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
 )
 
 type apiOptions struct {
-	Method  string
-	Input   string
-	Fields  []string
-	Headers []string
-	Params  []string
+	Method    string
+	Input     string
+	Fields    []string
+	RawFields []string
+	Headers   []string
+	Params    []string
+	Paginate  bool
 }
 
+var placeholderPattern = regexp.MustCompile(`\{(workspace|repo|project)\}`)
+
 // NewCmdAPI exposes a raw REST escape hatch akin to gh api.
 func NewCmdAPI(f *cmdutil.Factory) *cobra.Command {
 	opts := &apiOptions{}
@@ -27,9 +36,18 @@ func NewCmdAPI(f *cmdutil.Factory) *cobra.Command {
 		Short: "Make raw Bitbucket API requests",
 		Long: `Call Bitbucket REST APIs directly for endpoints that do not yet have first-class commands.
 
+The placeholders {workspace}, {repo} and {project} in the path are replaced
+with values from the active context. --field values are parsed as JSON when
+possible (numbers, booleans, arrays); --raw-field values are always strings.
+
+--paginate follows Bitbucket Cloud "next" links or Data Center "nextPageStart"
+offsets and prints the combined "values" of every page as one JSON array.
+
 Examples:
   bkt api /rest/api/1.0/projects
   bkt api /repositories --workspace my-team --param pagelen=50
+  bkt api /repositories/{workspace}/{repo}/pullrequests --paginate
+  bkt api /repositories/{workspace}/{repo}/pullrequests -X POST -f title=Fix -F draft=true
   bkt api /rest/api/1.0/projects/ABC/repos --method POST --field name=demo --field scmId=git`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -40,8 +58,10 @@ Examples:
 	cmd.Flags().StringVarP(&opts.Method, "method", "X", "", "HTTP method (default GET, or POST when a body is supplied)")
 	cmd.Flags().StringVarP(&opts.Input, "input", "d", "", "JSON string to use as the request body")
 	cmd.Flags().StringArrayVarP(&opts.Fields, "field", "F", nil, "Add JSON body field (key=value, repeatable)")
+	cmd.Flags().StringArrayVarP(&opts.RawFields, "raw-field", "f", nil, "Add string body field without JSON parsing (key=value, repeatable)")
 	cmd.Flags().StringArrayVarP(&opts.Headers, "header", "H", nil, "Add an HTTP request header (Key: Value)")
 	cmd.Flags().StringArrayVarP(&opts.Params, "param", "P", nil, "Append query parameter (key=value)")
+	cmd.Flags().BoolVar(&opts.Paginate, "paginate", false, "Fetch every page and combine the values into one JSON array")
 
 	return cmd
 }
@@ -50,12 +70,13 @@ func runAPI(cmd *cobra.Command, f *cmdutil.Factory, opts *apiOptions, path strin
 	method := strings.ToUpper(strings.TrimSpace(opts.Method))
 
 	var body any
-	if len(opts.Fields) > 0 && opts.Input != "" {
+	hasFields := len(opts.Fields) > 0 || len(opts.RawFields) > 0
+	if hasFields && opts.Input != "" {
 		return fmt.Errorf("--field and --input flags cannot be combined")
 	}
 
-	if len(opts.Fields) > 0 {
-		payload := make(map[string]any, len(opts.Fields))
+	if hasFields {
+		payload := make(map[string]any, len(opts.Fields)+len(opts.RawFields))
 		for _, field := range opts.Fields {
 			key, value, err := parseKeyValue(field)
 			if err != nil {
@@ -66,6 +87,16 @@ func runAPI(cmd *cobra.Command, f *cmdutil.Factory, opts *apiOptions, path strin
 			}
 			payload[key] = inferJSONValue(value)
 		}
+		for _, field := range opts.RawFields {
+			key, value, err := parseKeyValue(field)
+			if err != nil {
+				return fmt.Errorf("parse field %q: %w", field, err)
+			}
+			if key == "" {
+				return fmt.Errorf("field %q is missing a key", field)
+			}
+			payload[key] = value
+		}
 		body = payload
 	} else if strings.TrimSpace(opts.Input) != "" {
 		raw := json.RawMessage(opts.Input)
@@ -80,8 +111,17 @@ func runAPI(cmd *cobra.Command, f *cmdutil.Factory, opts *apiOptions, path strin
 		}
 	}
 
+	if opts.Paginate && method != "GET" {
+		return fmt.Errorf("--paginate is only supported for GET requests")
+	}
+
 	override := cmdutil.FlagValue(cmd, "context")
-	_, _, host, err := cmdutil.ResolveContext(f, cmd, override)
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	path, err = expandPlaceholders(path, ctxCfg)
 	if err != nil {
 		return err
 	}
@@ -127,6 +167,18 @@ func runAPI(cmd *cobra.Command, f *cmdutil.Factory, opts *apiOptions, path strin
 		return err
 	}
 
+	if opts.Paginate {
+		values, err := fetchAllPages(httpClient, req)
+		if err != nil {
+			return err
+		}
+		return cmdutil.WriteOutput(cmd, ios.Out, values, func() error {
+			enc := json.NewEncoder(ios.Out)
+			enc.SetIndent("", "  ")
+			return enc.Encode(values)
+		})
+	}
+
 	settings, err := cmdutil.ResolveOutputSettings(cmd)
 	if err != nil {
 		return err
@@ -162,6 +214,78 @@ func runAPI(cmd *cobra.Command, f *cmdutil.Factory, opts *apiOptions, path strin
 	})
 }
 
+// expandPlaceholders substitutes {workspace}, {repo} and {project} in path
+// with values from the active context.
+func expandPlaceholders(path string, ctx *config.Context) (string, error) {
+	var missing string
+	expanded := placeholderPattern.ReplaceAllStringFunc(path, func(match string) string {
+		var value string
+		switch match {
+		case "{workspace}":
+			value = ctx.Workspace
+		case "{repo}":
+			value = ctx.DefaultRepo
+		case "{project}":
+			value = ctx.ProjectKey
+		}
+		if value == "" && missing == "" {
+			missing = match
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("path uses %s but the context does not define it", missing)
+	}
+	return expanded, nil
+}
+
+// fetchAllPages follows pagination from req and returns the concatenated
+// "values" of every page. Cloud pages link to the next one through "next";
+// Data Center pages report "isLastPage" and "nextPageStart".
+func fetchAllPages(client *httpx.Client, req *http.Request) ([]any, error) {
+	values := []any{}
+	for {
+		var buf bytes.Buffer
+		if err := client.Do(req, &buf); err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Values        []any  `json:"values"`
+			Next          string `json:"next"`
+			IsLastPage    *bool  `json:"isLastPage"`
+			NextPageStart *int   `json:"nextPageStart"`
+		}
+		decoder := json.NewDecoder(&buf)
+		decoder.UseNumber()
+		if err := decoder.Decode(&page); err != nil {
+			return nil, fmt.Errorf("response is not a paginated JSON object: %w", err)
+		}
+		if page.Values == nil {
+			return nil, fmt.Errorf("response has no \"values\" array; --paginate requires a paged endpoint")
+		}
+		values = append(values, page.Values...)
+
+		next := req.Clone(req.Context())
+		switch {
+		case page.Next != "":
+			nextReq, err := client.NewRequest(req.Context(), http.MethodGet, page.Next, nil)
+			if err != nil {
+				return nil, err
+			}
+			nextReq.Header = req.Header.Clone()
+			next = nextReq
+		case page.IsLastPage != nil && !*page.IsLastPage && page.NextPageStart != nil:
+			query := next.URL.Query()
+			query.Set("start", strconv.Itoa(*page.NextPageStart))
+			next.URL.RawQuery = query.Encode()
+		default:
+			return values, nil
+		}
+		req = next
+	}
+}
+
 func parseKeyValue(input string) (string, string, error) {
 	parts := strings.SplitN(input, "=", 2)
 	if len(parts) != 2 {
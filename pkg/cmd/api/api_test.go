@@ -1,6 +1,8 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -197,3 +199,108 @@ func TestAPICommandStreamsJSONWithoutFlags(t *testing.T) {
 		t.Fatalf("expected raw JSON to be streamed, got %q", output)
 	}
 }
+
+func TestAPICommandPaginatesDataCenter(t *testing.T) {
+	factory, cleanup := newFactoryWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-Test") != "yes" {
+			t.Errorf("header not forwarded to page start=%q", r.URL.Query().Get("start"))
+		}
+		switch r.URL.Query().Get("start") {
+		case "":
+			_, _ = w.Write([]byte(`{"values":[{"key":"A"}],"isLastPage":false,"nextPageStart":1}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"values":[{"key":"B"}],"isLastPage":true}`))
+		default:
+			t.Errorf("unexpected start %q", r.URL.Query().Get("start"))
+		}
+	})
+	defer cleanup()
+
+	cmd := NewCmdAPI(factory)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.PersistentFlags().Bool("yaml", false, "")
+	cmd.PersistentFlags().String("jq", "", "")
+	cmd.PersistentFlags().String("template", "", "")
+	cmd.PersistentFlags().String("context", "", "")
+
+	cmd.SetArgs([]string{"--paginate", "-H", "X-Test: yes", "/rest/api/1.0/projects"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var got []map[string]string
+	output := factory.IOStreams.Out.(*strings.Builder).String()
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, output)
+	}
+	if len(got) != 2 || got[0]["key"] != "A" || got[1]["key"] != "B" {
+		t.Fatalf("unexpected combined values: %v", got)
+	}
+}
+
+func TestAPICommandPaginatesCloudNextLinks(t *testing.T) {
+	var serverURL string
+	factory, cleanup := newFactoryWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/repositories/team/api/pullrequests" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"values":[{"id":2}]}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"values":[{"id":1}],"next":"%s/repositories/team/api/pullrequests?page=2"}`, serverURL)
+	})
+	defer cleanup()
+
+	cfg, _ := factory.Config()
+	serverURL = cfg.Hosts["main"].BaseURL
+	cfg.Hosts["main"].Kind = "cloud"
+	cfg.Contexts["default"].Workspace = "team"
+	cfg.Contexts["default"].DefaultRepo = "api"
+
+	cmd := NewCmdAPI(factory)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.PersistentFlags().Bool("yaml", false, "")
+	cmd.PersistentFlags().String("jq", "", "")
+	cmd.PersistentFlags().String("template", "", "")
+	cmd.PersistentFlags().String("context", "", "")
+
+	cmd.SetArgs([]string{"--paginate", "--json", "--jq", "map(.id)", "/repositories/{workspace}/{repo}/pullrequests"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	output := factory.IOStreams.Out.(*strings.Builder).String()
+	var ids []int
+	if err := json.Unmarshal([]byte(output), &ids); err != nil || len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("unexpected output %q (err %v)", output, err)
+	}
+}
+
+func TestAPICommandRejectsUndefinedPlaceholder(t *testing.T) {
+	factory, cleanup := newFactoryWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+	defer cleanup()
+
+	cmd := NewCmdAPI(factory)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.PersistentFlags().String("context", "", "")
+
+	cmd.SetArgs([]string{"/repositories/{workspace}/x"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "{workspace}") {
+		t.Fatalf("expected placeholder error, got %v", err)
+	}
+}
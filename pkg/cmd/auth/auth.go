@@ -14,6 +14,7 @@ import (
 	"golang.org/x/term"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/oauth"
 	"github.com/alessandro308/bitbucket-cli/internal/secret"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
@@ -41,6 +42,8 @@ func NewCmdAuth(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(newLoginCmd(f))
 	cmd.AddCommand(newStatusCmd(f))
 	cmd.AddCommand(newLogoutCmd(f))
+	cmd.AddCommand(newSwitchCmd(f))
+	cmd.AddCommand(newMigrateCmd(f))
 
 	return cmd
 }
@@ -52,6 +55,36 @@ type loginOptions struct {
 	Token              string
 	AllowInsecureStore bool
 	Web                bool
+	GitProtocol        string
+	OAuth              bool
+	ClientID           string
+	ClientSecret       string
+	CallbackPort       int
+	Proxy              string
+	CACert             string
+	InsecureSkipVerify bool
+	// InsecureSkipVerifySet records that --insecure-skip-verify was passed, so
+	// an explicit false can turn off a saved setting.
+	InsecureSkipVerifySet bool
+}
+
+// networkSettings returns the proxy and TLS settings for hostKey: flags win,
+// otherwise those already saved for the host are kept so re-logging in does
+// not drop them.
+func (o *loginOptions) networkSettings(cfg *config.Config, hostKey string) config.Host {
+	settings := config.Host{
+		Proxy:              strings.TrimSpace(o.Proxy),
+		CACert:             strings.TrimSpace(o.CACert),
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+	if existing, err := cfg.Host(hostKey); err == nil {
+		settings.Proxy = cmdutil.FirstNonEmpty(settings.Proxy, existing.Proxy)
+		settings.CACert = cmdutil.FirstNonEmpty(settings.CACert, existing.CACert)
+		if !o.InsecureSkipVerifySet {
+			settings.InsecureSkipVerify = existing.InsecureSkipVerify
+		}
+	}
+	return settings
 }
 
 func newLoginCmd(f *cmdutil.Factory) *cobra.Command {
@@ -62,11 +95,30 @@ func newLoginCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "login [host]",
 		Short: "Authenticate against a Bitbucket Data Center or Cloud host",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Authenticate against a Bitbucket Data Center or Cloud host.
+
+By default you are prompted for a username and token. For Bitbucket Cloud,
+--oauth runs the OAuth 2.0 authorization-code flow instead: your browser opens
+the Bitbucket consent page and the token is received on a localhost callback.
+Access tokens are refreshed automatically.
+
+OAuth needs a consumer registered under Workspace settings > OAuth consumers
+with the callback URL http://localhost:8746/callback (see --callback-port).`,
+		Example: `  bkt auth login https://bitbucket.example.com
+  bkt auth login bitbucket.org --kind cloud
+  bkt auth login --oauth --client-id KEY --client-secret SECRET`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				opts.Host = args[0]
 			}
+			opts.InsecureSkipVerifySet = cmd.Flags().Changed("insecure-skip-verify")
+			if opts.OAuth {
+				if cmd.Flags().Changed("kind") && !strings.EqualFold(opts.Kind, "cloud") {
+					return fmt.Errorf("--oauth is only supported for Bitbucket Cloud")
+				}
+				return runOAuthLogin(cmd, f, opts)
+			}
 			return runLogin(cmd, f, opts)
 		},
 	}
@@ -76,6 +128,14 @@ func newLoginCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Token, "token", "", "Authentication token (DC: PAT, Cloud: API token)")
 	cmd.Flags().BoolVar(&opts.AllowInsecureStore, "allow-insecure-store", false, "Allow encrypted fallback secret storage when no OS keychain is available")
 	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open browser to create token, then prompt for credentials")
+	cmd.Flags().StringVar(&opts.GitProtocol, "git-protocol", "", "Preferred protocol for git operations (https or ssh)")
+	cmd.Flags().BoolVar(&opts.OAuth, "oauth", false, "Log in to Bitbucket Cloud through the OAuth browser flow")
+	cmd.Flags().StringVar(&opts.ClientID, "client-id", "", "OAuth consumer key (default $BKT_OAUTH_CLIENT_ID)")
+	cmd.Flags().StringVar(&opts.ClientSecret, "client-secret", "", "OAuth consumer secret (default $BKT_OAUTH_CLIENT_SECRET)")
+	cmd.Flags().IntVar(&opts.CallbackPort, "callback-port", oauth.DefaultCallbackPort, "Localhost port for the OAuth callback")
+	cmd.Flags().StringVar(&opts.Proxy, "proxy", "", "Proxy URL used to reach the host (saved with the host)")
+	cmd.Flags().StringVar(&opts.CACert, "ca-cert", "", "PEM bundle of additional trusted CA certificates (saved with the host)")
+	cmd.Flags().BoolVar(&opts.InsecureSkipVerify, "insecure-skip-verify", false, "Disable TLS certificate verification (unsafe; saved with the host)")
 
 	return cmd
 }
@@ -86,6 +146,10 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 		return err
 	}
 
+	if err := validateProfileName(f.Profile); err != nil {
+		return err
+	}
+
 	reader := bufio.NewReader(ios.In)
 
 	if opts.Host == "" {
@@ -103,6 +167,11 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 		return err
 	}
 
+	gitProtocol := strings.ToLower(strings.TrimSpace(opts.GitProtocol))
+	if gitProtocol != "" && gitProtocol != "https" && gitProtocol != "ssh" {
+		return fmt.Errorf("invalid --git-protocol %q (expected https or ssh)", opts.GitProtocol)
+	}
+
 	kind := strings.ToLower(opts.Kind)
 	if kind == "" {
 		kind = "dc"
@@ -160,16 +229,20 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 			}
 		}
 
+		network := opts.networkSettings(cfg, hostKey)
 		client, err := bbdc.New(bbdc.Options{
-			BaseURL:  baseURL,
-			Username: opts.Username,
-			Token:    opts.Token,
+			BaseURL:            baseURL,
+			Username:           opts.Username,
+			Token:              opts.Token,
+			Proxy:              network.Proxy,
+			CACertFile:         network.CACert,
+			InsecureSkipVerify: network.InsecureSkipVerify,
 		})
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		user, err := client.CurrentUser(ctx, opts.Username)
@@ -177,18 +250,22 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 			return fmt.Errorf("verify credentials: %w", err)
 		}
 
-		if err := storeHostToken(hostKey, opts.Token, opts.AllowInsecureStore); err != nil {
+		if err := storeHostToken(hostKey, f.Profile, opts.Token, opts.AllowInsecureStore); err != nil {
 			return fmt.Errorf("store token: %w", err)
 		}
 
-		cfg.SetHost(hostKey, &config.Host{
+		saveHostIdentity(cfg, hostKey, f.Profile, &config.Host{
 			Kind:               "dc",
 			BaseURL:            baseURL,
 			Username:           opts.Username,
 			AllowInsecureStore: opts.AllowInsecureStore,
+			GitProtocol:        gitProtocol,
+			Proxy:              network.Proxy,
+			CACert:             network.CACert,
+			InsecureSkipVerify: network.InsecureSkipVerify,
 		})
 
-		if err := cfg.Save(); err != nil {
+		if err := saveLoginConfig(cfg); err != nil {
 			return err
 		}
 
@@ -259,11 +336,15 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 			return err
 		}
 
+		network := opts.networkSettings(cfg, hostKey)
 		client, err := bbcloud.New(bbcloud.Options{
-			BaseURL:     apiURL,
-			Username:    opts.Username,
-			Token:       opts.Token,
-			EnableCache: true,
+			BaseURL:            apiURL,
+			Username:           opts.Username,
+			Token:              opts.Token,
+			Proxy:              network.Proxy,
+			CACertFile:         network.CACert,
+			InsecureSkipVerify: network.InsecureSkipVerify,
+			EnableCache:        true,
 			Retry: httpx.RetryPolicy{
 				MaxAttempts:    4,
 				InitialBackoff: 200 * time.Millisecond,
@@ -274,7 +355,7 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		user, err := client.CurrentUser(ctx)
@@ -282,18 +363,22 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 			return fmt.Errorf("verify credentials: %w", err)
 		}
 
-		if err := storeHostToken(hostKey, opts.Token, opts.AllowInsecureStore); err != nil {
+		if err := storeHostToken(hostKey, f.Profile, opts.Token, opts.AllowInsecureStore); err != nil {
 			return fmt.Errorf("store token: %w", err)
 		}
 
-		cfg.SetHost(hostKey, &config.Host{
+		saveHostIdentity(cfg, hostKey, f.Profile, &config.Host{
 			Kind:               "cloud",
 			BaseURL:            apiURL,
 			Username:           opts.Username,
 			AllowInsecureStore: opts.AllowInsecureStore,
+			GitProtocol:        gitProtocol,
+			Proxy:              network.Proxy,
+			CACert:             network.CACert,
+			InsecureSkipVerify: network.InsecureSkipVerify,
 		})
 
-		if err := cfg.Save(); err != nil {
+		if err := saveLoginConfig(cfg); err != nil {
 			return err
 		}
 
@@ -304,21 +389,302 @@ func runLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error
 		return fmt.Errorf("unsupported deployment kind %q", opts.Kind)
 	}
 
+	return reportProfile(ios.Out, f.Profile)
+}
+
+// oauthLoginTimeout bounds how long login waits for the user to approve access
+// in the browser.
+const oauthLoginTimeout = 5 * time.Minute
+
+func runOAuthLogin(cmd *cobra.Command, f *cmdutil.Factory, opts *loginOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if err := validateProfileName(f.Profile); err != nil {
+		return err
+	}
+
+	gitProtocol := strings.ToLower(strings.TrimSpace(opts.GitProtocol))
+	if gitProtocol != "" && gitProtocol != "https" && gitProtocol != "ssh" {
+		return fmt.Errorf("invalid --git-protocol %q (expected https or ssh)", opts.GitProtocol)
+	}
+
+	clientID := cmdutil.FirstNonEmpty(strings.TrimSpace(opts.ClientID), os.Getenv("BKT_OAUTH_CLIENT_ID"))
+	clientSecret := cmdutil.FirstNonEmpty(opts.ClientSecret, os.Getenv("BKT_OAUTH_CLIENT_SECRET"))
+	if clientID == "" {
+		return fmt.Errorf("OAuth consumer key required; pass --client-id or set BKT_OAUTH_CLIENT_ID")
+	}
+
+	apiURL := "https://api.bitbucket.org/2.0"
+	if opts.Host != "" {
+		baseURL, err := cmdutil.NormalizeBaseURL(opts.Host)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(baseURL, "bitbucket.org") {
+			apiURL = baseURL
+		}
+	}
+
+	hostKey, err := cmdutil.HostKeyFromURL(apiURL)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	network := opts.networkSettings(cfg, hostKey)
+	httpClient, err := cmdutil.NewStdHTTPClient(&network)
+	if err != nil {
+		return err
+	}
+
+	oauthCfg := oauth.Config{ClientID: clientID, ClientSecret: clientSecret, HTTPClient: httpClient}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), oauthLoginTimeout)
+	defer cancel()
+
+	token, err := oauth.Login(ctx, oauthCfg, oauth.LoginOptions{
+		Port: opts.CallbackPort,
+		Notify: func(authURL string) error {
+			_, err := fmt.Fprintf(ios.Out, "Opening %s in your browser\nWaiting for authorization...\n", authURL)
+			return err
+		},
+		OpenURL: func(authURL string) error {
+			if err := f.BrowserOpener().Open(authURL); err != nil {
+				if _, werr := fmt.Fprintf(ios.ErrOut, "Failed to open browser: %v\nPlease open the URL manually.\n", err); werr != nil {
+					return werr
+				}
+				return err
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("oauth login: %w", err)
+	}
+
+	client, err := bbcloud.New(bbcloud.Options{
+		BaseURL:            apiURL,
+		TokenSource:        oauth.NewRefresher(oauthCfg, *token, nil),
+		Proxy:              network.Proxy,
+		CACertFile:         network.CACert,
+		InsecureSkipVerify: network.InsecureSkipVerify,
+	})
+	if err != nil {
+		return err
+	}
+
+	verifyCtx, verifyCancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer verifyCancel()
+
+	user, err := client.CurrentUser(verifyCtx)
+	if err != nil {
+		return fmt.Errorf("verify credentials: %w", err)
+	}
+
+	encoded, err := oauth.Credentials{ClientSecret: clientSecret, Token: *token}.Encode()
+	if err != nil {
+		return err
+	}
+	if err := storeHostToken(hostKey, f.Profile, encoded, opts.AllowInsecureStore); err != nil {
+		return fmt.Errorf("store token: %w", err)
+	}
+
+	saveHostIdentity(cfg, hostKey, f.Profile, &config.Host{
+		Kind:               "cloud",
+		BaseURL:            apiURL,
+		Username:           user.Username,
+		AllowInsecureStore: opts.AllowInsecureStore,
+		GitProtocol:        gitProtocol,
+		AuthMethod:         config.AuthMethodOAuth,
+		OAuthClientID:      clientID,
+		Proxy:              network.Proxy,
+		CACert:             network.CACert,
+		InsecureSkipVerify: network.InsecureSkipVerify,
+	})
+
+	if err := saveLoginConfig(cfg); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(ios.Out, "✓ Logged in to Bitbucket Cloud as %s (%s) via OAuth\n", user.Display, user.Username); err != nil {
+		return err
+	}
+	return reportProfile(ios.Out, f.Profile)
+}
+
+// reportProfile notes which profile a login was saved under.
+// saveLoginConfig saves the config after a login. Save never writes tokens,
+// so any that older releases left in config.yml are moved to the credential
+// store first; when that fails nothing is saved and the tokens stay in place.
+func saveLoginConfig(cfg *config.Config) error {
+	if _, err := cmdutil.MigrateLegacyCredentials(cfg); err != nil {
+		return fmt.Errorf("move plaintext tokens out of %s: %w", cfg.Path(), err)
+	}
+	return cfg.Save()
+}
+
+func reportProfile(out io.Writer, profile string) error {
+	if profile == "" || profile == config.DefaultProfile {
+		return nil
+	}
+	_, err := fmt.Fprintf(out, "✓ Saved as profile %q; select it with --profile %s or `bkt auth switch %s`\n", profile, profile, profile)
+	return err
+}
+
+// saveHostIdentity records a successful login. Without a profile the host's
+// default identity is replaced; with one only that profile is written, so the
+// host's other identities are kept.
+func saveHostIdentity(cfg *config.Config, hostKey, profile string, login *config.Host) {
+	existing, _ := cfg.Host(hostKey)
+
+	if profile == "" || profile == config.DefaultProfile {
+		if existing != nil {
+			login.Profiles = existing.Profiles
+			login.ActiveProfile = existing.ActiveProfile
+		}
+		cfg.SetHost(hostKey, login)
+		return
+	}
+
+	if existing == nil {
+		// First login for this host: the profile becomes its active identity.
+		existing = &config.Host{Kind: login.Kind, BaseURL: login.BaseURL, ActiveProfile: profile}
+	}
+	if login.AllowInsecureStore {
+		existing.AllowInsecureStore = true
+	}
+	if login.GitProtocol != "" {
+		existing.GitProtocol = login.GitProtocol
+	}
+	existing.Proxy = login.Proxy
+	existing.CACert = login.CACert
+	existing.InsecureSkipVerify = login.InsecureSkipVerify
+	if existing.Profiles == nil {
+		existing.Profiles = make(map[string]*config.Profile)
+	}
+	existing.Profiles[profile] = &config.Profile{
+		Username:      login.Username,
+		AuthMethod:    login.AuthMethod,
+		OAuthClientID: login.OAuthClientID,
+	}
+	cfg.SetHost(hostKey, existing)
+}
+
+func validateProfileName(name string) error {
+	if name == "" {
+		return nil
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return fmt.Errorf("invalid profile name %q (use letters, digits, '.', '-' or '_')", name)
+		}
+	}
 	return nil
 }
 
+type statusOptions struct {
+	Offline bool
+}
+
 func newStatusCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &statusOptions{}
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show authentication status for configured hosts",
+		Long: `Show configured hosts and contexts, and verify each host's credential.
+
+For every host the stored credential is used to look up the current user,
+reporting the account, the token type and, on Bitbucket Cloud, the granted
+scopes. A warning is printed for each scope that a bkt command needs but the
+credential lacks. The command exits non-zero when any host fails to
+authenticate.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatus(cmd, f)
+			return runStatus(cmd, f, opts)
 		},
 	}
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Only show configuration; do not contact Bitbucket")
 	return cmd
 }
 
-func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
+// hostCheck is the result of verifying a host's credential against the API.
+type hostCheck struct {
+	OK        bool               `json:"ok"`
+	Account   string             `json:"account,omitempty"`
+	User      string             `json:"user,omitempty"`
+	TokenType string             `json:"token_type,omitempty"`
+	Scopes    []string           `json:"scopes,omitempty"`
+	Missing   []scopeRequirement `json:"missing_scopes,omitempty"`
+	Error     string             `json:"error,omitempty"`
+}
+
+func checkHost(ctx context.Context, f *cmdutil.Factory, hostKey string) *hostCheck {
+	_, host, err := cmdutil.ResolveHost(f, "", hostKey)
+	if err != nil {
+		return &hostCheck{Error: err.Error()}
+	}
+
+	check := &hostCheck{TokenType: tokenType(host)}
+
+	switch host.Kind {
+	case "cloud":
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			check.Error = err.Error()
+			return check
+		}
+		user, scopes, err := client.CurrentUserScopes(ctx)
+		if err != nil {
+			check.Error = err.Error()
+			return check
+		}
+		check.Account, check.User = user.Display, user.Username
+		check.Scopes = scopes
+		if scopes != nil {
+			check.Missing = missingScopes(scopes)
+		}
+	case "dc":
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			check.Error = err.Error()
+			return check
+		}
+		user, err := client.CurrentUser(ctx, host.Username)
+		if err != nil {
+			check.Error = err.Error()
+			return check
+		}
+		check.Account, check.User = user.FullName, user.Name
+	default:
+		check.Error = fmt.Sprintf("unsupported host kind %q", host.Kind)
+		return check
+	}
+
+	check.OK = true
+	return check
+}
+
+func tokenType(host *config.Host) string {
+	switch {
+	case host.AuthMethod == config.AuthMethodOAuth:
+		return "OAuth access token"
+	case host.Kind == "cloud":
+		return "API token"
+	case host.Username == "x-token-auth":
+		return "project/repository access token"
+	default:
+		return "personal access token"
+	}
+}
+
+func runStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *statusOptions) error {
 	ios, err := f.Streams()
 	if err != nil {
 		return err
@@ -330,10 +696,16 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 	}
 
 	type hostSummary struct {
-		Key      string `json:"key"`
-		Kind     string `json:"kind"`
-		BaseURL  string `json:"base_url"`
-		Username string `json:"username,omitempty"`
+		Key      string     `json:"key"`
+		Kind     string     `json:"kind"`
+		BaseURL  string     `json:"base_url"`
+		Username string     `json:"username,omitempty"`
+		Auth     string     `json:"auth_method,omitempty"`
+		Profiles []string   `json:"profiles,omitempty"`
+		Active   string     `json:"active_profile,omitempty"`
+		Check    *hostCheck `json:"check,omitempty"`
+		// Env marks a host known only from BKT_HOST.
+		Env bool `json:"env,omitempty"`
 	}
 
 	type contextSummary struct {
@@ -342,6 +714,7 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 		ProjectKey  string `json:"project_key,omitempty"`
 		Workspace   string `json:"workspace,omitempty"`
 		DefaultRepo string `json:"default_repo,omitempty"`
+		Profile     string `json:"profile,omitempty"`
 		Active      bool   `json:"active"`
 	}
 
@@ -354,14 +727,46 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 	var hosts []hostSummary
 	for _, key := range hostKeys {
 		h := cfg.Hosts[key]
-		hosts = append(hosts, hostSummary{
+		summary := hostSummary{
 			Key:      key,
 			Kind:     h.Kind,
 			BaseURL:  h.BaseURL,
 			Username: h.Username,
+			Auth:     h.AuthMethod,
+		}
+		if len(h.Profiles) > 0 {
+			summary.Profiles = h.ProfileNames()
+			summary.Active = cmdutil.FirstNonEmpty(h.ActiveProfile, config.DefaultProfile)
+		}
+		hosts = append(hosts, summary)
+	}
+
+	envKey, envHost, ok, err := cmdutil.EnvHost(cfg)
+	if err != nil {
+		return err
+	}
+	if _, configured := cfg.Hosts[envKey]; ok && !configured {
+		hosts = append(hosts, hostSummary{
+			Key:      envKey,
+			Kind:     envHost.Kind,
+			BaseURL:  envHost.BaseURL,
+			Username: strings.TrimSpace(os.Getenv(cmdutil.UsernameEnv)),
+			Env:      true,
 		})
 	}
 
+	failed := false
+	if !opts.Offline {
+		for i := range hosts {
+			ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+			hosts[i].Check = checkHost(ctx, f, hosts[i].Key)
+			cancel()
+			if !hosts[i].Check.OK {
+				failed = true
+			}
+		}
+	}
+
 	var contextNames []string
 	for name := range cfg.Contexts {
 		contextNames = append(contextNames, name)
@@ -377,6 +782,7 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 			ProjectKey:  ctx.ProjectKey,
 			Workspace:   ctx.Workspace,
 			DefaultRepo: ctx.DefaultRepo,
+			Profile:     ctx.Profile,
 			Active:      cfg.ActiveContext == name,
 		})
 	}
@@ -391,7 +797,7 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 		Contexts:      contexts,
 	}
 
-	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+	err = cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
 		if len(hosts) == 0 {
 			if _, err := fmt.Fprintln(ios.Out, "No hosts configured. Run `bkt auth login` to add one."); err != nil {
 				return err
@@ -406,11 +812,35 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 			if _, err := fmt.Fprintf(ios.Out, "  %s (%s)\n", h.BaseURL, h.Kind); err != nil {
 				return err
 			}
+			if h.Env {
+				if _, err := fmt.Fprintf(ios.Out, "    from: %s\n", cmdutil.HostEnv); err != nil {
+					return err
+				}
+			}
 			if h.Username != "" {
 				if _, err := fmt.Fprintf(ios.Out, "    user: %s\n", h.Username); err != nil {
 					return err
 				}
 			}
+			if h.Auth != "" {
+				if _, err := fmt.Fprintf(ios.Out, "    auth: %s\n", h.Auth); err != nil {
+					return err
+				}
+			}
+			for _, name := range h.Profiles {
+				marker := " "
+				if name == h.Active {
+					marker = "*"
+				}
+				if _, err := fmt.Fprintf(ios.Out, "    %s profile: %s\n", marker, name); err != nil {
+					return err
+				}
+			}
+			if h.Check != nil {
+				if err := printHostCheck(ios.Out, h.Kind, h.Check); err != nil {
+					return err
+				}
+			}
 		}
 
 		if len(contexts) == 0 {
@@ -444,9 +874,204 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory) error {
 					return err
 				}
 			}
+			if ctx.Profile != "" {
+				if _, err := fmt.Fprintf(ios.Out, "    profile: %s\n", ctx.Profile); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	if failed {
+		return cmdutil.ErrSilent
+	}
+	return nil
+}
+
+func printHostCheck(out io.Writer, kind string, check *hostCheck) error {
+	if !check.OK {
+		_, err := fmt.Fprintf(out, "    X Authentication failed: %s\n", check.Error)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(out, "    ✓ Logged in as %s (%s)\n", check.Account, check.User); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "    token: %s\n", check.TokenType); err != nil {
+		return err
+	}
+	if kind != "cloud" {
+		return nil
+	}
+
+	if check.Scopes == nil {
+		_, err := fmt.Fprintln(out, "    scopes: not reported for this credential")
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "    scopes: %s\n", strings.Join(check.Scopes, ", ")); err != nil {
+		return err
+	}
+	for _, m := range check.Missing {
+		if _, err := fmt.Fprintf(out, "    ! Missing scope %s (needed by: %s)\n", m.Scope, m.Commands); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type switchOptions struct {
+	Host string
+}
+
+func newSwitchCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &switchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "switch [profile]",
+		Short: "Change the active credential profile for a host",
+		Long: `Change which stored identity a host uses by default.
+
+Profiles are created with ` + "`bkt auth login --profile <name>`" + `. The identity stored
+without a profile is called "default". With no argument and exactly two
+profiles on the host, switch toggles between them.`,
+		Example: `  bkt auth switch work
+  bkt auth switch default --host bitbucket.example.com`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile := ""
+			if len(args) > 0 {
+				profile = args[0]
+			}
+			return runSwitch(cmd, f, opts, profile)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Host, "host", "", "Host key or base URL (default: the active context's host)")
+
+	return cmd
+}
+
+func runSwitch(cmd *cobra.Command, f *cmdutil.Factory, opts *switchOptions, profile string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	key, err := switchHostKey(f, cmd, cfg, opts.Host)
+	if err != nil {
+		return err
+	}
+	host := cfg.Hosts[key]
+
+	current := cmdutil.FirstNonEmpty(host.ActiveProfile, config.DefaultProfile)
+	names := host.ProfileNames()
+
+	if profile == "" {
+		if len(names) != 2 {
+			return fmt.Errorf("specify a profile for %s (available: %s)", key, strings.Join(names, ", "))
+		}
+		profile = names[0]
+		if profile == current {
+			profile = names[1]
+		}
+	}
+
+	if profile != config.DefaultProfile {
+		if _, ok := host.Profiles[profile]; !ok {
+			return fmt.Errorf("profile %q not found for host %s (available: %s)", profile, key, strings.Join(names, ", "))
+		}
+	}
+
+	host.ActiveProfile = profile
+	if profile == config.DefaultProfile {
+		host.ActiveProfile = ""
+	}
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Switched %s to profile %s\n", key, profile)
+	return err
+}
+
+// switchHostKey picks the host whose profile changes: --host, else the active
+// context's host, else the only configured host.
+func switchHostKey(f *cmdutil.Factory, cmd *cobra.Command, cfg *config.Config, hostFlag string) (string, error) {
+	if identifier := strings.TrimSpace(hostFlag); identifier != "" {
+		return lookupHostKey(cfg, identifier)
+	}
+
+	contextName := cmdutil.FirstNonEmpty(cmdutil.FlagValue(cmd, "context"), cfg.ActiveContext)
+	if contextName != "" {
+		ctx, err := cfg.Context(contextName)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := cfg.Hosts[ctx.Host]; ok {
+			return ctx.Host, nil
+		}
+	}
+
+	switch len(cfg.Hosts) {
+	case 0:
+		return "", fmt.Errorf("no hosts configured; run `%s auth login` first", f.ExecutableName)
+	case 1:
+		for key := range cfg.Hosts {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("multiple hosts configured; specify --host")
+}
+
+func newMigrateCmd(f *cmdutil.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Move plaintext tokens from config.yml to the credential store",
+		Long: `Move tokens that older releases wrote into config.yml to the OS keyring
+(or the encrypted file store for hosts that allow insecure storage), then
+rewrite config.yml without them. The config is left untouched when any token
+cannot be stored. ` + "`bkt auth login`" + ` performs the same migration.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrate(f)
+		},
+	}
+}
+
+func runMigrate(f *cmdutil.Factory) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	hostKeys, err := cmdutil.MigrateLegacyCredentials(cfg)
+	if err != nil {
+		return fmt.Errorf("migrate credentials: %w", err)
+	}
+	if len(hostKeys) == 0 {
+		_, err := fmt.Fprintf(ios.Out, "No plaintext tokens found in %s\n", cfg.Path())
+		return err
+	}
+	for _, key := range hostKeys {
+		if _, err := fmt.Fprintf(ios.Out, "✓ Moved the token for %s to the credential store\n", key); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type logoutOptions struct {
@@ -489,22 +1114,17 @@ func runLogout(cmd *cobra.Command, f *cmdutil.Factory, opts *logoutOptions) erro
 		return fmt.Errorf("host is required")
 	}
 
-	key := hostIdentifier
-	if _, ok := cfg.Hosts[key]; !ok {
-		baseURL, err := cmdutil.NormalizeBaseURL(hostIdentifier)
-		if err != nil {
-			return fmt.Errorf("unknown host %q", hostIdentifier)
-		}
-		key, err = cmdutil.HostKeyFromURL(baseURL)
-		if err != nil {
-			return err
-		}
-		if _, ok := cfg.Hosts[key]; !ok {
-			return fmt.Errorf("host %q not found in configuration", hostIdentifier)
-		}
+	key, err := lookupHostKey(cfg, hostIdentifier)
+	if err != nil {
+		return err
 	}
 
 	host := cfg.Hosts[key]
+
+	if profile := f.Profile; profile != "" && profile != config.DefaultProfile {
+		return logoutProfile(ios.Out, cfg, key, host, profile)
+	}
+
 	if err := deleteHostToken(key, host); err != nil {
 		return fmt.Errorf("delete credentials: %w", err)
 	}
@@ -527,18 +1147,13 @@ func runLogout(cmd *cobra.Command, f *cmdutil.Factory, opts *logoutOptions) erro
 	return nil
 }
 
-func storeHostToken(hostKey, token string, allowInsecure bool) error {
-	opts := []secret.Option{}
-	if allowInsecure {
-		opts = append(opts, secret.WithAllowFileFallback(true))
-	}
-
-	store, err := secret.Open(opts...)
+func storeHostToken(hostKey, profile, token string, allowInsecure bool) error {
+	store, err := cmdutil.OpenCredentialStore(allowInsecure)
 	if err != nil {
 		return err
 	}
 
-	return store.Set(secret.TokenKey(hostKey), token)
+	return store.Set(secret.ProfileTokenKey(hostKey, profile), token)
 }
 
 func deleteHostToken(hostKey string, host *config.Host) error {
@@ -546,12 +1161,7 @@ func deleteHostToken(hostKey string, host *config.Host) error {
 		return fmt.Errorf("host %q not configured", hostKey)
 	}
 
-	opts := []secret.Option{}
-	if host.AllowInsecureStore {
-		opts = append(opts, secret.WithAllowFileFallback(true))
-	}
-
-	store, err := secret.Open(opts...)
+	store, err := cmdutil.OpenCredentialStore(host.AllowInsecureStore)
 	if err != nil {
 		return err
 	}
@@ -559,10 +1169,67 @@ func deleteHostToken(hostKey string, host *config.Host) error {
 	if err := store.Delete(secret.TokenKey(hostKey)); err != nil {
 		return err
 	}
+	for name := range host.Profiles {
+		if err := store.Delete(secret.ProfileTokenKey(hostKey, name)); err != nil {
+			return err
+		}
+	}
 	host.Token = ""
 	return nil
 }
 
+// logoutProfile removes a single named profile from a host, along with any
+// contexts pinned to it, leaving the host's other identities in place.
+func logoutProfile(out io.Writer, cfg *config.Config, hostKey string, host *config.Host, profile string) error {
+	if _, ok := host.Profiles[profile]; !ok {
+		return fmt.Errorf("profile %q not found for host %s", profile, hostKey)
+	}
+
+	store, err := cmdutil.OpenCredentialStore(host.AllowInsecureStore)
+	if err != nil {
+		return fmt.Errorf("delete credentials: %w", err)
+	}
+	if err := store.Delete(secret.ProfileTokenKey(hostKey, profile)); err != nil {
+		return fmt.Errorf("delete credentials: %w", err)
+	}
+
+	delete(host.Profiles, profile)
+	if host.ActiveProfile == profile {
+		host.ActiveProfile = ""
+	}
+	for name, ctx := range cfg.Contexts {
+		if ctx.Host == hostKey && ctx.Profile == profile {
+			cfg.DeleteContext(name)
+		}
+	}
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "✓ Removed profile %q for %s\n", profile, hostKey)
+	return err
+}
+
+// lookupHostKey maps a host key or base URL to a configured host key.
+func lookupHostKey(cfg *config.Config, identifier string) (string, error) {
+	if _, ok := cfg.Hosts[identifier]; ok {
+		return identifier, nil
+	}
+	baseURL, err := cmdutil.NormalizeBaseURL(identifier)
+	if err != nil {
+		return "", fmt.Errorf("unknown host %q", identifier)
+	}
+	key, err := cmdutil.HostKeyFromURL(baseURL)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := cfg.Hosts[key]; !ok {
+		return "", fmt.Errorf("host %q not found in configuration", identifier)
+	}
+	return key, nil
+}
+
 func promptString(reader *bufio.Reader, out io.Writer, label string) (string, error) {
 	if _, err := fmt.Fprintf(out, "%s: ", label); err != nil {
 		return "", err
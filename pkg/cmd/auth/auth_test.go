@@ -1,10 +1,17 @@
 package auth
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/secret"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
 	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
 )
@@ -70,3 +77,280 @@ func TestCloudLoginPromptsNoAppPassword(t *testing.T) {
 		}
 	}
 }
+
+func TestOAuthLoginValidation(t *testing.T) {
+	t.Setenv("BKT_OAUTH_CLIENT_ID", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "dc kind", args: []string{"--oauth", "--kind", "dc", "--client-id", "abc"}, wantErr: "only supported for Bitbucket Cloud"},
+		{name: "missing client id", args: []string{"--oauth"}, wantErr: "--client-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			f := &cmdutil.Factory{
+				ExecutableName: "bkt",
+				IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+				Config: func() (*config.Config, error) {
+					return &config.Config{Hosts: map[string]*config.Host{}, Contexts: map[string]*config.Context{}}, nil
+				},
+			}
+
+			cmd := newLoginCmd(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&stdout)
+			cmd.SetErr(&stderr)
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSwitchChangesActiveProfile(t *testing.T) {
+	t.Setenv("BKT_CONFIG_DIR", t.TempDir())
+
+	cfg := &config.Config{
+		Contexts: map[string]*config.Context{},
+		Hosts: map[string]*config.Host{
+			"api.bitbucket.org": {
+				Kind:     "cloud",
+				BaseURL:  "https://api.bitbucket.org/2.0",
+				Username: "me@example.com",
+				Profiles: map[string]*config.Profile{"work": {Username: "me@acme.example"}},
+			},
+		},
+	}
+
+	run := func(args ...string) (string, error) {
+		var stdout, stderr strings.Builder
+		f := &cmdutil.Factory{
+			ExecutableName: "bkt",
+			IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+			Config:         func() (*config.Config, error) { return cfg, nil },
+		}
+		cmd := newSwitchCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := run("work")
+	if err != nil {
+		t.Fatalf("switch work: %v", err)
+	}
+	if got := cfg.Hosts["api.bitbucket.org"].ActiveProfile; got != "work" {
+		t.Fatalf("active profile = %q, want work", got)
+	}
+	if !strings.Contains(out, "Switched api.bitbucket.org to profile work") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	// With two profiles and no argument, switch toggles back to default.
+	if _, err := run(); err != nil {
+		t.Fatalf("toggle: %v", err)
+	}
+	if got := cfg.Hosts["api.bitbucket.org"].ActiveProfile; got != "" {
+		t.Fatalf("active profile = %q, want default", got)
+	}
+
+	if _, err := run("missing"); err == nil || !strings.Contains(err.Error(), "available: default, work") {
+		t.Fatalf("expected missing profile error, got %v", err)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	granted := []string{
+		"account",
+		"repository:admin",
+		"repository:write",
+		"pullrequest:write",
+		"write:issue:bitbucket",
+		"pipeline:variable",
+		"webhook",
+		"project:admin",
+		"project",
+		"snippet:write",
+	}
+
+	missing := missingScopes(granted)
+	var got []string
+	for _, m := range missing {
+		got = append(got, m.Scope)
+	}
+	if want := "pipeline:write"; strings.Join(got, ",") != want {
+		t.Fatalf("missing = %v, want [%s]", got, want)
+	}
+
+	if got := normalizeScope("read:user:bitbucket"); got != "account" {
+		t.Fatalf("normalizeScope(read:user:bitbucket) = %q", got)
+	}
+	if got := normalizeScope("delete:repository:bitbucket"); got != "repository:write" {
+		t.Fatalf("normalizeScope(delete:repository:bitbucket) = %q", got)
+	}
+}
+
+func TestStatusReportsAccountAndMissingScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("X-OAuth-Scopes", "repository, pullrequest, account")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"uuid":         "{1}",
+			"username":     "octo",
+			"display_name": "Octo Cat",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		Contexts: map[string]*config.Context{},
+		Hosts: map[string]*config.Host{
+			"api.bitbucket.org": {
+				Kind:     "cloud",
+				BaseURL:  server.URL,
+				Username: "me@example.com",
+				Token:    "token",
+			},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config:         func() (*config.Config, error) { return cfg, nil },
+	}
+	cmd := newStatusCmd(f)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SetArgs(nil)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("status: %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"✓ Logged in as Octo Cat (octo)",
+		"token: API token",
+		"scopes: account, pullrequest, repository",
+		"! Missing scope pipeline:write (needed by: pipeline run)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Missing scope repository (") {
+		t.Fatalf("repository should be granted:\n%s", out)
+	}
+}
+
+func TestStatusFailsWhenCredentialRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"Unauthorized"}}`, http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		Contexts: map[string]*config.Context{},
+		Hosts: map[string]*config.Host{
+			"api.bitbucket.org": {Kind: "cloud", BaseURL: server.URL, Username: "me", Token: "bad"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config:         func() (*config.Config, error) { return cfg, nil },
+	}
+	cmd := newStatusCmd(f)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SetArgs(nil)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	err := cmd.Execute()
+	if !errors.Is(err, cmdutil.ErrSilent) {
+		t.Fatalf("expected ErrSilent, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "X Authentication failed") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestNetworkSettingsExplicitFlagOverridesSavedHost(t *testing.T) {
+	cfg := &config.Config{Hosts: map[string]*config.Host{
+		"bitbucket.example.com": {Kind: "dc", Proxy: "http://proxy:8080", InsecureSkipVerify: true},
+	}}
+
+	kept := (&loginOptions{}).networkSettings(cfg, "bitbucket.example.com")
+	if kept.Proxy != "http://proxy:8080" || !kept.InsecureSkipVerify {
+		t.Fatalf("saved settings not kept: %+v", kept)
+	}
+
+	cleared := (&loginOptions{InsecureSkipVerifySet: true}).networkSettings(cfg, "bitbucket.example.com")
+	if cleared.InsecureSkipVerify {
+		t.Fatal("--insecure-skip-verify=false must turn off the saved setting")
+	}
+}
+
+func TestMigrateMovesLegacyTokens(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("BKT_CONFIG_DIR", dir)
+	t.Setenv("KEYRING_BACKEND", "file")
+	t.Setenv("BKT_ALLOW_INSECURE_STORE", "1")
+	t.Setenv("BKT_KEYRING_PASSPHRASE", "test")
+
+	legacy := "version: 1\nhosts:\n  bitbucket.example.com:\n    kind: dc\n    base_url: https://bitbucket.example.com\n    username: alice\n    token: legacy-token\n"
+	configPath := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(configPath, []byte(legacy), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	run := func() (string, error) {
+		var stdout strings.Builder
+		f := &cmdutil.Factory{
+			ExecutableName: "bkt",
+			IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &strings.Builder{}},
+			Config:         config.Load,
+		}
+		cmd := newMigrateCmd(f)
+		cmd.SetArgs(nil)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := run()
+	if err != nil {
+		t.Fatalf("auth migrate: %v", err)
+	}
+	if !strings.Contains(out, "✓ Moved the token for bitbucket.example.com") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if data, _ := os.ReadFile(configPath); strings.Contains(string(data), "legacy-token") {
+		t.Fatalf("config.yml still contains the token:\n%s", data)
+	}
+
+	store, err := cmdutil.OpenCredentialStore(false)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if got, err := store.Get(secret.TokenKey("bitbucket.example.com")); err != nil || got != "legacy-token" {
+		t.Fatalf("stored token = %q, %v", got, err)
+	}
+
+	out, err = run()
+	if err != nil || !strings.Contains(out, "No plaintext tokens found") {
+		t.Fatalf("second migrate = %q, %v", out, err)
+	}
+}
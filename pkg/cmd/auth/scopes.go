@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"regexp"
+	"strings"
+)
+
+// scopeRequirement ties a Bitbucket Cloud scope to the commands that need it.
+type scopeRequirement struct {
+	Scope    string `json:"scope"`
+	Commands string `json:"needed_by"`
+}
+
+// cloudScopeRequirements lists the scopes used by bkt's Cloud commands, in
+// the order they are reported.
+var cloudScopeRequirements = []scopeRequirement{
+	{Scope: "account", Commands: "auth login, workspace"},
+	{Scope: "repository", Commands: "repo, branch, commit, file, tag"},
+	{Scope: "repository:write", Commands: "branch create/delete, tag create, download upload"},
+	{Scope: "repository:admin", Commands: "repo edit, repo permission, repo deploy-key"},
+	{Scope: "pullrequest", Commands: "pr list/view/diff"},
+	{Scope: "pullrequest:write", Commands: "pr create/merge/approve/comment"},
+	{Scope: "issue", Commands: "issue list/view"},
+	{Scope: "issue:write", Commands: "issue create/edit/comment"},
+	{Scope: "pipeline", Commands: "pipeline list/view/logs"},
+	{Scope: "pipeline:write", Commands: "pipeline run"},
+	{Scope: "pipeline:variable", Commands: "variable"},
+	{Scope: "webhook", Commands: "webhook"},
+	{Scope: "project", Commands: "project list/view"},
+	{Scope: "project:admin", Commands: "project create/edit/delete"},
+	{Scope: "snippet", Commands: "snippet list/view"},
+	{Scope: "snippet:write", Commands: "snippet create/delete"},
+}
+
+// impliedScopes records scopes that Bitbucket grants implicitly alongside
+// another; a write scope always implies its read counterpart.
+var impliedScopes = map[string][]string{
+	"pullrequest":       {"repository"},
+	"pullrequest:write": {"repository:write"},
+	"pipeline:variable": {"pipeline"},
+}
+
+// apiTokenScope matches the scope names used by Atlassian API tokens, such as
+// "write:pullrequest:bitbucket".
+var apiTokenScope = regexp.MustCompile(`^(read|write|admin|delete):([a-z-]+):bitbucket$`)
+
+// missingScopes returns the requirements not satisfied by granted. Granted
+// scopes may use OAuth names (pullrequest:write) or API token names
+// (write:pullrequest:bitbucket).
+func missingScopes(granted []string) []scopeRequirement {
+	have := make(map[string]bool)
+	var add func(scope string)
+	add = func(scope string) {
+		if have[scope] {
+			return
+		}
+		have[scope] = true
+		if base, level, ok := strings.Cut(scope, ":"); ok && level == "write" {
+			add(base)
+		}
+		for _, implied := range impliedScopes[scope] {
+			add(implied)
+		}
+	}
+	for _, scope := range granted {
+		add(normalizeScope(scope))
+	}
+
+	var missing []scopeRequirement
+	for _, req := range cloudScopeRequirements {
+		if !have[req.Scope] {
+			missing = append(missing, req)
+		}
+	}
+	return missing
+}
+
+func normalizeScope(scope string) string {
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	m := apiTokenScope.FindStringSubmatch(scope)
+	if m == nil {
+		return scope
+	}
+
+	resource := m[2]
+	if resource == "user" {
+		resource = "account"
+	}
+	switch m[1] {
+	case "write", "delete":
+		return resource + ":write"
+	case "admin":
+		return resource + ":admin"
+	default:
+		return resource
+	}
+}
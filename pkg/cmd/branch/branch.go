@@ -3,6 +3,7 @@ package branch
 import (
 	"context"
 	"fmt"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,6 +24,7 @@ func NewCmdBranch(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(newCreateCmd(f))
 	cmd.AddCommand(newDeleteCmd(f))
 	cmd.AddCommand(newSetDefaultCmd(f))
+	cmd.AddCommand(newModelCmd(f))
 	cmd.AddCommand(newProtectCmd(f))
 	cmd.AddCommand(newRebaseCmd(f))
 
@@ -30,11 +32,12 @@ func NewCmdBranch(f *cmdutil.Factory) *cobra.Command {
 }
 
 type listOptions struct {
-	Project   string
-	Workspace string
-	Repo      string
-	Filter    string
-	Limit     int
+	Project     string
+	Workspace   string
+	Repo        string
+	Filter      string
+	Limit       int
+	AheadBehind bool
 }
 
 func newListCmd(f *cmdutil.Factory) *cobra.Command {
@@ -43,6 +46,11 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List branches",
+		Long: `List repository branches. The default branch is marked with "*".
+
+Data Center shows how many commits each branch is ahead of and behind the
+default branch. On Bitbucket Cloud pass --ahead-behind to compute the same
+counts; this costs two extra requests per branch.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd, f, opts)
 		},
@@ -53,6 +61,7 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
 	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter branches by text")
 	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum branches to list (0 for all)")
+	cmd.Flags().BoolVar(&opts.AheadBehind, "ahead-behind", false, "Compute commits ahead/behind the main branch (Cloud)")
 
 	return cmd
 }
@@ -82,10 +91,10 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
-		branches, err := client.ListBranches(ctx, projectKey, repoSlug, bbdc.BranchListOptions{Filter: opts.Filter, Limit: opts.Limit})
+		branches, err := client.ListBranches(ctx, projectKey, repoSlug, bbdc.BranchListOptions{Filter: opts.Filter, Limit: opts.Limit, Details: true})
 		if err != nil {
 			return err
 		}
@@ -102,16 +111,21 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 				return err
 			}
 
+			tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
 			for _, branch := range branches {
 				marker := " "
 				if branch.IsDefault {
 					marker = "*"
 				}
-				if _, err := fmt.Fprintf(ios.Out, "%s %s\t%s\n", marker, branch.DisplayID, branch.LatestCommit); err != nil {
+				status := ""
+				if ahead, behind, ok := branch.AheadBehind(); ok && !branch.IsDefault {
+					status = formatAheadBehind(branchCounts{Ahead: ahead, Behind: behind})
+				}
+				if _, err := fmt.Fprintf(tw, "%s %s\t%s\t%s\n", marker, branch.DisplayID, branch.LatestCommit, status); err != nil {
 					return err
 				}
 			}
-			return nil
+			return tw.Flush()
 		})
 
 	case "cloud":
@@ -126,7 +140,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		branches, err := client.ListBranches(ctx, workspace, repoSlug, bbcloud.BranchListOptions{Filter: opts.Filter, Limit: opts.Limit})
@@ -134,19 +148,58 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
+		var mainBranch string
+		counts := make(map[string]branchCounts)
+		if opts.AheadBehind {
+			mainBranch, err = cloudMainBranch(ctx, client, workspace, repoSlug)
+			if err != nil {
+				return err
+			}
+			countCtx, cancelCount := cmdutil.CommandContext(cmd, 60*time.Second)
+			defer cancelCount()
+			for _, branch := range branches {
+				if branch.Name == mainBranch {
+					continue
+				}
+				c, err := cloudAheadBehind(countCtx, client, workspace, repoSlug, branch.Name, mainBranch)
+				if err != nil {
+					return err
+				}
+				counts[branch.Name] = c
+			}
+		}
+
+		type branchEntry struct {
+			bbcloud.Branch
+			Ahead  *int `json:"ahead,omitempty"`
+			Behind *int `json:"behind,omitempty"`
+		}
+		entries := make([]branchEntry, 0, len(branches))
+		for _, branch := range branches {
+			entry := branchEntry{Branch: branch}
+			if branch.Name == mainBranch {
+				entry.IsDefault = true
+			}
+			if c, ok := counts[branch.Name]; ok {
+				entry.Ahead, entry.Behind = &c.Ahead, &c.Behind
+			}
+			entries = append(entries, entry)
+		}
+
 		payload := map[string]any{
 			"workspace": workspace,
 			"repo":      repoSlug,
-			"branches":  branches,
+			"branches":  entries,
 		}
 
 		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
-			if len(branches) == 0 {
+			if len(entries) == 0 {
 				_, err := fmt.Fprintln(ios.Out, "No branches found.")
 				return err
 			}
 
-			for _, branch := range branches {
+			tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+			for _, branch := range entries {
 				marker := " "
 				if branch.IsDefault {
 					marker = "*"
@@ -155,11 +208,15 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 				if len(hash) > 12 {
 					hash = hash[:12]
 				}
-				if _, err := fmt.Fprintf(ios.Out, "%s %s\t%s\n", marker, branch.Name, hash); err != nil {
+				status := ""
+				if c, ok := counts[branch.Name]; ok {
+					status = formatAheadBehind(c)
+				}
+				if _, err := fmt.Fprintf(tw, "%s %s\t%s\t%s\n", marker, branch.Name, hash, status); err != nil {
 					return err
 				}
 			}
-			return nil
+			return tw.Flush()
 		})
 
 	default:
@@ -168,10 +225,11 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 }
 
 type createOptions struct {
-	Project string
-	Repo    string
-	Source  string
-	Message string
+	Project   string
+	Workspace string
+	Repo      string
+	Source    string
+	Message   string
 }
 
 func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
@@ -179,17 +237,21 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create <branch>",
 		Short: "Create a new branch",
-		Args:  cobra.ExactArgs(1),
+		Example: `  bkt branch create feature/cache --from main
+  bkt branch create hotfix/1.2.1 --from 3f2a9c1e`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCreate(cmd, f, args[0], opts)
 		},
 	}
 
 	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
 	cmd.Flags().StringVar(&opts.Source, "from", "", "Branch or commit to start from (required)")
-	cmd.Flags().StringVar(&opts.Message, "message", "", "Optional branch creation message")
+	cmd.Flags().StringVar(&opts.Message, "message", "", "Optional branch creation message (Data Center)")
 	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.RegisterFlagCompletionFunc("from", cmdutil.CompleteBranches(f))
 
 	return cmd
 }
@@ -205,43 +267,76 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, name string, opts *create
 	if err != nil {
 		return err
 	}
-	if host.Kind != "dc" {
-		return fmt.Errorf("branch create currently supports Data Center contexts only")
-	}
 
-	projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
-	repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
-	if projectKey == "" || repoSlug == "" {
-		return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
-	}
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
 
-	client, err := cmdutil.NewDCClient(host)
-	if err != nil {
-		return err
-	}
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
-	defer cancel()
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
 
-	branch, err := client.CreateBranch(ctx, projectKey, repoSlug, bbdc.CreateBranchInput{
-		Name:       name,
-		StartPoint: opts.Source,
-		Message:    opts.Message,
-	})
-	if err != nil {
-		return err
-	}
+		branch, err := client.CreateBranch(ctx, projectKey, repoSlug, bbdc.CreateBranchInput{
+			Name:       name,
+			StartPoint: opts.Source,
+			Message:    opts.Message,
+		})
+		if err != nil {
+			return err
+		}
 
-	if _, err := fmt.Fprintf(ios.Out, "✓ Created branch %s (%s)\n", branch.DisplayID, branch.LatestCommit); err != nil {
-		return err
+		return cmdutil.WriteOutput(cmd, ios.Out, branch, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Created branch %s (%s)\n", branch.DisplayID, branch.LatestCommit)
+			return err
+		})
+
+	case "cloud":
+		if cmd.Flags().Changed("message") {
+			return fmt.Errorf("--message is only supported on Data Center")
+		}
+
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		branch, err := client.CreateBranch(ctx, workspace, repoSlug, name, opts.Source)
+		if err != nil {
+			return err
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, branch, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Created branch %s (%s)\n", branch.Name, branch.Target.Hash)
+			return err
+		})
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
 	}
-	return nil
 }
 
 type deleteOptions struct {
-	Project string
-	Repo    string
-	DryRun  bool
+	Project   string
+	Workspace string
+	Repo      string
+	DryRun    bool
 }
 
 func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
@@ -257,8 +352,9 @@ func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
-	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Perform a dry run without deleting")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Perform a dry run without deleting (Data Center)")
 
 	return cmd
 }
@@ -274,26 +370,52 @@ func runDelete(cmd *cobra.Command, f *cmdutil.Factory, name string, opts *delete
 	if err != nil {
 		return err
 	}
-	if host.Kind != "dc" {
-		return fmt.Errorf("branch delete currently supports Data Center contexts only")
-	}
 
-	projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
-	repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
-	if projectKey == "" || repoSlug == "" {
-		return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
-	}
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
 
-	client, err := cmdutil.NewDCClient(host)
-	if err != nil {
-		return err
-	}
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
-	defer cancel()
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
 
-	if err := client.DeleteBranch(ctx, projectKey, repoSlug, name, opts.DryRun); err != nil {
-		return err
+		if err := client.DeleteBranch(ctx, projectKey, repoSlug, name, opts.DryRun); err != nil {
+			return err
+		}
+
+	case "cloud":
+		if opts.DryRun {
+			return fmt.Errorf("--dry-run is only supported on Data Center")
+		}
+
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		if err := client.DeleteBranch(ctx, workspace, repoSlug, name); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
 	}
 
 	action := "Deleted"
@@ -344,7 +466,7 @@ func runSetDefault(cmd *cobra.Command, f *cmdutil.Factory, name string) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.SetDefaultBranch(ctx, projectKey, repoSlug, name); err != nil {
@@ -356,3 +478,51 @@ func runSetDefault(cmd *cobra.Command, f *cmdutil.Factory, name string) error {
 	}
 	return nil
 }
+
+// maxAheadBehindCommits caps how many commits are counted per direction on
+// Bitbucket Cloud, where counting requires paging through the commit log.
+const maxAheadBehindCommits = 500
+
+type branchCounts struct {
+	Ahead  int
+	Behind int
+	// AheadCapped and BehindCapped mark counts that hit maxAheadBehindCommits.
+	AheadCapped  bool
+	BehindCapped bool
+}
+
+func cloudMainBranch(ctx context.Context, client *bbcloud.Client, workspace, repoSlug string) (string, error) {
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return "", err
+	}
+	if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+		return "", fmt.Errorf("repository %s/%s has no main branch", workspace, repoSlug)
+	}
+	return repo.MainBranch.Name, nil
+}
+
+func cloudAheadBehind(ctx context.Context, client *bbcloud.Client, workspace, repoSlug, branch, base string) (branchCounts, error) {
+	ahead, aheadCapped, err := client.CountCommits(ctx, workspace, repoSlug, branch, base, maxAheadBehindCommits)
+	if err != nil {
+		return branchCounts{}, err
+	}
+	behind, behindCapped, err := client.CountCommits(ctx, workspace, repoSlug, base, branch, maxAheadBehindCommits)
+	if err != nil {
+		return branchCounts{}, err
+	}
+	return branchCounts{Ahead: ahead, Behind: behind, AheadCapped: aheadCapped, BehindCapped: behindCapped}, nil
+}
+
+func formatAheadBehind(c branchCounts) string {
+	if c.Ahead == 0 && c.Behind == 0 {
+		return "up to date"
+	}
+	count := func(n int, capped bool) string {
+		if capped {
+			return fmt.Sprintf("%d+", n)
+		}
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%s ahead, %s behind", count(c.Ahead, c.AheadCapped), count(c.Behind, c.BehindCapped))
+}
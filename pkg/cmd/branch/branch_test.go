@@ -0,0 +1,82 @@
+package branch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func TestListCloudAheadBehind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repositories/team/api/refs/branches":
+			_, _ = w.Write([]byte(`{"values":[{"name":"main","target":{"hash":"aaa"}},{"name":"feature","target":{"hash":"bbb"}}]}`))
+		case "/repositories/team/api":
+			_, _ = w.Write([]byte(`{"slug":"api","mainbranch":{"name":"main"}}`))
+		case "/repositories/team/api/commits":
+			if r.URL.Query().Get("include") == "feature" {
+				_, _ = w.Write([]byte(`{"values":[{"hash":"1"},{"hash":"2"}]}`))
+			} else {
+				_, _ = w.Write([]byte(`{"values":[{"hash":"3"}]}`))
+			}
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCmdBranch(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"list", "--ahead-behind"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("branch list: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "* main") {
+		t.Fatalf("expected main branch to be marked default, got %q", out)
+	}
+	if !strings.Contains(out, "2 ahead, 1 behind") {
+		t.Fatalf("expected ahead/behind counts, got %q", out)
+	}
+}
+
+func TestFormatAheadBehind(t *testing.T) {
+	if got := formatAheadBehind(branchCounts{}); got != "up to date" {
+		t.Fatalf("got %q", got)
+	}
+	if got := formatAheadBehind(branchCounts{Ahead: 500, AheadCapped: true, Behind: 3}); got != "500+ ahead, 3 behind" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+package branch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+func newModelCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "model",
+		Aliases: []string{"branching-model"},
+		Short:   "View or edit the repository branching model",
+		Long: `The branching model names the development and production branches and the
+prefixes used for feature, bugfix, release and hotfix branches. "bkt pr create"
+uses it to pick a destination branch when --target is omitted.`,
+	}
+
+	cmd.AddCommand(newModelViewCmd(f))
+	cmd.AddCommand(newModelEditCmd(f))
+
+	return cmd
+}
+
+type modelOptions struct {
+	Project      string
+	Workspace    string
+	Repo         string
+	Development  string
+	Production   string
+	NoProduction bool
+	Prefixes     []string
+}
+
+func newModelViewCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &modelOptions{}
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show the branching model",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelView(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+
+	return cmd
+}
+
+func runModelView(cmd *cobra.Command, f *cmdutil.Factory, opts *modelOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var summary cmdutil.BranchModel
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		model, err := client.GetBranchModel(ctx, projectKey, repoSlug)
+		if err != nil {
+			return err
+		}
+
+		summary = cmdutil.DCBranchModel(model)
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		model, err := client.GetBranchingModel(ctx, workspace, repoSlug)
+		if err != nil {
+			return err
+		}
+		summary = cmdutil.CloudBranchModel(model)
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, summary, func() error {
+		return printModelSummary(ios.Out, summary)
+	})
+}
+
+func newModelEditCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &modelOptions{}
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Change the branching model (Cloud)",
+		Long: `Change the repository branching model. Pass an empty value to
+--development or --production to use the main branch, and an empty prefix to
+disable a branch type.`,
+		Example: `  bkt branch model edit --development develop --production main
+  bkt branch model edit --prefix feature=feat/ --prefix hotfix=
+  bkt branch model edit --no-production`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelEdit(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Development, "development", "", "Development branch (empty for the main branch)")
+	cmd.Flags().StringVar(&opts.Production, "production", "", "Production branch (empty for the main branch)")
+	cmd.Flags().BoolVar(&opts.NoProduction, "no-production", false, "Disable the production branch")
+	cmd.Flags().StringArrayVar(&opts.Prefixes, "prefix", nil, "Branch prefix as kind=prefix (repeatable)")
+
+	return cmd
+}
+
+func runModelEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *modelOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	if opts.NoProduction && cmd.Flags().Changed("production") {
+		return fmt.Errorf("--production and --no-production are mutually exclusive")
+	}
+
+	var input bbcloud.UpdateBranchingModelInput
+	if cmd.Flags().Changed("development") {
+		input.Development = &opts.Development
+	}
+	if cmd.Flags().Changed("production") {
+		input.Production = &opts.Production
+	}
+	if opts.NoProduction {
+		disabled := false
+		input.ProductionEnabled = &disabled
+	}
+	if len(opts.Prefixes) > 0 {
+		input.Prefixes = make(map[string]string, len(opts.Prefixes))
+		for _, raw := range opts.Prefixes {
+			kind, prefix, ok := strings.Cut(raw, "=")
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			if !ok || kind == "" {
+				return fmt.Errorf("invalid --prefix %q (expected kind=prefix)", raw)
+			}
+			input.Prefixes[kind] = strings.TrimSpace(prefix)
+		}
+	}
+	if input.Development == nil && input.Production == nil && input.ProductionEnabled == nil && input.Prefixes == nil {
+		return fmt.Errorf("no updates specified: use flags like --development, --production, --prefix")
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	if _, err := client.UpdateBranchingModel(ctx, workspace, repoSlug, input); err != nil {
+		return err
+	}
+
+	// The settings endpoint echoes configuration rather than resolved
+	// branches, so re-read the effective model for display.
+	model, err := client.GetBranchingModel(ctx, workspace, repoSlug)
+	if err != nil {
+		return err
+	}
+	summary := cmdutil.CloudBranchModel(model)
+
+	return cmdutil.WriteOutput(cmd, ios.Out, summary, func() error {
+		if _, err := fmt.Fprintf(ios.Out, "✓ Updated branching model for %s/%s\n", workspace, repoSlug); err != nil {
+			return err
+		}
+		return printModelSummary(ios.Out, summary)
+	})
+}
+
+func printModelSummary(out io.Writer, summary cmdutil.BranchModel) error {
+	if _, err := fmt.Fprintf(out, "Development: %s\n", cmdutil.FirstNonEmpty(summary.Development, "-")); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "Production:  %s\n", cmdutil.FirstNonEmpty(summary.Production, "-")); err != nil {
+		return err
+	}
+
+	kinds := make([]string, 0, len(summary.Prefixes))
+	for kind := range summary.Prefixes {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	if len(kinds) > 0 {
+		if _, err := fmt.Fprintln(out, "Prefixes:"); err != nil {
+			return err
+		}
+	}
+	for _, kind := range kinds {
+		if _, err := fmt.Fprintf(out, "  %-8s %s\n", kind, summary.Prefixes[kind]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
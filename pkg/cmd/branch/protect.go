@@ -1,7 +1,6 @@
 package branch
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -120,7 +119,7 @@ func runProtectList(cmd *cobra.Command, f *cmdutil.Factory, opts *protectOptions
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	restrictions, err := client.ListBranchRestrictions(ctx, projectKey, repoSlug)
@@ -178,7 +177,7 @@ func runProtectAdd(cmd *cobra.Command, f *cmdutil.Factory, opts *protectOptions)
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	restriction, err := client.CreateBranchRestriction(ctx, projectKey, repoSlug, bbdc.BranchRestrictionInput{
@@ -223,7 +222,7 @@ func runProtectRemove(cmd *cobra.Command, f *cmdutil.Factory, opts *protectOptio
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.DeleteBranchRestriction(ctx, projectKey, repoSlug, opts.ID); err != nil {
@@ -0,0 +1,273 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+type options struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Branch    string
+	NoBrowser bool
+}
+
+// NewCmdBrowse opens repository files on Bitbucket.
+func NewCmdBrowse(f *cmdutil.Factory) *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:   "browse [<path>[:<line>[-<line>]]]",
+		Short: "Open a repository file or directory on Bitbucket",
+		Long: `Open the source view of a file or directory in the browser, at the current
+git branch and optionally highlighting a line or range of lines.
+
+Paths are relative to the current directory inside a clone of the repository
+and relative to the repository root elsewhere. Without a path the root of the
+repository is opened. On a detached HEAD the checked-out commit is used, and
+outside a clone of the repository its default branch; pick another with
+--branch. The branch must have been pushed for the page to exist.`,
+		Example: `  bkt browse
+  bkt browse pkg/bbcloud/client.go:120
+  bkt browse internal/config/config.go:40-58 --branch release/1.2
+  bkt browse README.md --no-browser`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := ""
+			if len(args) > 0 {
+				target = args[0]
+			}
+			return runBrowse(cmd, f, target, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override (Data Center)")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVarP(&opts.Branch, "branch", "b", "", "Branch, tag or commit to browse (default: current branch)")
+	cmd.Flags().BoolVarP(&opts.NoBrowser, "no-browser", "n", false, "Print the URL instead of opening it")
+	_ = cmd.RegisterFlagCompletionFunc("branch", cmdutil.CompleteBranches(f))
+
+	return cmd
+}
+
+// location is a repository path with an optional line range.
+type location struct {
+	Path      string
+	StartLine int
+	EndLine   int
+}
+
+var lineSuffix = regexp.MustCompile(`:(\d+)(?:-(\d+))?$`)
+
+// parseLocation splits "path:120" or "path:120-130" into its parts.
+func parseLocation(arg string) (location, error) {
+	loc := location{Path: arg}
+	m := lineSuffix.FindStringSubmatch(arg)
+	if m == nil {
+		return loc, nil
+	}
+
+	loc.Path = strings.TrimSuffix(arg, m[0])
+	loc.StartLine, _ = strconv.Atoi(m[1])
+	loc.EndLine = loc.StartLine
+	if m[2] != "" {
+		loc.EndLine, _ = strconv.Atoi(m[2])
+	}
+	if loc.StartLine < 1 || loc.EndLine < loc.StartLine {
+		return location{}, fmt.Errorf("invalid line range in %q", arg)
+	}
+	if loc.Path == "" {
+		return location{}, fmt.Errorf("a path is required with a line number")
+	}
+	return loc, nil
+}
+
+func runBrowse(cmd *cobra.Command, f *cmdutil.Factory, target string, opts *options) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	loc, err := parseLocation(target)
+	if err != nil {
+		return err
+	}
+
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	ref := strings.TrimSpace(opts.Branch)
+	// The working directory supplies the path prefix and the checked-out
+	// ref only when it is a clone of the repository being browsed.
+	useClone := func(namespace, repoSlug string) {
+		if !cmdutil.InRepoClone(host, namespace, repoSlug) {
+			return
+		}
+		loc.Path = repoPath(ctx, loc.Path)
+		if ref == "" {
+			ref = currentRef(ctx)
+		}
+	}
+
+	var link string
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+		useClone(projectKey, repoSlug)
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		repo, err := client.GetRepository(ctx, projectKey, repoSlug)
+		if err != nil {
+			return err
+		}
+		if len(repo.Links.Web) == 0 || repo.Links.Web[0].Href == "" {
+			return fmt.Errorf("repository does not expose a web URL")
+		}
+		if ref == "" {
+			if branch, err := client.GetDefaultBranch(ctx, projectKey, repoSlug); err == nil {
+				ref = branch.DisplayID
+			}
+		}
+		link = dcSourceURL(repo.Links.Web[0].Href, ref, loc)
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+		useClone(workspace, repoSlug)
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		repo, err := client.GetRepository(ctx, workspace, repoSlug)
+		if err != nil {
+			return err
+		}
+		if repo.Links.HTML.Href == "" {
+			return fmt.Errorf("repository does not expose a web URL")
+		}
+		if ref == "" && repo.MainBranch != nil {
+			ref = repo.MainBranch.Name
+		}
+		link = cloudSourceURL(repo.Links.HTML.Href, ref, loc)
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	if opts.NoBrowser {
+		_, err := fmt.Fprintln(ios.Out, link)
+		return err
+	}
+	return cmdutil.OpenInBrowser(f, ios.Out, "repository", link)
+}
+
+// cloudSourceURL builds https://bitbucket.org/ws/repo/src/<ref>/<path>#lines-N:M.
+func cloudSourceURL(base, ref string, loc location) string {
+	link := strings.TrimSuffix(base, "/") + "/src/"
+	if ref == "" {
+		ref = "HEAD"
+	}
+	link += escapePath(ref) + "/" + escapePath(loc.Path)
+	if loc.StartLine > 0 {
+		link += "#lines-" + strconv.Itoa(loc.StartLine)
+		if loc.EndLine > loc.StartLine {
+			link += ":" + strconv.Itoa(loc.EndLine)
+		}
+	}
+	return link
+}
+
+// dcSourceURL builds .../projects/P/repos/r/browse/<path>?at=<ref>#N-M.
+func dcSourceURL(base, ref string, loc location) string {
+	link := strings.TrimSuffix(strings.TrimSuffix(base, "/"), "/browse") + "/browse"
+	if loc.Path != "" {
+		link += "/" + escapePath(loc.Path)
+	}
+	if ref != "" {
+		link += "?at=" + url.QueryEscape(ref)
+	}
+	if loc.StartLine > 0 {
+		link += "#" + strconv.Itoa(loc.StartLine)
+		if loc.EndLine > loc.StartLine {
+			link += "-" + strconv.Itoa(loc.EndLine)
+		}
+	}
+	return link
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// repoPath resolves p against the current directory's position in the clone,
+// returning a slash-separated path from the repository root. Outside a clone
+// p is taken to be relative to the root already.
+func repoPath(ctx context.Context, p string) string {
+	prefix, err := git(ctx, "rev-parse", "--show-prefix")
+	if err != nil {
+		return strings.Trim(path.Clean("/"+filepath.ToSlash(p)), "/")
+	}
+	if filepath.IsAbs(p) {
+		if root, err := git(ctx, "rev-parse", "--show-toplevel"); err == nil {
+			if rel, err := filepath.Rel(root, p); err == nil {
+				return strings.Trim(filepath.ToSlash(rel), "/")
+			}
+		}
+	}
+	return strings.Trim(path.Clean("/"+prefix+filepath.ToSlash(p)), "/")
+}
+
+// currentRef returns the checked-out branch, the commit on a detached HEAD,
+// or "" outside a clone.
+func currentRef(ctx context.Context) string {
+	if branch, err := git(ctx, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil && branch != "" {
+		return branch
+	}
+	if sha, err := git(ctx, "rev-parse", "HEAD"); err == nil {
+		return sha
+	}
+	return ""
+}
+
+func git(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
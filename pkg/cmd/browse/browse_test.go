@@ -0,0 +1,240 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+type fakeBrowser struct {
+	opened []string
+}
+
+func (b *fakeBrowser) Open(url string) error {
+	b.opened = append(b.opened, url)
+	return nil
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    location
+		wantErr bool
+	}{
+		{arg: "", want: location{}},
+		{arg: "pkg/bbcloud/client.go", want: location{Path: "pkg/bbcloud/client.go"}},
+		{arg: "pkg/bbcloud/client.go:120", want: location{Path: "pkg/bbcloud/client.go", StartLine: 120, EndLine: 120}},
+		{arg: "main.go:10-20", want: location{Path: "main.go", StartLine: 10, EndLine: 20}},
+		{arg: "main.go:20-10", wantErr: true},
+		{arg: "main.go:0", wantErr: true},
+		{arg: ":12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLocation(tt.arg)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("parseLocation(%q): expected error", tt.arg)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Fatalf("parseLocation(%q) = %+v, %v; want %+v", tt.arg, got, err, tt.want)
+		}
+	}
+}
+
+func TestSourceURLs(t *testing.T) {
+	loc := location{Path: "pkg/my file.go", StartLine: 12, EndLine: 15}
+
+	if got, want := cloudSourceURL("https://bitbucket.org/team/api", "feature/x", loc),
+		"https://bitbucket.org/team/api/src/feature/x/pkg/my%20file.go#lines-12:15"; got != want {
+		t.Fatalf("cloud URL = %q, want %q", got, want)
+	}
+	if got, want := cloudSourceURL("https://bitbucket.org/team/api/", "", location{}),
+		"https://bitbucket.org/team/api/src/HEAD/"; got != want {
+		t.Fatalf("cloud root URL = %q, want %q", got, want)
+	}
+	if got, want := dcSourceURL("https://bb.example.com/projects/DATA/repos/api/browse", "feature/x", loc),
+		"https://bb.example.com/projects/DATA/repos/api/browse/pkg/my%20file.go?at=feature%2Fx#12-15"; got != want {
+		t.Fatalf("dc URL = %q, want %q", got, want)
+	}
+	if got, want := dcSourceURL("https://bb.example.com/projects/DATA/repos/api/browse", "", location{Path: "README.md", StartLine: 3, EndLine: 3}),
+		"https://bb.example.com/projects/DATA/repos/api/browse/README.md#3"; got != want {
+		t.Fatalf("dc URL = %q, want %q", got, want)
+	}
+}
+
+func TestRepoPathAndRefInsideClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "topic/browse"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	sub := filepath.Join(dir, "pkg", "cmd")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	ctx := context.Background()
+	if got := repoPath(ctx, "browse.go"); got != "pkg/cmd/browse.go" {
+		t.Fatalf("repoPath = %q", got)
+	}
+	if got := repoPath(ctx, "../../README.md"); got != "README.md" {
+		t.Fatalf("repoPath = %q", got)
+	}
+	if got := currentRef(ctx); got != "topic/browse" {
+		t.Fatalf("currentRef = %q", got)
+	}
+}
+
+func TestBrowseOpensCloudSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/team/api" {
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"api","mainbranch":{"name":"main"},"links":{"html":{"href":"https://bitbucket.org/team/api"}}}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Chdir(t.TempDir())
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	run := func(args ...string) (string, *fakeBrowser) {
+		t.Helper()
+		var stdout strings.Builder
+		browser := &fakeBrowser{}
+		f := &cmdutil.Factory{
+			AppVersion:     "test",
+			ExecutableName: "bkt",
+			IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &strings.Builder{}},
+			Browser:        browser,
+			Config: func() (*config.Config, error) {
+				return cfg, nil
+			},
+		}
+		cmd := NewCmdBrowse(f)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("browse %v: %v", args, err)
+		}
+		return stdout.String(), browser
+	}
+
+	out, browser := run("pkg/bbcloud/client.go:120")
+	want := "https://bitbucket.org/team/api/src/main/pkg/bbcloud/client.go#lines-120"
+	if len(browser.opened) != 1 || browser.opened[0] != want {
+		t.Fatalf("opened %v, want %s", browser.opened, want)
+	}
+	if !strings.Contains(out, "Opening "+want) {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	out, browser = run("README.md", "--branch", "release/1.2", "--no-browser")
+	if len(browser.opened) != 0 {
+		t.Fatalf("--no-browser opened %v", browser.opened)
+	}
+	if out != "https://bitbucket.org/team/api/src/release/1.2/README.md\n" {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestBrowseIgnoresCloneOfAnotherRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := path.Base(r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"slug":%q,"mainbranch":{"name":"main"},"links":{"html":{"href":"https://bitbucket.org/team/%s"}}}`, slug, slug)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "topic"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"remote", "add", "origin", "git@bitbucket.org:team/web.git"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	sub := filepath.Join(dir, "docs")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	browse := func(repo string) string {
+		t.Helper()
+		var stdout strings.Builder
+		f := &cmdutil.Factory{
+			AppVersion:     "test",
+			ExecutableName: "bkt",
+			IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &strings.Builder{}},
+			Config: func() (*config.Config, error) {
+				return cfg, nil
+			},
+		}
+		cmd := NewCmdBrowse(f)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetArgs([]string{"README.md", "--repo", repo, "--no-browser"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("browse: %v", err)
+		}
+		return stdout.String()
+	}
+
+	if got := browse("api"); got != "https://bitbucket.org/team/api/src/main/README.md\n" {
+		t.Fatalf("browse outside a clone of team/api = %q", got)
+	}
+	if got := browse("web"); got != "https://bitbucket.org/team/web/src/topic/docs/README.md\n" {
+		t.Fatalf("browse inside a clone of team/web = %q", got)
+	}
+}
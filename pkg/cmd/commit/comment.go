@@ -0,0 +1,364 @@
+package commit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newCommentCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Review individual commits with comments",
+	}
+
+	cmd.AddCommand(newCommentListCmd(f))
+	cmd.AddCommand(newCommentCreateCmd(f))
+
+	return cmd
+}
+
+// commentSummary is the host-agnostic view of a commit comment.
+type commentSummary struct {
+	ID        int    `json:"id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedOn string `json:"created_on,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Line      int    `json:"line,omitempty"`
+}
+
+func cloudCommentSummary(c bbcloud.CommitComment) commentSummary {
+	s := commentSummary{
+		ID:        c.ID,
+		Text:      c.Content.Raw,
+		CreatedOn: c.CreatedOn,
+	}
+	if c.User != nil {
+		s.Author = c.User.DisplayName
+	}
+	if c.Inline != nil {
+		s.Path = c.Inline.Path
+		if c.Inline.To != nil {
+			s.Line = *c.Inline.To
+		} else if c.Inline.From != nil {
+			s.Line = *c.Inline.From
+		}
+	}
+	return s
+}
+
+func dcCommentSummary(c bbdc.CommitComment) commentSummary {
+	s := commentSummary{
+		ID:     c.ID,
+		Author: cmdutil.FirstNonEmpty(c.Author.FullName, c.Author.Name),
+		Text:   c.Text,
+	}
+	if c.CreatedDate > 0 {
+		s.CreatedOn = time.UnixMilli(c.CreatedDate).UTC().Format(time.RFC3339)
+	}
+	if c.Anchor != nil {
+		s.Path = c.Anchor.Path
+		s.Line = c.Anchor.Line
+	}
+	return s
+}
+
+func (s commentSummary) location() string {
+	switch {
+	case s.Path == "":
+		return ""
+	case s.Line > 0:
+		return fmt.Sprintf("%s:%d", s.Path, s.Line)
+	default:
+		return s.Path
+	}
+}
+
+type commentListOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Path      string
+	Limit     int
+}
+
+func newCommentListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &commentListOptions{Limit: 50}
+	cmd := &cobra.Command{
+		Use:     "list <commit>",
+		Aliases: []string{"ls"},
+		Short:   "List comments on a commit",
+		Long: `List comments on a commit. Data Center groups commit comments by file; use
+--path to list the comments made on a particular file.`,
+		Example: `  bkt commit comment list 3f2a9c1e
+  bkt commit comment list 3f2a9c1e --path src/main.go`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentList(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Path, "path", "", "Only comments on this file")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "n", opts.Limit, "Maximum comments to list (0 for all)")
+
+	return cmd
+}
+
+func runCommentList(cmd *cobra.Command, f *cmdutil.Factory, revision string, opts *commentListOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var summaries []commentSummary
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		comments, err := client.ListCommitComments(ctx, projectKey, repoSlug, revision, opts.Path, opts.Limit)
+		if err != nil {
+			return err
+		}
+		for _, c := range comments {
+			summaries = append(summaries, dcCommentSummary(c))
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		comments, err := client.ListCommitComments(ctx, workspace, repoSlug, revision, opts.Limit)
+		if err != nil {
+			return err
+		}
+		for _, c := range comments {
+			if c.Deleted {
+				continue
+			}
+			s := cloudCommentSummary(c)
+			if opts.Path != "" && s.Path != opts.Path {
+				continue
+			}
+			summaries = append(summaries, s)
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	payload := map[string]any{
+		"commit":   revision,
+		"comments": summaries,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(summaries) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No comments on commit %s.\n", shortHash(revision))
+			return err
+		}
+		for _, c := range summaries {
+			if err := printComment(ios, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func printComment(ios *iostreams.IOStreams, c commentSummary) error {
+	header := fmt.Sprintf("@%s", cmdutil.FirstNonEmpty(c.Author, "unknown"))
+	if c.CreatedOn != "" {
+		header += fmt.Sprintf(" (%s)", c.CreatedOn)
+	}
+	if loc := c.location(); loc != "" {
+		header += " on " + loc
+	}
+	_, err := fmt.Fprintf(ios.Out, "%s:\n%s\n\n", header, cmdutil.RenderMarkdown(ios, c.Text))
+	return err
+}
+
+type commentCreateOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Text      string
+	FilePath  string
+	Line      int
+	LineFrom  int
+}
+
+func newCommentCreateCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &commentCreateOptions{}
+	cmd := &cobra.Command{
+		Use:     "create <commit> --text <message>",
+		Aliases: []string{"add"},
+		Short:   "Comment on a commit",
+		Long: `Comment on a commit. Use --file and --line to attach the comment to a line of
+the commit's version of a file. --line-from adds a line range on Bitbucket Cloud.`,
+		Example: `  # Add a general comment
+  bkt commit comment create 3f2a9c1e --text "Nice cleanup"
+
+  # Add an inline comment on a specific line
+  bkt commit comment create 3f2a9c1e --text "Off by one?" --file src/main.go --line 42
+
+  # Add an inline comment on a line range (Cloud only)
+  bkt commit comment create 3f2a9c1e --text "Extract this" --file src/main.go --line-from 10 --line 20`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.FilePath != "" && opts.Line <= 0 {
+				return fmt.Errorf("--line is required when --file is specified")
+			}
+			if opts.Line > 0 && opts.FilePath == "" {
+				return fmt.Errorf("--file is required when --line is specified")
+			}
+			if opts.LineFrom > 0 && opts.Line <= 0 {
+				return fmt.Errorf("--line is required when --line-from is specified")
+			}
+			return runCommentCreate(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Text, "text", "", "Comment text")
+	cmd.Flags().StringVar(&opts.FilePath, "file", "", "File path for inline comment")
+	cmd.Flags().IntVar(&opts.Line, "line", 0, "Line number for inline comment (requires --file)")
+	cmd.Flags().IntVar(&opts.LineFrom, "line-from", 0, "Starting line for range comment (Cloud only, requires --file and --line)")
+	_ = cmd.MarkFlagRequired("text")
+
+	return cmd
+}
+
+func runCommentCreate(cmd *cobra.Command, f *cmdutil.Factory, revision string, opts *commentCreateOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var summary commentSummary
+
+	switch host.Kind {
+	case "dc":
+		if opts.LineFrom > 0 {
+			return fmt.Errorf("--line-from is only supported for Bitbucket Cloud")
+		}
+
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		comment, err := client.CreateCommitComment(ctx, projectKey, repoSlug, revision, bbdc.CommitCommentOptions{
+			Text:     opts.Text,
+			FilePath: opts.FilePath,
+			Line:     opts.Line,
+		})
+		if err != nil {
+			return err
+		}
+		summary = dcCommentSummary(*comment)
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		comment, err := client.CreateCommitComment(ctx, workspace, repoSlug, revision, bbcloud.CommentPullRequestOptions{
+			Text:     opts.Text,
+			FilePath: opts.FilePath,
+			Line:     opts.Line,
+			LineFrom: opts.LineFrom,
+		})
+		if err != nil {
+			return err
+		}
+		summary = cloudCommentSummary(*comment)
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	payload := map[string]any{
+		"commit":  revision,
+		"comment": summary,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		msg := fmt.Sprintf("✓ Commented on commit %s", shortHash(revision))
+		if opts.FilePath != "" {
+			if opts.LineFrom > 0 {
+				msg += fmt.Sprintf(" (%s lines %d-%d)", opts.FilePath, opts.LineFrom, opts.Line)
+			} else {
+				msg += fmt.Sprintf(" (%s line %d)", opts.FilePath, opts.Line)
+			}
+		}
+		_, err := fmt.Fprintln(ios.Out, msg)
+		return err
+	})
+}
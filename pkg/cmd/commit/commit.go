@@ -0,0 +1,204 @@
+package commit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdCommit exposes commit operations.
+func NewCmdCommit(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit",
+		Short: "Inspect repository commits",
+	}
+
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newViewCmd(f))
+	cmd.AddCommand(newStatusCmd(f))
+	cmd.AddCommand(newCommentCmd(f))
+
+	return cmd
+}
+
+type listOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Branch    string
+	Path      string
+	Exclude   []string
+	Limit     int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "log"},
+		Short:   "List commits, newest first",
+		Long: `List commits like "git log --oneline", reading history from the Bitbucket API
+rather than a local clone.`,
+		Example: `  bkt commit list --branch feature -n 50
+  bkt commit list --path cmd/bkt --exclude main
+  bkt commit list --branch release/2.0 --exclude release/1.9`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVarP(&opts.Branch, "branch", "b", "", "Branch, tag or commit to list from (default: main branch)")
+	cmd.Flags().StringVar(&opts.Path, "path", "", "Only commits touching this file or directory")
+	cmd.Flags().StringArrayVar(&opts.Exclude, "exclude", nil, "Omit commits reachable from this ref (repeatable on Cloud)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "n", opts.Limit, "Maximum commits to list (0 for all)")
+	_ = cmd.RegisterFlagCompletionFunc("branch", cmdutil.CompleteBranches(f))
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	switch host.Kind {
+	case "dc":
+		if len(opts.Exclude) > 1 {
+			return fmt.Errorf("only a single --exclude ref is supported on Data Center")
+		}
+
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		listOpts := bbdc.CommitListOptions{
+			Until: opts.Branch,
+			Path:  opts.Path,
+			Limit: opts.Limit,
+		}
+		if len(opts.Exclude) == 1 {
+			listOpts.Since = opts.Exclude[0]
+		}
+
+		commits, err := client.ListCommits(ctx, projectKey, repoSlug, listOpts)
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"project": projectKey,
+			"repo":    repoSlug,
+			"commits": commits,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			if len(commits) == 0 {
+				_, err := fmt.Fprintln(ios.Out, "No commits found.")
+				return err
+			}
+			for _, c := range commits {
+				if err := printOneline(ios.Out, c.ID, c.Message); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		listOpts := bbcloud.CommitListOptions{
+			Exclude: opts.Exclude,
+			Path:    opts.Path,
+			Limit:   opts.Limit,
+		}
+		if opts.Branch != "" {
+			listOpts.Include = []string{opts.Branch}
+		}
+
+		commits, err := client.ListCommits(ctx, workspace, repoSlug, listOpts)
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"workspace": workspace,
+			"repo":      repoSlug,
+			"commits":   commits,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			if len(commits) == 0 {
+				_, err := fmt.Fprintln(ios.Out, "No commits found.")
+				return err
+			}
+			for _, c := range commits {
+				if err := printOneline(ios.Out, c.Hash, c.Message); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+func printOneline(out io.Writer, hash, message string) error {
+	_, err := fmt.Fprintf(out, "%s %s\n", shortHash(hash), subject(message))
+	return err
+}
+
+// subject returns the first line of a commit message.
+func subject(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(line)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,230 @@
+package commit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, cfg *config.Config) (*cmdutil.Factory, *strings.Builder, *strings.Builder) {
+	t.Helper()
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout, &stderr
+}
+
+func cloudConfig(baseURL string) *config.Config {
+	return &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+}
+
+func TestListCloudOneline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/repositories/team/api/commits" || q.Get("include") != "feature" || q.Get("exclude") != "main" || q.Get("path") != "docs" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[
+			{"hash":"1234567890abcdef","message":"Add docs\n\nLonger body"},
+			{"hash":"fedcba0987654321","message":"Fix typo"}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, cloudConfig(server.URL))
+
+	cmd := NewCmdCommit(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"list", "--branch", "feature", "--exclude", "main", "--path", "docs", "-n", "5"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("commit list: %v", err)
+	}
+
+	want := "1234567 Add docs\nfedcba0 Fix typo\n"
+	if stdout.String() != want {
+		t.Fatalf("output = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestViewCloudSummarizesBuilds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repositories/team/api/commit/main":
+			_, _ = w.Write([]byte(`{"hash":"1234567890abcdef","message":"Ship it\n\nDetails here","author":{"raw":"Alice <alice@example.com>"},"parents":[{"hash":"aaaaaaaaaaaa"}]}`))
+		case "/repositories/team/api/commit/1234567890abcdef/statuses":
+			_, _ = w.Write([]byte(`{"values":[
+				{"state":"SUCCESSFUL","key":"unit","name":"Unit tests"},
+				{"state":"FAILED","key":"lint","name":"Lint","url":"https://ci.example.com/lint"}
+			]}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, cloudConfig(server.URL))
+
+	cmd := NewCmdCommit(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"view", "main"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("commit view: %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"commit 1234567890abcdef",
+		"Author: Alice <alice@example.com>",
+		"Parents: aaaaaaa",
+		"    Details here",
+		"Builds: FAILED (1 failed, 1 successful)",
+		"✗ Lint: FAILED",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestStatusSetCloudResolvesRef(t *testing.T) {
+	const full = "1234567890abcdef1234567890abcdef12345678"
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repositories/team/api/commit/main":
+			_, _ = w.Write([]byte(`{"hash":"` + full + `"}`))
+		case "POST /repositories/team/api/commit/" + full + "/statuses/build":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"key":"ci","state":"SUCCESSFUL"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, cloudConfig(server.URL))
+
+	cmd := NewCmdCommit(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"status", "set", "main", "--key", "ci", "--state", "successful", "--url", "https://ci.example.com/1"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("commit status set: %v", err)
+	}
+
+	if body["state"] != "SUCCESSFUL" || body["key"] != "ci" || body["name"] != "ci" || body["url"] != "https://ci.example.com/1" {
+		t.Fatalf("unexpected body %+v", body)
+	}
+	if !strings.Contains(stdout.String(), "✓ Set ci to SUCCESSFUL on 1234567") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestStatusSetRejectsUnknownState(t *testing.T) {
+	f, _, _ := newTestFactory(t, cloudConfig("http://example.invalid"))
+
+	cmd := NewCmdCommit(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"status", "set", "main", "--key", "ci", "--state", "passed", "--url", "https://ci.example.com/1"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --state") {
+		t.Fatalf("expected invalid state error, got %v", err)
+	}
+}
+
+func TestCommentCreateCloudInline(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repositories/team/api/commit/abc1234/comments" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"content":{"raw":"Off by one?"},"inline":{"path":"main.go","from":40,"to":42}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, cloudConfig(server.URL))
+
+	cmd := NewCmdCommit(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"comment", "create", "abc1234", "--text", "Off by one?", "--file", "main.go", "--line-from", "40", "--line", "42"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("commit comment create: %v", err)
+	}
+
+	inline, ok := body["inline"].(map[string]any)
+	if !ok || inline["path"] != "main.go" || inline["from"] != float64(40) || inline["to"] != float64(42) {
+		t.Fatalf("unexpected inline payload %+v", body)
+	}
+	if !strings.Contains(stdout.String(), "✓ Commented on commit abc1234 (main.go lines 40-42)") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCommentListCloudFiltersPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[
+			{"id":1,"content":{"raw":"General"},"user":{"display_name":"Alice"}},
+			{"id":2,"content":{"raw":"Inline"},"user":{"display_name":"Bob"},"inline":{"path":"main.go","to":3}},
+			{"id":3,"content":{"raw":""},"deleted":true,"inline":{"path":"main.go","to":4}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, cloudConfig(server.URL))
+
+	cmd := NewCmdCommit(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"comment", "list", "abc1234", "--path", "main.go"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("commit comment list: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "@Bob on main.go:3:\nInline") || strings.Contains(out, "General") || strings.Count(out, "@") != 1 {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
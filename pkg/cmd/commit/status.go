@@ -0,0 +1,195 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/types"
+)
+
+var buildStates = []string{"SUCCESSFUL", "FAILED", "INPROGRESS", "STOPPED"}
+
+func newStatusCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Publish build statuses for commits",
+	}
+
+	cmd.AddCommand(newStatusSetCmd(f))
+
+	return cmd
+}
+
+type statusSetOptions struct {
+	Project     string
+	Workspace   string
+	Repo        string
+	Key         string
+	State       string
+	URL         string
+	Name        string
+	Description string
+}
+
+func newStatusSetCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &statusSetOptions{}
+	cmd := &cobra.Command{
+		Use:   "set <commit>",
+		Short: "Report a build status for a commit",
+		Long: `Report a build status for a commit so CI systems outside Bitbucket can
+surface results on commits and pull requests. Publishing again with the same
+--key replaces the earlier status, so a job typically reports INPROGRESS when
+it starts and SUCCESSFUL or FAILED when it finishes.`,
+		Example: `  bkt commit status set $GIT_COMMIT --key ci-build --state inprogress --url "$BUILD_URL"
+  bkt commit status set $GIT_COMMIT --key ci-build --state successful --url "$BUILD_URL" \
+    --name "CI build" --description "All 412 tests passed"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatusSet(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Key, "key", "", "Identifier of the build, unique per commit")
+	cmd.Flags().StringVar(&opts.State, "state", "", "Build state: "+strings.Join(buildStates, ", "))
+	cmd.Flags().StringVar(&opts.URL, "url", "", "Link to the build results")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Display name of the build (default: key)")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Short description of the result")
+	_ = cmd.MarkFlagRequired("key")
+	_ = cmd.MarkFlagRequired("state")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func runStatusSet(cmd *cobra.Command, f *cmdutil.Factory, revision string, opts *statusSetOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	state, err := normalizeBuildState(opts.State)
+	if err != nil {
+		return err
+	}
+	input := types.BuildStatusInput{
+		Key:         strings.TrimSpace(opts.Key),
+		State:       state,
+		URL:         strings.TrimSpace(opts.URL),
+		Name:        cmdutil.FirstNonEmpty(strings.TrimSpace(opts.Name), strings.TrimSpace(opts.Key)),
+		Description: opts.Description,
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var hash string
+
+	switch host.Kind {
+	case "dc":
+		if state == "STOPPED" {
+			return fmt.Errorf("state STOPPED is not supported on Data Center")
+		}
+
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		// Build statuses are keyed by the full SHA, so resolve short hashes
+		// and refs through the repository first.
+		hash = revision
+		if !isFullSHA(hash) {
+			if projectKey == "" || repoSlug == "" {
+				return fmt.Errorf("context must supply project and repo to resolve %q; use --project/--repo or pass a full SHA", revision)
+			}
+			commit, err := client.GetCommit(ctx, projectKey, repoSlug, revision)
+			if err != nil {
+				return err
+			}
+			hash = commit.ID
+		}
+
+		if err := client.CreateCommitStatus(ctx, hash, input); err != nil {
+			return err
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		hash = revision
+		if !isFullSHA(hash) {
+			commit, err := client.GetCommit(ctx, workspace, repoSlug, revision)
+			if err != nil {
+				return err
+			}
+			hash = commit.Hash
+		}
+
+		if _, err := client.CreateCommitStatus(ctx, workspace, repoSlug, hash, input); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	payload := map[string]any{
+		"commit": hash,
+		"status": input,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Set %s to %s on %s\n", input.Key, input.State, shortHash(hash))
+		return err
+	})
+}
+
+func normalizeBuildState(state string) (string, error) {
+	upper := strings.ToUpper(strings.TrimSpace(state))
+	for _, s := range buildStates {
+		if upper == s {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --state %q (expected one of %s)", state, strings.Join(buildStates, ", "))
+}
+
+func isFullSHA(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
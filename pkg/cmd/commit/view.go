@@ -0,0 +1,285 @@
+package commit
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/types"
+)
+
+type viewOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Web       bool
+}
+
+func newViewCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &viewOptions{}
+	cmd := &cobra.Command{
+		Use:   "view <commit>",
+		Short: "Show a commit and its build status",
+		Long: `Show a commit's message, author and parents together with an aggregated
+summary of the build statuses reported against it.`,
+		Example: `  bkt commit view 3f2a9c1e
+  bkt commit view main --web`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runView(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "commit")
+
+	return cmd
+}
+
+type commitDetail struct {
+	Hash    string       `json:"hash"`
+	Author  string       `json:"author"`
+	Date    string       `json:"date,omitempty"`
+	Message string       `json:"message"`
+	Parents []string     `json:"parents,omitempty"`
+	WebURL  string       `json:"web_url,omitempty"`
+	Builds  buildSummary `json:"builds"`
+}
+
+type buildSummary struct {
+	State    string               `json:"state"`
+	Counts   map[string]int       `json:"counts,omitempty"`
+	Statuses []types.CommitStatus `json:"statuses"`
+}
+
+func runView(cmd *cobra.Command, f *cmdutil.Factory, revision string, opts *viewOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var detail commitDetail
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		commit, err := client.GetCommit(ctx, projectKey, repoSlug, revision)
+		if err != nil {
+			return err
+		}
+		statuses, err := client.CommitStatuses(ctx, commit.ID)
+		if err != nil {
+			return err
+		}
+
+		detail = commitDetail{
+			Hash:    commit.ID,
+			Author:  formatAuthor(cmdutil.FirstNonEmpty(commit.Author.DisplayName, commit.Author.Name), commit.Author.EmailAddress),
+			Message: commit.Message,
+			WebURL: fmt.Sprintf("%s/projects/%s/repos/%s/commits/%s",
+				strings.TrimRight(host.BaseURL, "/"),
+				url.PathEscape(projectKey),
+				url.PathEscape(repoSlug),
+				commit.ID,
+			),
+			Builds: summarizeBuilds(statuses),
+		}
+		if commit.AuthorTimestamp > 0 {
+			detail.Date = time.UnixMilli(commit.AuthorTimestamp).UTC().Format(time.RFC3339)
+		}
+		for _, p := range commit.Parents {
+			detail.Parents = append(detail.Parents, p.ID)
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		commit, err := client.GetCommit(ctx, workspace, repoSlug, revision)
+		if err != nil {
+			return err
+		}
+		statuses, err := client.CommitStatuses(ctx, workspace, repoSlug, commit.Hash)
+		if err != nil {
+			return err
+		}
+
+		author := commit.Author.Raw
+		if commit.Author.User != nil && commit.Author.User.DisplayName != "" && author == "" {
+			author = commit.Author.User.DisplayName
+		}
+
+		detail = commitDetail{
+			Hash:    commit.Hash,
+			Author:  author,
+			Date:    commit.Date,
+			Message: commit.Message,
+			WebURL:  commit.Links.HTML.Href,
+			Builds:  summarizeBuilds(statuses),
+		}
+		for _, p := range commit.Parents {
+			detail.Parents = append(detail.Parents, p.Hash)
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	if opts.Web {
+		return cmdutil.OpenInBrowser(f, ios.Out, "commit", detail.WebURL)
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, detail, func() error {
+		return printCommitDetail(ios.Out, detail)
+	})
+}
+
+func formatAuthor(name, email string) string {
+	if email == "" {
+		return name
+	}
+	return fmt.Sprintf("%s <%s>", name, email)
+}
+
+// summarizeBuilds reduces individual build statuses to an overall state:
+// FAILED if any build failed, INPROGRESS if any is still running, SUCCESSFUL
+// when every build passed, and NONE when nothing has been reported.
+func summarizeBuilds(statuses []types.CommitStatus) buildSummary {
+	summary := buildSummary{State: "NONE", Statuses: statuses}
+	if len(statuses) == 0 {
+		summary.Statuses = []types.CommitStatus{}
+		return summary
+	}
+
+	summary.Counts = make(map[string]int)
+	for _, s := range statuses {
+		summary.Counts[strings.ToUpper(s.State)]++
+	}
+
+	switch {
+	case summary.Counts["FAILED"] > 0:
+		summary.State = "FAILED"
+	case summary.Counts["INPROGRESS"] > 0:
+		summary.State = "INPROGRESS"
+	case summary.Counts["SUCCESSFUL"] == len(statuses):
+		summary.State = "SUCCESSFUL"
+	default:
+		summary.State = "STOPPED"
+	}
+	return summary
+}
+
+func printCommitDetail(out io.Writer, d commitDetail) error {
+	if _, err := fmt.Fprintf(out, "commit %s\n", d.Hash); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "Author: %s\n", d.Author); err != nil {
+		return err
+	}
+	if d.Date != "" {
+		if _, err := fmt.Fprintf(out, "Date:   %s\n", d.Date); err != nil {
+			return err
+		}
+	}
+	if len(d.Parents) > 0 {
+		short := make([]string, len(d.Parents))
+		for i, p := range d.Parents {
+			short[i] = shortHash(p)
+		}
+		if _, err := fmt.Fprintf(out, "Parents: %s\n", strings.Join(short, " ")); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintln(out); err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.TrimRight(d.Message, "\n"), "\n") {
+		if _, err := fmt.Fprintf(out, "    %s\n", line); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(out); err != nil {
+		return err
+	}
+
+	if len(d.Builds.Statuses) == 0 {
+		_, err := fmt.Fprintln(out, "Builds: none reported")
+		return err
+	}
+
+	states := make([]string, 0, len(d.Builds.Counts))
+	for state := range d.Builds.Counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	parts := make([]string, len(states))
+	for i, state := range states {
+		parts[i] = fmt.Sprintf("%d %s", d.Builds.Counts[state], strings.ToLower(state))
+	}
+	if _, err := fmt.Fprintf(out, "Builds: %s (%s)\n", d.Builds.State, strings.Join(parts, ", ")); err != nil {
+		return err
+	}
+	for _, s := range d.Builds.Statuses {
+		if _, err := fmt.Fprintf(out, "  %s %s: %s\n", buildIcon(s.State), cmdutil.FirstNonEmpty(s.Name, s.Key), s.State); err != nil {
+			return err
+		}
+		if s.URL != "" {
+			if _, err := fmt.Fprintf(out, "      %s\n", s.URL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func buildIcon(state string) string {
+	switch strings.ToUpper(state) {
+	case "SUCCESSFUL":
+		return "✓"
+	case "FAILED":
+		return "✗"
+	case "INPROGRESS":
+		return "○"
+	default:
+		return "■"
+	}
+}
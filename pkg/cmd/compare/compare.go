@@ -0,0 +1,201 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+type options struct {
+	Workspace string
+	Repo      string
+	Stat      bool
+	Limit     int
+}
+
+// NewCmdCompare compares two refs of a repository.
+func NewCmdCompare(f *cmdutil.Factory) *cobra.Command {
+	opts := &options{Limit: 100}
+	cmd := &cobra.Command{
+		Use:   "compare <base>..<head>",
+		Short: "Show the commits and changes between two refs (Cloud)",
+		Long: `Compare two branches, tags or commits on Bitbucket Cloud without a local clone.
+
+Lists the commits on head that are not on base, the files they change and the
+full diff, as a pull request from head into base would show them. Changes made
+on base since the refs diverged are not included. "<base>...<head>" is
+accepted as well and means the same.
+
+Only the newest --limit commits are listed (100 by default, 0 for all); the
+output says when more were left out. The file list and diff are complete.`,
+		Example: `  bkt compare main..release/1.4
+  bkt compare v1.3.0..v1.4.0 --stat
+  bkt compare main..feature/login --json commits`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompare(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().BoolVar(&opts.Stat, "stat", false, "Show the changed files without the full diff")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum commits to list (0 for all)")
+
+	return cmd
+}
+
+func runCompare(cmd *cobra.Command, f *cmdutil.Factory, spec string, opts *options) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := bbcloud.ParseCompareSpec(spec); err != nil {
+		return err
+	}
+	if opts.Limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	cmp, err := client.CompareRefs(ctx, workspace, repoSlug, spec, opts.Limit)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace":         workspace,
+		"repo":              repoSlug,
+		"base":              cmp.Base,
+		"head":              cmp.Head,
+		"commits":           cmp.Commits,
+		"files":             cmp.Files,
+		"commits_truncated": cmp.CommitsTruncated,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(cmp.Commits) == 0 && len(cmp.Files) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "%s is up to date with %s.\n", cmp.Head, cmp.Base)
+			return err
+		}
+		if err := printComparison(ios.Out, cmp); err != nil {
+			return err
+		}
+		if opts.Stat || len(cmp.Files) == 0 {
+			return nil
+		}
+		if _, err := fmt.Fprintln(ios.Out); err != nil {
+			return err
+		}
+		return client.CompareDiff(ctx, workspace, repoSlug, spec, ios.Out)
+	})
+}
+
+func printComparison(out io.Writer, cmp *bbcloud.Comparison) error {
+	commits := plural(len(cmp.Commits), "commit")
+	if cmp.CommitsTruncated {
+		commits = "more than " + commits
+	}
+	if _, err := fmt.Fprintf(out, "Comparing %s..%s: %s, %s\n\n", cmp.Base, cmp.Head,
+		commits, plural(len(cmp.Files), "file")+" changed"); err != nil {
+		return err
+	}
+
+	for _, c := range cmp.Commits {
+		if _, err := fmt.Fprintf(out, "%s %s\n", shortHash(c.Hash), subject(c.Message)); err != nil {
+			return err
+		}
+	}
+	if cmp.CommitsTruncated {
+		if _, err := fmt.Fprintf(out, "... older commits not shown; raise --limit to list more\n"); err != nil {
+			return err
+		}
+	}
+	if len(cmp.Files) == 0 {
+		return nil
+	}
+	if len(cmp.Commits) > 0 {
+		if _, err := fmt.Fprintln(out); err != nil {
+			return err
+		}
+	}
+
+	added, removed := 0, 0
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, file := range cmp.Files {
+		added += file.LinesAdded
+		removed += file.LinesRemoved
+		if _, err := fmt.Fprintf(tw, " %s %s\t+%d -%d\n", statusLetter(file.Status), filePath(file), file.LinesAdded, file.LinesRemoved); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, " %s changed, %s(+), %s(-)\n",
+		plural(len(cmp.Files), "file"), plural(added, "insertion"), plural(removed, "deletion"))
+	return err
+}
+
+// statusLetter abbreviates a diffstat status the way git status --short does.
+func statusLetter(status string) string {
+	switch status {
+	case "added":
+		return "A"
+	case "removed":
+		return "D"
+	case "renamed":
+		return "R"
+	case "merge conflict":
+		return "U"
+	default:
+		return "M"
+	}
+}
+
+func filePath(file bbcloud.DiffStatEntry) string {
+	if file.Status == "renamed" && file.Old != nil && file.New != nil {
+		return file.Old.Path + " => " + file.New.Path
+	}
+	return file.Path()
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// subject returns the first line of a commit message.
+func subject(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(line)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,148 @@
+package compare
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud/bbtest"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestCommand(t *testing.T, srv *bbtest.Server) (*strings.Builder, func(args ...string) error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: srv.URL, Username: "user", Token: "token"},
+		},
+	}
+	stdout := &strings.Builder{}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return stdout, func(args ...string) error {
+		cmd := NewCmdCompare(f)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+}
+
+func TestCompareStat(t *testing.T) {
+	srv := bbtest.NewServer(t)
+	srv.Paginate("GET /repositories/team/api/commits", []map[string]any{
+		{"hash": "9ff173e2c1", "message": "Bump version to 1.4\n\nRelease notes follow."},
+		{"hash": "3a8b42d0aa", "message": "Fix login redirect"},
+	})
+	srv.Paginate("GET /repositories/team/api/diffstat/{spec}", []map[string]any{
+		{"status": "modified", "lines_added": 1, "lines_removed": 1, "old": map[string]string{"path": "VERSION"}, "new": map[string]string{"path": "VERSION"}},
+		{"status": "renamed", "lines_added": 2, "old": map[string]string{"path": "auth.go"}, "new": map[string]string{"path": "login.go"}},
+	})
+
+	stdout, run := newTestCommand(t, srv)
+	if err := run("main..release/1.4", "--stat"); err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+
+	want := `Comparing main..release/1.4: 2 commits, 2 files changed
+
+9ff173e Bump version to 1.4
+3a8b42d Fix login redirect
+
+ M VERSION              +1 -1
+ R auth.go => login.go  +2 -0
+ 2 files changed, 3 insertions(+), 1 deletion(-)
+`
+	if stdout.String() != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", stdout.String(), want)
+	}
+
+	commits := srv.RequestsFor("GET /repositories/team/api/commits")
+	if len(commits) != 1 || commits[0].Query.Get("include") != "release/1.4" || commits[0].Query.Get("exclude") != "main" {
+		t.Fatalf("commit requests = %+v", commits)
+	}
+	if len(srv.RequestsFor("GET /repositories/team/api/diff/{spec}")) != 0 {
+		t.Fatal("--stat fetched the full diff")
+	}
+}
+
+func TestCompareShowsDiff(t *testing.T) {
+	srv := bbtest.NewServer(t)
+	srv.Paginate("GET /repositories/team/api/commits", []map[string]any{
+		{"hash": "9ff173e2c1", "message": "Bump version"},
+	})
+	srv.Paginate("GET /repositories/team/api/diffstat/{spec}", []map[string]any{
+		{"status": "modified", "lines_added": 1, "lines_removed": 1, "new": map[string]string{"path": "VERSION"}},
+	})
+	const diff = "diff --git a/VERSION b/VERSION\n-1.3.0\n+1.4.0\n"
+	srv.JSON("GET /repositories/team/api/diff/{spec}", http.StatusOK, diff)
+
+	stdout, run := newTestCommand(t, srv)
+	if err := run("v1.3.0...v1.4.0"); err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if !strings.HasSuffix(stdout.String(), " 1 file changed, 1 insertion(+), 1 deletion(-)\n\n"+diff) {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+	if requests := srv.RequestsFor("GET /repositories/team/api/diff/{spec}"); len(requests) != 1 || requests[0].Path != "/repositories/team/api/diff/v1.4.0..v1.3.0" {
+		t.Fatalf("diff requests = %+v", requests)
+	}
+}
+
+func TestCompareUpToDate(t *testing.T) {
+	srv := bbtest.NewServer(t)
+	srv.Paginate("GET /repositories/team/api/commits", []map[string]any{})
+	srv.Paginate("GET /repositories/team/api/diffstat/{spec}", []map[string]any{})
+
+	stdout, run := newTestCommand(t, srv)
+	if err := run("main..develop"); err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if stdout.String() != "develop is up to date with main.\n" {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+	if err := run("main"); err == nil || !strings.Contains(err.Error(), "<base>..<head>") {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+func TestCompareLimitsCommits(t *testing.T) {
+	srv := bbtest.NewServer(t)
+	srv.Paginate("GET /repositories/team/api/commits", []map[string]any{
+		{"hash": "9ff173e2c1", "message": "Bump version"},
+		{"hash": "3a8b42d0aa", "message": "Fix login redirect"},
+		{"hash": "77e0c1ab00", "message": "Add retries"},
+	})
+	srv.Paginate("GET /repositories/team/api/diffstat/{spec}", []map[string]any{
+		{"status": "modified", "lines_added": 1, "new": map[string]string{"path": "VERSION"}},
+	})
+
+	stdout, run := newTestCommand(t, srv)
+	if err := run("main..release/1.4", "--stat", "--limit", "2"); err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	out := stdout.String()
+	if !strings.HasPrefix(out, "Comparing main..release/1.4: more than 2 commits, 1 file changed\n") {
+		t.Fatalf("stdout = %q", out)
+	}
+	if strings.Contains(out, "Add retries") || !strings.Contains(out, "older commits not shown") {
+		t.Fatalf("stdout = %q", out)
+	}
+	if requests := srv.RequestsFor("GET /repositories/team/api/commits"); len(requests) != 1 || requests[0].Query.Get("pagelen") != "3" {
+		t.Fatalf("commit requests = %+v", requests)
+	}
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdConfig returns the config command tree.
+func NewCmdConfig(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and write bkt settings",
+		Long: `Read and write settings such as the default workspace, the preferred merge
+strategy and the editor.
+
+Settings are read from three files, later ones taking precedence:
+
+  system  /etc/bkt/config.yml (%ProgramData%\bkt\config.yml on Windows)
+  user    config.yml in the bkt config directory (BKT_CONFIG_DIR)
+  repo    .bkt.yml at the root of the current git repository
+
+Flags always win over settings, and a context's own workspace or project key
+wins over the workspace and project settings. The editor setting is only read
+from the user file so a cloned repository cannot choose a program to run.`,
+	}
+
+	cmd.AddCommand(newGetCmd(f))
+	cmd.AddCommand(newSetCmd(f))
+	cmd.AddCommand(newListCmd(f))
+
+	return cmd
+}
+
+func completeKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, s := range config.Settings() {
+		keys = append(keys, s.Key+"\t"+s.Description)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func lookupKey(key string) (config.Setting, error) {
+	s, ok := config.FindSetting(key)
+	if !ok {
+		var keys []string
+		for _, s := range config.Settings() {
+			keys = append(keys, s.Key)
+		}
+		return config.Setting{}, fmt.Errorf("unknown config key %q (available: %s)", key, strings.Join(keys, ", "))
+	}
+	return s, nil
+}
+
+func newGetCmd(f *cmdutil.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print the effective value of a setting",
+		Example:           `  bkt config get merge_strategy`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGet(cmd, f, args[0])
+		},
+	}
+}
+
+func runGet(cmd *cobra.Command, f *cmdutil.Factory, key string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if _, err := lookupKey(key); err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	value, layer := cfg.LookupSetting(key)
+	payload := map[string]any{
+		"key":    key,
+		"value":  value,
+		"source": layer,
+	}
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if value == "" {
+			return nil
+		}
+		_, err := fmt.Fprintln(ios.Out, value)
+		return err
+	})
+}
+
+type setOptions struct {
+	Local bool
+}
+
+func newSetCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &setOptions{}
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting",
+		Long: `Store a setting in the user config file, or with --local in the .bkt.yml of
+the current repository so it applies to everyone working on it. An empty value
+removes the setting from that file.`,
+		Example: `  bkt config set editor "code --wait"
+  bkt config set merge_strategy squash --local
+  bkt config set workspace ""`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(cmd, f, opts, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Local, "local", false, "Write the repository's .bkt.yml instead of the user config")
+
+	return cmd
+}
+
+func runSet(cmd *cobra.Command, f *cmdutil.Factory, opts *setOptions, key, value string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if _, err := lookupKey(key); err != nil {
+		return err
+	}
+	value = strings.TrimSpace(value)
+
+	var path string
+	if opts.Local {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		if path, err = config.SetRepoSetting(wd, key, value); err != nil {
+			return err
+		}
+	} else {
+		cfg, err := f.ResolveConfig()
+		if err != nil {
+			return err
+		}
+		if err := cfg.SetSetting(key, value); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		path = cfg.Path()
+
+		if _, layer := cfg.LookupSetting(key); value != "" && layer == config.LayerRepo {
+			if _, err := fmt.Fprintf(ios.ErrOut, "! %s in %s overrides this value in the current repository\n", key, cfg.LayerPath(config.LayerRepo)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if value == "" {
+		_, err = fmt.Fprintf(ios.Out, "✓ Removed %s from %s\n", key, path)
+		return err
+	}
+	_, err = fmt.Fprintf(ios.Out, "✓ Set %s to %q in %s\n", key, value, path)
+	return err
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List settings with their values and sources",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f)
+		},
+	}
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	type settingSummary struct {
+		Key         string       `json:"key"`
+		Value       string       `json:"value"`
+		Source      config.Layer `json:"source"`
+		Path        string       `json:"path,omitempty"`
+		Description string       `json:"description"`
+	}
+	var summaries []settingSummary
+	for _, s := range config.Settings() {
+		value, layer := cfg.LookupSetting(s.Key)
+		summaries = append(summaries, settingSummary{
+			Key:         s.Key,
+			Value:       value,
+			Source:      layer,
+			Path:        cfg.LayerPath(layer),
+			Description: s.Description,
+		})
+	}
+
+	payload := map[string]any{"settings": summaries}
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		if _, err := fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE"); err != nil {
+			return err
+		}
+		for _, s := range summaries {
+			value, source := s.Value, string(s.Source)
+			if value == "" {
+				value, source = "-", ""
+			}
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Key, value, source); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
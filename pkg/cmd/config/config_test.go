@@ -0,0 +1,114 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestRoot(f *cmdutil.Factory) *cobra.Command {
+	root := &cobra.Command{Use: "bkt", SilenceErrors: true, SilenceUsage: true}
+	root.PersistentFlags().String("json", "", "")
+	root.PersistentFlags().Lookup("json").NoOptDefVal = "true"
+	root.AddCommand(NewCmdConfig(f))
+	return root
+}
+
+func TestConfigSetGetList(t *testing.T) {
+	t.Setenv("BKT_CONFIG_DIR", t.TempDir())
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+
+	cfg := &config.Config{}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config:         func() (*config.Config, error) { return cfg, nil },
+	}
+
+	run := func(args ...string) error {
+		stdout.Reset()
+		root := newTestRoot(f)
+		root.SetArgs(append([]string{"config"}, args...))
+		return root.Execute()
+	}
+
+	if err := run("set", "merge_strategy", "squash"); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	if cfg.MergeStrategy != "squash" {
+		t.Fatalf("merge_strategy = %q", cfg.MergeStrategy)
+	}
+	if _, err := os.Stat(cfg.Path()); err != nil {
+		t.Fatalf("config not saved: %v", err)
+	}
+
+	if err := run("get", "merge_strategy"); err != nil {
+		t.Fatalf("config get: %v", err)
+	}
+	if got := stdout.String(); got != "squash\n" {
+		t.Fatalf("config get = %q", got)
+	}
+
+	if err := run("set", "pager", "sometimes"); err == nil {
+		t.Fatal("expected invalid pager value to be rejected")
+	}
+	if err := run("get", "colour"); err == nil || !strings.Contains(err.Error(), "available: workspace") {
+		t.Fatalf("config get unknown = %v", err)
+	}
+
+	if err := run("set", "workspace", "acme", "--local"); err != nil {
+		t.Fatalf("config set --local: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(repo, config.RepoConfigName))
+	if err != nil || !strings.Contains(string(data), "workspace: acme") {
+		t.Fatalf(".bkt.yml = %q, %v", data, err)
+	}
+	if err := run("set", "editor", "vim", "--local"); err == nil {
+		t.Fatal("expected editor to be rejected for --local")
+	}
+
+	if err := run("list"); err != nil {
+		t.Fatalf("config list: %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "KEY") || !strings.Contains(out, "merge_strategy  squash") || !strings.Contains(out, "user") {
+		t.Fatalf("config list output:\n%s", out)
+	}
+
+	if err := run("list", "--json"); err != nil {
+		t.Fatalf("config list --json: %v", err)
+	}
+	var payload struct {
+		Settings []struct {
+			Key    string `json:"key"`
+			Value  string `json:"value"`
+			Source string `json:"source"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal([]byte(stdout.String()), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if len(payload.Settings) != len(config.Settings()) || payload.Settings[2].Key != "merge_strategy" || payload.Settings[2].Source != "user" {
+		t.Fatalf("unexpected settings %+v", payload.Settings)
+	}
+
+	if err := run("set", "merge_strategy", ""); err != nil {
+		t.Fatalf("config set empty: %v", err)
+	}
+	if cfg.MergeStrategy != "" || !strings.Contains(stdout.String(), "Removed merge_strategy") {
+		t.Fatalf("merge_strategy = %q, output %q", cfg.MergeStrategy, stdout.String())
+	}
+}
@@ -89,6 +89,16 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, name string, opts *create
 	ctx := &config.Context{
 		Host:        hostKey,
 		DefaultRepo: strings.TrimSpace(opts.Repo),
+		// The global --profile pins the new context to that identity.
+		Profile: strings.TrimSpace(f.Profile),
+	}
+	if ctx.Profile == config.DefaultProfile {
+		ctx.Profile = ""
+	}
+	if ctx.Profile != "" {
+		if _, err := host.WithProfile(ctx.Profile); err != nil {
+			return fmt.Errorf("%w for host %s; run `%s auth login --profile %s` first", err, hostKey, f.ExecutableName, ctx.Profile)
+		}
 	}
 
 	switch host.Kind {
@@ -195,6 +205,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory) error {
 		ProjectKey  string `json:"project_key,omitempty"`
 		Workspace   string `json:"workspace,omitempty"`
 		DefaultRepo string `json:"default_repo,omitempty"`
+		Profile     string `json:"profile,omitempty"`
 		Active      bool   `json:"active"`
 	}
 
@@ -213,6 +224,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory) error {
 			ProjectKey:  ctx.ProjectKey,
 			Workspace:   ctx.Workspace,
 			DefaultRepo: ctx.DefaultRepo,
+			Profile:     ctx.Profile,
 			Active:      cfg.ActiveContext == name,
 		})
 	}
@@ -254,6 +266,11 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory) error {
 					return err
 				}
 			}
+			if ctx.Profile != "" {
+				if _, err := fmt.Fprintf(ios.Out, "    profile: %s\n", ctx.Profile); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -0,0 +1,296 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/progress"
+)
+
+// transferTimeout bounds uploads and downloads, which are exempt from the
+// regular per-request timeout because artifacts can be large.
+const transferTimeout = time.Hour
+
+// NewCmdDownload exposes the repository Downloads section.
+func NewCmdDownload(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "download",
+		Aliases: []string{"downloads"},
+		Short:   "Manage repository downloads (Cloud)",
+		Long: `Upload, list and fetch files in a repository's Downloads section, typically
+release artifacts. Files are streamed, so large artifacts are never held in
+memory.`,
+	}
+
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newUploadCmd(f))
+	cmd.AddCommand(newGetCmd(f))
+
+	return cmd
+}
+
+type listOptions struct {
+	Workspace string
+	Repo      string
+	Limit     int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List repository downloads",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum downloads to list (0 for all)")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	downloads, err := client.ListDownloads(ctx, workspace, repoSlug, opts.Limit)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"repo":      repoSlug,
+		"downloads": downloads,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(downloads) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No downloads in %s/%s.\n", workspace, repoSlug)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, d := range downloads {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Name, humanSize(d.Size), d.CreatedOn); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+type uploadOptions struct {
+	Workspace string
+	Repo      string
+}
+
+func newUploadCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &uploadOptions{}
+	cmd := &cobra.Command{
+		Use:   "upload <file>...",
+		Short: "Upload files to the Downloads section",
+		Long: `Upload files to the repository's Downloads section. A file with the same name
+replaces the existing download.`,
+		Example: `  bkt download upload dist/app-1.4.0.tar.gz
+  bkt download upload dist/*.zip --repo api`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpload(cmd, f, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+
+	return cmd
+}
+
+func runUpload(cmd *cobra.Command, f *cmdutil.Factory, paths []string, opts *uploadOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	// Validate every path before uploading anything.
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("cannot access file %s: %w", path, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("cannot upload directory: %s", path)
+		}
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, transferTimeout)
+	defer cancel()
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("cannot access file %s: %w", path, err)
+		}
+		name := filepath.Base(path)
+
+		bar := f.ProgressBar("Uploading "+name, info.Size())
+		err = client.UploadDownloadFrom(ctx, workspace, repoSlug, name, info.Size(), func() (io.ReadCloser, error) {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			bar.Reset()
+			return progress.NewReader(file, bar), nil
+		})
+		if err != nil {
+			bar.Fail("")
+			return fmt.Errorf("failed to upload %s: %w", path, err)
+		}
+		bar.Stop("")
+		if _, err := fmt.Fprintf(ios.Out, "✓ Uploaded %s\n", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type getOptions struct {
+	Workspace string
+	Repo      string
+	Output    string
+	Clobber   bool
+}
+
+func newGetCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &getOptions{}
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Fetch a file from the Downloads section",
+		Example: `  bkt download get app-1.4.0.tar.gz
+  bkt download get app-1.4.0.tar.gz -o /tmp/app.tar.gz
+  bkt download get checksums.txt -o -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGet(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Destination path, or - for stdout (default: the file name)")
+	cmd.Flags().BoolVar(&opts.Clobber, "clobber", false, "Overwrite an existing file")
+
+	return cmd
+}
+
+func runGet(cmd *cobra.Command, f *cmdutil.Factory, name string, opts *getOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, transferTimeout)
+	defer cancel()
+
+	if opts.Output == "-" {
+		// No progress bar: it would interleave with the artifact when
+		// stdout and stderr share a terminal.
+		return client.GetDownload(ctx, workspace, repoSlug, name, ios.Out)
+	}
+
+	dest := cmdutil.FirstNonEmpty(opts.Output, filepath.Base(name))
+	if !opts.Clobber {
+		if _, err := os.Stat(dest); err == nil {
+			return fmt.Errorf("%s already exists; use --clobber to overwrite", dest)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	// Write to a temporary file beside the destination so an interrupted
+	// transfer never leaves a truncated artifact behind.
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	bar := f.ProgressBar("Downloading "+name, 0)
+	if err := client.GetDownload(ctx, workspace, repoSlug, name, progress.NewWriter(tmp, bar)); err != nil {
+		bar.Fail("")
+		_ = tmp.Close()
+		return err
+	}
+	bar.Stop("")
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Downloaded %s to %s\n", name, dest)
+	return err
+}
+
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,111 @@
+package download
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, baseURL string) (*cmdutil.Factory, *strings.Builder, *strings.Builder) {
+	t.Helper()
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout, &stderr
+}
+
+func TestGetWritesFileAndRefusesClobber(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/team/api/downloads/app.zip" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte("zip-bytes"))
+	}))
+	t.Cleanup(server.Close)
+
+	dest := filepath.Join(t.TempDir(), "out.zip")
+	f, stdout, _ := newTestFactory(t, server.URL)
+
+	cmd := NewCmdDownload(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"get", "app.zip", "-o", dest})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("download get: %v", err)
+	}
+
+	data, err := os.ReadFile(dest)
+	if err != nil || string(data) != "zip-bytes" {
+		t.Fatalf("unexpected file %q (%v)", data, err)
+	}
+	if !strings.Contains(stdout.String(), "✓ Downloaded app.zip") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+
+	cmd = NewCmdDownload(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"get", "app.zip", "-o", dest})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--clobber") {
+		t.Fatalf("expected clobber error, got %v", err)
+	}
+}
+
+func TestGetToStdoutWritesOnlyTheArtifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("zip-bytes"))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout, stderr := newTestFactory(t, server.URL)
+
+	cmd := NewCmdDownload(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"get", "app.zip", "-o", "-"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("download get: %v", err)
+	}
+
+	if stdout.String() != "zip-bytes" {
+		t.Fatalf("unexpected stdout %q", stdout.String())
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no progress output, got %q", stderr.String())
+	}
+}
+
+func TestHumanSize(t *testing.T) {
+	cases := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for in, want := range cases {
+		if got := humanSize(in); got != want {
+			t.Errorf("humanSize(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package extension
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// Find locates the executable for the extension name. An extension installed
+// with `bkt extension install` wins over a bkt-<name> executable on PATH; dir
+// is empty for the latter.
+func Find(f *cmdutil.Factory, name string) (execPath, dir string, err error) {
+	if root, err := extensionRoot(f); err == nil {
+		candidate := filepath.Join(root, name)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			execPath, err := findExtensionExecutable(candidate, name)
+			if err != nil {
+				return "", "", err
+			}
+			return execPath, candidate, nil
+		}
+	}
+
+	execPath, err = exec.LookPath("bkt-" + name)
+	if err != nil {
+		return "", "", fmt.Errorf("extension %q is not installed", name)
+	}
+	return execPath, "", nil
+}
+
+// Dispatch runs the extension named by args[0] when it is not a built-in
+// command. It reports false when no such extension exists so the caller can
+// fall through to normal command handling.
+func Dispatch(root *cobra.Command, f *cmdutil.Factory, args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || cmdutil.IsBuiltinCommand(root, args[0]) {
+		return false, nil
+	}
+
+	name := args[0]
+	execPath, dir, err := Find(f, name)
+	if err != nil {
+		return false, nil
+	}
+	return true, run(root, f, name, execPath, dir, args[1:])
+}
+
+func run(cmd *cobra.Command, f *cmdutil.Factory, name, execPath, dir string, args []string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	ext := exec.CommandContext(cmd.Context(), execPath, args...)
+	ext.Stdout = ios.Out
+	ext.Stderr = ios.ErrOut
+	ext.Stdin = ios.In
+	if dir != "" {
+		ext.Dir = dir
+	}
+	ext.Env = append(os.Environ(), extensionEnv(cmd, f, name, dir)...)
+
+	if err := ext.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &cmdutil.ExitError{Code: exitErr.ExitCode()}
+		}
+		return fmt.Errorf("run extension %q: %w", name, err)
+	}
+	return nil
+}
+
+// extensionEnv describes the active context to an extension so it can call
+// the Bitbucket API without its own login. Auth variables are omitted when no
+// context resolves.
+func extensionEnv(cmd *cobra.Command, f *cmdutil.Factory, name, dir string) []string {
+	env := []string{"BKT_EXTENSION_NAME=" + name}
+	if dir != "" {
+		env = append(env, "BKT_EXTENSION_DIR="+dir)
+	}
+	if self, err := os.Executable(); err == nil {
+		env = append(env, "BKT_EXECUTABLE="+self)
+	}
+
+	contextName, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return env
+	}
+
+	// The host, credentials and workspace use the names bkt itself reads, so
+	// an extension calling $BKT_EXECUTABLE reaches the same host as the same
+	// user.
+	env = append(env,
+		"BKT_CONTEXT="+contextName,
+		cmdutil.HostEnv+"="+host.BaseURL,
+		"BKT_HOST_KIND="+host.Kind,
+		cmdutil.WorkspaceEnv+"="+ctxCfg.Workspace,
+		"BKT_PROJECT="+ctxCfg.ProjectKey,
+		"BKT_REPO="+ctxCfg.DefaultRepo,
+	)
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	token, err := cmdutil.AccessToken(ctx, host)
+	if err != nil || token == "" {
+		return env
+	}
+	env = append(env, cmdutil.TokenEnv+"="+token, cmdutil.UsernameEnv+"="+host.Username)
+	if host.AuthMethod == config.AuthMethodOAuth || host.AuthMethod == config.AuthMethodBearer {
+		env = append(env, cmdutil.AuthMethodEnv+"="+config.AuthMethodBearer, "BKT_AUTHORIZATION=Bearer "+token)
+	} else {
+		basic := base64.StdEncoding.EncodeToString([]byte(host.Username + ":" + token))
+		env = append(env, cmdutil.AuthMethodEnv+"=token", "BKT_AUTHORIZATION=Basic "+basic)
+	}
+	return env
+}
+
+// pathExtensions lists bkt-<name> executables found on PATH, keyed by name
+// and keeping the first match in PATH order.
+func pathExtensions() map[string]string {
+	found := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), "bkt-") || !isExecutable(entry) {
+				continue
+			}
+			name := strings.TrimPrefix(entry.Name(), "bkt-")
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if _, ok := found[name]; !ok && name != "" {
+				found[name] = filepath.Join(dir, entry.Name())
+			}
+		}
+	}
+	return found
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,143 @@
+package extension
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/oauth"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud/bbtest"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func TestDispatchRunsPathExtensionWithContextEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script extensions are not executable on Windows")
+	}
+
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"$BKT_EXTENSION_NAME|$BKT_HOST_KIND|$BITBUCKET_WORKSPACE|$BKT_REPO|$BKT_AUTHORIZATION|$*\"\nexit 4\n"
+	if err := os.WriteFile(filepath.Join(bin, "bkt-hello"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	t.Setenv("BKT_CONFIG_DIR", t.TempDir())
+
+	cfg := &config.Config{
+		ActiveContext: "team",
+		Contexts: map[string]*config.Context{
+			"team": {Host: "api.bitbucket.org", Workspace: "acme", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"api.bitbucket.org": {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Username: "me", Token: "secret"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config:         func() (*config.Config, error) { return cfg, nil },
+	}
+	root := &cobra.Command{Use: "bkt"}
+	root.AddCommand(NewCmdExtension(f))
+	root.SetContext(context.Background())
+
+	if handled, _ := Dispatch(root, f, []string{"extension", "list"}); handled {
+		t.Fatal("built-in command must not be dispatched to an extension")
+	}
+	if handled, _ := Dispatch(root, f, []string{"missing"}); handled {
+		t.Fatal("unknown extension must not be handled")
+	}
+
+	handled, err := Dispatch(root, f, []string{"hello", "a", "b"})
+	if !handled {
+		t.Fatal("expected bkt-hello to be dispatched")
+	}
+	var exitErr *cmdutil.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 4 {
+		t.Fatalf("expected exit code 4, got %v", err)
+	}
+
+	want := "hello|cloud|acme|api|Basic bWU6c2VjcmV0|a b\n"
+	if got := stdout.String(); got != want {
+		t.Fatalf("unexpected extension output %q, want %q", got, want)
+	}
+}
+
+func TestExtensionEnvResolvesToSameHostAndUser(t *testing.T) {
+	t.Setenv("BKT_CONFIG_DIR", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	srv := bbtest.NewServer(t)
+	srv.Handle("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer access" {
+			t.Errorf("Authorization = %q, want the OAuth access token as a bearer token", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uuid":"{me}"}`))
+	})
+
+	hostKey, err := cmdutil.HostKeyFromURL(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds, err := oauth.Credentials{Token: oauth.Token{AccessToken: "access", Expiry: time.Now().Add(time.Hour)}}.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		ActiveContext: "team",
+		Contexts: map[string]*config.Context{
+			"team": {Host: hostKey, Workspace: "acme", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			hostKey: {Kind: "cloud", BaseURL: srv.URL, Username: "me", AuthMethod: config.AuthMethodOAuth, Token: creds},
+		},
+	}
+	f := &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &strings.Builder{}, ErrOut: &strings.Builder{}},
+		Config:         func() (*config.Config, error) { return cfg, nil },
+	}
+	cmd := &cobra.Command{Use: "hello"}
+	cmd.SetContext(context.Background())
+
+	// A nested $BKT_EXECUTABLE call sees the extension's environment and the
+	// same config file, but no active context of its own.
+	for _, kv := range extensionEnv(cmd, f, "hello", "") {
+		key, value, _ := strings.Cut(kv, "=")
+		t.Setenv(key, value)
+	}
+	nested := &config.Config{Hosts: cfg.Hosts}
+	f = &cmdutil.Factory{
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &strings.Builder{}, ErrOut: &strings.Builder{}},
+		Config:         func() (*config.Config, error) { return nested, nil },
+	}
+
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, "")
+	if err != nil {
+		t.Fatalf("ResolveContext: %v", err)
+	}
+	if ctxCfg.Host != hostKey || ctxCfg.Workspace != "acme" {
+		t.Fatalf("context = %+v", ctxCfg)
+	}
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CurrentUser(context.Background()); err != nil {
+		t.Fatalf("CurrentUser: %v", err)
+	}
+}
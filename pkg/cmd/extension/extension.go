@@ -20,6 +20,15 @@ func NewCmdExtension(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "extension",
 		Short: "Manage bkt CLI extensions",
+		Long: `Extensions are executables named bkt-<name>. They are either installed
+with "bkt extension install" or discovered on PATH, and run as "bkt <name>".
+
+Extensions receive the active context in the environment: BKT_CONTEXT,
+BKT_HOST, BKT_HOST_KIND, BITBUCKET_WORKSPACE, BKT_PROJECT, BKT_REPO,
+BKT_USERNAME, BKT_TOKEN, BKT_AUTH_METHOD and BKT_AUTHORIZATION (a ready-made
+Authorization header value), plus BKT_EXTENSION_NAME, BKT_EXTENSION_DIR and
+BKT_EXECUTABLE. Running $BKT_EXECUTABLE from an extension reuses the same host
+and credentials.`,
 	}
 
 	cmd.AddCommand(newInstallCmd(f))
@@ -150,6 +159,7 @@ func runExtensionList(cmd *cobra.Command, f *cmdutil.Factory) error {
 		Name       string `json:"name"`
 		Path       string `json:"path"`
 		Executable string `json:"executable,omitempty"`
+		Source     string `json:"source"` // installed | path
 	}
 
 	var summaries []extensionSummary
@@ -168,6 +178,20 @@ func runExtensionList(cmd *cobra.Command, f *cmdutil.Factory) error {
 			Name:       name,
 			Path:       dir,
 			Executable: rel,
+			Source:     "installed",
+		})
+	}
+
+	onPath := pathExtensions()
+	for _, name := range sortedKeys(onPath) {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			continue
+		}
+		summaries = append(summaries, extensionSummary{
+			Name:       name,
+			Path:       onPath[name],
+			Executable: filepath.Base(onPath[name]),
+			Source:     "path",
 		})
 	}
 
@@ -189,6 +213,9 @@ func runExtensionList(cmd *cobra.Command, f *cmdutil.Factory) error {
 			if ext.Executable != "" {
 				line = fmt.Sprintf("%s\t%s", ext.Name, ext.Executable)
 			}
+			if ext.Source == "path" {
+				line = fmt.Sprintf("%s\t%s (PATH)", ext.Name, ext.Path)
+			}
 			if _, err := fmt.Fprintln(ios.Out, line); err != nil {
 				return err
 			}
@@ -226,40 +253,11 @@ func runExtensionRemove(cmd *cobra.Command, f *cmdutil.Factory, name string) err
 }
 
 func runExtensionExec(cmd *cobra.Command, f *cmdutil.Factory, name string, args []string) error {
-	ios, err := f.Streams()
-	if err != nil {
-		return err
-	}
-
-	root, err := extensionRoot(f)
+	execPath, dir, err := Find(f, name)
 	if err != nil {
 		return err
 	}
-
-	dir := filepath.Join(root, name)
-	if _, err := os.Stat(dir); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return fmt.Errorf("extension %q is not installed", name)
-		}
-		return err
-	}
-
-	execPath, err := findExtensionExecutable(dir, name)
-	if err != nil {
-		return err
-	}
-
-	cmdExec := exec.CommandContext(cmd.Context(), execPath, args...)
-	cmdExec.Stdout = ios.Out
-	cmdExec.Stderr = ios.ErrOut
-	cmdExec.Stdin = ios.In
-	cmdExec.Dir = dir
-	cmdExec.Env = append(os.Environ(),
-		fmt.Sprintf("BKT_EXTENSION_DIR=%s", dir),
-		fmt.Sprintf("BKT_EXTENSION_NAME=%s", name),
-	)
-
-	return cmdExec.Run()
+	return run(cmd, f, name, execPath, dir, args)
 }
 
 func extensionRoot(f *cmdutil.Factory) (string, error) {
@@ -1,6 +1,8 @@
 package factory
 
 import (
+	"fmt"
+
 	"github.com/alessandro308/bitbucket-cli/internal/config"
 	"github.com/alessandro308/bitbucket-cli/pkg/browser"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
@@ -26,7 +28,16 @@ func New(appVersion string) (*cmdutil.Factory, error) {
 	f.Spinner = progress.NewSpinner(ios)
 
 	f.Config = func() (*config.Config, error) {
-		return config.Load()
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range cfg.Warnings() {
+			if _, err := fmt.Fprintf(ios.ErrOut, "! %s\n", warning); err != nil {
+				return nil, err
+			}
+		}
+		return cfg, nil
 	}
 
 	return f, nil
@@ -0,0 +1,323 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdFile exposes repository file browsing without a local clone.
+func NewCmdFile(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "file",
+		Short: "Read repository files without cloning",
+	}
+
+	cmd.AddCommand(newCatCmd(f))
+	cmd.AddCommand(newListCmd(f))
+
+	return cmd
+}
+
+type catOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+}
+
+func newCatCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &catOptions{}
+	cmd := &cobra.Command{
+		Use:   "cat <[ref:]path>",
+		Short: "Print a file at a branch, tag or commit",
+		Long: `Print the raw content of a repository file. Prefix the path with a branch,
+tag or commit and a colon to read it at that ref; without a prefix the default
+branch is used.`,
+		Example: `  bkt file cat main:cmd/root.go
+  bkt file cat v1.4.0:go.mod
+  bkt file cat README.md --repo api > README.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, path := parseRefPath(args[0])
+			return runCat(cmd, f, ref, path, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+
+	return cmd
+}
+
+func runCat(cmd *cobra.Command, f *cmdutil.Factory, ref, path string, opts *catOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if strings.Trim(path, "/") == "" {
+		return fmt.Errorf("file path is required")
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 2*time.Minute)
+	defer cancel()
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		return client.GetFileContent(ctx, projectKey, repoSlug, ref, path, ios.Out)
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		if ref == "" {
+			ref, err = cloudMainBranch(ctx, client, workspace, repoSlug)
+			if err != nil {
+				return err
+			}
+		}
+
+		return client.GetFileContent(ctx, workspace, repoSlug, ref, path, ios.Out)
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+type listOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Recursive bool
+	Limit     int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+	cmd := &cobra.Command{
+		Use:     "ls <ref> [path]",
+		Aliases: []string{"list"},
+		Short:   "List files and directories at a ref",
+		Long: `List the contents of a repository directory at a branch, tag or commit,
+like "ls" on a checkout. Without a path the repository root is listed.
+
+With --recursive every file below the directory is listed. Data Center only
+reports file paths in this mode, so directories and sizes are omitted there.`,
+		Example: `  bkt file ls main
+  bkt file ls v1.4.0 cmd/bkt
+  bkt file ls main docs --recursive --json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) > 1 {
+				path = args[1]
+			}
+			return runList(cmd, f, args[0], path, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().BoolVarP(&opts.Recursive, "recursive", "r", false, "List subdirectories recursively")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum entries to list (0 for all)")
+
+	return cmd
+}
+
+// treeRow is the display form shared by Cloud and Data Center entries.
+type treeRow struct {
+	Dir  bool
+	Kind string
+	Size int64
+	Path string
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, ref, path string, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return fmt.Errorf("ref is required")
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, time.Minute)
+	defer cancel()
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		entries, err := client.ListDirectory(ctx, projectKey, repoSlug, ref, path, bbdc.ListDirectoryOptions{
+			Recursive: opts.Recursive,
+			Limit:     opts.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"project": projectKey,
+			"repo":    repoSlug,
+			"ref":     ref,
+			"path":    path,
+			"entries": entries,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			rows := make([]treeRow, 0, len(entries))
+			for _, e := range entries {
+				rows = append(rows, treeRow{Dir: e.IsDir(), Kind: entryKind(e.Type), Size: e.Size, Path: e.Path})
+			}
+			return printTree(ios.Out, rows)
+		})
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		entries, err := client.ListDirectory(ctx, workspace, repoSlug, ref, path, bbcloud.ListDirectoryOptions{
+			Recursive: opts.Recursive,
+			Limit:     opts.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"workspace": workspace,
+			"repo":      repoSlug,
+			"ref":       ref,
+			"path":      path,
+			"entries":   entries,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			rows := make([]treeRow, 0, len(entries))
+			for _, e := range entries {
+				rows = append(rows, treeRow{Dir: e.IsDir(), Kind: entryKind(e.Type), Size: e.Size, Path: e.Path})
+			}
+			return printTree(ios.Out, rows)
+		})
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+func printTree(out io.Writer, rows []treeRow) error {
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(out, "No files found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		size, path := "-", row.Path
+		if row.Dir {
+			path += "/"
+		} else if row.Size > 0 {
+			size = fmt.Sprintf("%d", row.Size)
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", row.Kind, size, path); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// entryKind maps Cloud (commit_file, commit_directory, commit_link) and Data
+// Center (FILE, DIRECTORY, SUBMODULE) entry types to short display names.
+func entryKind(t string) string {
+	switch strings.ToLower(strings.TrimPrefix(t, "commit_")) {
+	case "directory":
+		return "dir"
+	case "link", "submodule":
+		return "submodule"
+	default:
+		return "file"
+	}
+}
+
+// parseRefPath splits "ref:path" into its parts. A spec without a colon is a
+// path on the default branch.
+func parseRefPath(spec string) (ref, path string) {
+	if ref, path, ok := strings.Cut(spec, ":"); ok {
+		return strings.TrimSpace(ref), path
+	}
+	return "", spec
+}
+
+// cloudMainBranch looks up the repository's main branch, since the Cloud
+// source endpoint always needs an explicit ref.
+func cloudMainBranch(ctx context.Context, client *bbcloud.Client, workspace, repoSlug string) (string, error) {
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return "", err
+	}
+	if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+		return "", fmt.Errorf("repository %s/%s has no main branch; pass <ref>:<path>", workspace, repoSlug)
+	}
+	return repo.MainBranch.Name, nil
+}
@@ -0,0 +1,195 @@
+package file
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, host *config.Host, ctx *config.Context) (*cmdutil.Factory, *strings.Builder) {
+	t.Helper()
+	ctx.Host = "main"
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts:      map[string]*config.Context{"default": ctx},
+		Hosts:         map[string]*config.Host{"main": host},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout
+}
+
+func execute(t *testing.T, f *cmdutil.Factory, args ...string) error {
+	t.Helper()
+	cmd := NewCmdFile(f)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestCatCloudAtRef(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/team/api/src/main/cmd/root.go" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte("package cmd\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t,
+		&config.Host{Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		&config.Context{Workspace: "team", DefaultRepo: "api"},
+	)
+	if err := execute(t, f, "cat", "main:cmd/root.go"); err != nil {
+		t.Fatalf("file cat: %v", err)
+	}
+	if stdout.String() != "package cmd\n" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCatCloudDefaultsToMainBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/team/api":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"slug":"api","mainbranch":{"name":"develop"}}`))
+		case "/repositories/team/api/src/develop/README.md":
+			_, _ = w.Write([]byte("# api\n"))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t,
+		&config.Host{Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		&config.Context{Workspace: "team", DefaultRepo: "api"},
+	)
+	if err := execute(t, f, "cat", "README.md"); err != nil {
+		t.Fatalf("file cat: %v", err)
+	}
+	if stdout.String() != "# api\n" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCatDataCenter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/1.0/projects/PRJ/repos/api/raw/docs/guide.md" || r.URL.Query().Get("at") != "release/1.0" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte("guide"))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t,
+		&config.Host{Kind: "dc", BaseURL: server.URL, Username: "user", Token: "token"},
+		&config.Context{ProjectKey: "PRJ", DefaultRepo: "api"},
+	)
+	if err := execute(t, f, "cat", "release/1.0:docs/guide.md"); err != nil {
+		t.Fatalf("file cat: %v", err)
+	}
+	if stdout.String() != "guide" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestParseRefPath(t *testing.T) {
+	cases := []struct{ spec, ref, path string }{
+		{"main:cmd/root.go", "main", "cmd/root.go"},
+		{"README.md", "", "README.md"},
+		{"v1.0:", "v1.0", ""},
+	}
+	for _, tc := range cases {
+		ref, path := parseRefPath(tc.spec)
+		if ref != tc.ref || path != tc.path {
+			t.Errorf("parseRefPath(%q) = %q, %q", tc.spec, ref, path)
+		}
+	}
+}
+
+func TestListCloudRecursive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/repositories/team/api/src/main/cmd/" || r.URL.Query().Get("max_depth") == "" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"values":[
+			{"path":"cmd/bkt","type":"commit_directory","commit":{"hash":"abc"}},
+			{"path":"cmd/doc.go","type":"commit_file","size":42,"commit":{"hash":"abc"}},
+			{"path":"cmd/bkt/main.go","type":"commit_file","size":120,"commit":{"hash":"abc"}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t,
+		&config.Host{Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		&config.Context{Workspace: "team", DefaultRepo: "api"},
+	)
+	if err := execute(t, f, "ls", "main", "cmd", "-r"); err != nil {
+		t.Fatalf("file ls: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 entries, got %q", stdout.String())
+	}
+	if !strings.HasPrefix(lines[0], "dir") || !strings.HasSuffix(lines[0], "cmd/bkt/") {
+		t.Fatalf("unexpected directory row %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "120") || !strings.HasSuffix(lines[2], "cmd/bkt/main.go") {
+		t.Fatalf("unexpected nested row %q", lines[2])
+	}
+}
+
+func TestListDataCenterBrowse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/1.0/projects/PRJ/repos/api/browse/docs" || r.URL.Query().Get("at") != "main" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"children":{"isLastPage":true,"values":[
+			{"path":{"toString":"images"},"type":"DIRECTORY"},
+			{"path":{"toString":"guide.md"},"type":"FILE","size":300}
+		]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t,
+		&config.Host{Kind: "dc", BaseURL: server.URL, Username: "user", Token: "token"},
+		&config.Context{ProjectKey: "PRJ", DefaultRepo: "api"},
+	)
+	if err := execute(t, f, "ls", "main", "docs", "--json"); err != nil {
+		t.Fatalf("file ls: %v", err)
+	}
+
+	var payload struct {
+		Entries []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+			Size int64  `json:"size"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(stdout.String()), &payload); err != nil {
+		t.Fatalf("decode: %v (%s)", err, stdout.String())
+	}
+	if len(payload.Entries) != 2 || payload.Entries[0].Path != "docs/images" || payload.Entries[1].Size != 300 {
+		t.Fatalf("unexpected entries %+v", payload.Entries)
+	}
+}
@@ -1,8 +1,8 @@
 package issue
 
 import (
-	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/progress"
 )
 
 func newAttachmentCmd(f *cmdutil.Factory) *cobra.Command {
@@ -102,7 +103,7 @@ func runAttachmentList(cmd *cobra.Command, f *cmdutil.Factory, opts *attachmentL
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	attachments, err := client.ListIssueAttachments(ctx, workspace, repoSlug, issueID)
@@ -151,6 +152,7 @@ func runAttachmentList(cmd *cobra.Command, f *cmdutil.Factory, opts *attachmentL
 type attachmentUploadOptions struct {
 	Workspace string
 	Repo      string
+	Name      string
 }
 
 func newAttachmentUploadCmd(f *cmdutil.Factory) *cobra.Command {
@@ -162,7 +164,10 @@ func newAttachmentUploadCmd(f *cmdutil.Factory) *cobra.Command {
   bkt issue attachment upload 42 screenshot.png
 
   # Upload multiple files
-  bkt issue attachment upload 42 file1.txt file2.txt`,
+  bkt issue attachment upload 42 file1.txt file2.txt
+
+  # Attach build output read from stdin
+  make test 2>&1 | bkt issue attach upload 42 - --name test.log`,
 		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			issueID, err := strconv.Atoi(args[0])
@@ -175,6 +180,7 @@ func newAttachmentUploadCmd(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Attachment name when reading from stdin (-)")
 
 	return cmd
 }
@@ -213,6 +219,15 @@ func runAttachmentUpload(cmd *cobra.Command, f *cmdutil.Factory, opts *attachmen
 
 	// Validate all files exist and are not directories before uploading
 	for _, filePath := range files {
+		if filePath == "-" {
+			if len(files) > 1 {
+				return fmt.Errorf("stdin (-) cannot be combined with other files")
+			}
+			if strings.TrimSpace(opts.Name) == "" {
+				return fmt.Errorf("--name is required when uploading from stdin")
+			}
+			continue
+		}
 		info, err := os.Stat(filePath)
 		if err != nil {
 			if os.IsNotExist(err) {
@@ -230,22 +245,43 @@ func runAttachmentUpload(cmd *cobra.Command, f *cmdutil.Factory, opts *attachmen
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Minute)
 	defer cancel()
 
+	if len(files) == 1 && files[0] == "-" {
+		name := strings.TrimSpace(opts.Name)
+		bar := f.ProgressBar("Uploading "+name, 0)
+		attachment, err := client.UploadIssueAttachment(ctx, workspace, repoSlug, issueID, name, progress.NewReader(ios.In, bar))
+		if err != nil {
+			bar.Fail("")
+			return fmt.Errorf("failed to upload %s: %w", name, err)
+		}
+		bar.Stop("")
+		_, err = fmt.Fprintf(ios.Out, "Uploaded: %s\n", attachment.Name)
+		return err
+	}
+
 	for _, filePath := range files {
-		file, err := os.Open(filePath)
+		info, err := os.Stat(filePath)
 		if err != nil {
 			return fmt.Errorf("failed to open %s: %w", filePath, err)
 		}
 
 		filename := filepath.Base(filePath)
-		attachment, err := client.UploadIssueAttachment(ctx, workspace, repoSlug, issueID, filename, file)
-		_ = file.Close()
-
+		bar := f.ProgressBar("Uploading "+filename, info.Size())
+		attachment, err := client.UploadIssueAttachmentFrom(ctx, workspace, repoSlug, issueID, filename, info.Size(), func() (io.ReadCloser, error) {
+			file, err := os.Open(filePath)
+			if err != nil {
+				return nil, err
+			}
+			bar.Reset()
+			return progress.NewReader(file, bar), nil
+		})
 		if err != nil {
+			bar.Fail("")
 			return fmt.Errorf("failed to upload %s: %w", filePath, err)
 		}
+		bar.Stop("")
 
 		if _, err := fmt.Fprintf(ios.Out, "Uploaded: %s\n", attachment.Name); err != nil {
 			return err
@@ -367,7 +403,7 @@ func runAttachmentDownload(cmd *cobra.Command, f *cmdutil.Factory, opts *attachm
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Minute)
 	defer cancel()
 
 	// Collect files to download
@@ -436,14 +472,17 @@ func runAttachmentDownload(cmd *cobra.Command, f *cmdutil.Factory, opts *attachm
 			return fmt.Errorf("failed to create %s: %w", outputPath, err)
 		}
 
-		err = client.DownloadIssueAttachment(ctx, workspace, repoSlug, issueID, name, file)
+		bar := f.ProgressBar("Downloading "+safeName, 0)
+		err = client.DownloadIssueAttachment(ctx, workspace, repoSlug, issueID, name, progress.NewWriter(file, bar))
 		_ = file.Close()
 
 		if err != nil {
+			bar.Fail("")
 			// Clean up partial file
 			_ = os.Remove(outputPath)
 			return fmt.Errorf("failed to download %s: %w", name, err)
 		}
+		bar.Stop("")
 
 		if _, err := fmt.Fprintf(ios.Out, "Downloaded: %s\n", outputPath); err != nil {
 			return err
@@ -537,7 +576,7 @@ func runAttachmentDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *attachmen
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	if err := client.DeleteIssueAttachment(ctx, workspace, repoSlug, issueID, filename); err != nil {
@@ -1,6 +1,9 @@
 package issue
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -163,6 +166,56 @@ func TestAttachmentUploadDirectory(t *testing.T) {
 
 // --- Attachment Download Command Tests ---
 
+func TestAttachmentUploadRequiresNameForStdin(t *testing.T) {
+	f := newTestFactory()
+	cmd := newAttachmentUploadCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	cmd.SetArgs([]string{"42", "-"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--name is required") {
+		t.Fatalf("expected --name error, got %v", err)
+	}
+}
+
+func TestAttachmentUploadFromStdin(t *testing.T) {
+	var name, content string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/testworkspace/testrepo/issues/42/attachments" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		file, header, err := r.FormFile("files")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		name, content = header.Filename, string(data)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`[{"name":"test.log"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newServerFactory(t, server.URL)
+	f.IOStreams.In = io.NopCloser(strings.NewReader("FAIL: TestThing"))
+
+	cmd := newAttachmentUploadCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"42", "-", "--name", "test.log"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	if name != "test.log" || content != "FAIL: TestThing" {
+		t.Fatalf("unexpected upload %q: %q", name, content)
+	}
+	if !strings.Contains(stdout.String(), "Uploaded: test.log") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
 func TestAttachmentDownloadRequiresIssueID(t *testing.T) {
 	f := newTestFactory()
 	cmd := newAttachmentDownloadCmd(f)
@@ -1,8 +1,8 @@
 package issue
 
 import (
-	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +34,9 @@ uses Jira for issue tracking.`,
 	cmd.AddCommand(newCommentCmd(f))
 	cmd.AddCommand(newStatusCmd(f))
 	cmd.AddCommand(newAttachmentCmd(f))
+	cmd.AddCommand(newTrackerFieldCmd(f, milestoneField))
+	cmd.AddCommand(newTrackerFieldCmd(f, versionField))
+	cmd.AddCommand(newTrackerFieldCmd(f, componentField))
 
 	return cmd
 }
@@ -47,7 +50,10 @@ type listOptions struct {
 	Kind      string
 	Priority  string
 	Assignee  string
+	Reporter  string
 	Milestone string
+	Query     string
+	Sort      string
 	Limit     int
 }
 
@@ -67,7 +73,10 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
   bkt issue list --kind bug --priority major
 
   # List issues assigned to a user
-  bkt issue list --assignee {uuid}`,
+  bkt issue list --assignee {uuid}
+
+  # Filter with a raw BBQL expression, oldest first
+  bkt issue list --state all --query 'title ~ "crash"' --sort created_on`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd, f, opts)
 		},
@@ -79,7 +88,10 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Kind, "kind", "k", "", "Filter by kind (bug, enhancement, proposal, task)")
 	cmd.Flags().StringVarP(&opts.Priority, "priority", "p", "", "Filter by priority (trivial, minor, major, critical, blocker)")
 	cmd.Flags().StringVarP(&opts.Assignee, "assignee", "a", "", "Filter by assignee (UUID, e.g., {abc-123})")
+	cmd.Flags().StringVar(&opts.Reporter, "reporter", "", "Filter by reporter (UUID, e.g., {abc-123})")
 	cmd.Flags().StringVar(&opts.Milestone, "milestone", "", "Filter by milestone")
+	cmd.Flags().StringVarP(&opts.Query, "query", "q", "", "Additional BBQL filter, combined with the other filters using AND")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "", "Sort field, prefix with - for descending (e.g., -updated_on)")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", opts.Limit, "Maximum issues to display")
 
 	return cmd
@@ -122,7 +134,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	issues, err := client.ListIssues(ctx, workspace, repoSlug, bbcloud.IssueListOptions{
@@ -130,7 +142,10 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		Kind:      opts.Kind,
 		Priority:  opts.Priority,
 		Assignee:  opts.Assignee,
+		Reporter:  opts.Reporter,
 		Milestone: opts.Milestone,
+		Query:     opts.Query,
+		Sort:      opts.Sort,
 		Limit:     opts.Limit,
 	})
 	if err != nil {
@@ -229,7 +244,7 @@ func newViewCmd(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug")
-	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open in browser")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "issue")
 	cmd.Flags().BoolVar(&opts.Comments, "comments", false, "Show comments")
 
 	return cmd
@@ -272,7 +287,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions, issueID
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	issue, err := client.GetIssue(ctx, workspace, repoSlug, issueID)
@@ -280,9 +295,8 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions, issueID
 		return err
 	}
 
-	if opts.Web && issue.Links.HTML.Href != "" {
-		browser := f.BrowserOpener()
-		return browser.Open(issue.Links.HTML.Href)
+	if opts.Web {
+		return cmdutil.OpenInBrowser(f, ios.Out, "issue", issue.Links.HTML.Href)
 	}
 
 	type commentSummary struct {
@@ -385,7 +399,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions, issueID
 			}
 		}
 		if details.Body != "" {
-			if _, err := fmt.Fprintf(ios.Out, "\n%s\n", details.Body); err != nil {
+			if _, err := fmt.Fprintf(ios.Out, "\n%s\n", cmdutil.RenderMarkdown(ios, details.Body)); err != nil {
 				return err
 			}
 		}
@@ -396,7 +410,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions, issueID
 			}
 			for _, c := range details.Comments {
 				if _, err := fmt.Fprintf(ios.Out, "\n@%s (%s):\n%s\n",
-					c.Author, c.CreatedOn, c.Body); err != nil {
+					c.Author, c.CreatedOn, cmdutil.RenderMarkdown(ios, c.Body)); err != nil {
 					return err
 				}
 			}
@@ -428,6 +442,11 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new issue",
+		Long: `Create an issue in a Bitbucket Cloud repository.
+
+Milestone, component and version names are matched against those configured
+for the issue tracker, ignoring case. An unknown name opens a picker when
+running in a terminal and fails otherwise.`,
 		Example: `  # Create a bug
   bkt issue create -t "Login button broken" -b "The login button does not respond"
 
@@ -435,7 +454,10 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
   bkt issue create -t "Add dark mode" -k enhancement -p minor
 
   # Create with assignee (use UUID from user profile)
-  bkt issue create -t "Fix memory leak" -a "{abc-123-def}"`,
+  bkt issue create -t "Fix memory leak" -a "{abc-123-def}"
+
+  # File against a milestone and component
+  bkt issue create -t "Slow search" --milestone "Sprint 12" --component api`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCreate(cmd, f, opts)
 		},
@@ -448,9 +470,9 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Kind, "kind", "k", opts.Kind, "Issue kind (bug, enhancement, proposal, task)")
 	cmd.Flags().StringVarP(&opts.Priority, "priority", "p", "", "Priority (trivial, minor, major, critical, blocker)")
 	cmd.Flags().StringVarP(&opts.Assignee, "assignee", "a", "", "Assignee UUID (e.g., {abc-123})")
-	cmd.Flags().StringVar(&opts.Milestone, "milestone", "", "Milestone name")
-	cmd.Flags().StringVar(&opts.Component, "component", "", "Component name")
-	cmd.Flags().StringVar(&opts.Version, "version", "", "Version name")
+	cmd.Flags().StringVar(&opts.Milestone, "milestone", "", "Milestone name (see \"bkt issue milestone list\")")
+	cmd.Flags().StringVar(&opts.Component, "component", "", "Component name (see \"bkt issue component list\")")
+	cmd.Flags().StringVar(&opts.Version, "version", "", "Version name (see \"bkt issue version list\")")
 
 	_ = cmd.MarkFlagRequired("title")
 
@@ -498,9 +520,22 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
+	for _, tf := range []struct {
+		field trackerField
+		value *string
+	}{
+		{milestoneField, &opts.Milestone},
+		{componentField, &opts.Component},
+		{versionField, &opts.Version},
+	} {
+		if *tf.value, err = resolveTrackerValue(ctx, f, ios, client, workspace, repoSlug, tf.field, *tf.value); err != nil {
+			return err
+		}
+	}
+
 	issue, err := client.CreateIssue(ctx, workspace, repoSlug, bbcloud.CreateIssueInput{
 		Title:     opts.Title,
 		Content:   opts.Body,
@@ -559,6 +594,11 @@ func newEditCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "edit <issue-id>",
 		Short: "Edit an existing issue",
+		Long: `Update the fields of an existing issue; only the flags given are changed.
+
+Milestone, component and version names are matched against those configured
+for the issue tracker, ignoring case. An unknown name opens a picker when
+running in a terminal and fails otherwise.`,
 		Example: `  # Update title
   bkt issue edit 42 --title "New title"
 
@@ -674,9 +714,25 @@ func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions, issueID
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
+	for _, tf := range []struct {
+		field trackerField
+		value *string
+	}{
+		{milestoneField, input.Milestone},
+		{componentField, input.Component},
+		{versionField, input.Version},
+	} {
+		if tf.value == nil {
+			continue
+		}
+		if *tf.value, err = resolveTrackerValue(ctx, f, ios, client, workspace, repoSlug, tf.field, *tf.value); err != nil {
+			return err
+		}
+	}
+
 	issue, err := client.UpdateIssue(ctx, workspace, repoSlug, issueID, input)
 	if err != nil {
 		return err
@@ -709,25 +765,47 @@ func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions, issueID
 
 // --- Close Command ---
 
+// closeReasons are the terminal states an issue can be closed with.
+var closeReasons = []string{"resolved", "closed", "invalid", "duplicate", "wontfix", "on hold"}
+
+type stateChangeOptions struct {
+	Workspace string
+	Repo      string
+	Reason    string
+	Comment   string
+	Assignee  string
+	Component string
+}
+
 func newCloseCmd(f *cmdutil.Factory) *cobra.Command {
-	var workspace, repo string
+	opts := &stateChangeOptions{Reason: "closed"}
 	cmd := &cobra.Command{
 		Use:   "close <issue-id>",
 		Short: "Close an issue",
 		Example: `  # Close issue #42
-  bkt issue close 42`,
+  bkt issue close 42
+
+  # Resolve with a comment explaining the fix
+  bkt issue close 42 --reason resolved -m "Fixed in 3f2a9c1"
+
+  # Mark as a duplicate
+  bkt issue close 42 --reason duplicate -m "Duplicate of #17"`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			issueID, err := strconv.Atoi(args[0])
 			if err != nil {
 				return fmt.Errorf("invalid issue ID %q: must be a number", args[0])
 			}
-			return runStateChange(cmd, f, workspace, repo, issueID, "closed")
+			reason := strings.ToLower(strings.TrimSpace(opts.Reason))
+			if !slices.Contains(closeReasons, reason) {
+				return fmt.Errorf("invalid --reason %q (expected one of %s)", opts.Reason, strings.Join(closeReasons, ", "))
+			}
+			return runStateChange(cmd, f, opts, issueID, reason)
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Bitbucket workspace")
-	cmd.Flags().StringVar(&repo, "repo", "", "Repository slug")
+	addStateChangeFlags(cmd, opts)
+	cmd.Flags().StringVar(&opts.Reason, "reason", opts.Reason, "State to close with ("+strings.Join(closeReasons, ", ")+")")
 
 	return cmd
 }
@@ -735,29 +813,39 @@ func newCloseCmd(f *cmdutil.Factory) *cobra.Command {
 // --- Reopen Command ---
 
 func newReopenCmd(f *cmdutil.Factory) *cobra.Command {
-	var workspace, repo string
+	opts := &stateChangeOptions{}
 	cmd := &cobra.Command{
 		Use:   "reopen <issue-id>",
 		Short: "Reopen a closed issue",
 		Example: `  # Reopen issue #42
-  bkt issue reopen 42`,
+  bkt issue reopen 42
+
+  # Reopen and hand it to someone else
+  bkt issue reopen 42 --assignee 557058:abc -m "Still failing on ARM"`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			issueID, err := strconv.Atoi(args[0])
 			if err != nil {
 				return fmt.Errorf("invalid issue ID %q: must be a number", args[0])
 			}
-			return runStateChange(cmd, f, workspace, repo, issueID, "open")
+			return runStateChange(cmd, f, opts, issueID, "open")
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Bitbucket workspace")
-	cmd.Flags().StringVar(&repo, "repo", "", "Repository slug")
+	addStateChangeFlags(cmd, opts)
 
 	return cmd
 }
 
-func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, workspace, repo string, issueID int, newState string) error {
+func addStateChangeFlags(cmd *cobra.Command, opts *stateChangeOptions) {
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug")
+	cmd.Flags().StringVarP(&opts.Comment, "comment", "m", "", "Comment to post with the change")
+	cmd.Flags().StringVarP(&opts.Assignee, "assignee", "a", "", "Reassign to this account ID (use empty string to unassign)")
+	cmd.Flags().StringVar(&opts.Component, "component", "", "Set component (use empty string to clear)")
+}
+
+func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, opts *stateChangeOptions, issueID int, newState string) error {
 	ios, err := f.Streams()
 	if err != nil {
 		return err
@@ -773,7 +861,7 @@ func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, workspace, repo stri
 		return fmt.Errorf("issue tracker is only available for Bitbucket Cloud; current context uses %s", host.Kind)
 	}
 
-	ws := strings.TrimSpace(workspace)
+	ws := strings.TrimSpace(opts.Workspace)
 	if ws == "" {
 		ws = ctxCfg.Workspace
 	}
@@ -781,7 +869,7 @@ func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, workspace, repo stri
 		return fmt.Errorf("workspace required; set with --workspace or configure the context default")
 	}
 
-	repoSlug := strings.TrimSpace(repo)
+	repoSlug := strings.TrimSpace(opts.Repo)
 	if repoSlug == "" {
 		repoSlug = ctxCfg.DefaultRepo
 	}
@@ -794,12 +882,21 @@ func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, workspace, repo stri
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
-	issue, err := client.UpdateIssue(ctx, ws, repoSlug, issueID, bbcloud.UpdateIssueInput{
-		State: &newState,
-	})
+	input := bbcloud.IssueChangeInput{
+		State:   newState,
+		Message: opts.Comment,
+	}
+	if cmd.Flags().Changed("assignee") {
+		input.AssigneeAccountID = &opts.Assignee
+	}
+	if cmd.Flags().Changed("component") {
+		input.Component = &opts.Component
+	}
+
+	change, err := client.ChangeIssueState(ctx, ws, repoSlug, issueID, input)
 	if err != nil {
 		return err
 	}
@@ -807,6 +904,8 @@ func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, workspace, repo stri
 	action := "Closed"
 	if newState == "open" {
 		action = "Reopened"
+	} else if newState != "closed" {
+		action = fmt.Sprintf("Closed (%s)", newState)
 	}
 
 	type result struct {
@@ -818,14 +917,20 @@ func runStateChange(cmd *cobra.Command, f *cmdutil.Factory, workspace, repo stri
 	}
 
 	r := result{
-		ID:     issue.ID,
-		Title:  issue.Title,
-		State:  issue.State,
-		URL:    issue.Links.HTML.Href,
+		ID:     issueID,
+		State:  newState,
 		Action: action,
 	}
+	if change.Issue != nil {
+		r.Title = change.Issue.Title
+		r.URL = change.Issue.Links.HTML.Href
+	}
 
 	return cmdutil.WriteOutput(cmd, ios.Out, r, func() error {
+		if r.Title == "" {
+			_, err := fmt.Fprintf(ios.Out, "%s issue #%d\n", action, r.ID)
+			return err
+		}
 		_, err := fmt.Fprintf(ios.Out, "%s issue #%d: %s\n", action, r.ID, r.Title)
 		return err
 	})
@@ -903,7 +1008,7 @@ func runDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deleteOptions, issu
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	// Fetch issue first to show title and confirm
@@ -1020,7 +1125,7 @@ func runComment(cmd *cobra.Command, f *cmdutil.Factory, opts *commentOptions, is
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	if opts.List {
@@ -1065,7 +1170,7 @@ func runComment(cmd *cobra.Command, f *cmdutil.Factory, opts *commentOptions, is
 
 			for _, c := range summaries {
 				if _, err := fmt.Fprintf(ios.Out, "@%s (%s):\n%s\n\n",
-					c.Author, c.CreatedOn, c.Body); err != nil {
+					c.Author, c.CreatedOn, cmdutil.RenderMarkdown(ios, c.Body)); err != nil {
 					return err
 				}
 			}
@@ -1108,6 +1213,7 @@ func runComment(cmd *cobra.Command, f *cmdutil.Factory, opts *commentOptions, is
 type statusOptions struct {
 	Workspace string
 	Repo      string
+	FailFast  bool
 }
 
 func newStatusCmd(f *cmdutil.Factory) *cobra.Command {
@@ -1127,6 +1233,7 @@ This command requires authentication to identify the current user.`,
 
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
 
 	return cmd
 }
@@ -1168,7 +1275,7 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *statusOptions) erro
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	// Get current user
@@ -1185,12 +1292,14 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *statusOptions) erro
 		Priority string `json:"priority"`
 	}
 
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+
 	// Fetch issues assigned to user
 	assignedIssues, err := client.ListIssues(ctx, workspace, repoSlug, bbcloud.IssueListOptions{
 		Assignee: user.UUID,
 		Limit:    10,
 	})
-	if err != nil {
+	if err := partial.Record("assigned", err); err != nil {
 		return err
 	}
 
@@ -1199,7 +1308,7 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *statusOptions) erro
 		Reporter: user.UUID,
 		Limit:    10,
 	})
-	if err != nil {
+	if err := partial.Record("created", err); err != nil {
 		return err
 	}
 
@@ -1208,7 +1317,7 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *statusOptions) erro
 		Sort:  "-updated_on", // Most recently updated first
 		Limit: 10,
 	})
-	if err != nil {
+	if err := partial.Record("recently_updated", err); err != nil {
 		return err
 	}
 
@@ -1242,67 +1351,60 @@ func runStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *statusOptions) erro
 	}
 
 	payload := struct {
-		User            string         `json:"user"`
-		Assigned        []issueSummary `json:"assigned"`
-		Created         []issueSummary `json:"created"`
-		RecentlyUpdated []issueSummary `json:"recently_updated"`
+		User            string                   `json:"user"`
+		Assigned        []issueSummary           `json:"assigned"`
+		Created         []issueSummary           `json:"created"`
+		RecentlyUpdated []issueSummary           `json:"recently_updated"`
+		Errors          []cmdutil.PartialFailure `json:"errors,omitempty"`
 	}{
 		User:            user.Username,
 		Assigned:        toSummary(assignedIssues),
 		Created:         toSummary(createdIssues),
 		RecentlyUpdated: toSummary(filteredRecent),
+		Errors:          partial.Failures(),
 	}
 
-	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
-		if _, err := fmt.Fprintf(ios.Out, "Issues for @%s in %s/%s\n\n", user.Username, workspace, repoSlug); err != nil {
+	section := func(title, item, empty string, summaries []issueSummary) error {
+		if _, err := fmt.Fprintf(ios.Out, "%s%s:\n", partial.Marker(item), title); err != nil {
 			return err
 		}
-
-		if _, err := fmt.Fprintln(ios.Out, "Assigned to you:"); err != nil {
+		if partial.Failed(item) {
+			_, err := fmt.Fprintln(ios.Out, "  Unavailable (request failed).")
+			return err
+		}
+		if len(summaries) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "  %s\n", empty)
 			return err
 		}
-		if len(payload.Assigned) == 0 {
-			if _, err := fmt.Fprintln(ios.Out, "  No issues assigned to you."); err != nil {
+		for _, s := range summaries {
+			if _, err := fmt.Fprintf(ios.Out, "  #%d\t%s\t[%s]\n", s.ID, s.Title, s.State); err != nil {
 				return err
 			}
-		} else {
-			for _, s := range payload.Assigned {
-				if _, err := fmt.Fprintf(ios.Out, "  #%d\t%s\t[%s]\n", s.ID, s.Title, s.State); err != nil {
-					return err
-				}
-			}
 		}
+		return nil
+	}
 
-		if _, err := fmt.Fprintln(ios.Out, "\nCreated by you:"); err != nil {
+	if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if _, err := fmt.Fprintf(ios.Out, "Issues for @%s in %s/%s\n\n", user.Username, workspace, repoSlug); err != nil {
 			return err
 		}
-		if len(payload.Created) == 0 {
-			if _, err := fmt.Fprintln(ios.Out, "  No issues created by you."); err != nil {
-				return err
-			}
-		} else {
-			for _, s := range payload.Created {
-				if _, err := fmt.Fprintf(ios.Out, "  #%d\t%s\t[%s]\n", s.ID, s.Title, s.State); err != nil {
-					return err
-				}
-			}
-		}
 
-		if _, err := fmt.Fprintln(ios.Out, "\nRecently updated:"); err != nil {
+		if err := section("Assigned to you", "assigned", "No issues assigned to you.", payload.Assigned); err != nil {
 			return err
 		}
-		if len(payload.RecentlyUpdated) == 0 {
-			if _, err := fmt.Fprintln(ios.Out, "  No other recently updated issues."); err != nil {
-				return err
-			}
-		} else {
-			for _, s := range payload.RecentlyUpdated {
-				if _, err := fmt.Fprintf(ios.Out, "  #%d\t%s\t[%s]\n", s.ID, s.Title, s.State); err != nil {
-					return err
-				}
-			}
+		if _, err := fmt.Fprintln(ios.Out); err != nil {
+			return err
+		}
+		if err := section("Created by you", "created", "No issues created by you.", payload.Created); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(ios.Out); err != nil {
+			return err
 		}
+		return section("Recently updated", "recently_updated", "No other recently updated issues.", payload.RecentlyUpdated)
+	}); err != nil {
+		return err
+	}
 
-		return nil
-	})
+	return partial.Err(ios.ErrOut)
 }
@@ -0,0 +1,116 @@
+package issue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newServerFactory(t *testing.T, baseURL string) (*cmdutil.Factory, *strings.Builder) {
+	t.Helper()
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "testworkspace", DefaultRepo: "testrepo"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout
+}
+
+func TestListPassesQueryReporterAndSort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		wantQ := `state = "open" AND reporter.uuid = "{abc}" AND title ~ "crash"`
+		if r.URL.Path != "/repositories/testworkspace/testrepo/issues" || q.Get("q") != wantQ || q.Get("sort") != "-updated_on" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":4,"title":"Crash on start","state":"open","priority":"major"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newServerFactory(t, server.URL)
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"list", "--reporter", "{abc}", "--query", `title ~ "crash"`, "--sort", "-updated_on"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("issue list: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "#4\tCrash on start\t[open/major]") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCloseWithReasonAndComment(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repositories/testworkspace/testrepo/issues/42/changes" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"issue":{"id":42,"title":"Crash on start"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newServerFactory(t, server.URL)
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"close", "42", "--reason", "resolved", "-m", "Fixed in 3f2a9c1", "--component", "core"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("issue close: %v", err)
+	}
+
+	changes, _ := body["changes"].(map[string]any)
+	if state, _ := changes["state"].(map[string]any); state["new"] != "resolved" {
+		t.Fatalf("unexpected state change %+v", body)
+	}
+	if component, _ := changes["component"].(map[string]any); component["new"] != "core" {
+		t.Fatalf("unexpected component change %+v", body)
+	}
+	if _, ok := changes["assignee_account_id"]; ok {
+		t.Fatalf("assignee should be untouched: %+v", changes)
+	}
+	if !strings.Contains(stdout.String(), "Closed (resolved) issue #42: Crash on start") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCloseRejectsUnknownReason(t *testing.T) {
+	f, _ := newServerFactory(t, "http://example.invalid")
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"close", "42", "--reason", "done"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --reason") {
+		t.Fatalf("expected invalid reason error, got %v", err)
+	}
+}
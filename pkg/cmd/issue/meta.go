@@ -0,0 +1,204 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+// trackerField describes one of the issue tracker's configurable fields.
+type trackerField struct {
+	Name   string
+	Plural string
+	List   func(ctx context.Context, client *bbcloud.Client, workspace, repoSlug string) ([]trackerValue, error)
+}
+
+type trackerValue struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var (
+	milestoneField = trackerField{
+		Name:   "milestone",
+		Plural: "milestones",
+		List: func(ctx context.Context, client *bbcloud.Client, workspace, repoSlug string) ([]trackerValue, error) {
+			items, err := client.ListIssueMilestones(ctx, workspace, repoSlug)
+			values := make([]trackerValue, 0, len(items))
+			for _, item := range items {
+				values = append(values, trackerValue{ID: item.ID, Name: item.Name})
+			}
+			return values, err
+		},
+	}
+	versionField = trackerField{
+		Name:   "version",
+		Plural: "versions",
+		List: func(ctx context.Context, client *bbcloud.Client, workspace, repoSlug string) ([]trackerValue, error) {
+			items, err := client.ListIssueVersions(ctx, workspace, repoSlug)
+			values := make([]trackerValue, 0, len(items))
+			for _, item := range items {
+				values = append(values, trackerValue{ID: item.ID, Name: item.Name})
+			}
+			return values, err
+		},
+	}
+	componentField = trackerField{
+		Name:   "component",
+		Plural: "components",
+		List: func(ctx context.Context, client *bbcloud.Client, workspace, repoSlug string) ([]trackerValue, error) {
+			items, err := client.ListIssueComponents(ctx, workspace, repoSlug)
+			values := make([]trackerValue, 0, len(items))
+			for _, item := range items {
+				values = append(values, trackerValue{ID: item.ID, Name: item.Name})
+			}
+			return values, err
+		},
+	}
+)
+
+func newTrackerFieldCmd(f *cmdutil.Factory, field trackerField) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   field.Name,
+		Short: fmt.Sprintf("List the issue tracker's %s", field.Plural),
+		Long: fmt.Sprintf(`List the %s defined for a repository's issue tracker.
+
+Bitbucket Cloud only exposes %s for reading; add, rename or remove them
+under Repository settings > Issue tracker.`, field.Plural, field.Plural),
+	}
+
+	cmd.AddCommand(newTrackerFieldListCmd(f, field))
+
+	return cmd
+}
+
+type trackerFieldListOptions struct {
+	Workspace string
+	Repo      string
+}
+
+func newTrackerFieldListCmd(f *cmdutil.Factory, field trackerField) *cobra.Command {
+	opts := &trackerFieldListOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   fmt.Sprintf("List %s", field.Plural),
+		Example: fmt.Sprintf(`  bkt issue %s list
+  bkt issue %s list --json`, field.Name, field.Name),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrackerFieldList(cmd, f, field, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug")
+
+	return cmd
+}
+
+func runTrackerFieldList(cmd *cobra.Command, f *cmdutil.Factory, field trackerField, opts *trackerFieldListOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	if host.Kind != "cloud" {
+		return fmt.Errorf("issue tracker is only available for Bitbucket Cloud; current context uses %s", host.Kind)
+	}
+
+	workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+	if workspace == "" {
+		return fmt.Errorf("workspace required; set with --workspace or configure the context default")
+	}
+	repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+	if repoSlug == "" {
+		return fmt.Errorf("repository slug required; set with --repo or configure the context default")
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	values, err := field.List(ctx, client, workspace, repoSlug)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace":  workspace,
+		"repo":       repoSlug,
+		field.Plural: values,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(values) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No %s defined for %s/%s.\n", field.Plural, workspace, repoSlug)
+			return err
+		}
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, v := range values {
+			if _, err := fmt.Fprintf(tw, "%d\t%s\n", v.ID, v.Name); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+// resolveTrackerValue maps name onto one of the field's configured values,
+// ignoring case. An unknown name opens a picker when a terminal is attached
+// and is rejected otherwise; an empty name is returned as is.
+func resolveTrackerValue(ctx context.Context, f *cmdutil.Factory, ios *iostreams.IOStreams, client *bbcloud.Client, workspace, repoSlug string, field trackerField, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil
+	}
+
+	values, err := field.List(ctx, client, workspace, repoSlug)
+	if err != nil {
+		return "", fmt.Errorf("list %s: %w", field.Plural, err)
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("no %s defined for %s/%s; add them under Repository settings > Issue tracker", field.Plural, workspace, repoSlug)
+	}
+
+	names := make([]string, len(values))
+	suggested := -1
+	for i, v := range values {
+		if strings.EqualFold(v.Name, name) {
+			return v.Name, nil
+		}
+		names[i] = v.Name
+		if suggested < 0 && strings.Contains(strings.ToLower(v.Name), strings.ToLower(name)) {
+			suggested = i
+		}
+	}
+
+	if !ios.CanPrompt() {
+		return "", fmt.Errorf("unknown %s %q (available: %s)", field.Name, name, strings.Join(names, ", "))
+	}
+	idx, err := f.Prompt().Select(fmt.Sprintf("No %s named %q; choose one", field.Name, name), names, suggested)
+	if err != nil {
+		return "", err
+	}
+	return names[idx], nil
+}
@@ -0,0 +1,144 @@
+package issue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTrackerServer(t *testing.T, body *map[string]any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repositories/testworkspace/testrepo/milestones":
+			_, _ = w.Write([]byte(`{"values":[{"id":1,"name":"Sprint 11"},{"id":2,"name":"Sprint 12"}]}`))
+		case "/repositories/testworkspace/testrepo/components":
+			_, _ = w.Write([]byte(`{"values":[{"id":3,"name":"api"},{"id":4,"name":"web-ui"}]}`))
+		case "/repositories/testworkspace/testrepo/versions":
+			_, _ = w.Write([]byte(`{"values":[{"id":5,"name":"1.0"},{"id":6,"name":"2.0"}]}`))
+		case "/repositories/testworkspace/testrepo/issues", "/repositories/testworkspace/testrepo/issues/42":
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"id":42,"title":"Slow search","state":"new"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTrackerFieldList(t *testing.T) {
+	server := newTrackerServer(t, nil)
+	f, stdout := newServerFactory(t, server.URL)
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"milestone", "list"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("issue milestone list: %v", err)
+	}
+	if got := stdout.String(); got != "1  Sprint 11\n2  Sprint 12\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
+
+func TestCreateMatchesTrackerNamesIgnoringCase(t *testing.T) {
+	var body map[string]any
+	server := newTrackerServer(t, &body)
+	f, _ := newServerFactory(t, server.URL)
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"create", "-t", "Slow search", "--milestone", "sprint 12", "--component", "API"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("issue create: %v", err)
+	}
+	if milestone, _ := body["milestone"].(map[string]any); milestone["name"] != "Sprint 12" {
+		t.Fatalf("unexpected milestone in %+v", body)
+	}
+	if component, _ := body["component"].(map[string]any); component["name"] != "api" {
+		t.Fatalf("unexpected component in %+v", body)
+	}
+	if _, ok := body["version"]; ok {
+		t.Fatalf("version should be unset: %+v", body)
+	}
+}
+
+func TestCreateRejectsUnknownTrackerNameWithoutTTY(t *testing.T) {
+	server := newTrackerServer(t, nil)
+	f, _ := newServerFactory(t, server.URL)
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"create", "-t", "Slow search", "--component", "backend"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `unknown component "backend" (available: api, web-ui)`) {
+		t.Fatalf("expected unknown component error, got %v", err)
+	}
+}
+
+type stubPrompter struct {
+	prompt       string
+	options      []string
+	defaultIndex int
+	choice       int
+}
+
+func (p *stubPrompter) Input(string, string) (string, error) { return "", nil }
+func (p *stubPrompter) Password(string) (string, error)      { return "", nil }
+func (p *stubPrompter) Confirm(string, bool) (bool, error)   { return true, nil }
+func (p *stubPrompter) MultiSelect(string, []string, []int) ([]int, error) {
+	return nil, nil
+}
+
+func (p *stubPrompter) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	p.prompt, p.options, p.defaultIndex = prompt, options, defaultIndex
+	return p.choice, nil
+}
+
+func TestEditPicksUnknownTrackerName(t *testing.T) {
+	var body map[string]any
+	server := newTrackerServer(t, &body)
+	f, _ := newServerFactory(t, server.URL)
+	forceStdinTTY(f.IOStreams)
+	prompter := &stubPrompter{choice: 1}
+	f.Prompter = prompter
+
+	cmd := NewCmdIssue(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"edit", "42", "--component", "ui", "--version", ""})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("issue edit: %v", err)
+	}
+	if prompter.prompt != `No component named "ui"; choose one` || prompter.defaultIndex != 1 {
+		t.Fatalf("unexpected prompt %q (default %d)", prompter.prompt, prompter.defaultIndex)
+	}
+	if component, _ := body["component"].(map[string]any); component["name"] != "web-ui" {
+		t.Fatalf("unexpected component in %+v", body)
+	}
+	if version, ok := body["version"]; !ok || version != nil {
+		t.Fatalf("version should be cleared: %+v", body)
+	}
+}
+
+func forceStdinTTY(ios *iostreams.IOStreams) {
+	field := reflect.ValueOf(ios).Elem().FieldByName("isStdinTTY")
+	reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().SetBool(true)
+}
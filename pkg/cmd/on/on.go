@@ -0,0 +1,557 @@
+package on
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// Events that can be detected by polling. Names follow Bitbucket Cloud
+// webhook event keys so handlers can be reused with real webhooks.
+const (
+	EventPullRequestCreated    = "pullrequest:created"
+	EventPullRequestUpdated    = "pullrequest:updated"
+	EventPullRequestApproved   = "pullrequest:approved"
+	EventPullRequestUnapproved = "pullrequest:unapproved"
+	EventPullRequestFulfilled  = "pullrequest:fulfilled"
+	EventPullRequestRejected   = "pullrequest:rejected"
+	EventRepoPush              = "repo:push"
+)
+
+var supportedEvents = []string{
+	EventPullRequestCreated,
+	EventPullRequestUpdated,
+	EventPullRequestApproved,
+	EventPullRequestUnapproved,
+	EventPullRequestFulfilled,
+	EventPullRequestRejected,
+	EventRepoPush,
+}
+
+type options struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Interval  time.Duration
+	Once      bool
+	Patterns  []string
+	Command   []string
+}
+
+// NewCmdOn runs a local command whenever a repository event occurs.
+func NewCmdOn(f *cmdutil.Factory) *cobra.Command {
+	opts := &options{Interval: 30 * time.Second}
+	cmd := &cobra.Command{
+		Use:   "on <event>[,<event>...] -- <command> [args...]",
+		Short: "Run a local command when a repository event occurs",
+		Long: `Poll the current repository and run a local command whenever a matching
+event occurs. The event payload is written to the command's stdin as JSON and
+the event name is exported as BKT_EVENT.
+
+Supported events: ` + strings.Join(supportedEvents, ", ") + `.
+Patterns may use shell globs, e.g. 'pullrequest:*'.
+
+The first poll records a baseline; only changes observed afterwards trigger
+the command.`,
+		Example: `  bkt on pullrequest:approved -- ./scripts/notify.sh
+  bkt on 'pullrequest:*' --interval 1m -- jq .pullrequest.title
+  bkt on repo:push --once -- make deploy`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dash := cmd.ArgsLenAtDash()
+			if dash != 1 || len(args) < 2 {
+				return fmt.Errorf("usage: bkt on <event> -- <command> [args...]")
+			}
+			patterns, err := parsePatterns(args[0])
+			if err != nil {
+				return err
+			}
+			opts.Patterns = patterns
+			opts.Command = args[1:]
+			if opts.Interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s")
+			}
+			return runOn(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", opts.Interval, "Polling interval")
+	cmd.Flags().BoolVar(&opts.Once, "once", false, "Exit after the command has run for the first matching event")
+
+	return cmd
+}
+
+// parsePatterns splits a comma-separated event list and rejects patterns that
+// cannot match any supported event.
+func parsePatterns(raw string) ([]string, error) {
+	var patterns []string
+	for _, p := range strings.Split(raw, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid event pattern %q: %w", p, err)
+		}
+		matched := false
+		for _, name := range supportedEvents {
+			if ok, _ := path.Match(p, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unknown event %q (supported: %s)", p, strings.Join(supportedEvents, ", "))
+		}
+		patterns = append(patterns, p)
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("at least one event is required")
+	}
+	return patterns, nil
+}
+
+func matches(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func wantsPrefix(patterns []string, prefix string) bool {
+	for _, name := range supportedEvents {
+		if strings.HasPrefix(name, prefix) && matches(patterns, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// prState is the host-agnostic view of a pull request used for diffing.
+type prState struct {
+	ID        int
+	State     string
+	Head      string
+	Approvals map[string]bool
+	Raw       any
+}
+
+type snapshot struct {
+	PullRequests map[int]prState
+	Branches     map[string]string
+}
+
+type event struct {
+	Name    string
+	Payload map[string]any
+}
+
+// source fetches repository state for a single host kind.
+type source interface {
+	Repo() string
+	PullRequests(ctx context.Context) (map[int]prState, error)
+	PullRequest(ctx context.Context, id int) (prState, error)
+	Branches(ctx context.Context) (map[string]string, error)
+}
+
+func runOn(cmd *cobra.Command, f *cmdutil.Factory, opts *options) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	src, err := newSource(cmd, f, opts)
+	if err != nil {
+		return err
+	}
+
+	watchPRs := wantsPrefix(opts.Patterns, "pullrequest:")
+	watchPush := wantsPrefix(opts.Patterns, "repo:")
+
+	take := func(prev *snapshot) (*snapshot, error) {
+		ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+		defer cancel()
+		return takeSnapshot(ctx, src, prev, watchPRs, watchPush)
+	}
+
+	prev, err := take(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(ios.ErrOut, "Watching %s for %s every %s (Ctrl+C to stop)\n", src.Repo(), strings.Join(opts.Patterns, ", "), opts.Interval); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		curr, err := take(prev)
+		if err != nil {
+			if cmd.Context().Err() != nil {
+				return nil
+			}
+			if _, werr := fmt.Fprintf(ios.ErrOut, "! poll failed: %v\n", err); werr != nil {
+				return werr
+			}
+			continue
+		}
+
+		for _, ev := range diffSnapshots(prev, curr) {
+			if !matches(opts.Patterns, ev.Name) {
+				continue
+			}
+			ev.Payload["event"] = ev.Name
+			ev.Payload["repository"] = map[string]any{"full_name": src.Repo()}
+
+			if _, err := fmt.Fprintf(ios.ErrOut, "→ %s %s\n", ev.Name, describe(ev)); err != nil {
+				return err
+			}
+			if err := runHandler(cmd.Context(), ios.Out, ios.ErrOut, opts.Command, src.Repo(), ev); err != nil {
+				if opts.Once {
+					return err
+				}
+				if _, werr := fmt.Fprintf(ios.ErrOut, "! handler failed: %v\n", err); werr != nil {
+					return werr
+				}
+			}
+			if opts.Once {
+				return nil
+			}
+		}
+		prev = curr
+	}
+}
+
+func takeSnapshot(ctx context.Context, src source, prev *snapshot, watchPRs, watchPush bool) (*snapshot, error) {
+	snap := &snapshot{PullRequests: map[int]prState{}, Branches: map[string]string{}}
+
+	if watchPRs {
+		prs, err := src.PullRequests(ctx)
+		if err != nil {
+			return nil, err
+		}
+		snap.PullRequests = prs
+
+		// Pull requests that left the open list were merged or declined; look
+		// them up once so the transition can be reported.
+		if prev != nil {
+			for id, old := range prev.PullRequests {
+				if _, ok := prs[id]; ok || !strings.EqualFold(old.State, "OPEN") {
+					continue
+				}
+				closed, err := src.PullRequest(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				snap.PullRequests[id] = closed
+			}
+		}
+	}
+
+	if watchPush {
+		branches, err := src.Branches(ctx)
+		if err != nil {
+			return nil, err
+		}
+		snap.Branches = branches
+	}
+
+	return snap, nil
+}
+
+// diffSnapshots reports the events implied by moving from prev to curr.
+func diffSnapshots(prev, curr *snapshot) []event {
+	var events []event
+
+	ids := make([]int, 0, len(curr.PullRequests))
+	for id := range curr.PullRequests {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		pr := curr.PullRequests[id]
+		old, seen := prev.PullRequests[id]
+		base := func() map[string]any { return map[string]any{"pullrequest": pr.Raw} }
+
+		if !seen {
+			if strings.EqualFold(pr.State, "OPEN") {
+				events = append(events, event{Name: EventPullRequestCreated, Payload: base()})
+			}
+			continue
+		}
+
+		if !strings.EqualFold(old.State, pr.State) {
+			switch strings.ToUpper(pr.State) {
+			case "MERGED":
+				events = append(events, event{Name: EventPullRequestFulfilled, Payload: base()})
+			case "DECLINED", "SUPERSEDED":
+				events = append(events, event{Name: EventPullRequestRejected, Payload: base()})
+			}
+			continue
+		}
+
+		if pr.Head != "" && old.Head != "" && pr.Head != old.Head {
+			events = append(events, event{Name: EventPullRequestUpdated, Payload: base()})
+		}
+
+		for _, user := range sortedKeys(pr.Approvals) {
+			if !old.Approvals[user] {
+				payload := base()
+				payload["approval"] = map[string]any{"user": user}
+				events = append(events, event{Name: EventPullRequestApproved, Payload: payload})
+			}
+		}
+		for _, user := range sortedKeys(old.Approvals) {
+			if !pr.Approvals[user] {
+				payload := base()
+				payload["approval"] = map[string]any{"user": user}
+				events = append(events, event{Name: EventPullRequestUnapproved, Payload: payload})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(curr.Branches)+len(prev.Branches))
+	for name := range curr.Branches {
+		names = append(names, name)
+	}
+	for name := range prev.Branches {
+		if _, ok := curr.Branches[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldHash, hadOld := prev.Branches[name]
+		newHash, hasNew := curr.Branches[name]
+		if hadOld && hasNew && oldHash == newHash {
+			continue
+		}
+		change := map[string]any{"created": !hadOld, "closed": !hasNew}
+		if hadOld {
+			change["old"] = map[string]any{"type": "branch", "name": name, "target": map[string]any{"hash": oldHash}}
+		}
+		if hasNew {
+			change["new"] = map[string]any{"type": "branch", "name": name, "target": map[string]any{"hash": newHash}}
+		}
+		events = append(events, event{
+			Name:    EventRepoPush,
+			Payload: map[string]any{"push": map[string]any{"changes": []any{change}}},
+		})
+	}
+
+	return events
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		if v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func describe(ev event) string {
+	if push, ok := ev.Payload["push"].(map[string]any); ok {
+		if changes, ok := push["changes"].([]any); ok && len(changes) > 0 {
+			change, _ := changes[0].(map[string]any)
+			for _, key := range []string{"new", "old"} {
+				if ref, ok := change[key].(map[string]any); ok {
+					return fmt.Sprint(ref["name"])
+				}
+			}
+		}
+		return ""
+	}
+	switch pr := ev.Payload["pullrequest"].(type) {
+	case bbcloud.PullRequest:
+		return fmt.Sprintf("#%d %s", pr.ID, pr.Title)
+	case bbdc.PullRequest:
+		return fmt.Sprintf("#%d %s", pr.ID, pr.Title)
+	}
+	return ""
+}
+
+// runHandler executes the user command with the event payload on stdin.
+func runHandler(ctx context.Context, out, errOut io.Writer, command []string, repo string, ev event) error {
+	data, err := json.Marshal(ev.Payload)
+	if err != nil {
+		return err
+	}
+
+	handler := exec.CommandContext(ctx, command[0], command[1:]...)
+	handler.Stdin = bytes.NewReader(data)
+	handler.Stdout = out
+	handler.Stderr = errOut
+	handler.Env = append(os.Environ(), "BKT_EVENT="+ev.Name, "BKT_REPO="+repo)
+	return handler.Run()
+}
+
+func newSource(cmd *cobra.Command, f *cmdutil.Factory, opts *options) (source, error) {
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return nil, err
+	}
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return nil, fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return nil, err
+		}
+		return &dcSource{client: client, project: projectKey, repo: repoSlug}, nil
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return nil, fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return nil, err
+		}
+		return &cloudSource{client: client, workspace: workspace, repo: repoSlug}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+type cloudSource struct {
+	client    *bbcloud.Client
+	workspace string
+	repo      string
+}
+
+func (s *cloudSource) Repo() string { return s.workspace + "/" + s.repo }
+
+func (s *cloudSource) PullRequests(ctx context.Context) (map[int]prState, error) {
+	prs, err := s.client.ListPullRequests(ctx, s.workspace, s.repo, bbcloud.PullRequestListOptions{
+		State:  "OPEN",
+		Fields: "+values.participants",
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]prState, len(prs))
+	for _, pr := range prs {
+		out[pr.ID] = cloudPRState(pr)
+	}
+	return out, nil
+}
+
+func (s *cloudSource) PullRequest(ctx context.Context, id int) (prState, error) {
+	pr, err := s.client.GetPullRequest(ctx, s.workspace, s.repo, id)
+	if err != nil {
+		return prState{}, err
+	}
+	return cloudPRState(*pr), nil
+}
+
+func (s *cloudSource) Branches(ctx context.Context) (map[string]string, error) {
+	branches, err := s.client.ListBranches(ctx, s.workspace, s.repo, bbcloud.BranchListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(branches))
+	for _, b := range branches {
+		out[b.Name] = b.Target.Hash
+	}
+	return out, nil
+}
+
+func cloudPRState(pr bbcloud.PullRequest) prState {
+	approvals := map[string]bool{}
+	for _, p := range pr.Participants {
+		if p.Approved {
+			approvals[cmdutil.FirstNonEmpty(p.User.DisplayName, p.User.Nickname, p.User.UUID)] = true
+		}
+	}
+	return prState{ID: pr.ID, State: pr.State, Head: pr.Source.Commit.Hash, Approvals: approvals, Raw: pr}
+}
+
+type dcSource struct {
+	client  *bbdc.Client
+	project string
+	repo    string
+}
+
+func (s *dcSource) Repo() string { return s.project + "/" + s.repo }
+
+func (s *dcSource) PullRequests(ctx context.Context) (map[int]prState, error) {
+	prs, err := s.client.ListPullRequests(ctx, s.project, s.repo, "OPEN", 0)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]prState, len(prs))
+	for _, pr := range prs {
+		out[pr.ID] = dcPRState(pr)
+	}
+	return out, nil
+}
+
+func (s *dcSource) PullRequest(ctx context.Context, id int) (prState, error) {
+	pr, err := s.client.GetPullRequest(ctx, s.project, s.repo, id)
+	if err != nil {
+		return prState{}, err
+	}
+	return dcPRState(*pr), nil
+}
+
+func (s *dcSource) Branches(ctx context.Context) (map[string]string, error) {
+	branches, err := s.client.ListBranches(ctx, s.project, s.repo, bbdc.BranchListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(branches))
+	for _, b := range branches {
+		out[b.DisplayID] = b.LatestCommit
+	}
+	return out, nil
+}
+
+func dcPRState(pr bbdc.PullRequest) prState {
+	approvals := map[string]bool{}
+	for _, r := range pr.Reviewers {
+		if r.Approved || strings.EqualFold(r.Status, "APPROVED") {
+			approvals[cmdutil.FirstNonEmpty(r.User.FullName, r.User.Name, r.User.Slug)] = true
+		}
+	}
+	return prState{ID: pr.ID, State: pr.State, Head: pr.FromRef.LatestCommit, Approvals: approvals, Raw: pr}
+}
@@ -0,0 +1,78 @@
+package on
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePatterns(t *testing.T) {
+	patterns, err := parsePatterns("pullrequest:approved, repo:*")
+	if err != nil {
+		t.Fatalf("parsePatterns: %v", err)
+	}
+	if len(patterns) != 2 || patterns[1] != "repo:*" {
+		t.Fatalf("unexpected patterns: %v", patterns)
+	}
+
+	if _, err := parsePatterns("issue:created"); err == nil || !strings.Contains(err.Error(), "unknown event") {
+		t.Fatalf("expected unknown event error, got %v", err)
+	}
+	if _, err := parsePatterns(" , "); err == nil {
+		t.Fatal("expected error for empty event list")
+	}
+}
+
+func TestDiffSnapshotsPullRequests(t *testing.T) {
+	prev := &snapshot{
+		PullRequests: map[int]prState{
+			1: {ID: 1, State: "OPEN", Head: "aaa", Approvals: map[string]bool{}},
+			2: {ID: 2, State: "OPEN", Head: "bbb", Approvals: map[string]bool{"alice": true}},
+			3: {ID: 3, State: "OPEN", Head: "ccc"},
+		},
+	}
+	curr := &snapshot{
+		PullRequests: map[int]prState{
+			1: {ID: 1, State: "OPEN", Head: "aaa2", Approvals: map[string]bool{"bob": true}},
+			2: {ID: 2, State: "OPEN", Head: "bbb", Approvals: map[string]bool{}},
+			3: {ID: 3, State: "MERGED", Head: "ccc"},
+			4: {ID: 4, State: "OPEN", Head: "ddd"},
+		},
+	}
+
+	var got []string
+	for _, ev := range diffSnapshots(prev, curr) {
+		got = append(got, ev.Name)
+	}
+
+	want := []string{
+		EventPullRequestUpdated,
+		EventPullRequestApproved,
+		EventPullRequestUnapproved,
+		EventPullRequestFulfilled,
+		EventPullRequestCreated,
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestDiffSnapshotsBranches(t *testing.T) {
+	prev := &snapshot{Branches: map[string]string{"main": "1", "old": "2", "same": "3"}}
+	curr := &snapshot{Branches: map[string]string{"main": "9", "new": "4", "same": "3"}}
+
+	events := diffSnapshots(prev, curr)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 push events, got %d", len(events))
+	}
+
+	var names []string
+	for _, ev := range events {
+		if ev.Name != EventRepoPush {
+			t.Fatalf("unexpected event %s", ev.Name)
+		}
+		names = append(names, describe(ev))
+	}
+	if strings.Join(names, ",") != "main,new,old" {
+		t.Fatalf("unexpected branches: %v", names)
+	}
+}
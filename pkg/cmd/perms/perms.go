@@ -1,13 +1,13 @@
 package perms
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/repo"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
 )
 
@@ -89,77 +89,13 @@ func newProjectCmd(f *cmdutil.Factory) *cobra.Command {
 	return cmd
 }
 
-type repoListOptions struct {
-	Project string
-	Repo    string
-	Limit   int
-}
-
-type repoGrantOptions struct {
-	Project    string
-	Repo       string
-	Username   string
-	Permission string
-}
-
-type repoRevokeOptions struct {
-	Project  string
-	Repo     string
-	Username string
-}
-
+// newRepoCmd mounts "bkt repo permission" under perms so both paths share
+// one implementation.
 func newRepoCmd(f *cmdutil.Factory) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "repo",
-		Short: "Manage repository-level permissions",
-	}
-
-	listOpts := &repoListOptions{Limit: 100}
-	list := &cobra.Command{
-		Use:   "list",
-		Short: "List repository permissions",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRepoList(cmd, f, listOpts)
-		},
-	}
-	list.Flags().StringVar(&listOpts.Project, "project", "", "Bitbucket project key (required)")
-	list.Flags().StringVar(&listOpts.Repo, "repo", "", "Repository slug (required)")
-	list.Flags().IntVar(&listOpts.Limit, "limit", listOpts.Limit, "Maximum entries to display (0 for all)")
-	_ = list.MarkFlagRequired("project")
-	_ = list.MarkFlagRequired("repo")
-
-	grantOpts := &repoGrantOptions{}
-	grant := &cobra.Command{
-		Use:   "grant",
-		Short: "Grant repository permissions",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRepoGrant(cmd, f, grantOpts)
-		},
-	}
-	grant.Flags().StringVar(&grantOpts.Project, "project", "", "Bitbucket project key (required)")
-	grant.Flags().StringVar(&grantOpts.Repo, "repo", "", "Repository slug (required)")
-	grant.Flags().StringVar(&grantOpts.Username, "user", "", "Username to grant (required)")
-	grant.Flags().StringVar(&grantOpts.Permission, "perm", "REPO_READ", "Permission (REPO_READ, REPO_WRITE, REPO_ADMIN)")
-	_ = grant.MarkFlagRequired("project")
-	_ = grant.MarkFlagRequired("repo")
-	_ = grant.MarkFlagRequired("user")
-
-	revokeOpts := &repoRevokeOptions{}
-	revoke := &cobra.Command{
-		Use:   "revoke",
-		Short: "Revoke repository permissions",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRepoRevoke(cmd, f, revokeOpts)
-		},
-	}
-	revoke.Flags().StringVar(&revokeOpts.Project, "project", "", "Bitbucket project key (required)")
-	revoke.Flags().StringVar(&revokeOpts.Repo, "repo", "", "Repository slug (required)")
-	revoke.Flags().StringVar(&revokeOpts.Username, "user", "", "Username to revoke (required)")
-	_ = revoke.MarkFlagRequired("project")
-	_ = revoke.MarkFlagRequired("repo")
-	_ = revoke.MarkFlagRequired("user")
-
-	cmd.AddCommand(list, grant, revoke)
+	cmd := repo.NewCmdPermission(f)
+	cmd.Use = "repo"
+	cmd.Aliases = nil
+	cmd.Short = "Manage repository-level permissions (same as \"bkt repo permission\")"
 	return cmd
 }
 
@@ -183,7 +119,7 @@ func runProjectList(cmd *cobra.Command, f *cmdutil.Factory, opts *projectListOpt
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	perms, err := client.ListProjectPermissions(ctx, opts.Project, opts.Limit)
@@ -231,7 +167,7 @@ func runProjectGrant(cmd *cobra.Command, f *cmdutil.Factory, opts *projectGrantO
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.GrantProjectPermission(ctx, opts.Project, opts.Username, opts.Permission); err != nil {
@@ -264,7 +200,7 @@ func runProjectRevoke(cmd *cobra.Command, f *cmdutil.Factory, opts *projectRevok
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.RevokeProjectPermission(ctx, opts.Project, opts.Username); err != nil {
@@ -276,118 +212,3 @@ func runProjectRevoke(cmd *cobra.Command, f *cmdutil.Factory, opts *projectRevok
 	}
 	return nil
 }
-
-func runRepoList(cmd *cobra.Command, f *cmdutil.Factory, opts *repoListOptions) error {
-	ios, err := f.Streams()
-	if err != nil {
-		return err
-	}
-
-	override := cmdutil.FlagValue(cmd, "context")
-	_, _, host, err := cmdutil.ResolveContext(f, cmd, override)
-	if err != nil {
-		return err
-	}
-	if host.Kind != "dc" {
-		return fmt.Errorf("perms repo list currently supports Data Center contexts only")
-	}
-
-	client, err := cmdutil.NewDCClient(host)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
-	defer cancel()
-
-	perms, err := client.ListRepoPermissions(ctx, opts.Project, opts.Repo, opts.Limit)
-	if err != nil {
-		return err
-	}
-
-	payload := map[string]any{
-		"project":     opts.Project,
-		"repo":        opts.Repo,
-		"permissions": perms,
-	}
-
-	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
-		for _, p := range perms {
-			if _, err := fmt.Fprintf(ios.Out, "%s\t%s\n", cmdutil.FirstNonEmpty(p.User.FullName, p.User.Name), p.Permission); err != nil {
-				return err
-			}
-		}
-		if len(perms) == 0 {
-			if _, err := fmt.Fprintln(ios.Out, "No permissions found."); err != nil {
-				return err
-			}
-		}
-		return nil
-	})
-}
-
-func runRepoGrant(cmd *cobra.Command, f *cmdutil.Factory, opts *repoGrantOptions) error {
-	ios, err := f.Streams()
-	if err != nil {
-		return err
-	}
-
-	override := cmdutil.FlagValue(cmd, "context")
-	_, _, host, err := cmdutil.ResolveContext(f, cmd, override)
-	if err != nil {
-		return err
-	}
-	if host.Kind != "dc" {
-		return fmt.Errorf("perms repo grant currently supports Data Center contexts only")
-	}
-
-	client, err := cmdutil.NewDCClient(host)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
-	defer cancel()
-
-	if err := client.GrantRepoPermission(ctx, opts.Project, opts.Repo, opts.Username, opts.Permission); err != nil {
-		return err
-	}
-
-	if _, err := fmt.Fprintf(ios.Out, "✓ Granted %s on %s/%s to %s\n", strings.ToUpper(opts.Permission), opts.Project, opts.Repo, opts.Username); err != nil {
-		return err
-	}
-	return nil
-}
-
-func runRepoRevoke(cmd *cobra.Command, f *cmdutil.Factory, opts *repoRevokeOptions) error {
-	ios, err := f.Streams()
-	if err != nil {
-		return err
-	}
-
-	override := cmdutil.FlagValue(cmd, "context")
-	_, _, host, err := cmdutil.ResolveContext(f, cmd, override)
-	if err != nil {
-		return err
-	}
-	if host.Kind != "dc" {
-		return fmt.Errorf("perms repo revoke currently supports Data Center contexts only")
-	}
-
-	client, err := cmdutil.NewDCClient(host)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
-	defer cancel()
-
-	if err := client.RevokeRepoPermission(ctx, opts.Project, opts.Repo, opts.Username); err != nil {
-		return err
-	}
-
-	if _, err := fmt.Fprintf(ios.Out, "✓ Revoked repository permission for %s on %s/%s\n", opts.Username, opts.Project, opts.Repo); err != nil {
-		return err
-	}
-	return nil
-}
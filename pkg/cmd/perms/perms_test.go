@@ -0,0 +1,56 @@
+package perms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func TestRepoGrantAcceptsDataCenterPermissionNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/1.0/projects/DATA/repos/api/permissions/users" ||
+			q.Get("name") != "alice" || q.Get("permission") != "REPO_WRITE" {
+			t.Fatalf("unexpected request %s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "main"},
+		},
+		Hosts: map[string]*config.Host{
+			"main": {Kind: "dc", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCommand(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"repo", "grant", "--project", "DATA", "--repo", "api", "--user", "alice", "--perm", "REPO_WRITE"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("perms repo grant: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "✓ Granted write on DATA/api to user alice") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
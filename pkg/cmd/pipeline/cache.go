@@ -0,0 +1,231 @@
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+func newCacheCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and clear pipeline dependency caches",
+		Long: `Inspect and clear the dependency caches saved by pipeline steps. Clearing a
+stale cache makes the next run rebuild it from scratch.`,
+	}
+
+	cmd.AddCommand(newCacheListCmd(f))
+	cmd.AddCommand(newCacheClearCmd(f))
+
+	return cmd
+}
+
+type cacheListOptions struct {
+	baseOptions
+}
+
+func newCacheListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &cacheListOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List pipeline caches",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket Cloud workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+
+	return cmd
+}
+
+func runCacheList(cmd *cobra.Command, f *cmdutil.Factory, opts *cacheListOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repo, host, err := resolveCloudRepo(cmd, f, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	caches, err := client.ListPipelineCaches(ctx, workspace, repo)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"repo":      repo,
+		"caches":    caches,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(caches) == 0 {
+			_, err := fmt.Fprintln(ios.Out, "No pipeline caches found.")
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		var total int64
+		for _, c := range caches {
+			total += c.FileSizeBytes
+			created := c.CreatedOn
+			if t, err := time.Parse(time.RFC3339Nano, c.CreatedOn); err == nil {
+				created = t.Local().Format("2006-01-02 15:04")
+			}
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Name, c.Path, humanSize(c.FileSizeBytes), created); err != nil {
+				return err
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(ios.Out, "\n%d cache(s), %s in total\n", len(caches), humanSize(total))
+		return err
+	})
+}
+
+type cacheClearOptions struct {
+	baseOptions
+	All bool
+	Yes bool
+}
+
+func newCacheClearCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &cacheClearOptions{}
+	cmd := &cobra.Command{
+		Use:     "clear [<name>...]",
+		Aliases: []string{"delete", "rm"},
+		Short:   "Clear pipeline caches",
+		Long: `Delete pipeline caches by name (as listed by "bkt pipeline cache list"), or
+every cache of the repository with --all. All entries sharing a name are
+removed, including those saved for different cache keys.`,
+		Example: `  bkt pipeline cache clear node
+  bkt pipeline cache clear gradle maven --yes
+  bkt pipeline cache clear --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.All == (len(args) > 0) {
+				return fmt.Errorf("specify cache names or --all")
+			}
+			return runCacheClear(cmd, f, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket Cloud workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Clear every cache of the repository")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runCacheClear(cmd *cobra.Command, f *cmdutil.Factory, names []string, opts *cacheClearOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repo, host, err := resolveCloudRepo(cmd, f, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	caches, err := client.ListPipelineCaches(ctx, workspace, repo)
+	if err != nil {
+		return err
+	}
+
+	var available []string
+	for _, c := range caches {
+		if !slices.Contains(available, c.Name) {
+			available = append(available, c.Name)
+		}
+	}
+	if opts.All {
+		if len(available) == 0 {
+			_, err := fmt.Fprintln(ios.Out, "No pipeline caches found.")
+			return err
+		}
+		names = available
+	}
+	for _, name := range names {
+		if !slices.Contains(available, name) {
+			if len(available) == 0 {
+				return fmt.Errorf("no cache named %q in %s/%s; the repository has no caches", name, workspace, repo)
+			}
+			return fmt.Errorf("no cache named %q in %s/%s (available: %s)", name, workspace, repo, strings.Join(available, ", "))
+		}
+	}
+
+	var selected []bbcloud.PipelineCache
+	var size int64
+	for _, c := range caches {
+		if slices.Contains(names, c.Name) {
+			selected = append(selected, c)
+			size += c.FileSizeBytes
+		}
+	}
+
+	if !opts.Yes {
+		confirmed, err := f.Prompt().Confirm(fmt.Sprintf("Clear %s from %s/%s (%d entries, %s)?",
+			strings.Join(names, ", "), workspace, repo, len(selected), humanSize(size)), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, err := fmt.Fprintln(ios.Out, "Aborted.")
+			return err
+		}
+	}
+
+	for _, name := range names {
+		if err := client.DeletePipelineCachesByName(ctx, workspace, repo, name); err != nil {
+			return fmt.Errorf("clear cache %q: %w", name, err)
+		}
+		if _, err := fmt.Fprintf(ios.Out, "✓ Cleared cache %s\n", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -26,6 +26,7 @@ func NewCmdPipeline(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(newListCmd(f))
 	cmd.AddCommand(newViewCmd(f))
 	cmd.AddCommand(newLogsCmd(f))
+	cmd.AddCommand(newCacheCmd(f))
 
 	return cmd
 }
@@ -49,6 +50,7 @@ type listOptions struct {
 type viewOptions struct {
 	baseOptions
 	Identifier string // UUID or build number
+	Web        bool
 }
 
 type logsOptions struct {
@@ -71,6 +73,7 @@ func newRunCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
 	cmd.Flags().StringVar(&opts.Ref, "ref", "main", "Git ref to run the pipeline on")
 	cmd.Flags().StringSliceVar(&opts.Variables, "var", nil, "Pipeline variable in KEY=VALUE form (repeatable)")
+	_ = cmd.RegisterFlagCompletionFunc("ref", cmdutil.CompleteBranches(f))
 
 	return cmd
 }
@@ -108,6 +111,7 @@ func newViewCmd(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket Cloud workspace override")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "pipeline")
 
 	return cmd
 }
@@ -157,7 +161,7 @@ func runPipelineRun(cmd *cobra.Command, f *cmdutil.Factory, opts *runOptions) er
 		vars[strings.TrimSpace(parts[0])] = parts[1]
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	pipeline, err := client.TriggerPipeline(ctx, workspace, repo, bbcloud.TriggerPipelineInput{
@@ -190,7 +194,7 @@ func runPipelineList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions)
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	pipelines, err := client.ListPipelines(ctx, workspace, repo, opts.Limit)
@@ -257,7 +261,7 @@ func runPipelineView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions)
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	pipeline, err := resolvePipeline(ctx, client, workspace, repo, opts.Identifier)
@@ -265,6 +269,14 @@ func runPipelineView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions)
 		return err
 	}
 
+	if opts.Web {
+		link, err := pipelineWebURL(ctx, client, workspace, repo, pipeline)
+		if err != nil {
+			return err
+		}
+		return cmdutil.OpenInBrowser(f, ios.Out, "pipeline", link)
+	}
+
 	steps, err := client.ListPipelineSteps(ctx, workspace, repo, pipeline.UUID)
 	if err != nil {
 		return err
@@ -293,6 +305,20 @@ func runPipelineView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions)
 	})
 }
 
+// pipelineWebURL builds the results page of a pipeline run. The pipelines API
+// carries no HTML link, so it is derived from the repository's.
+func pipelineWebURL(ctx context.Context, client *bbcloud.Client, workspace, repo string, pipeline *bbcloud.Pipeline) (string, error) {
+	repository, err := client.GetRepository(ctx, workspace, repo)
+	if err != nil {
+		return "", err
+	}
+	base := strings.TrimSuffix(repository.Links.HTML.Href, "/")
+	if base == "" || pipeline.BuildNumber == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%s/pipelines/results/%d", base, pipeline.BuildNumber), nil
+}
+
 func runPipelineLogs(cmd *cobra.Command, f *cmdutil.Factory, opts *logsOptions) error {
 	ios, err := f.Streams()
 	if err != nil {
@@ -309,7 +335,7 @@ func runPipelineLogs(cmd *cobra.Command, f *cmdutil.Factory, opts *logsOptions)
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	// Resolve build number or UUID to pipeline
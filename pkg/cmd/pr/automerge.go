@@ -1,7 +1,6 @@
 package pr
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -37,9 +36,10 @@ func newAutoMergeCmd(f *cmdutil.Factory) *cobra.Command {
 func newAutoMergeEnableCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &autoMergeOptions{CloseSource: true}
 	cmd := &cobra.Command{
-		Use:   "enable <id>",
-		Short: "Enable auto-merge for a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "enable <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Enable auto-merge for a pull request",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -62,9 +62,10 @@ func newAutoMergeEnableCmd(f *cmdutil.Factory) *cobra.Command {
 func newAutoMergeDisableCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &autoMergeOptions{}
 	cmd := &cobra.Command{
-		Use:   "disable <id>",
-		Short: "Disable auto-merge",
-		Args:  cobra.ExactArgs(1),
+		Use:               "disable <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Disable auto-merge",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -82,9 +83,10 @@ func newAutoMergeDisableCmd(f *cmdutil.Factory) *cobra.Command {
 func newAutoMergeStatusCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &autoMergeOptions{}
 	cmd := &cobra.Command{
-		Use:   "status <id>",
-		Short: "Show auto-merge configuration",
-		Args:  cobra.ExactArgs(1),
+		Use:               "status <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Show auto-merge configuration",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -125,7 +127,7 @@ func runAutoMergeEnable(cmd *cobra.Command, f *cmdutil.Factory, opts *autoMergeO
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	settings := bbdc.AutoMergeSettings{
@@ -169,7 +171,7 @@ func runAutoMergeDisable(cmd *cobra.Command, f *cmdutil.Factory, opts *autoMerge
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.DisableAutoMerge(ctx, projectKey, repoSlug, opts.ID); err != nil {
@@ -207,7 +209,7 @@ func runAutoMergeStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *autoMergeO
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	settings, err := client.GetAutoMerge(ctx, projectKey, repoSlug, opts.ID)
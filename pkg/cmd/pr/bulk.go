@@ -0,0 +1,335 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// bulkConcurrency bounds the pull requests approved or merged at once.
+const bulkConcurrency = 4
+
+// bulkOptions selects the pull requests a bulk command acts on: the ids given
+// as arguments, or with All every open pull request matching Query.
+type bulkOptions struct {
+	Project  string
+	Repo     string
+	All      bool
+	Query    string
+	Yes      bool
+	FailFast bool
+	// OutDir and OutFormat configure the report written with --out-dir.
+	OutDir    string
+	OutFormat string
+}
+
+func addBulkFlags(cmd *cobra.Command, opts *bulkOptions, verb string) {
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().BoolVar(&opts.All, "all", false, fmt.Sprintf("%s every open pull request matching --query", verb))
+	cmd.Flags().StringVar(&opts.Query, "query", "", "Filter for --all: words in the title, author:<user>, branch:<prefix>, target:<branch>")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip the confirmation prompt for --all")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+	cmdutil.AddOutDirFlags(cmd, &opts.OutDir, &opts.OutFormat)
+}
+
+// parseBulkArgs validates the pull request ids given to a bulk command.
+func parseBulkArgs(args []string, opts *bulkOptions) ([]int, error) {
+	switch {
+	case opts.All && len(args) > 0:
+		return nil, fmt.Errorf("--all cannot be combined with pull request ids")
+	case !opts.All && opts.Query != "":
+		return nil, fmt.Errorf("--query requires --all")
+	case !opts.All && len(args) == 0:
+		return nil, fmt.Errorf("specify pull request ids or --all")
+	}
+	if opts.OutDir != "" {
+		if err := cmdutil.CheckReportFormat(opts.OutFormat); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]int, 0, len(args))
+	seen := make(map[int]bool)
+	for _, arg := range args {
+		id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid pull request id %q", arg)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// prQuery is a parsed --query filter. All terms must match.
+type prQuery struct {
+	words    []string
+	authors  []string
+	branches []string
+	targets  []string
+}
+
+func parsePRQuery(query string) (prQuery, error) {
+	var q prQuery
+	for _, term := range strings.Fields(query) {
+		key, value, found := strings.Cut(term, ":")
+		if !found {
+			q.words = append(q.words, strings.ToLower(term))
+			continue
+		}
+		var field *[]string
+		switch strings.ToLower(key) {
+		case "author":
+			field = &q.authors
+		case "branch":
+			field = &q.branches
+		case "target":
+			field = &q.targets
+		default:
+			// Titles such as "deps: bump foo" contain colons too.
+			q.words = append(q.words, strings.ToLower(term))
+			continue
+		}
+		if value == "" {
+			return q, fmt.Errorf("empty value in query term %q", term)
+		}
+		*field = append(*field, value)
+	}
+	return q, nil
+}
+
+func (q prQuery) matches(pr bbdc.PullRequest) bool {
+	title := strings.ToLower(pr.Title)
+	for _, word := range q.words {
+		if !strings.Contains(title, word) {
+			return false
+		}
+	}
+	author := pr.Author.User
+	for _, want := range q.authors {
+		if !strings.EqualFold(want, author.Name) && !strings.EqualFold(want, author.Slug) && !strings.EqualFold(want, author.FullName) {
+			return false
+		}
+	}
+	for _, prefix := range q.branches {
+		if !strings.HasPrefix(pr.FromRef.DisplayID, prefix) {
+			return false
+		}
+	}
+	for _, target := range q.targets {
+		if pr.ToRef.DisplayID != target {
+			return false
+		}
+	}
+	return true
+}
+
+// bulkTarget is a pull request a bulk command acts on. PR is set when the
+// pull request was listed by --all.
+type bulkTarget struct {
+	ID int
+	PR *bbdc.PullRequest
+}
+
+// resolveBulkTargets turns ids, or --all and --query, into targets. For --all
+// it lists the matching pull requests and asks for confirmation; ok is false
+// when the user declines.
+func resolveBulkTargets(ctx context.Context, f *cmdutil.Factory, client *bbdc.Client, projectKey, repoSlug string, ids []int, opts *bulkOptions, verb string) (targets []bulkTarget, ok bool, err error) {
+	if !opts.All {
+		for _, id := range ids {
+			targets = append(targets, bulkTarget{ID: id})
+		}
+		return targets, true, nil
+	}
+
+	ios, err := f.Streams()
+	if err != nil {
+		return nil, false, err
+	}
+
+	query, err := parsePRQuery(opts.Query)
+	if err != nil {
+		return nil, false, err
+	}
+	prs, err := client.ListPullRequests(ctx, projectKey, repoSlug, "OPEN", 0)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range prs {
+		if query.matches(prs[i]) {
+			targets = append(targets, bulkTarget{ID: prs[i].ID, PR: &prs[i]})
+		}
+	}
+	if len(targets) == 0 || opts.Yes {
+		return targets, true, nil
+	}
+
+	if !ios.CanPrompt() {
+		return nil, false, fmt.Errorf("--yes is required to %s %d pull requests when not running interactively", strings.ToLower(verb), len(targets))
+	}
+	if err := printBulkTargets(ios.ErrOut, targets); err != nil {
+		return nil, false, err
+	}
+	confirmed, err := f.Prompt().Confirm(fmt.Sprintf("%s %d pull requests in %s/%s?", verb, len(targets), projectKey, repoSlug), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if !confirmed {
+		_, err := fmt.Fprintln(ios.ErrOut, "Aborted.")
+		return nil, false, err
+	}
+	return targets, true, nil
+}
+
+func printBulkTargets(w io.Writer, targets []bulkTarget) error {
+	for _, t := range targets {
+		if _, err := fmt.Fprintf(w, "  #%d  %s  (%s)\n", t.ID, t.PR.Title, t.PR.FromRef.DisplayID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errBulkSkipped marks the targets that were never attempted because an
+// earlier failure stopped a --fail-fast run.
+var errBulkSkipped = errors.New("skipped after an earlier failure")
+
+// runBulk calls op for every target on a bounded pool of workers, starting
+// them in target order, and returns the error of each. With failFast the
+// first failure stops targets not yet started, which fail with
+// errBulkSkipped, and is returned as first; operations already in flight
+// are left to finish.
+func runBulk(ctx context.Context, targets []bulkTarget, failFast bool, op func(context.Context, bulkTarget) error) (errs []error, first error) {
+	errs = make([]error, len(targets))
+	var (
+		once    sync.Once
+		stopped atomic.Bool
+		wg      sync.WaitGroup
+	)
+	next := make(chan int)
+	for range min(bulkConcurrency, len(targets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if stopped.Load() {
+					errs[i] = errBulkSkipped
+					continue
+				}
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				if errs[i] = op(ctx, targets[i]); errs[i] != nil && failFast {
+					once.Do(func() {
+						first = errs[i]
+						stopped.Store(true)
+					})
+				}
+			}
+		}()
+	}
+	for i := range targets {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return errs, first
+}
+
+// bulkTimeout scales a command's default deadline with the number of pull
+// requests it acts on.
+func bulkTimeout(single time.Duration, ids []int, opts *bulkOptions) time.Duration {
+	if opts.All || len(ids) > 1 {
+		return 5 * time.Minute
+	}
+	return single
+}
+
+// reportBulk writes one success line per pull request and one warning per
+// failed or skipped one, or with --out-dir a report for the repository, then
+// returns the command's error: first when a --fail-fast run stopped early.
+func reportBulk(cmd *cobra.Command, f *cmdutil.Factory, repo string, opts *bulkOptions, targets []bulkTarget, errs []error, first error, key, verb string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+	if len(targets) == 1 && errs[0] != nil && opts.OutDir == "" {
+		return errs[0]
+	}
+
+	partial := &cmdutil.PartialResults{}
+	done := []int{}
+	for i, t := range targets {
+		if errs[i] != nil {
+			_ = partial.Record(fmt.Sprintf("#%d", t.ID), errs[i])
+			continue
+		}
+		done = append(done, t.ID)
+	}
+
+	payload := map[string]any{key: done}
+	if failures := partial.Failures(); len(failures) > 0 {
+		payload["errors"] = failures
+	}
+
+	if opts.OutDir != "" {
+		payload["repo"] = repo
+		report := cmdutil.RepoReport{
+			Repo:    repo,
+			Summary: fmt.Sprintf("%d %s, %d failed", len(done), key, len(targets)-len(done)),
+			Data:    payload,
+			Markdown: func(w io.Writer) error {
+				if _, err := fmt.Fprint(w, "| Pull request | Result |\n| --- | --- |\n"); err != nil {
+					return err
+				}
+				for i, t := range targets {
+					result := verb
+					if errs[i] != nil {
+						result = "failed: " + errs[i].Error()
+					}
+					if _, err := fmt.Fprintf(w, "| #%d | %s |\n", t.ID, cmdutil.MarkdownText(result)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+		if err := cmdutil.WriteReports(ios.Out, opts.OutDir, opts.OutFormat, []cmdutil.RepoReport{report}); err != nil {
+			return err
+		}
+	} else if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(targets) == 0 {
+			_, err := fmt.Fprintln(ios.Out, "No matching open pull requests.")
+			return err
+		}
+		for _, id := range done {
+			if _, err := fmt.Fprintf(ios.Out, "✓ %s pull request #%d\n", verb, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	err = partial.Err(ios.ErrOut)
+	var exitErr *cmdutil.ExitError
+	if first != nil && (err == nil || errors.As(err, &exitErr)) {
+		return first
+	}
+	return err
+}
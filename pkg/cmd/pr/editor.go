@@ -0,0 +1,23 @@
+package pr
+
+import (
+	"fmt"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+// canEdit reports whether text can be composed in the user's editor, which
+// needs a terminal on both stdin and stdout.
+func canEdit(ios *iostreams.IOStreams) bool {
+	return ios.CanPrompt() && ios.IsStdoutTTY()
+}
+
+// editDescription opens the current description of pull request id in the
+// user's editor and returns the result.
+func editDescription(f *cmdutil.Factory, id int, current string) (string, error) {
+	return f.TextEditor().Edit("PULLREQ_DESCRIPTION.md", current,
+		fmt.Sprintf("Edit the description of pull request #%d.", id),
+		"Saving an empty description clears it.",
+	)
+}
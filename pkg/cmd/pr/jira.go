@@ -0,0 +1,181 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// defaultJiraKeyPattern matches Jira issue keys such as PROJ-123. It also
+// matches identifiers like UTF-8 or SHA-256, so it is only applied to branch
+// names and skips the prefixes in nonIssuePrefixes.
+const defaultJiraKeyPattern = `\b[A-Z][A-Z0-9_]+-[0-9]+\b`
+
+// nonIssuePrefixes are standards and encodings that look like issue keys.
+var nonIssuePrefixes = map[string]bool{
+	"CVE": true, "ISO": true, "RFC": true, "SHA": true, "UTF": true, "UCS": true,
+}
+
+// jiraDetector finds Jira issue keys. Only a configured jira.key_pattern or
+// jira.projects is trusted in commit messages, which often mention things
+// like ISO-8601 that the default pattern would take for keys.
+type jiraDetector struct {
+	re         *regexp.Regexp
+	configured bool
+}
+
+// newJiraDetector builds a detector from jira.projects, jira.key_pattern or
+// the default pattern, in that order.
+func newJiraDetector(cfg *config.Jira) (*jiraDetector, error) {
+	if cfg != nil && len(cfg.Projects) > 0 {
+		projects := make([]string, 0, len(cfg.Projects))
+		for _, project := range cfg.Projects {
+			if project = strings.TrimSpace(project); project != "" {
+				projects = append(projects, regexp.QuoteMeta(project))
+			}
+		}
+		if len(projects) > 0 {
+			re := regexp.MustCompile(`\b(?:` + strings.Join(projects, "|") + `)-[0-9]+\b`)
+			return &jiraDetector{re: re, configured: true}, nil
+		}
+	}
+	if cfg != nil && strings.TrimSpace(cfg.KeyPattern) != "" {
+		re, err := regexp.Compile(cfg.KeyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jira.key_pattern %q: %w", cfg.KeyPattern, err)
+		}
+		return &jiraDetector{re: re, configured: true}, nil
+	}
+	return &jiraDetector{re: regexp.MustCompile(defaultJiraKeyPattern)}, nil
+}
+
+// keys returns the distinct keys matched in texts, in order of first
+// appearance.
+func (d *jiraDetector) keys(texts ...string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, key := range d.re.FindAllString(text, -1) {
+			if !d.configured {
+				prefix, _, _ := strings.Cut(key, "-")
+				if nonIssuePrefixes[prefix] {
+					continue
+				}
+			}
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// detect looks for keys in the source branch name and then, for configured
+// patterns, in the messages of the commits the branch adds over target.
+// Commit messages come from the local clone, so outside one only the branch
+// name is used.
+func (d *jiraDetector) detect(ctx context.Context, source, target string) []string {
+	if keys := d.keys(source); len(keys) > 0 || !d.configured {
+		return keys
+	}
+	return d.keys(localCommitMessages(ctx, source, target))
+}
+
+// localCommitMessages returns the messages of commits on source that are not
+// on target, trying remote-tracking refs before local branches. It returns ""
+// when git or the refs are unavailable.
+func localCommitMessages(ctx context.Context, source, target string) string {
+	if source == "" || target == "" {
+		return ""
+	}
+	for _, refs := range [][2]string{
+		{"origin/" + target, "origin/" + source},
+		{"origin/" + target, source},
+		{target, source},
+	} {
+		out, err := exec.CommandContext(ctx, "git", "log", "--format=%B", refs[0]+".."+refs[1], "--").Output()
+		if err == nil {
+			return string(out)
+		}
+	}
+	return ""
+}
+
+// applyJira adds the Jira keys from --jira, or the detected ones, to the
+// title and description of the pull request about to be created.
+func applyJira(ctx context.Context, f *cmdutil.Factory, errOut io.Writer, opts *createOptions) error {
+	cfg, err := f.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	keys := opts.Jira
+	if len(keys) == 1 && strings.EqualFold(keys[0], "none") {
+		return nil
+	}
+	if len(keys) == 0 {
+		if cfg.Jira != nil && cfg.Jira.Disabled {
+			return nil
+		}
+		detector, err := newJiraDetector(cfg.Jira)
+		if err != nil {
+			return err
+		}
+		keys = detector.detect(ctx, opts.Source, opts.Target)
+		if len(keys) > 0 {
+			if _, err := fmt.Fprintf(errOut, "Linking Jira issue %s\n", strings.Join(keys, ", ")); err != nil {
+				return err
+			}
+		}
+	}
+
+	baseURL := ""
+	if cfg.Jira != nil {
+		baseURL = cfg.Jira.BaseURL
+	}
+	opts.Title, opts.Description = applyJiraKeys(opts.Title, opts.Description, keys, baseURL)
+	return nil
+}
+
+// applyJiraKeys prefixes title with the keys it does not mention yet and
+// appends a reference to each key missing from description, linking to the
+// issue when baseURL is set.
+func applyJiraKeys(title, description string, keys []string, baseURL string) (string, string) {
+	var missing []string
+	for _, key := range keys {
+		if !strings.Contains(title, key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		title = strings.Join(missing, " ") + ": " + title
+	}
+
+	var refs []string
+	for _, key := range keys {
+		if strings.Contains(description, key) {
+			continue
+		}
+		if baseURL != "" {
+			refs = append(refs, fmt.Sprintf("[%s](%s/browse/%s)", key, strings.TrimRight(baseURL, "/"), key))
+		} else {
+			refs = append(refs, key)
+		}
+	}
+	if len(refs) > 0 {
+		line := "Jira: " + strings.Join(refs, ", ")
+		if strings.TrimSpace(description) == "" {
+			description = line
+		} else {
+			description = strings.TrimRight(description, "\n") + "\n\n" + line
+		}
+	}
+	return title, description
+}
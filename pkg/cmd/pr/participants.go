@@ -0,0 +1,278 @@
+package pr
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// participant is a reviewer or participant of a pull request in a form
+// shared by both hosts.
+type participant struct {
+	Name           string `json:"name"`
+	User           string `json:"user"`
+	Role           string `json:"role"`
+	Approved       bool   `json:"approved"`
+	State          string `json:"state"`
+	ParticipatedOn string `json:"participated_on,omitempty"`
+}
+
+// Participant states, from the reviewer's point of view.
+const (
+	stateApproved         = "approved"
+	stateChangesRequested = "changes requested"
+	statePending          = "pending"
+	stateCommented        = "commented"
+)
+
+// participantsDC merges the reviewers and other participants of a Data
+// Center pull request, reviewers first.
+func participantsDC(pr *bbdc.PullRequest) []participant {
+	out := make([]participant, 0, len(pr.Reviewers)+len(pr.Participants))
+	for _, r := range pr.Reviewers {
+		out = append(out, participant{
+			Name:     cmdutil.FirstNonEmpty(r.User.FullName, r.User.Name),
+			User:     cmdutil.FirstNonEmpty(r.User.Slug, r.User.Name),
+			Role:     "reviewer",
+			Approved: r.Approved,
+			State:    participantStateDC(r.Status, r.Approved, true),
+		})
+	}
+	for _, p := range pr.Participants {
+		out = append(out, participant{
+			Name:     cmdutil.FirstNonEmpty(p.User.FullName, p.User.Name),
+			User:     cmdutil.FirstNonEmpty(p.User.Slug, p.User.Name),
+			Role:     strings.ToLower(cmdutil.FirstNonEmpty(p.Role, "participant")),
+			Approved: p.Approved,
+			State:    participantStateDC(p.Status, p.Approved, false),
+		})
+	}
+	return out
+}
+
+func participantStateDC(status string, approved, reviewer bool) string {
+	switch {
+	case approved || strings.EqualFold(status, "APPROVED"):
+		return stateApproved
+	case strings.EqualFold(status, "NEEDS_WORK"):
+		return stateChangesRequested
+	case reviewer:
+		return statePending
+	default:
+		return stateCommented
+	}
+}
+
+// participantsCloud lists the participants of a Cloud pull request,
+// reviewers first.
+func participantsCloud(pr *bbcloud.PullRequest) []participant {
+	var reviewers, others []participant
+	for _, p := range pr.Participants {
+		row := participant{
+			Name:           cmdutil.FirstNonEmpty(p.User.DisplayName, p.User.Nickname),
+			User:           cmdutil.FirstNonEmpty(p.User.AccountID, p.User.UUID),
+			Role:           strings.ToLower(cmdutil.FirstNonEmpty(p.Role, "participant")),
+			Approved:       p.Approved,
+			ParticipatedOn: p.ParticipatedOn,
+		}
+		reviewer := strings.EqualFold(p.Role, "REVIEWER")
+		switch {
+		case p.Approved || p.State == "approved":
+			row.State = stateApproved
+		case p.State == "changes_requested":
+			row.State = stateChangesRequested
+		case reviewer:
+			row.State = statePending
+		default:
+			row.State = stateCommented
+		}
+		if reviewer {
+			reviewers = append(reviewers, row)
+		} else {
+			others = append(others, row)
+		}
+	}
+	return append(reviewers, others...)
+}
+
+// approvalSummary returns e.g. "1 of 2 reviewers approved", counting
+// approvals by non-reviewers on top.
+func approvalSummary(rows []participant) string {
+	reviewers, approved, extra := 0, 0, 0
+	for _, p := range rows {
+		switch {
+		case p.Role == "reviewer":
+			reviewers++
+			if p.Approved {
+				approved++
+			}
+		case p.Approved:
+			extra++
+		}
+	}
+	summary := fmt.Sprintf("%d of %d reviewers approved", approved, reviewers)
+	if extra > 0 {
+		summary += fmt.Sprintf(", %d other approval(s)", extra)
+	}
+	return summary
+}
+
+func participantMark(state string) string {
+	switch state {
+	case stateApproved:
+		return "✓"
+	case stateChangesRequested:
+		return "✗"
+	case statePending:
+		return "•"
+	default:
+		return " "
+	}
+}
+
+// printParticipants writes one aligned line per participant, indented by
+// prefix.
+func printParticipants(w io.Writer, rows []participant, prefix string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, p := range rows {
+		line := fmt.Sprintf("%s%s %s\t%s\t%s", prefix, participantMark(p.State), p.Name, p.Role, p.State)
+		if p.ParticipatedOn != "" {
+			when := p.ParticipatedOn
+			if t, err := time.Parse(time.RFC3339Nano, when); err == nil {
+				when = t.Local().Format("2006-01-02 15:04")
+			}
+			line += "\t" + when
+		}
+		if _, err := fmt.Fprintln(tw, line); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// printViewParticipants renders the participants section of pr view.
+func printViewParticipants(w io.Writer, rows []participant) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nParticipants (%s):\n", approvalSummary(rows)); err != nil {
+		return err
+	}
+	return printParticipants(w, rows, "  ")
+}
+
+type participantsOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+}
+
+func newParticipantsCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &participantsOptions{}
+	cmd := &cobra.Command{
+		Use:               "participants <id>",
+		Aliases:           []string{"reviewers"},
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Show who has approved a pull request",
+		Long: `List the reviewers and other participants of a pull request with their review
+state: approved (✓), changes requested (✗), or pending (•) for reviewers who
+have not reviewed yet. Participants who only commented are listed last.`,
+		Example: `  bkt pr participants 42
+  bkt pr participants 42 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid pull request id %q", args[0])
+			}
+			return runParticipants(cmd, f, id, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+
+	return cmd
+}
+
+func runParticipants(cmd *cobra.Command, f *cmdutil.Factory, id int, opts *participantsOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	var rows []participant
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+		pr, err := client.GetPullRequest(ctx, projectKey, repoSlug, id)
+		if err != nil {
+			return err
+		}
+		rows = participantsDC(pr)
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+		pr, err := client.GetPullRequest(ctx, workspace, repoSlug, id)
+		if err != nil {
+			return err
+		}
+		rows = participantsCloud(pr)
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	payload := map[string]any{
+		"id":           id,
+		"participants": rows,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(rows) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "Pull request #%d has no reviewers or participants.\n", id)
+			return err
+		}
+		if err := printParticipants(ios.Out, rows, ""); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(ios.Out, "\n%s\n", approvalSummary(rows))
+		return err
+	})
+}
@@ -0,0 +1,208 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+type patchOptions struct {
+	Workspace string
+	Repo      string
+	Output    string
+	Clobber   bool
+	Apply     bool
+}
+
+func newPatchCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &patchOptions{}
+	cmd := &cobra.Command{
+		Use:               "patch <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Export a pull request as a patch file (Cloud)",
+		Long: `Download the commits of a pull request as a series of patches in the format
+written by git format-patch, without checking out its branch.
+
+The patch is saved to pr-<id>.patch unless --output names another file or -
+for stdout, and can be applied with git am to keep the original commits. With
+--apply it is piped into git apply instead, changing the working tree of the
+current repository without committing; the current directory must be a clone
+of the pull request's repository.`,
+		Example: `  bkt pr patch 42
+  bkt pr patch 42 -o - | git am
+  bkt pr patch 42 --apply`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid pull request id %q", args[0])
+			}
+			if opts.Apply && opts.Output != "" {
+				return fmt.Errorf("--apply cannot be combined with --output")
+			}
+			return runPatch(cmd, f, id, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Destination path, or - for stdout (default: pr-<id>.patch)")
+	cmd.Flags().BoolVar(&opts.Clobber, "clobber", false, "Overwrite an existing file")
+	cmd.Flags().BoolVar(&opts.Apply, "apply", false, "Apply the patch to the working tree with git apply")
+
+	return cmd
+}
+
+func runPatch(cmd *cobra.Command, f *cmdutil.Factory, id int, opts *patchOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	if opts.Apply && !cmdutil.InRepoClone(host, workspace, repoSlug) {
+		return fmt.Errorf("--apply needs a clone of %s/%s; the current directory has no remote for it (use --output to save the patch instead)", workspace, repoSlug)
+	}
+
+	// Patches can be large, so only --timeout bounds the download.
+	ctx, cancel := cmdutil.CommandContext(cmd, 0)
+	defer cancel()
+
+	if opts.Output == "-" {
+		return client.GetPullRequestPatch(ctx, workspace, repoSlug, id, ios.Out)
+	}
+
+	if opts.Apply {
+		apply := &gitApplyWriter{ctx: ctx, stdout: ios.Out, stderr: ios.ErrOut}
+		if err := client.GetPullRequestPatch(ctx, workspace, repoSlug, id, apply); err != nil {
+			apply.abort()
+			return err
+		}
+		if apply.cmd == nil {
+			return fmt.Errorf("pull request #%d has no changes to export", id)
+		}
+		if err := apply.close(); err != nil {
+			return fmt.Errorf("git apply: %w", err)
+		}
+		_, err := fmt.Fprintf(ios.Out, "✓ Applied pull request #%d to the working tree\n", id)
+		return err
+	}
+
+	dest := cmdutil.FirstNonEmpty(opts.Output, fmt.Sprintf("pr-%d.patch", id))
+	if !opts.Clobber {
+		if _, err := os.Stat(dest); err == nil {
+			return fmt.Errorf("%s already exists; use --clobber to overwrite", dest)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	// Write to a temporary file beside the destination so a failed download
+	// never leaves a truncated patch behind.
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := client.GetPullRequestPatch(ctx, workspace, repoSlug, id, tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return fmt.Errorf("pull request #%d has no changes to export", id)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace":    workspace,
+		"repo":         repoSlug,
+		"pull_request": id,
+		"path":         dest,
+	}
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Wrote pull request #%d to %s (apply with: git am %s)\n", id, dest, dest)
+		return err
+	})
+}
+
+// gitApplyWriter pipes a patch into git apply, starting it on the first write
+// so an empty patch never reaches git.
+type gitApplyWriter struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+}
+
+func (g *gitApplyWriter) Write(p []byte) (int, error) {
+	if g.cmd == nil {
+		cmd := exec.CommandContext(g.ctx, "git", "apply")
+		cmd.Stdout = g.stdout
+		cmd.Stderr = g.stderr
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return 0, err
+		}
+		if err := cmd.Start(); err != nil {
+			return 0, fmt.Errorf("git apply: %w", err)
+		}
+		g.cmd, g.stdin = cmd, stdin
+	}
+	return g.stdin.Write(p)
+}
+
+// close ends the patch and waits for git apply to finish.
+func (g *gitApplyWriter) close() error {
+	if g.cmd == nil {
+		return nil
+	}
+	if err := g.stdin.Close(); err != nil {
+		_ = g.cmd.Wait()
+		return err
+	}
+	return g.cmd.Wait()
+}
+
+// abort stops git apply before it sees the end of a partial patch, so the
+// working tree is left untouched.
+func (g *gitApplyWriter) abort() {
+	if g.cmd == nil {
+		return
+	}
+	_ = g.cmd.Process.Kill()
+	_ = g.cmd.Wait()
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
 	"os/exec"
@@ -42,6 +43,7 @@ func NewCmdPR(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(newEditCmd(f))
 	cmd.AddCommand(newCheckoutCmd(f))
 	cmd.AddCommand(newDiffCmd(f))
+	cmd.AddCommand(newPatchCmd(f))
 	cmd.AddCommand(newApproveCmd(f))
 	cmd.AddCommand(newMergeCmd(f))
 	cmd.AddCommand(newCommentCmd(f))
@@ -51,6 +53,7 @@ func NewCmdPR(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(newReactionCmd(f))
 	cmd.AddCommand(newSuggestionCmd(f))
 	cmd.AddCommand(newChecksCmd(f))
+	cmd.AddCommand(newParticipantsCmd(f))
 
 	return cmd
 }
@@ -62,6 +65,12 @@ type listOptions struct {
 	State     string
 	Limit     int
 	Mine      bool
+	// ReviewRequested and ReviewedBy hold a user, or "@me" for the
+	// authenticated one.
+	ReviewRequested string
+	ReviewedBy      string
+	OutDir          string
+	OutFormat       string
 }
 
 func newListCmd(f *cmdutil.Factory) *cobra.Command {
@@ -70,6 +79,16 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List pull requests",
+		Long: `List pull requests in a repository.
+
+--review-requested and --reviewed-by take a user or @me. On Bitbucket Cloud a
+user is a UUID in braces or an account ID; on Data Center it is a username.
+Without a repository, --mine and (Data Center only) --review-requested @me
+list pull requests across every repository you can see.`,
+		Example: `  bkt pr list
+  bkt pr list --review-requested @me
+  bkt pr list --reviewed-by @me --state MERGED
+  bkt pr list --mine --state ALL`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd, f, opts)
 		},
@@ -81,6 +100,9 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.State, "state", opts.State, "Filter by state (OPEN, MERGED, DECLINED)")
 	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum pull requests to list (0 for all)")
 	cmd.Flags().BoolVar(&opts.Mine, "mine", false, "Show pull requests authored by the authenticated user")
+	cmd.Flags().StringVar(&opts.ReviewRequested, "review-requested", "", "Only pull requests with this `user` (or @me) as a reviewer")
+	cmd.Flags().StringVar(&opts.ReviewedBy, "reviewed-by", "", "Only pull requests this `user` (or @me) approved, requested changes on or commented on")
+	cmdutil.AddOutDirFlags(cmd, &opts.OutDir, &opts.OutFormat)
 
 	return cmd
 }
@@ -102,12 +124,16 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
 		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
 
-		// If no repo specified, use the dashboard endpoint (requires --mine)
+		// If no repo specified, use the dashboard endpoint (requires --mine
+		// or --review-requested @me)
 		if repoSlug == "" {
-			if !opts.Mine {
-				return fmt.Errorf("--mine is required when not specifying a repository")
+			switch {
+			case opts.Mine:
+				return runListDashboardDC(cmd, f, ios, host, opts, "AUTHOR")
+			case opts.ReviewRequested == "@me" && opts.ReviewedBy == "":
+				return runListDashboardDC(cmd, f, ios, host, opts, "REVIEWER")
 			}
-			return runListDashboardDC(cmd, f, ios, host, opts)
+			return fmt.Errorf("--mine is required when not specifying a repository (or --review-requested @me)")
 		}
 
 		if projectKey == "" {
@@ -119,7 +145,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		prs, err := client.ListPullRequests(ctx, projectKey, repoSlug, opts.State, opts.Limit)
@@ -138,6 +164,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			}
 			prs = filtered
 		}
+		prs = filterReviewersDC(prs, host, opts)
 
 		payload := map[string]any{
 			"project":       projectKey,
@@ -145,6 +172,14 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			"pull_requests": prs,
 		}
 
+		if opts.OutDir != "" {
+			return writePRReports(ios, opts, []cmdutil.RepoReport{{
+				Repo:    projectKey + "/" + repoSlug,
+				Summary: fmt.Sprintf("%d pull request(s)", len(prs)),
+				Data:    payload,
+			}})
+		}
+
 		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
 			if len(prs) == 0 {
 				_, err := fmt.Fprintf(ios.Out, "No pull requests (%s).\n", strings.ToUpper(opts.State))
@@ -169,6 +204,9 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 
 		// If no repo specified, use the workspace endpoint (requires --mine)
 		if repoSlug == "" {
+			if opts.ReviewRequested != "" || opts.ReviewedBy != "" {
+				return fmt.Errorf("--review-requested and --reviewed-by need a repository on Bitbucket Cloud; use --repo")
+			}
 			if !opts.Mine {
 				return fmt.Errorf("--mine is required when not specifying a repository")
 			}
@@ -187,7 +225,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		mine := ""
@@ -195,10 +233,17 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			mine = host.Username
 		}
 
+		reviewRequested, reviewedBy, err := resolveReviewUsersCloud(ctx, client, opts)
+		if err != nil {
+			return err
+		}
+
 		prs, err := client.ListPullRequests(ctx, workspace, repoSlug, bbcloud.PullRequestListOptions{
-			State: opts.State,
-			Limit: opts.Limit,
-			Mine:  mine,
+			State:           opts.State,
+			Limit:           opts.Limit,
+			Mine:            mine,
+			ReviewRequested: reviewRequested,
+			ReviewedBy:      reviewedBy,
 		})
 		if err != nil {
 			return err
@@ -210,6 +255,14 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			"pull_requests": prs,
 		}
 
+		if opts.OutDir != "" {
+			return writePRReports(ios, opts, []cmdutil.RepoReport{{
+				Repo:    workspace + "/" + repoSlug,
+				Summary: fmt.Sprintf("%d pull request(s)", len(prs)),
+				Data:    payload,
+			}})
+		}
+
 		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
 			if len(prs) == 0 {
 				_, err := fmt.Fprintf(ios.Out, "No pull requests (%s).\n", strings.ToUpper(opts.State))
@@ -233,25 +286,120 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 	}
 }
 
+// resolveReviewUsersCloud turns the --review-requested and --reviewed-by
+// values into Cloud user identifiers, looking up the UUID for @me.
+func resolveReviewUsersCloud(ctx context.Context, client *bbcloud.Client, opts *listOptions) (reviewRequested, reviewedBy string, err error) {
+	reviewRequested = strings.TrimSpace(opts.ReviewRequested)
+	reviewedBy = strings.TrimSpace(opts.ReviewedBy)
+	if reviewRequested != "@me" && reviewedBy != "@me" {
+		return reviewRequested, reviewedBy, nil
+	}
+
+	me, err := client.CurrentUser(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if reviewRequested == "@me" {
+		reviewRequested = me.UUID
+	}
+	if reviewedBy == "@me" {
+		reviewedBy = me.UUID
+	}
+	return reviewRequested, reviewedBy, nil
+}
+
+// filterReviewersDC applies --review-requested and --reviewed-by to a Data
+// Center listing, matching users by name or slug.
+func filterReviewersDC(prs []bbdc.PullRequest, host *config.Host, opts *listOptions) []bbdc.PullRequest {
+	resolve := func(user string) string {
+		user = strings.TrimSpace(user)
+		if user == "@me" {
+			user = host.Username
+		}
+		return user
+	}
+	reviewRequested := resolve(opts.ReviewRequested)
+	reviewedBy := resolve(opts.ReviewedBy)
+	if reviewRequested == "" && reviewedBy == "" {
+		return prs
+	}
+
+	is := func(u bbdc.User, name string) bool {
+		return strings.EqualFold(u.Name, name) || strings.EqualFold(u.Slug, name)
+	}
+
+	filtered := prs[:0]
+	for _, pr := range prs {
+		requested, reviewed := false, false
+		for _, r := range pr.Reviewers {
+			if reviewRequested != "" && is(r.User, reviewRequested) {
+				requested = true
+			}
+			if reviewedBy != "" && is(r.User, reviewedBy) && (r.Approved || (r.Status != "" && !strings.EqualFold(r.Status, "UNAPPROVED"))) {
+				reviewed = true
+			}
+		}
+		for _, p := range pr.Participants {
+			if reviewedBy != "" && is(p.User, reviewedBy) {
+				reviewed = true
+			}
+		}
+		if (reviewRequested == "" || requested) && (reviewedBy == "" || reviewed) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
 // runListDashboardDC lists pull requests for the authenticated user across all repositories (Data Center).
-func runListDashboardDC(cmd *cobra.Command, f *cmdutil.Factory, ios *iostreams.IOStreams, host *config.Host, opts *listOptions) error {
+func runListDashboardDC(cmd *cobra.Command, f *cmdutil.Factory, ios *iostreams.IOStreams, host *config.Host, opts *listOptions, role string) error {
 	client, err := cmdutil.NewDCClient(host)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	prs, err := client.ListDashboardPullRequests(ctx, bbdc.DashboardPullRequestsOptions{
 		State: opts.State,
-		Role:  "AUTHOR",
+		Role:  role,
 		Limit: opts.Limit,
 	})
 	if err != nil {
 		return err
 	}
 
+	if opts.OutDir != "" {
+		groups := make(map[string][]bbdc.PullRequest)
+		for _, pr := range prs {
+			repoInfo := pr.ToRef.Repository.Slug
+			if pr.ToRef.Repository.Project != nil && pr.ToRef.Repository.Project.Key != "" {
+				repoInfo = pr.ToRef.Repository.Project.Key + "/" + repoInfo
+			}
+			groups[repoInfo] = append(groups[repoInfo], pr)
+		}
+		var reports []cmdutil.RepoReport
+		for repoInfo, repoPRs := range groups {
+			repoPRs := repoPRs
+			reports = append(reports, cmdutil.RepoReport{
+				Repo:    repoInfo,
+				Summary: fmt.Sprintf("%d pull request(s)", len(repoPRs)),
+				Data:    map[string]any{"repo": repoInfo, "pull_requests": repoPRs},
+				Markdown: func(w io.Writer) error {
+					for _, pr := range repoPRs {
+						author := cmdutil.FirstNonEmpty(pr.Author.User.FullName, pr.Author.User.Name)
+						if _, err := fmt.Fprintf(w, "- #%d %s %s (%s -> %s) by %s\n", pr.ID, pr.State, cmdutil.MarkdownText(pr.Title), cmdutil.MarkdownText(pr.FromRef.DisplayID), cmdutil.MarkdownText(pr.ToRef.DisplayID), cmdutil.MarkdownText(author)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			})
+		}
+		return writePRReports(ios, opts, reports)
+	}
+
 	payload := map[string]any{
 		"pull_requests": prs,
 	}
@@ -297,7 +445,7 @@ func runListWorkspaceCloud(cmd *cobra.Command, f *cmdutil.Factory, ios *iostream
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	// Fetch the current user to get the actual Bitbucket username (not the email used for auth)
@@ -327,6 +475,36 @@ func runListWorkspaceCloud(cmd *cobra.Command, f *cmdutil.Factory, ios *iostream
 		return err
 	}
 
+	if opts.OutDir != "" {
+		groups := make(map[string][]bbcloud.PullRequest)
+		for _, pr := range prs {
+			repoInfo := pr.Destination.Repository.Slug
+			if repoInfo == "" {
+				repoInfo = extractRepoFromCloudPRLink(pr.Links.HTML.Href)
+			}
+			groups[workspace+"/"+repoInfo] = append(groups[workspace+"/"+repoInfo], pr)
+		}
+		var reports []cmdutil.RepoReport
+		for repoInfo, repoPRs := range groups {
+			repoPRs := repoPRs
+			reports = append(reports, cmdutil.RepoReport{
+				Repo:    repoInfo,
+				Summary: fmt.Sprintf("%d pull request(s)", len(repoPRs)),
+				Data:    map[string]any{"repo": repoInfo, "pull_requests": repoPRs},
+				Markdown: func(w io.Writer) error {
+					for _, pr := range repoPRs {
+						author := cmdutil.FirstNonEmpty(pr.Author.DisplayName, pr.Author.Username)
+						if _, err := fmt.Fprintf(w, "- #%d %s %s (%s -> %s) by %s\n", pr.ID, pr.State, cmdutil.MarkdownText(pr.Title), cmdutil.MarkdownText(pr.Source.Branch.Name), cmdutil.MarkdownText(pr.Destination.Branch.Name), cmdutil.MarkdownText(author)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			})
+		}
+		return writePRReports(ios, opts, reports)
+	}
+
 	payload := map[string]any{
 		"workspace":     workspace,
 		"pull_requests": prs,
@@ -363,6 +541,11 @@ func runListWorkspaceCloud(cmd *cobra.Command, f *cmdutil.Factory, ios *iostream
 	})
 }
 
+// writePRReports writes per-repository pull request reports for --out-dir.
+func writePRReports(ios *iostreams.IOStreams, opts *listOptions, reports []cmdutil.RepoReport) error {
+	return cmdutil.WriteReports(ios.Out, opts.OutDir, opts.OutFormat, reports)
+}
+
 // extractRepoFromCloudPRLink extracts the repository slug from a Bitbucket Cloud PR URL.
 // This is a fallback method; prefer using PullRequest.Destination.Repository.Slug directly.
 // URL format: https://bitbucket.org/{workspace}/{repo}/pull-requests/{id}
@@ -386,9 +569,10 @@ type viewOptions struct {
 func newViewCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &viewOptions{}
 	cmd := &cobra.Command{
-		Use:   "view <id>",
-		Short: "Show details for a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "view <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Show details for a pull request",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -402,7 +586,7 @@ func newViewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
-	cmd.Flags().BoolVar(&opts.Web, "web", false, "Open the pull request in your browser")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "pull request")
 
 	return cmd
 }
@@ -432,7 +616,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		pr, err := client.GetPullRequest(ctx, projectKey, repoSlug, opts.ID)
@@ -447,13 +631,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 		}
 
 		if opts.Web {
-			if link := firstPRLinkDC(pr, "self"); link != "" {
-				if err := f.BrowserOpener().Open(link); err != nil {
-					return fmt.Errorf("open browser: %w", err)
-				}
-			} else {
-				return fmt.Errorf("pull request does not expose a web URL")
-			}
+			return cmdutil.OpenInBrowser(f, ios.Out, "pull request", firstPRLinkDC(pr, "self"))
 		}
 
 		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
@@ -470,22 +648,12 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 				return err
 			}
 			if strings.TrimSpace(pr.Description) != "" {
-				if _, err := fmt.Fprintf(ios.Out, "\n%s\n", pr.Description); err != nil {
+				if _, err := fmt.Fprintf(ios.Out, "\n%s\n", cmdutil.RenderMarkdown(ios, pr.Description)); err != nil {
 					return err
 				}
 			}
 
-			if len(pr.Reviewers) > 0 {
-				if _, err := fmt.Fprintln(ios.Out, "\nReviewers:"); err != nil {
-					return err
-				}
-				for _, reviewer := range pr.Reviewers {
-					if _, err := fmt.Fprintf(ios.Out, "  %s\n", cmdutil.FirstNonEmpty(reviewer.User.FullName, reviewer.User.Name)); err != nil {
-						return err
-					}
-				}
-			}
-			return nil
+			return printViewParticipants(ios.Out, participantsDC(pr))
 		})
 
 	case "cloud":
@@ -500,7 +668,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		pr, err := client.GetPullRequest(ctx, workspace, repoSlug, opts.ID)
@@ -515,13 +683,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 		}
 
 		if opts.Web {
-			if link := firstPRLinkCloud(pr); link != "" {
-				if err := f.BrowserOpener().Open(link); err != nil {
-					return fmt.Errorf("open browser: %w", err)
-				}
-			} else {
-				return fmt.Errorf("pull request does not expose a web URL")
-			}
+			return cmdutil.OpenInBrowser(f, ios.Out, "pull request", firstPRLinkCloud(pr))
 		}
 
 		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
@@ -538,11 +700,11 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 				return err
 			}
 			if strings.TrimSpace(pr.Summary.Raw) != "" {
-				if _, err := fmt.Fprintf(ios.Out, "\n%s\n", pr.Summary.Raw); err != nil {
+				if _, err := fmt.Fprintf(ios.Out, "\n%s\n", cmdutil.RenderMarkdown(ios, pr.Summary.Raw)); err != nil {
 					return err
 				}
 			}
-			return nil
+			return printViewParticipants(ios.Out, participantsCloud(pr))
 		})
 
 	default:
@@ -585,6 +747,7 @@ type createOptions struct {
 	Description string
 	Reviewers   []string
 	CloseSource bool
+	Jira        []string
 }
 
 func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
@@ -592,7 +755,36 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new pull request",
+		Long: `Create a new pull request. When --target is omitted the destination is
+taken from the repository branching model: release and hotfix branches target
+the production branch, everything else targets the development branch.
+
+Jira issue keys such as PROJ-123 found in the source branch name, or else in
+the messages of the commits it adds, are prepended to the title and listed in
+the description so the Jira integration links the pull request. Set
+jira.key_pattern in config.yml to match other keys, jira.base_url to link each
+key, or pass --jira to choose the keys yourself (--jira none skips them).
+
+Without --description, an interactive session opens BKT_EDITOR, GIT_EDITOR,
+VISUAL or EDITOR to write one; pass --description "" to skip it.`,
+		Example: `  bkt pr create --source feature/PROJ-123-retries --title "Retry uploads"
+  bkt pr create --source fix/timeouts --title "Fix timeouts" --jira OPS-7,OPS-9
+  bkt pr create --source spike --title "Try gRPC" --jira none`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("description") {
+				ios, err := f.Streams()
+				if err != nil {
+					return err
+				}
+				if canEdit(ios) {
+					if opts.Description, err = f.TextEditor().Edit("PULLREQ_DESCRIPTION.md", "",
+						fmt.Sprintf("Describe pull request %q (%s).", opts.Title, opts.Source),
+						"Leave it empty to create the pull request without a description.",
+					); err != nil {
+						return err
+					}
+				}
+			}
 			return runCreate(cmd, f, opts)
 		},
 	}
@@ -603,13 +795,15 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Title, "title", "", "Pull request title (required)")
 	cmd.Flags().StringVar(&opts.Description, "description", "", "Pull request description")
 	cmd.Flags().StringVar(&opts.Source, "source", "", "Source branch (required)")
-	cmd.Flags().StringVar(&opts.Target, "target", "", "Target branch (required)")
+	cmd.Flags().StringVar(&opts.Target, "target", "", "Target branch (default: suggested by the branching model)")
 	cmd.Flags().StringSliceVar(&opts.Reviewers, "reviewer", nil, "Reviewers to request (repeatable)")
 	cmd.Flags().BoolVar(&opts.CloseSource, "close-source", false, "Close source branch on merge")
+	cmd.Flags().StringSliceVar(&opts.Jira, "jira", nil, "Jira issue keys to reference instead of detected ones (none to skip)")
+	_ = cmd.RegisterFlagCompletionFunc("source", cmdutil.CompleteBranches(f))
+	_ = cmd.RegisterFlagCompletionFunc("target", cmdutil.CompleteBranches(f))
 
 	_ = cmd.MarkFlagRequired("title")
 	_ = cmd.MarkFlagRequired("source")
-	_ = cmd.MarkFlagRequired("target")
 
 	return cmd
 }
@@ -639,9 +833,23 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
+		if opts.Target == "" {
+			model, err := client.GetBranchModel(ctx, projectKey, repoSlug)
+			if err != nil {
+				return fmt.Errorf("determine destination branch: %w; pass --target", err)
+			}
+			if opts.Target, err = suggestTarget(ios.ErrOut, opts.Source, cmdutil.DCBranchModel(model)); err != nil {
+				return err
+			}
+		}
+
+		if err := applyJira(ctx, f, ios.ErrOut, opts); err != nil {
+			return err
+		}
+
 		pr, err := client.CreatePullRequest(ctx, projectKey, repoSlug, bbdc.CreatePROptions{
 			Title:        opts.Title,
 			Description:  opts.Description,
@@ -671,9 +879,23 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
+		if opts.Target == "" {
+			model, err := client.GetBranchingModel(ctx, workspace, repoSlug)
+			if err != nil {
+				return fmt.Errorf("determine destination branch: %w; pass --target", err)
+			}
+			if opts.Target, err = suggestTarget(ios.ErrOut, opts.Source, cmdutil.CloudBranchModel(model)); err != nil {
+				return err
+			}
+		}
+
+		if err := applyJira(ctx, f, ios.ErrOut, opts); err != nil {
+			return err
+		}
+
 		pr, err := client.CreatePullRequest(ctx, workspace, repoSlug, bbcloud.CreatePullRequestInput{
 			Title:       opts.Title,
 			Description: opts.Description,
@@ -704,14 +926,21 @@ type editOptions struct {
 	Title       string
 	Description string
 	Body        string
+	// Editor is set when no field flag was given in an interactive session,
+	// so the current description opens in the user's editor.
+	Editor bool
 }
 
 func newEditCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &editOptions{}
 	cmd := &cobra.Command{
-		Use:   "edit <id>",
-		Short: "Edit a pull request",
-		Long:  "Edit a pull request's title and/or description.",
+		Use:               "edit <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Edit a pull request",
+		Long: `Edit a pull request's title and/or description.
+
+Without --title, --body or --description, an interactive session opens the
+current description in BKT_EDITOR, GIT_EDITOR, VISUAL or EDITOR.`,
 		Example: `  # Update pull request title
   bkt pr edit 123 --title "New feature: user authentication"
 
@@ -719,7 +948,10 @@ func newEditCmd(f *cmdutil.Factory) *cobra.Command {
   bkt pr edit 123 --body "This PR adds OAuth2 support"
 
   # Update both title and description
-  bkt pr edit 123 -t "Fix login bug" -b "Resolves issue with session timeout"`,
+  bkt pr edit 123 -t "Fix login bug" -b "Resolves issue with session timeout"
+
+  # Rewrite the description in your editor
+  bkt pr edit 123`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
@@ -738,9 +970,16 @@ func newEditCmd(f *cmdutil.Factory) *cobra.Command {
 				opts.Description = opts.Body
 			}
 
-			// Require at least one field to update
+			// Require at least one field to update, or edit the description
 			if !cmd.Flags().Changed("title") && !cmd.Flags().Changed("description") && !cmd.Flags().Changed("body") {
-				return fmt.Errorf("at least one of --title, --body, or --description is required")
+				ios, err := f.Streams()
+				if err != nil {
+					return err
+				}
+				if !canEdit(ios) {
+					return fmt.Errorf("at least one of --title, --body, or --description is required")
+				}
+				opts.Editor = true
 			}
 
 			return runEdit(cmd, f, opts)
@@ -782,7 +1021,7 @@ func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		// Fetch current PR to get version (optimistic locking) and current values
@@ -800,6 +1039,14 @@ func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions) error {
 		if cmd.Flags().Changed("description") || cmd.Flags().Changed("body") {
 			newDesc = opts.Description
 		}
+		if opts.Editor {
+			if newDesc, err = editDescription(f, opts.ID, pr.Description); err != nil {
+				return err
+			}
+			// Editing may outlast the deadline; give the update its own.
+			ctx, cancel = cmdutil.CommandContext(cmd, 15*time.Second)
+			defer cancel()
+		}
 
 		updatedPR, err := client.UpdatePullRequest(ctx, projectKey, repoSlug, opts.ID, pr.Version, bbdc.UpdatePROptions{
 			Title:       newTitle,
@@ -835,7 +1082,7 @@ func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		// Build input with only changed fields
@@ -846,6 +1093,19 @@ func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions) error {
 		if cmd.Flags().Changed("description") || cmd.Flags().Changed("body") {
 			input.Description = &opts.Description
 		}
+		if opts.Editor {
+			pr, err := client.GetPullRequest(ctx, workspace, repoSlug, opts.ID)
+			if err != nil {
+				return err
+			}
+			if opts.Description, err = editDescription(f, opts.ID, pr.Summary.Raw); err != nil {
+				return err
+			}
+			input.Description = &opts.Description
+			// Editing may outlast the deadline; give the update its own.
+			ctx, cancel = cmdutil.CommandContext(cmd, 15*time.Second)
+			defer cancel()
+		}
 
 		updatedPR, err := client.UpdatePullRequest(ctx, workspace, repoSlug, opts.ID, input)
 		if err != nil {
@@ -879,9 +1139,10 @@ type checkoutOptions struct {
 func newCheckoutCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &checkoutOptions{Remote: "origin"}
 	cmd := &cobra.Command{
-		Use:   "checkout <id>",
-		Short: "Check out the pull request branch",
-		Args:  cobra.ExactArgs(1),
+		Use:               "checkout <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Check out the pull request branch",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -943,9 +1204,10 @@ type diffOptions struct {
 func newDiffCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &diffOptions{}
 	cmd := &cobra.Command{
-		Use:   "diff <id>",
-		Short: "Show the diff for a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "diff <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Show the diff for a pull request",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -989,7 +1251,7 @@ func runDiff(cmd *cobra.Command, f *cmdutil.Factory, opts *diffOptions) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	if opts.Stat {
@@ -1009,40 +1271,36 @@ func runDiff(cmd *cobra.Command, f *cmdutil.Factory, opts *diffOptions) error {
 		})
 	}
 
-	pager := f.PagerManager()
-	if pager.Enabled() {
-		w, err := pager.Start()
-		if err == nil {
-			defer func() { _ = pager.Stop() }()
-			return client.PullRequestDiff(ctx, projectKey, repoSlug, opts.ID, w)
-		}
-	}
-
 	return client.PullRequestDiff(ctx, projectKey, repoSlug, opts.ID, ios.Out)
 }
 
 func newApproveCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &bulkOptions{}
 	cmd := &cobra.Command{
-		Use:   "approve <id>",
-		Short: "Approve a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "approve <id>...",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Approve pull requests",
+		Long: `Approve one or more pull requests, or with --all every open pull request
+matching --query. Pull requests are approved concurrently; failures are
+reported at the end and make the command exit non-zero.`,
+		Example: `  bkt pr approve 12
+  bkt pr approve 12 14 18
+  bkt pr approve --all --query "author:renovate-bot branch:renovate/"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
+			ids, err := parseBulkArgs(args, opts)
 			if err != nil {
-				return fmt.Errorf("invalid pull request id %q", args[0])
+				return err
 			}
-			return runApprove(cmd, f, id)
+			return runApprove(cmd, f, ids, opts)
 		},
 	}
+
+	addBulkFlags(cmd, opts, "Approve")
+
 	return cmd
 }
 
-func runApprove(cmd *cobra.Command, f *cmdutil.Factory, id int) error {
-	ios, err := f.Streams()
-	if err != nil {
-		return err
-	}
-
+func runApprove(cmd *cobra.Command, f *cmdutil.Factory, ids []int, opts *bulkOptions) error {
 	override := cmdutil.FlagValue(cmd, "context")
 	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
 	if err != nil {
@@ -1052,10 +1310,10 @@ func runApprove(cmd *cobra.Command, f *cmdutil.Factory, id int) error {
 		return fmt.Errorf("pr approve currently supports Data Center contexts only")
 	}
 
-	projectKey := ctxCfg.ProjectKey
-	repoSlug := ctxCfg.DefaultRepo
+	projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+	repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
 	if projectKey == "" || repoSlug == "" {
-		return fmt.Errorf("context must supply project and repo")
+		return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
 	}
 
 	client, err := cmdutil.NewDCClient(host)
@@ -1063,57 +1321,63 @@ func runApprove(cmd *cobra.Command, f *cmdutil.Factory, id int) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, bulkTimeout(10*time.Second, ids, opts))
 	defer cancel()
 
-	if err := client.ApprovePullRequest(ctx, projectKey, repoSlug, id); err != nil {
+	targets, ok, err := resolveBulkTargets(ctx, f, client, projectKey, repoSlug, ids, opts, "Approve")
+	if err != nil || !ok {
 		return err
 	}
 
-	if _, err := fmt.Fprintf(ios.Out, "✓ Approved pull request #%d\n", id); err != nil {
-		return err
-	}
-	return nil
+	errs, first := runBulk(ctx, targets, opts.FailFast, func(ctx context.Context, t bulkTarget) error {
+		return client.ApprovePullRequest(ctx, projectKey, repoSlug, t.ID)
+	})
+	return reportBulk(cmd, f, projectKey+"/"+repoSlug, opts, targets, errs, first, "approved", "Approved")
 }
 
 type mergeOptions struct {
+	bulkOptions
 	Message     string
 	Strategy    string
 	CloseSource bool
-	Project     string
-	Repo        string
 }
 
 func newMergeCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &mergeOptions{}
 	cmd := &cobra.Command{
-		Use:   "merge <id>",
-		Short: "Merge a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "merge <id>...",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Merge pull requests",
+		Long: `Merge one or more pull requests, or with --all every open pull request matching
+--query, for example the flood of updates a dependency bot opens. Pull requests
+are merged concurrently; failures such as merge conflicts are reported at the
+end and make the command exit non-zero.`,
+		Example: `  bkt pr merge 42
+  bkt pr merge 12 14 18 --strategy squash
+  bkt pr merge --all --query "author:dependabot target:main" --yes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
+			ids, err := parseBulkArgs(args, &opts.bulkOptions)
 			if err != nil {
-				return fmt.Errorf("invalid pull request id %q", args[0])
+				return err
+			}
+			if len(ids) > 1 || opts.All {
+				if cmd.Flags().Changed("message") {
+					return fmt.Errorf("--message applies to a single pull request")
+				}
 			}
-			return runMerge(cmd, f, id, opts)
+			return runMerge(cmd, f, ids, opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
-	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	addBulkFlags(cmd, &opts.bulkOptions, "Merge")
 	cmd.Flags().StringVar(&opts.Message, "message", "", "Merge commit message override")
-	cmd.Flags().StringVar(&opts.Strategy, "strategy", "", "Merge strategy ID (e.g., fast-forward)")
+	cmd.Flags().StringVar(&opts.Strategy, "strategy", "", "Merge strategy ID (e.g., fast-forward); defaults to the merge_strategy setting")
 	cmd.Flags().BoolVar(&opts.CloseSource, "close-source", true, "Close source branch on merge")
 
 	return cmd
 }
 
-func runMerge(cmd *cobra.Command, f *cmdutil.Factory, id int, opts *mergeOptions) error {
-	ios, err := f.Streams()
-	if err != nil {
-		return err
-	}
-
+func runMerge(cmd *cobra.Command, f *cmdutil.Factory, ids []int, opts *mergeOptions) error {
 	override := cmdutil.FlagValue(cmd, "context")
 	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
 	if err != nil {
@@ -1134,26 +1398,36 @@ func runMerge(cmd *cobra.Command, f *cmdutil.Factory, id int, opts *mergeOptions
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, bulkTimeout(15*time.Second, ids, &opts.bulkOptions))
 	defer cancel()
 
-	pr, err := client.GetPullRequest(ctx, projectKey, repoSlug, id)
-	if err != nil {
-		return err
+	strategy := opts.Strategy
+	if strategy == "" {
+		if cfg, err := f.ResolveConfig(); err == nil {
+			strategy = cfg.Setting("merge_strategy")
+		}
 	}
 
-	if err := client.MergePullRequest(ctx, projectKey, repoSlug, id, pr.Version, bbdc.MergePROptions{
-		Message:           opts.Message,
-		Strategy:          opts.Strategy,
-		CloseSourceBranch: opts.CloseSource,
-	}); err != nil {
+	targets, ok, err := resolveBulkTargets(ctx, f, client, projectKey, repoSlug, ids, &opts.bulkOptions, "Merge")
+	if err != nil || !ok {
 		return err
 	}
 
-	if _, err := fmt.Fprintf(ios.Out, "✓ Merged pull request #%d\n", id); err != nil {
-		return err
-	}
-	return nil
+	errs, first := runBulk(ctx, targets, opts.FailFast, func(ctx context.Context, t bulkTarget) error {
+		pr := t.PR
+		if pr == nil {
+			var err error
+			if pr, err = client.GetPullRequest(ctx, projectKey, repoSlug, t.ID); err != nil {
+				return err
+			}
+		}
+		return client.MergePullRequest(ctx, projectKey, repoSlug, t.ID, pr.Version, bbdc.MergePROptions{
+			Message:           opts.Message,
+			Strategy:          strategy,
+			CloseSourceBranch: opts.CloseSource,
+		})
+	})
+	return reportBulk(cmd, f, projectKey+"/"+repoSlug, &opts.bulkOptions, targets, errs, first, "merged", "Merged")
 }
 
 type commentOptions struct {
@@ -1169,12 +1443,16 @@ type commentOptions struct {
 func newCommentCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &commentOptions{}
 	cmd := &cobra.Command{
-		Use:   "comment <id> --text <message>",
-		Short: "Comment on a pull request",
+		Use:               "comment <id> [--text <message>]",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Comment on a pull request",
 		Long: `Comment on a pull request.
 
 For Bitbucket Cloud, you can create inline comments on specific file lines using --file and --line flags.
-Use --line-from to specify a line range for the comment.`,
+Use --line-from to specify a line range for the comment.
+
+Without --text, an interactive session opens BKT_EDITOR, GIT_EDITOR, VISUAL or
+EDITOR to write the comment.`,
 		Example: `  # Add a general comment
   bkt pr comment 123 --text "Looks good!"
 
@@ -1204,6 +1482,22 @@ Use --line-from to specify a line range for the comment.`,
 				return fmt.Errorf("--line is required when --line-from is specified")
 			}
 
+			if !cmd.Flags().Changed("text") {
+				ios, err := f.Streams()
+				if err != nil {
+					return err
+				}
+				if !canEdit(ios) {
+					return fmt.Errorf("--text is required when not running interactively")
+				}
+				if opts.Text, err = f.TextEditor().Edit("PULLREQ_COMMENT.md", "", fmt.Sprintf("Write your comment on pull request #%d.", id)); err != nil {
+					return err
+				}
+			}
+			if strings.TrimSpace(opts.Text) == "" {
+				return fmt.Errorf("aborting: comment is empty")
+			}
+
 			return runComment(cmd, f, id, opts)
 		},
 	}
@@ -1215,7 +1509,6 @@ Use --line-from to specify a line range for the comment.`,
 	cmd.Flags().StringVar(&opts.FilePath, "file", "", "File path for inline comment (Cloud only)")
 	cmd.Flags().IntVar(&opts.Line, "line", 0, "Line number for inline comment (Cloud only, requires --file)")
 	cmd.Flags().IntVar(&opts.LineFrom, "line-from", 0, "Starting line for range comment (Cloud only, requires --file and --line)")
-	_ = cmd.MarkFlagRequired("text")
 
 	return cmd
 }
@@ -1250,7 +1543,7 @@ func runComment(cmd *cobra.Command, f *cmdutil.Factory, id int, opts *commentOpt
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 		defer cancel()
 
 		if err := client.CommentPullRequest(ctx, projectKey, repoSlug, id, opts.Text); err != nil {
@@ -1274,7 +1567,7 @@ func runComment(cmd *cobra.Command, f *cmdutil.Factory, id int, opts *commentOpt
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 		defer cancel()
 
 		comment, err := client.CommentPullRequest(ctx, workspace, repoSlug, id, bbcloud.CommentPullRequestOptions{
@@ -1328,10 +1621,11 @@ type checksOptions struct {
 func newChecksCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &checksOptions{}
 	cmd := &cobra.Command{
-		Use:     "checks <id>",
-		Aliases: []string{"builds"},
-		Short:   "Show build/CI status for a pull request",
-		Args:    cobra.ExactArgs(1),
+		Use:               "checks <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Aliases:           []string{"builds"},
+		Short:             "Show build/CI status for a pull request",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
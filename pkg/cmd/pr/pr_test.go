@@ -6,15 +6,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud/bbtest"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
 	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
@@ -2895,3 +2902,766 @@ func TestListWorkspaceCloudURLFallback(t *testing.T) {
 		t.Errorf("PR without slug should fallback to URL parsing and show 'repo-from-url', got:\n%s", output)
 	}
 }
+
+func TestSuggestTarget(t *testing.T) {
+	targets := cmdutil.BranchModel{
+		Development: "develop",
+		Production:  "main",
+		Prefixes: map[string]string{
+			"feature": "feature/",
+			"release": "release/",
+			"hotfix":  "hotfix/",
+		},
+	}
+
+	cases := map[string]string{
+		"feature/login": "develop",
+		"release/2.0":   "main",
+		"hotfix/crash":  "main",
+		"spike":         "develop",
+	}
+	for source, want := range cases {
+		var errOut strings.Builder
+		got, err := suggestTarget(&errOut, source, targets)
+		if err != nil {
+			t.Fatalf("suggestTarget(%q): %v", source, err)
+		}
+		if got != want {
+			t.Fatalf("suggestTarget(%q) = %q, want %q", source, got, want)
+		}
+		if !strings.Contains(errOut.String(), "Using destination branch "+want) {
+			t.Fatalf("expected notice on stderr, got %q", errOut.String())
+		}
+	}
+
+	if _, err := suggestTarget(io.Discard, "develop", targets); err == nil {
+		t.Fatal("expected error when source is the development branch")
+	}
+}
+
+func TestListReviewRequestedMeCloud(t *testing.T) {
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("failed to change to temp directory: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWd)
+	})
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			_ = json.NewEncoder(w).Encode(bbcloud.User{UUID: "{me}"})
+		case "/repositories/workspace/repo/pullrequests":
+			query = r.URL.Query().Get("q")
+			_, _ = w.Write([]byte(`{"values":[{"id":5,"title":"Needs eyes","state":"OPEN"}]}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "workspace", DefaultRepo: "repo"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "testuser", Token: "token"},
+		},
+	}
+	stdout := &strings.Builder{}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newListCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--review-requested", "@me", "--reviewed-by", "557058:abc"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("pr list: %v", err)
+	}
+	if want := `(reviewers.uuid="{me}") AND (participants.account_id="557058:abc")`; query != want {
+		t.Fatalf("q = %q, want %q", query, want)
+	}
+	if !strings.Contains(stdout.String(), "#5") {
+		t.Fatalf("expected the pull request in output, got %q", stdout.String())
+	}
+}
+
+func TestFilterReviewersDC(t *testing.T) {
+	pr := func(id int, reviewer string, status string, participant string) bbdc.PullRequest {
+		p := bbdc.PullRequest{ID: id}
+		if reviewer != "" {
+			p.Reviewers = []bbdc.PullRequestReviewer{{User: bbdc.User{Name: reviewer}, Status: status}}
+		}
+		if participant != "" {
+			p.Participants = []bbdc.PullRequestParticipant{{User: bbdc.User{Name: participant}}}
+		}
+		return p
+	}
+	host := &config.Host{Username: "alice"}
+
+	tests := []struct {
+		name string
+		opts listOptions
+		want []int
+	}{
+		{"review requested from me", listOptions{ReviewRequested: "@me"}, []int{1, 2}},
+		{"reviewed by me", listOptions{ReviewedBy: "@me"}, []int{2, 3}},
+		{"review requested from bob", listOptions{ReviewRequested: "BOB"}, []int{4}},
+		{"no filter", listOptions{}, []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs := []bbdc.PullRequest{
+				pr(1, "alice", "UNAPPROVED", ""),
+				pr(2, "alice", "APPROVED", ""),
+				pr(3, "", "", "alice"),
+				pr(4, "bob", "UNAPPROVED", ""),
+			}
+			var got []int
+			for _, p := range filterReviewersDC(prs, host, &tt.opts) {
+				got = append(got, p.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJiraKeys(t *testing.T) {
+	tests := []struct {
+		name, title, description, baseURL string
+		keys                              []string
+		wantTitle, wantDescription        string
+	}{
+		{
+			name:            "prefix and plain reference",
+			title:           "Retry uploads",
+			keys:            []string{"PROJ-123"},
+			wantTitle:       "PROJ-123: Retry uploads",
+			wantDescription: "Jira: PROJ-123",
+		},
+		{
+			name:            "link appended after description",
+			title:           "Retry uploads",
+			description:     "Adds backoff.\n",
+			baseURL:         "https://acme.atlassian.net/",
+			keys:            []string{"PROJ-1", "OPS-2"},
+			wantTitle:       "PROJ-1 OPS-2: Retry uploads",
+			wantDescription: "Adds backoff.\n\nJira: [PROJ-1](https://acme.atlassian.net/browse/PROJ-1), [OPS-2](https://acme.atlassian.net/browse/OPS-2)",
+		},
+		{
+			name:            "keys already mentioned are left alone",
+			title:           "PROJ-123 Retry uploads",
+			description:     "Fixes PROJ-123",
+			keys:            []string{"PROJ-123"},
+			wantTitle:       "PROJ-123 Retry uploads",
+			wantDescription: "Fixes PROJ-123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, description := applyJiraKeys(tt.title, tt.description, tt.keys, tt.baseURL)
+			if title != tt.wantTitle || description != tt.wantDescription {
+				t.Fatalf("got (%q, %q), want (%q, %q)", title, description, tt.wantTitle, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestDetectJiraKeys(t *testing.T) {
+	detector, err := newJiraDetector(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := detector.detect(context.Background(), "feature/PROJ-42-retries", "main"); fmt.Sprint(got) != "[PROJ-42]" {
+		t.Fatalf("branch detection = %v", got)
+	}
+	for _, branch := range []string{"fix/UTF-8-decoding", "feat/SHA-256-digests", "chore/ISO-8601-dates", "docs/RFC-1234"} {
+		if got := detector.keys(branch); len(got) != 0 {
+			t.Errorf("default pattern matched %v in %q", got, branch)
+		}
+	}
+
+	custom, err := newJiraDetector(&config.Jira{KeyPattern: `\bops-[0-9]+\b`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := custom.keys("fix/ops-7", "PROJ-1"); fmt.Sprint(got) != "[ops-7]" {
+		t.Fatalf("custom pattern = %v", got)
+	}
+	if _, err := newJiraDetector(&config.Jira{KeyPattern: "("}); err == nil {
+		t.Fatal("expected an invalid pattern error")
+	}
+
+	projects, err := newJiraDetector(&config.Jira{Projects: []string{"PROJ", "OPS"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := projects.keys("Use UTF-8 for OPS-3, see RFC-1234 and PROJ-12"); fmt.Sprint(got) != "[OPS-3 PROJ-12]" {
+		t.Fatalf("project keys = %v", got)
+	}
+
+	// Configured detectors fall back to the commits the branch adds over the
+	// target; the default one only trusts the branch name.
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	git("commit", "-q", "--allow-empty", "-m", "Initial OLD-1")
+	git("checkout", "-q", "-b", "retries")
+	git("commit", "-q", "--allow-empty", "-m", "Add backoff\n\nStore ISO-8601 dates as UTF-8. Refs PROJ-9")
+
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origWd) })
+
+	if got := projects.detect(context.Background(), "retries", "main"); fmt.Sprint(got) != "[PROJ-9]" {
+		t.Fatalf("commit detection = %v", got)
+	}
+	if got := detector.detect(context.Background(), "retries", "main"); len(got) != 0 {
+		t.Fatalf("default detector read commit messages: %v", got)
+	}
+}
+
+func TestParticipantsDC(t *testing.T) {
+	pr := &bbdc.PullRequest{
+		Reviewers: []bbdc.PullRequestReviewer{
+			{User: bbdc.User{Name: "alice", FullName: "Alice"}, Status: "APPROVED", Approved: true},
+			{User: bbdc.User{Name: "bob", FullName: "Bob"}, Status: "NEEDS_WORK"},
+			{User: bbdc.User{Name: "carol"}, Status: "UNAPPROVED"},
+		},
+		Participants: []bbdc.PullRequestParticipant{
+			{User: bbdc.User{Name: "dave", FullName: "Dave"}, Role: "PARTICIPANT", Status: "UNAPPROVED"},
+		},
+	}
+
+	rows := participantsDC(pr)
+	want := []string{"Alice/reviewer/approved", "Bob/reviewer/changes requested", "carol/reviewer/pending", "Dave/participant/commented"}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(rows), len(want), rows)
+	}
+	for i, row := range rows {
+		if got := row.Name + "/" + row.Role + "/" + row.State; got != want[i] {
+			t.Fatalf("row %d = %q, want %q", i, got, want[i])
+		}
+	}
+	if got := approvalSummary(rows); got != "1 of 3 reviewers approved" {
+		t.Fatalf("approvalSummary = %q", got)
+	}
+}
+
+func TestParticipantsCommandCloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/workspace/repo/pullrequests/7" {
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"participants":[
+			{"user":{"display_name":"Eve"},"role":"PARTICIPANT","approved":true,"state":"approved","participated_on":"2026-03-01T10:00:00Z"},
+			{"user":{"display_name":"Alice"},"role":"REVIEWER","approved":true,"state":"approved","participated_on":"2026-03-02T10:00:00Z"},
+			{"user":{"display_name":"Bob"},"role":"REVIEWER","approved":false,"state":null}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Chdir(t.TempDir())
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "workspace", DefaultRepo: "repo"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "testuser", Token: "token"},
+		},
+	}
+	stdout := &strings.Builder{}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCmdPR(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"participants", "7"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("pr participants: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("unexpected output:\n%s", stdout.String())
+	}
+	for i, want := range []string{"✓ Alice", "• Bob", "✓ Eve"} {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Fatalf("line %d = %q, want prefix %q", i, lines[i], want)
+		}
+	}
+	if !strings.Contains(lines[1], "pending") || !strings.Contains(lines[2], "participant") {
+		t.Fatalf("unexpected output:\n%s", stdout.String())
+	}
+	if lines[4] != "1 of 2 reviewers approved, 1 other approval(s)" {
+		t.Fatalf("unexpected summary %q", lines[4])
+	}
+}
+
+func TestPRQueryMatches(t *testing.T) {
+	q, err := parsePRQuery("author:Renovate-Bot branch:renovate/ target:main bump deps:")
+	if err != nil {
+		t.Fatalf("parsePRQuery: %v", err)
+	}
+	pr := bbdc.PullRequest{Title: "deps: Bump lodash to 4.17.21"}
+	pr.Author.User = bbdc.User{Name: "renovate-bot"}
+	pr.FromRef.DisplayID = "renovate/lodash"
+	pr.ToRef.DisplayID = "main"
+	if !q.matches(pr) {
+		t.Fatal("expected pull request to match")
+	}
+	pr.ToRef.DisplayID = "develop"
+	if q.matches(pr) {
+		t.Fatal("expected target mismatch")
+	}
+	if _, err := parsePRQuery("author:"); err == nil {
+		t.Fatal("expected empty value error")
+	}
+}
+
+func TestParseBulkArgs(t *testing.T) {
+	ids, err := parseBulkArgs([]string{"12", "#14", "12"}, &bulkOptions{})
+	if err != nil || len(ids) != 2 || ids[0] != 12 || ids[1] != 14 {
+		t.Fatalf("ids = %v, %v", ids, err)
+	}
+	for _, tc := range []struct {
+		args []string
+		opts bulkOptions
+	}{
+		{nil, bulkOptions{}},
+		{[]string{"1"}, bulkOptions{All: true}},
+		{[]string{"1"}, bulkOptions{Query: "bump"}},
+		{[]string{"x"}, bulkOptions{}},
+	} {
+		if _, err := parseBulkArgs(tc.args, &tc.opts); err == nil {
+			t.Errorf("parseBulkArgs(%v, %+v) succeeded", tc.args, tc.opts)
+		}
+	}
+}
+
+func newBulkTestCommand(t *testing.T, handler http.HandlerFunc) (*cmdutil.Factory, *strings.Builder, *strings.Builder) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Chdir(t.TempDir())
+
+	cfg := &config.Config{
+		ActiveContext: "dc",
+		Contexts: map[string]*config.Context{
+			"dc": {Host: "dc", ProjectKey: "PROJ", DefaultRepo: "repo"},
+		},
+		Hosts: map[string]*config.Host{
+			"dc": {Kind: "dc", BaseURL: server.URL, Username: "alice", Token: "token"},
+		},
+	}
+	stdout, stderr := &strings.Builder{}, &strings.Builder{}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: stdout, ErrOut: stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, stdout, stderr
+}
+
+func TestBulkApproveReportsPartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	approved := map[string]bool{}
+	f, stdout, stderr := newBulkTestCommand(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		approved[r.URL.Path] = true
+		mu.Unlock()
+		if r.URL.Path == "/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/14/approve" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Pull request 14 does not exist."}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cmd := NewCmdPR(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"approve", "12", "14", "18"})
+	err := cmd.Execute()
+
+	var exitErr *cmdutil.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 1 {
+		t.Fatalf("error = %v, want exit code 1", err)
+	}
+	if len(approved) != 3 {
+		t.Fatalf("requests = %v", approved)
+	}
+	if out := stdout.String(); out != "✓ Approved pull request #12\n✓ Approved pull request #18\n" {
+		t.Fatalf("stdout = %q", out)
+	}
+	if !strings.Contains(stderr.String(), "! #14: ") || !strings.Contains(stderr.String(), "does not exist") {
+		t.Fatalf("stderr = %q", stderr.String())
+	}
+}
+
+func TestRunBulkFailFastFinishesInFlightOperations(t *testing.T) {
+	targets := make([]bulkTarget, 2*bulkConcurrency+1)
+	for i := range targets {
+		targets[i].ID = i + 1
+	}
+	failure := errors.New("boom")
+	release := make(chan struct{})
+	var started atomic.Int32
+	errs, first := runBulk(context.Background(), targets, true, func(ctx context.Context, target bulkTarget) error {
+		started.Add(1)
+		if target.ID == 1 {
+			time.AfterFunc(50*time.Millisecond, func() { close(release) })
+			return failure
+		}
+		<-release
+		return ctx.Err()
+	})
+
+	if first != failure {
+		t.Fatalf("first = %v", first)
+	}
+	if int(started.Load()) >= len(targets) {
+		t.Fatalf("all %d targets started after the failure", started.Load())
+	}
+	succeeded, skipped := 0, 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, errBulkSkipped):
+			skipped++
+		case i != 0:
+			t.Errorf("target %d: unexpected error %v", targets[i].ID, err)
+		}
+	}
+	if succeeded != int(started.Load())-1 || skipped == 0 {
+		t.Fatalf("succeeded = %d, skipped = %d, started = %d", succeeded, skipped, started.Load())
+	}
+}
+
+func TestBulkApproveFailFastReportsResults(t *testing.T) {
+	f, stdout, stderr := newBulkTestCommand(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/14/approve" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Pull request 14 does not exist."}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cmd := NewCmdPR(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"approve", "12", "14", "--fail-fast"})
+	err := cmd.Execute()
+
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("error = %v, want the first failure", err)
+	}
+	if out := stdout.String(); out != "✓ Approved pull request #12\n" {
+		t.Fatalf("stdout = %q", out)
+	}
+	if !strings.Contains(stderr.String(), "! #14: ") {
+		t.Fatalf("stderr = %q", stderr.String())
+	}
+}
+
+func TestBulkMergeAllWithQuery(t *testing.T) {
+	var mu sync.Mutex
+	var merged []string
+	f, stdout, _ := newBulkTestCommand(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/PROJ/repos/repo/pull-requests":
+			if r.URL.Query().Get("state") != "OPEN" {
+				t.Errorf("state = %q", r.URL.Query().Get("state"))
+			}
+			_, _ = w.Write([]byte(`{"isLastPage":true,"values":[
+				{"id":1,"version":3,"title":"Bump a","author":{"user":{"name":"renovate-bot"}},"fromRef":{"displayId":"renovate/a"},"toRef":{"displayId":"main"}},
+				{"id":2,"version":1,"title":"Add feature","author":{"user":{"name":"alice"}},"fromRef":{"displayId":"feature/x"},"toRef":{"displayId":"main"}},
+				{"id":3,"version":7,"title":"Bump b","author":{"user":{"name":"renovate-bot"}},"fromRef":{"displayId":"renovate/b"},"toRef":{"displayId":"main"}}
+			]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/merge"):
+			var body struct {
+				Version  int    `json:"version"`
+				Strategy string `json:"mergeStrategyId"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			merged = append(merged, fmt.Sprintf("%s@%d/%s", r.URL.Path, body.Version, body.Strategy))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cmd := NewCmdPR(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"merge", "--all", "--query", "author:renovate-bot", "--strategy", "squash", "--yes"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("pr merge --all: %v", err)
+	}
+
+	sort.Strings(merged)
+	want := []string{
+		"/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/merge@3/squash",
+		"/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/3/merge@7/squash",
+	}
+	if strings.Join(merged, ",") != strings.Join(want, ",") {
+		t.Fatalf("merged = %v", merged)
+	}
+	if out := stdout.String(); out != "✓ Merged pull request #1\n✓ Merged pull request #3\n" {
+		t.Fatalf("stdout = %q", out)
+	}
+}
+
+func TestBulkMergeAllRequiresYesWithoutTTY(t *testing.T) {
+	f, _, _ := newBulkTestCommand(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"id":1,"title":"Bump a"}]}`))
+	})
+
+	cmd := NewCmdPR(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"merge", "--all"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--yes is required") {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+func TestBulkMergeAllPromptsOnStderr(t *testing.T) {
+	f, stdout, stderr := newBulkTestCommand(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"id":1,"title":"Bump a","fromRef":{"displayId":"renovate/a"}}]}`))
+	})
+	f.IOStreams.In = io.NopCloser(strings.NewReader("n\n"))
+	f.IOStreams.SetStdinTTY(true)
+
+	cmd := NewCmdPR(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"merge", "--all"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("pr merge --all: %v", err)
+	}
+
+	if stdout.Len() != 0 {
+		t.Fatalf("stdout = %q, want nothing", stdout.String())
+	}
+	for _, want := range []string{"#1  Bump a  (renovate/a)", "Merge 1 pull requests in PROJ/repo? [y/N]", "Aborted."} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("stderr lacks %q: %q", want, stderr.String())
+		}
+	}
+}
+
+func TestPatchWritesAndAppliesPatch(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README")
+	git("commit", "-q", "-m", "Initial")
+	git("remote", "add", "origin", "git@bitbucket.org:workspace/repo.git")
+	t.Chdir(dir)
+
+	const patch = `From 1a2b3c4d Mon Sep 17 00:00:00 2001
+From: Alice <alice@example.com>
+Date: Mon, 2 Mar 2026 10:00:00 +0000
+Subject: [PATCH] Greet the world
+
+---
+ README | 2 +-
+ 1 file changed, 1 insertion(+), 1 deletion(-)
+
+diff --git a/README b/README
+--- a/README
++++ b/README
+@@ -1 +1 @@
+-hello
++hello, world
+--
+2.45.0
+
+`
+	srv := bbtest.NewServer(t)
+	srv.JSON("GET /repositories/workspace/repo/pullrequests/7/patch", http.StatusOK, patch)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "workspace", DefaultRepo: "repo"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: srv.URL, Username: "testuser", Token: "token"},
+		},
+	}
+	stdout := &strings.Builder{}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	run := func(args ...string) error {
+		cmd := NewCmdPR(f)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetArgs(append([]string{"patch", "7"}, args...))
+		return cmd.Execute()
+	}
+
+	if err := run(); err != nil {
+		t.Fatalf("pr patch: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "pr-7.patch"))
+	if err != nil || string(data) != patch {
+		t.Fatalf("pr-7.patch = %q, %v", data, err)
+	}
+	if err := run(); err == nil || !strings.Contains(err.Error(), "--clobber") {
+		t.Fatalf("second write error = %v", err)
+	}
+
+	if err := run("--apply", "--repo", "other"); err == nil || !strings.Contains(err.Error(), "clone of workspace/other") {
+		t.Fatalf("--apply for another repository error = %v", err)
+	}
+	if err := run("--apply"); err != nil {
+		t.Fatalf("pr patch --apply: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README")); string(data) != "hello, world\n" {
+		t.Fatalf("README = %q", data)
+	}
+	if !strings.HasSuffix(stdout.String(), "✓ Applied pull request #7 to the working tree\n") {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+}
+
+func TestPatchLeavesNothingBehindWhenTheDownloadFails(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README")
+	git("commit", "-q", "-m", "Initial")
+	git("remote", "add", "origin", "git@bitbucket.org:workspace/repo.git")
+	t.Chdir(dir)
+
+	// Announce more bytes than are sent so the patch arrives truncated.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4096")
+		_, _ = w.Write([]byte("diff --git a/README b/README\n--- a/README\n+++ b/README\n@@ -1 +1 @@\n-hello\n+hello, world\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "workspace", DefaultRepo: "repo"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "testuser", Token: "token"},
+		},
+	}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &strings.Builder{}, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	run := func(args ...string) error {
+		cmd := NewCmdPR(f)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetArgs(append([]string{"patch", "7"}, args...))
+		return cmd.Execute()
+	}
+
+	if err := run(); err == nil {
+		t.Fatal("expected the truncated download to fail")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.Contains(e.Name(), "pr-7.patch") {
+			t.Fatalf("left %s behind", e.Name())
+		}
+	}
+
+	if err := run("--apply"); err == nil {
+		t.Fatal("expected the truncated download to fail with --apply")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README")); string(data) != "hello\n" {
+		t.Fatalf("README = %q after a failed download", data)
+	}
+}
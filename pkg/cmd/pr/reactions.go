@@ -1,7 +1,6 @@
 package pr
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -35,9 +34,10 @@ func newReactionCmd(f *cmdutil.Factory) *cobra.Command {
 func newReactionListCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &reactionOptions{}
 	cmd := &cobra.Command{
-		Use:   "list <id> <comment-id>",
-		Short: "List comment reactions",
-		Args:  cobra.ExactArgs(2),
+		Use:               "list <id> <comment-id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "List comment reactions",
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prID, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -61,9 +61,10 @@ func newReactionListCmd(f *cmdutil.Factory) *cobra.Command {
 func newReactionAddCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &reactionOptions{}
 	cmd := &cobra.Command{
-		Use:   "add <id> <comment-id>",
-		Short: "Add a reaction to a comment",
-		Args:  cobra.ExactArgs(2),
+		Use:               "add <id> <comment-id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Add a reaction to a comment",
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prID, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -88,9 +89,10 @@ func newReactionAddCmd(f *cmdutil.Factory) *cobra.Command {
 func newReactionRemoveCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &reactionOptions{}
 	cmd := &cobra.Command{
-		Use:   "remove <id> <comment-id>",
-		Short: "Remove a reaction",
-		Args:  cobra.ExactArgs(2),
+		Use:               "remove <id> <comment-id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Remove a reaction",
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prID, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -138,7 +140,7 @@ func runReactionList(cmd *cobra.Command, f *cmdutil.Factory, opts *reactionOptio
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 	defer cancel()
 
 	reactions, err := client.ListCommentReactions(ctx, projectKey, repoSlug, opts.ID, opts.Comment)
@@ -186,7 +188,7 @@ func runReactionAdd(cmd *cobra.Command, f *cmdutil.Factory, opts *reactionOption
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 	defer cancel()
 
 	if err := client.AddCommentReaction(ctx, projectKey, repoSlug, opts.ID, opts.Comment, opts.Emoji); err != nil {
@@ -223,7 +225,7 @@ func runReactionRemove(cmd *cobra.Command, f *cmdutil.Factory, opts *reactionOpt
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 	defer cancel()
 
 	if err := client.RemoveCommentReaction(ctx, projectKey, repoSlug, opts.ID, opts.Comment, opts.Emoji); err != nil {
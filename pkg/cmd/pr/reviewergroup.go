@@ -1,7 +1,6 @@
 package pr
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -100,7 +99,7 @@ func runReviewerGroupList(cmd *cobra.Command, f *cmdutil.Factory, opts *reviewer
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	groups, err := client.ListReviewerGroups(ctx, projectKey, repoSlug)
@@ -152,7 +151,7 @@ func runReviewerGroupAdd(cmd *cobra.Command, f *cmdutil.Factory, opts *reviewerG
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.AddReviewerGroup(ctx, projectKey, repoSlug, opts.Name); err != nil {
@@ -189,7 +188,7 @@ func runReviewerGroupRemove(cmd *cobra.Command, f *cmdutil.Factory, opts *review
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if err := client.RemoveReviewerGroup(ctx, projectKey, repoSlug, opts.Name); err != nil {
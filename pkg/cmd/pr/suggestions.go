@@ -1,7 +1,6 @@
 package pr
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -23,9 +22,10 @@ type suggestionOptions struct {
 func newSuggestionCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &suggestionOptions{}
 	cmd := &cobra.Command{
-		Use:   "suggestion <id> <comment-id> <suggestion-id>",
-		Short: "Apply or preview a code suggestion",
-		Args:  cobra.ExactArgs(3),
+		Use:               "suggestion <id> <comment-id> <suggestion-id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Apply or preview a code suggestion",
+		Args:              cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prID, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -78,7 +78,7 @@ func runSuggestion(cmd *cobra.Command, f *cmdutil.Factory, opts *suggestionOptio
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if opts.Preview {
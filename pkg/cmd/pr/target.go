@@ -0,0 +1,51 @@
+package pr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// branchKind returns the branch kind whose prefix matches source, preferring
+// the longest matching prefix.
+func branchKind(source string, prefixes map[string]string) string {
+	kind, longest := "", 0
+	for k, prefix := range prefixes {
+		if prefix == "" || !strings.HasPrefix(source, prefix) {
+			continue
+		}
+		if len(prefix) > longest {
+			kind, longest = k, len(prefix)
+		}
+	}
+	return kind
+}
+
+// suggestTarget picks the destination branch for source and reports the
+// choice on errOut. Release and hotfix branches go to production when the
+// model has one; everything else goes to development.
+func suggestTarget(errOut io.Writer, source string, targets cmdutil.BranchModel) (string, error) {
+	kind := branchKind(source, targets.Prefixes)
+
+	target := targets.Development
+	if (kind == "release" || kind == "hotfix") && targets.Production != "" {
+		target = targets.Production
+	}
+	if target == "" || target == source {
+		return "", fmt.Errorf("could not determine a destination branch for %s; pass --target", source)
+	}
+
+	reason := "development branch"
+	if target == targets.Production && target != targets.Development {
+		reason = "production branch"
+	}
+	if kind != "" {
+		reason = fmt.Sprintf("%s for %s branches", reason, kind)
+	}
+	if _, err := fmt.Fprintf(errOut, "Using destination branch %s (%s)\n", target, reason); err != nil {
+		return "", err
+	}
+	return target, nil
+}
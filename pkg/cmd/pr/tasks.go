@@ -1,7 +1,6 @@
 package pr
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -37,9 +36,10 @@ func newTaskCmd(f *cmdutil.Factory) *cobra.Command {
 func newTaskListCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &taskOptions{}
 	cmd := &cobra.Command{
-		Use:   "list <id>",
-		Short: "List tasks for a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "list <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "List tasks for a pull request",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -57,9 +57,10 @@ func newTaskListCmd(f *cmdutil.Factory) *cobra.Command {
 func newTaskCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &taskOptions{}
 	cmd := &cobra.Command{
-		Use:   "create <id>",
-		Short: "Create a task on a pull request",
-		Args:  cobra.ExactArgs(1),
+		Use:               "create <id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Create a task on a pull request",
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -81,9 +82,10 @@ func newTaskCreateCmd(f *cmdutil.Factory) *cobra.Command {
 func newTaskCompleteCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &taskOptions{}
 	cmd := &cobra.Command{
-		Use:   "complete <id> <task-id>",
-		Short: "Complete a pull request task",
-		Args:  cobra.ExactArgs(2),
+		Use:               "complete <id> <task-id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Complete a pull request task",
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prID, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -107,9 +109,10 @@ func newTaskCompleteCmd(f *cmdutil.Factory) *cobra.Command {
 func newTaskReopenCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &taskOptions{}
 	cmd := &cobra.Command{
-		Use:   "reopen <id> <task-id>",
-		Short: "Reopen a resolved task",
-		Args:  cobra.ExactArgs(2),
+		Use:               "reopen <id> <task-id>",
+		ValidArgsFunction: cmdutil.CompletePullRequestIDs(f),
+		Short:             "Reopen a resolved task",
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prID, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -154,7 +157,7 @@ func runTaskList(cmd *cobra.Command, f *cmdutil.Factory, opts *taskOptions) erro
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	tasks, err := client.ListPullRequestTasks(ctx, projectKey, repoSlug, opts.ID)
@@ -207,7 +210,7 @@ func runTaskCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *taskOptions) er
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	task, err := client.CreatePullRequestTask(ctx, projectKey, repoSlug, opts.ID, opts.Text)
@@ -254,7 +257,7 @@ func toggleTaskState(cmd *cobra.Command, f *cmdutil.Factory, opts *taskOptions,
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	if resolve {
@@ -0,0 +1,450 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+func runListCloud(cmd *cobra.Command, f *cmdutil.Factory, host *config.Host, workspace string, limit int) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	projects, err := client.ListProjects(ctx, workspace, limit)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"projects":  projects,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(projects) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No projects in workspace %s.\n", workspace)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, p := range projects {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Key, p.Name, visibility(p.IsPrivate)); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+type viewOptions struct {
+	Workspace string
+	Web       bool
+}
+
+func newViewCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &viewOptions{}
+	cmd := &cobra.Command{
+		Use:   "view <key>",
+		Short: "Show a Cloud project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runView(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "project")
+
+	return cmd
+}
+
+func runView(cmd *cobra.Command, f *cmdutil.Factory, key string, opts *viewOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	project, err := client.GetProject(ctx, workspace, key)
+	if err != nil {
+		return err
+	}
+
+	if opts.Web {
+		return cmdutil.OpenInBrowser(f, ios.Out, "project", project.Links.HTML.Href)
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, project, func() error {
+		return printProject(ios.Out, project)
+	})
+}
+
+type createOptions struct {
+	Workspace   string
+	Name        string
+	Description string
+	Public      bool
+}
+
+func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create <key>",
+		Short: "Create a project in a Cloud workspace",
+		Long:  `Create a project in a Bitbucket Cloud workspace. Projects are private unless --public is passed.`,
+		Example: `  bkt project create PLAT --name "Platform"
+  bkt project create WEB --name "Web" --description "Customer-facing sites" --public`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Project name (default: the key)")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Project description")
+	cmd.Flags().BoolVar(&opts.Public, "public", false, "Make the project public")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, f *cmdutil.Factory, key string, opts *createOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	key = strings.ToUpper(strings.TrimSpace(key))
+	project, err := client.CreateProject(ctx, workspace, bbcloud.CreateProjectInput{
+		Key:         key,
+		Name:        cmdutil.FirstNonEmpty(opts.Name, key),
+		Description: opts.Description,
+		IsPrivate:   !opts.Public,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, project, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Created project %s in %s\n", project.Key, workspace)
+		return err
+	})
+}
+
+type editOptions struct {
+	Workspace   string
+	Name        string
+	Description string
+	Visibility  string
+}
+
+func newEditCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &editOptions{}
+	cmd := &cobra.Command{
+		Use:   "edit <key>",
+		Short: "Edit a Cloud project",
+		Long:  `Edit a project's name, description or visibility. Only the flags you pass are changed.`,
+		Example: `  bkt project edit PLAT --description "Shared infrastructure"
+  bkt project edit WEB --visibility private`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Rename the project")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Set the project description")
+	cmd.Flags().StringVar(&opts.Visibility, "visibility", "", "Set visibility: public or private")
+
+	return cmd
+}
+
+func runEdit(cmd *cobra.Command, f *cmdutil.Factory, key string, opts *editOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	var input bbcloud.UpdateProjectInput
+	if cmd.Flags().Changed("name") {
+		input.Name = &opts.Name
+	}
+	if cmd.Flags().Changed("description") {
+		input.Description = &opts.Description
+	}
+	if cmd.Flags().Changed("visibility") {
+		switch strings.ToLower(strings.TrimSpace(opts.Visibility)) {
+		case "public":
+			v := false
+			input.IsPrivate = &v
+		case "private":
+			v := true
+			input.IsPrivate = &v
+		default:
+			return fmt.Errorf("invalid --visibility %q (use public or private)", opts.Visibility)
+		}
+	}
+	if input.Name == nil && input.Description == nil && input.IsPrivate == nil {
+		return fmt.Errorf("nothing to change; pass --name, --description or --visibility")
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	project, err := client.UpdateProject(ctx, workspace, key, input)
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, project, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Updated project %s\n", project.Key)
+		return err
+	})
+}
+
+type deleteOptions struct {
+	Workspace string
+	Yes       bool
+}
+
+func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:   "delete <key>",
+		Short: "Delete an empty Cloud project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(cmd *cobra.Command, f *cmdutil.Factory, key string, opts *deleteOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if !opts.Yes {
+		confirmed, err := f.Prompt().Confirm(fmt.Sprintf("Delete project %s from %s?", key, workspace), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, err := fmt.Fprintln(ios.Out, "Aborted.")
+			return err
+		}
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	if err := client.DeleteProject(ctx, workspace, key); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Deleted project %s\n", key)
+	return err
+}
+
+type reposOptions struct {
+	Workspace string
+	Limit     int
+}
+
+func newReposCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &reposOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:   "repos <key>",
+		Short: "List repositories in a project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRepos(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum repositories to list (0 for all)")
+
+	return cmd
+}
+
+func runRepos(cmd *cobra.Command, f *cmdutil.Factory, key string, opts *reposOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	key = strings.ToUpper(strings.TrimSpace(key))
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	var (
+		payload map[string]any
+		rows    [][2]string
+	)
+
+	switch host.Kind {
+	case "dc":
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		repos, err := client.ListRepositories(ctx, key, opts.Limit)
+		if err != nil {
+			return err
+		}
+		for _, r := range repos {
+			rows = append(rows, [2]string{r.Slug, r.Name})
+		}
+		payload = map[string]any{"project": key, "repositories": repos}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		if workspace == "" {
+			return fmt.Errorf("context must supply workspace; use --workspace if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		repos, err := client.ListProjectRepositories(ctx, workspace, key, opts.Limit)
+		if err != nil {
+			return err
+		}
+		for _, r := range repos {
+			rows = append(rows, [2]string{r.Slug, r.Name})
+		}
+		payload = map[string]any{"workspace": workspace, "project": key, "repositories": repos}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(rows) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No repositories in project %s.\n", key)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, row := range rows {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1]); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+func printProject(out io.Writer, p *bbcloud.Project) error {
+	if _, err := fmt.Fprintf(out, "%s (%s)\n", p.Name, p.Key); err != nil {
+		return err
+	}
+	if p.Description != "" {
+		if _, err := fmt.Fprintf(out, "%s\n", p.Description); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(out, "Visibility: %s\n", visibility(p.IsPrivate)); err != nil {
+		return err
+	}
+	if p.UpdatedOn != "" {
+		if _, err := fmt.Fprintf(out, "Updated: %s\n", p.UpdatedOn); err != nil {
+			return err
+		}
+	}
+	if p.Links.HTML.Href != "" {
+		if _, err := fmt.Fprintf(out, "URL: %s\n", p.Links.HTML.Href); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func visibility(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
@@ -1,7 +1,6 @@
 package project
 
 import (
-	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -20,13 +19,19 @@ func NewCmdProject(f *cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newViewCmd(f))
+	cmd.AddCommand(newCreateCmd(f))
+	cmd.AddCommand(newEditCmd(f))
+	cmd.AddCommand(newDeleteCmd(f))
+	cmd.AddCommand(newReposCmd(f))
 
 	return cmd
 }
 
 type listOptions struct {
-	Host  string
-	Limit int
+	Host      string
+	Workspace string
+	Limit     int
 }
 
 func newListCmd(f *cmdutil.Factory) *cobra.Command {
@@ -36,13 +41,14 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
-		Short:   "List Bitbucket Data Center projects",
+		Short:   "List projects on the host or in a Cloud workspace",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd, f, opts)
 		},
 	}
 
 	cmd.Flags().StringVar(&opts.Host, "host", "", "Host key or base URL override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum projects to display (0 for all)")
 
 	return cmd
@@ -60,8 +66,17 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		return err
 	}
 
+	if hostCfg.Kind == "cloud" {
+		workspace := strings.TrimSpace(opts.Workspace)
+		if workspace == "" {
+			if workspace, _, err = cmdutil.ResolveCloudWorkspace(f, cmd, ""); err != nil {
+				return err
+			}
+		}
+		return runListCloud(cmd, f, hostCfg, workspace, opts.Limit)
+	}
 	if hostCfg.Kind != "dc" {
-		return fmt.Errorf("project listing is only supported for Bitbucket Data Center hosts")
+		return fmt.Errorf("unsupported host kind %q", hostCfg.Kind)
 	}
 
 	client, err := cmdutil.NewDCClient(hostCfg)
@@ -69,7 +84,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 	defer cancel()
 
 	projects, err := client.ListProjects(ctx, opts.Limit)
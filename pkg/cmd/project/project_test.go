@@ -0,0 +1,96 @@
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newCloudFactory(t *testing.T, baseURL string) (*cmdutil.Factory, *strings.Builder) {
+	t.Helper()
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "acme"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout
+}
+
+func execute(f *cmdutil.Factory, args ...string) error {
+	cmd := NewCmdProject(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestListCloudProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/acme/projects" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"key":"PLAT","name":"Platform","is_private":true}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newCloudFactory(t, server.URL)
+	if err := execute(f, "list"); err != nil {
+		t.Fatalf("project list: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "PLAT") || !strings.Contains(stdout.String(), "private") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCreateCloudProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/workspaces/acme/projects" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["key"] != "WEB" || body["name"] != "WEB" || body["is_private"] != true {
+			t.Fatalf("unexpected body %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"WEB","name":"WEB","is_private":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newCloudFactory(t, server.URL)
+	if err := execute(f, "create", "web"); err != nil {
+		t.Fatalf("project create: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "✓ Created project WEB in acme") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestEditRequiresChange(t *testing.T) {
+	f, _ := newCloudFactory(t, "http://127.0.0.1:0")
+	if err := execute(f, "edit", "PLAT"); err == nil || !strings.Contains(err.Error(), "nothing to change") {
+		t.Fatalf("expected nothing to change error, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package repo
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+func newDeployKeyCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deploy-key",
+		Aliases: []string{"deploy-keys"},
+		Short:   "Manage repository deploy keys (Cloud)",
+		Long: `List, add and delete the read-only SSH keys that grant access to a single
+Bitbucket Cloud repository.`,
+	}
+
+	cmd.AddCommand(newDeployKeyListCmd(f))
+	cmd.AddCommand(newDeployKeyAddCmd(f))
+	cmd.AddCommand(newDeployKeyDeleteCmd(f))
+
+	return cmd
+}
+
+type deployKeyOptions struct {
+	Workspace string
+	Repo      string
+	Limit     int
+	Title     string
+	Yes       bool
+}
+
+func newDeployKeyListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deployKeyOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List deploy keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeployKeyList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum keys to list (0 for all)")
+
+	return cmd
+}
+
+func runDeployKeyList(cmd *cobra.Command, f *cmdutil.Factory, opts *deployKeyOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	keys, err := client.ListDeployKeys(ctx, workspace, repoSlug, opts.Limit)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace":   workspace,
+		"repo":        repoSlug,
+		"deploy_keys": keys,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(keys) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No deploy keys configured for %s/%s.\n", workspace, repoSlug)
+			return err
+		}
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		if _, err := fmt.Fprintln(tw, "ID\tLABEL\tKEY\tCREATED"); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if _, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n",
+				k.ID,
+				cmdutil.FirstNonEmpty(k.Label, "-"),
+				truncate(k.Key, 40),
+				cmdutil.FirstNonEmpty(formatUpdated(k.CreatedOn), "-"),
+			); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+func newDeployKeyAddCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deployKeyOptions{}
+	cmd := &cobra.Command{
+		Use:   "add <key-file>",
+		Short: "Add a deploy key",
+		Long: `Add a public SSH key as a read-only deploy key. Pass "-" as the key file to
+read the key from standard input.`,
+		Example: `  bkt repo deploy-key add ~/.ssh/ci.pub --title "CI runner"
+  cat deploy.pub | bkt repo deploy-key add - --repo api`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeployKeyAdd(cmd, f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Label for the key (defaults to the key comment)")
+
+	return cmd
+}
+
+func runDeployKeyAdd(cmd *cobra.Command, f *cmdutil.Factory, opts *deployKeyOptions, keyFile string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	key, err := cmdutil.ReadPublicKey(ios.In, keyFile)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	label := cmdutil.FirstNonEmpty(opts.Title, cmdutil.PublicKeyComment(key))
+	deployKey, err := client.AddDeployKey(ctx, workspace, repoSlug, label, key)
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, deployKey, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Added deploy key %d (%s) to %s/%s\n",
+			deployKey.ID, cmdutil.FirstNonEmpty(deployKey.Label, "unlabelled"), workspace, repoSlug)
+		return err
+	})
+}
+
+func newDeployKeyDeleteCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deployKeyOptions{}
+	cmd := &cobra.Command{
+		Use:     "delete <id>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a deploy key",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid deploy key id %q", args[0])
+			}
+			return runDeployKeyDelete(cmd, f, opts, id)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDeployKeyDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deployKeyOptions, id int) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Yes {
+		confirmed, err := f.Prompt().Confirm(fmt.Sprintf("Delete deploy key %d from %s/%s?", id, workspace, repoSlug), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, _ = fmt.Fprintln(ios.Out, "Aborted.")
+			return nil
+		}
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	if err := client.DeleteDeployKey(ctx, workspace, repoSlug, id); err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"repo":      repoSlug,
+		"id":        id,
+		"deleted":   true,
+	}
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Deleted deploy key %d from %s/%s\n", id, workspace, repoSlug)
+		return err
+	})
+}
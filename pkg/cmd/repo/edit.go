@@ -0,0 +1,311 @@
+package repo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+type editOptions struct {
+	Project       string
+	Workspace     string
+	Repo          string
+	Name          string
+	Description   string
+	DefaultBranch string
+	Visibility    string
+	ForkPolicy    string
+	Forkable      bool
+}
+
+func newEditCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &editOptions{}
+	cmd := &cobra.Command{
+		Use:   "edit [repository]",
+		Short: "Edit repository settings",
+		Long: `Edit mutable repository settings such as the description, default branch
+and visibility. Only the flags you pass are changed.`,
+		Example: `  bkt repo edit --description "Payments API"
+  bkt repo edit my-team/api --default-branch develop
+  bkt repo edit api --visibility private --fork-policy no_public_forks`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Repo = args[0]
+			}
+			return runEdit(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Rename the repository")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Set the repository description")
+	cmd.Flags().StringVar(&opts.DefaultBranch, "default-branch", "", "Set the default (main) branch")
+	cmd.Flags().StringVar(&opts.Visibility, "visibility", "", "Set visibility: public or private")
+	cmd.Flags().StringVar(&opts.ForkPolicy, "fork-policy", "", "Set fork policy: allow_forks, no_public_forks, no_forks (Cloud)")
+	cmd.Flags().BoolVar(&opts.Forkable, "forkable", false, "Allow forking (Data Center)")
+
+	return cmd
+}
+
+func runEdit(cmd *cobra.Command, f *cmdutil.Factory, opts *editOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var private *bool
+	if cmd.Flags().Changed("visibility") {
+		switch strings.ToLower(strings.TrimSpace(opts.Visibility)) {
+		case "public":
+			v := false
+			private = &v
+		case "private":
+			v := true
+			private = &v
+		default:
+			return fmt.Errorf("invalid --visibility %q (expected public or private)", opts.Visibility)
+		}
+	}
+
+	changed := func(name string, value *string) *string {
+		if cmd.Flags().Changed(name) {
+			return value
+		}
+		return nil
+	}
+
+	owner, repoArg := splitRepoArg(opts.Repo)
+
+	switch host.Kind {
+	case "dc":
+		if cmd.Flags().Changed("fork-policy") {
+			return fmt.Errorf("--fork-policy is only supported on Bitbucket Cloud; use --forkable")
+		}
+
+		projectKey := cmdutil.FirstNonEmpty(owner, opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(repoArg, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		input := bbdc.UpdateRepositoryInput{
+			Name:          changed("name", &opts.Name),
+			Description:   changed("description", &opts.Description),
+			DefaultBranch: changed("default-branch", &opts.DefaultBranch),
+		}
+		if cmd.Flags().Changed("forkable") {
+			input.Forkable = &opts.Forkable
+		}
+		if private != nil {
+			public := !*private
+			input.Public = &public
+		}
+		if input == (bbdc.UpdateRepositoryInput{}) {
+			return fmt.Errorf("no updates specified: use flags like --description, --default-branch, --visibility")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		repo, err := client.UpdateRepository(ctx, strings.ToUpper(projectKey), repoSlug, input)
+		if err != nil {
+			return err
+		}
+
+		details := dcRepoDetails(*repo)
+		return cmdutil.WriteOutput(cmd, ios.Out, details, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Updated repository %s/%s\n", details.Project, details.Slug)
+			return err
+		})
+
+	case "cloud":
+		if cmd.Flags().Changed("forkable") {
+			return fmt.Errorf("--forkable is only supported on Data Center; use --fork-policy")
+		}
+
+		workspace := cmdutil.FirstNonEmpty(owner, opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(repoArg, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		input := bbcloud.UpdateRepositoryInput{
+			Name:          changed("name", &opts.Name),
+			Description:   changed("description", &opts.Description),
+			DefaultBranch: changed("default-branch", &opts.DefaultBranch),
+			ForkPolicy:    changed("fork-policy", &opts.ForkPolicy),
+			IsPrivate:     private,
+		}
+		if input == (bbcloud.UpdateRepositoryInput{}) {
+			return fmt.Errorf("no updates specified: use flags like --description, --default-branch, --visibility")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+		defer cancel()
+
+		repo, err := client.UpdateRepository(ctx, workspace, repoSlug, input)
+		if err != nil {
+			return err
+		}
+
+		details := cloudRepoDetails(workspace, *repo)
+		return cmdutil.WriteOutput(cmd, ios.Out, details, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Updated repository %s/%s\n", details.Workspace, details.Slug)
+			return err
+		})
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+type dcRepoDetail struct {
+	Project       string   `json:"project"`
+	Slug          string   `json:"slug"`
+	Name          string   `json:"name"`
+	ID            int      `json:"id"`
+	Description   string   `json:"description,omitempty"`
+	DefaultBranch string   `json:"default_branch,omitempty"`
+	Visibility    string   `json:"visibility"`
+	Forkable      bool     `json:"forkable"`
+	WebURL        string   `json:"web_url,omitempty"`
+	Clone         []string `json:"clone_urls,omitempty"`
+}
+
+func dcRepoDetails(repo bbdc.Repository) dcRepoDetail {
+	details := dcRepoDetail{
+		Slug:          repo.Slug,
+		Name:          repo.Name,
+		ID:            repo.ID,
+		Description:   repo.Description,
+		DefaultBranch: strings.TrimPrefix(repo.DefaultBranch, "refs/heads/"),
+		Visibility:    visibility(!repo.Public),
+		Forkable:      repo.Forkable,
+		WebURL:        firstLinkDC(repo, "web"),
+		Clone:         cloneLinksDC(repo),
+	}
+	if repo.Project != nil {
+		details.Project = repo.Project.Key
+	}
+	return details
+}
+
+type cloudRepoDetail struct {
+	Workspace     string   `json:"workspace"`
+	Slug          string   `json:"slug"`
+	Name          string   `json:"name"`
+	UUID          string   `json:"uuid"`
+	Description   string   `json:"description,omitempty"`
+	DefaultBranch string   `json:"default_branch,omitempty"`
+	Visibility    string   `json:"visibility"`
+	ForkPolicy    string   `json:"fork_policy,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	Size          int64    `json:"size,omitempty"`
+	UpdatedOn     string   `json:"updated_on,omitempty"`
+	WebURL        string   `json:"web_url,omitempty"`
+	Clone         []string `json:"clone_urls,omitempty"`
+}
+
+func cloudRepoDetails(workspace string, repo bbcloud.Repository) cloudRepoDetail {
+	details := cloudRepoDetail{
+		Workspace:   workspace,
+		Slug:        repo.Slug,
+		Name:        repo.Name,
+		UUID:        strings.Trim(repo.UUID, "{}"),
+		Description: repo.Description,
+		Visibility:  visibility(repo.IsPrivate),
+		ForkPolicy:  repo.ForkPolicy,
+		Language:    repo.Language,
+		Size:        repo.Size,
+		UpdatedOn:   repo.UpdatedOn,
+		WebURL:      firstLinkCloud(repo),
+		Clone:       cloneLinksCloud(repo),
+	}
+	if repo.MainBranch != nil {
+		details.DefaultBranch = repo.MainBranch.Name
+	}
+	return details
+}
+
+func visibility(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
+
+type detailField struct {
+	Label string
+	Value string
+}
+
+func printRepoDetails(out io.Writer, heading, name string, fields []detailField, webURL string, clone []string) error {
+	if _, err := fmt.Fprintln(out, heading); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "Name: %s\n", name); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if field.Value == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "%s: %s\n", field.Label, field.Value); err != nil {
+			return err
+		}
+	}
+	if webURL != "" {
+		if _, err := fmt.Fprintf(out, "Web:  %s\n", webURL); err != nil {
+			return err
+		}
+	}
+	for _, url := range clone {
+		if _, err := fmt.Fprintf(out, "Clone: %s\n", url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatSize renders a byte count using binary units.
+func formatSize(bytes int64) string {
+	if bytes <= 0 {
+		return ""
+	}
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
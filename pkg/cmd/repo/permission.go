@@ -0,0 +1,422 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdPermission wires the repository permission subcommands. It is also
+// mounted as "bkt perms repo".
+func NewCmdPermission(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "permission",
+		Aliases: []string{"permissions", "perm"},
+		Short:   "Manage explicit user and group access to a repository",
+		Long: `List, grant and revoke explicit user and group permissions on a repository.
+Permissions are read, write or admin on both Cloud and Data Center (where they
+map to REPO_READ, REPO_WRITE and REPO_ADMIN). Granting to a user or group that
+already has access updates the permission.
+
+On Cloud, --user takes an account ID or UUID and --group a group slug. On Data
+Center they take the user name and group name.`,
+	}
+
+	cmd.AddCommand(newPermissionListCmd(f))
+	cmd.AddCommand(newPermissionGrantCmd(f))
+	cmd.AddCommand(newPermissionRevokeCmd(f))
+
+	return cmd
+}
+
+type permissionOptions struct {
+	Project    string
+	Workspace  string
+	Repo       string
+	User       string
+	Group      string
+	Permission string
+	Limit      int
+}
+
+func (o *permissionOptions) addScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&o.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&o.Repo, "repo", "", "Repository slug override")
+}
+
+func (o *permissionOptions) addSubjectFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.User, "user", "", "User to change (Cloud account ID or UUID; DC user name)")
+	cmd.Flags().StringVar(&o.Group, "group", "", "Group to change (Cloud group slug; DC group name)")
+	cmd.MarkFlagsMutuallyExclusive("user", "group")
+	cmd.MarkFlagsOneRequired("user", "group")
+}
+
+// permissionEntry is the display form shared by Cloud and Data Center.
+type permissionEntry struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	ID         string `json:"id,omitempty"`
+	Permission string `json:"permission"`
+}
+
+func newPermissionListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &permissionOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List explicit repository permissions",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermissionList(cmd, f, opts)
+		},
+	}
+
+	opts.addScopeFlags(cmd)
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum entries to display (0 for all)")
+
+	return cmd
+}
+
+func runPermissionList(cmd *cobra.Command, f *cmdutil.Factory, opts *permissionOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	var (
+		scope   string
+		entries []permissionEntry
+	)
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+		scope = projectKey + "/" + repoSlug
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		users, err := client.ListRepoPermissions(ctx, projectKey, repoSlug, 0)
+		if err != nil {
+			return err
+		}
+		groups, err := client.ListRepoGroupPermissions(ctx, projectKey, repoSlug, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range users {
+			entries = append(entries, permissionEntry{
+				Type:       "user",
+				Name:       cmdutil.FirstNonEmpty(p.User.FullName, p.User.Name),
+				ID:         p.User.Name,
+				Permission: displayPermission(p.Permission),
+			})
+		}
+		for _, p := range groups {
+			entries = append(entries, permissionEntry{
+				Type:       "group",
+				Name:       p.Group.Name,
+				ID:         p.Group.Name,
+				Permission: displayPermission(p.Permission),
+			})
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+		scope = workspace + "/" + repoSlug
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		users, err := client.ListRepoUserPermissions(ctx, workspace, repoSlug)
+		if err != nil {
+			return err
+		}
+		groups, err := client.ListRepoGroupPermissions(ctx, workspace, repoSlug)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range users {
+			entries = append(entries, permissionEntry{
+				Type:       "user",
+				Name:       p.User.DisplayName,
+				ID:         cmdutil.FirstNonEmpty(p.User.AccountID, p.User.UUID),
+				Permission: displayPermission(p.Permission),
+			})
+		}
+		for _, p := range groups {
+			entries = append(entries, permissionEntry{
+				Type:       "group",
+				Name:       cmdutil.FirstNonEmpty(p.Group.Name, p.Group.Slug),
+				ID:         p.Group.Slug,
+				Permission: displayPermission(p.Permission),
+			})
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	if opts.Limit > 0 && len(entries) > opts.Limit {
+		entries = entries[:opts.Limit]
+	}
+
+	payload := map[string]any{
+		"repository":  scope,
+		"permissions": entries,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(entries) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No explicit permissions on %s.\n", scope)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		if _, err := fmt.Fprintln(tw, "TYPE\tNAME\tID\tPERMISSION"); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Type, e.Name, cmdutil.FirstNonEmpty(e.ID, "-"), e.Permission); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+func newPermissionGrantCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &permissionOptions{}
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "Grant or update a user or group permission",
+		Example: `  bkt repo permission grant --user 557058:1a2b --perm write
+  bkt repo permission grant --group developers --perm read --repo api`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermissionGrant(cmd, f, opts)
+		},
+	}
+
+	opts.addScopeFlags(cmd)
+	opts.addSubjectFlags(cmd)
+	cmd.Flags().StringVar(&opts.Permission, "perm", "read", "Permission to grant: read, write or admin")
+
+	return cmd
+}
+
+func runPermissionGrant(cmd *cobra.Command, f *cmdutil.Factory, opts *permissionOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	perm, err := normalizePermission(opts.Permission)
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	var scope string
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+		scope = projectKey + "/" + repoSlug
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		dcPerm := "REPO_" + strings.ToUpper(perm)
+		if opts.Group != "" {
+			err = client.GrantRepoGroupPermission(ctx, projectKey, repoSlug, opts.Group, dcPerm)
+		} else {
+			err = client.GrantRepoPermission(ctx, projectKey, repoSlug, opts.User, dcPerm)
+		}
+		if err != nil {
+			return err
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+		scope = workspace + "/" + repoSlug
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		if opts.Group != "" {
+			err = client.GrantRepoGroupPermission(ctx, workspace, repoSlug, opts.Group, perm)
+		} else {
+			err = client.GrantRepoUserPermission(ctx, workspace, repoSlug, opts.User, perm)
+		}
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Granted %s on %s to %s\n", perm, scope, permissionSubject(opts))
+	return err
+}
+
+func newPermissionRevokeCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &permissionOptions{}
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke a user or group permission",
+		Example: `  bkt repo permission revoke --user 557058:1a2b
+  bkt repo permission revoke --group contractors --repo api`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermissionRevoke(cmd, f, opts)
+		},
+	}
+
+	opts.addScopeFlags(cmd)
+	opts.addSubjectFlags(cmd)
+
+	return cmd
+}
+
+func runPermissionRevoke(cmd *cobra.Command, f *cmdutil.Factory, opts *permissionOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	var scope string
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+		scope = projectKey + "/" + repoSlug
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		if opts.Group != "" {
+			err = client.RevokeRepoGroupPermission(ctx, projectKey, repoSlug, opts.Group)
+		} else {
+			err = client.RevokeRepoPermission(ctx, projectKey, repoSlug, opts.User)
+		}
+		if err != nil {
+			return err
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+		scope = workspace + "/" + repoSlug
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		if opts.Group != "" {
+			err = client.RevokeRepoGroupPermission(ctx, workspace, repoSlug, opts.Group)
+		} else {
+			err = client.RevokeRepoUserPermission(ctx, workspace, repoSlug, opts.User)
+		}
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Revoked %s access to %s\n", permissionSubject(opts), scope)
+	return err
+}
+
+// normalizePermission accepts read/write/admin in any case, with or without
+// the Data Center REPO_ prefix.
+func normalizePermission(perm string) (string, error) {
+	p := strings.ToLower(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(perm)), "REPO_"))
+	switch p {
+	case "read", "write", "admin":
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid --perm %q (use read, write or admin)", perm)
+	}
+}
+
+// displayPermission maps REPO_WRITE style values to their short form.
+func displayPermission(perm string) string {
+	return strings.ToLower(strings.TrimPrefix(perm, "REPO_"))
+}
+
+func permissionSubject(opts *permissionOptions) string {
+	if opts.Group != "" {
+		return "group " + opts.Group
+	}
+	return "user " + opts.User
+}
@@ -2,14 +2,20 @@ package repo
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
+	"net/url"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/alessandro308/bitbucket-cli/internal/config"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
@@ -24,9 +30,16 @@ func NewCmdRepo(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.AddCommand(newListCmd(f))
 	cmd.AddCommand(newViewCmd(f))
+	cmd.AddCommand(newEditCmd(f))
 	cmd.AddCommand(newCreateCmd(f))
 	cmd.AddCommand(newCloneCmd(f))
 	cmd.AddCommand(newBrowseCmd(f))
+	cmd.AddCommand(newDefaultReviewerCmd(f))
+	cmd.AddCommand(newDeployKeyCmd(f))
+	cmd.AddCommand(NewCmdPermission(f))
+	cmd.AddCommand(newWatchCmd(f))
+	cmd.AddCommand(newUnwatchCmd(f))
+	cmd.AddCommand(newWatchingCmd(f))
 
 	return cmd
 }
@@ -34,6 +47,9 @@ func NewCmdRepo(f *cmdutil.Factory) *cobra.Command {
 type listOptions struct {
 	Project   string
 	Workspace string
+	Role      string
+	Query     string
+	Sort      string
 	Limit     int
 }
 
@@ -53,15 +69,26 @@ func newListCmd(f *cmdutil.Factory) *cobra.Command {
 		Limit: 30,
 	}
 	cmd := &cobra.Command{
-		Use:     "list",
+		Use:     "list [workspace|project]",
 		Aliases: []string{"ls"},
 		Short:   "List repositories within the active scope",
+		Example: `  bkt repo list
+  bkt repo list my-team --role admin
+  bkt repo list my-team --query 'name ~ "api"' --sort name`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Project = args[0]
+				opts.Workspace = args[0]
+			}
 			return runList(cmd, f, opts)
 		},
 	}
 	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Role, "role", "", "Only repositories where you hold this role: member, contributor, admin, owner (Cloud)")
+	cmd.Flags().StringVar(&opts.Query, "query", "", "BBQL filter expression, e.g. 'name ~ \"api\"' (Cloud)")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "-updated_on", "Sort field; prefix with - for descending (Cloud)")
 	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum repositories to display (0 for all)")
 	return cmd
 }
@@ -89,16 +116,12 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		}
 		projectKey = strings.ToUpper(projectKey)
 
-		client, err := bbdc.New(bbdc.Options{
-			BaseURL:  host.BaseURL,
-			Username: host.Username,
-			Token:    host.Token,
-		})
+		client, err := cmdutil.NewDCClient(host)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		repos, err := client.ListRepositories(ctx, projectKey, opts.Limit)
@@ -173,32 +196,43 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
-		repos, err := client.ListRepositories(ctx, workspace, opts.Limit)
+		repos, err := client.ListRepositories(ctx, workspace, bbcloud.ListRepositoriesOptions{
+			Role:  opts.Role,
+			Query: opts.Query,
+			Sort:  opts.Sort,
+			Limit: opts.Limit,
+		})
 		if err != nil {
 			return err
 		}
 
 		type repoSummary struct {
-			Workspace string   `json:"workspace"`
-			Slug      string   `json:"slug"`
-			Name      string   `json:"name"`
-			UUID      string   `json:"uuid"`
-			WebURL    string   `json:"web_url,omitempty"`
-			Clone     []string `json:"clone_urls,omitempty"`
+			Workspace   string   `json:"workspace"`
+			Slug        string   `json:"slug"`
+			Name        string   `json:"name"`
+			UUID        string   `json:"uuid"`
+			Description string   `json:"description,omitempty"`
+			UpdatedOn   string   `json:"updated_on,omitempty"`
+			Private     bool     `json:"is_private"`
+			WebURL      string   `json:"web_url,omitempty"`
+			Clone       []string `json:"clone_urls,omitempty"`
 		}
 
 		var summaries []repoSummary
 		for _, repo := range repos {
 			summaries = append(summaries, repoSummary{
-				Workspace: workspace,
-				Slug:      repo.Slug,
-				Name:      repo.Name,
-				UUID:      strings.Trim(repo.UUID, "{}"),
-				WebURL:    firstLinkCloud(repo),
-				Clone:     cloneLinksCloud(repo),
+				Workspace:   workspace,
+				Slug:        repo.Slug,
+				Name:        repo.Name,
+				UUID:        strings.Trim(repo.UUID, "{}"),
+				Description: repo.Description,
+				UpdatedOn:   repo.UpdatedOn,
+				Private:     repo.IsPrivate,
+				WebURL:      firstLinkCloud(repo),
+				Clone:       cloneLinksCloud(repo),
 			})
 		}
 
@@ -216,22 +250,21 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 				return err
 			}
 
+			tw := tabwriter.NewWriter(ios.Out, 0, 0, 2, ' ', 0)
+			if _, err := fmt.Fprintln(tw, "SLUG\tDESCRIPTION\tUPDATED\tVISIBILITY"); err != nil {
+				return err
+			}
 			for _, r := range summaries {
-				if _, err := fmt.Fprintf(ios.Out, "%s/%s\t%s\n", r.Workspace, r.Slug, r.Name); err != nil {
+				if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+					r.Slug,
+					truncate(oneLine(r.Description), 60),
+					cmdutil.FirstNonEmpty(formatUpdated(r.UpdatedOn), "-"),
+					visibility(r.Private),
+				); err != nil {
 					return err
 				}
-				if r.WebURL != "" {
-					if _, err := fmt.Fprintf(ios.Out, "    web:   %s\n", r.WebURL); err != nil {
-						return err
-					}
-				}
-				if len(r.Clone) > 0 {
-					if _, err := fmt.Fprintf(ios.Out, "    clone: %s\n", strings.Join(r.Clone, ", ")); err != nil {
-						return err
-					}
-				}
 			}
-			return nil
+			return tw.Flush()
 		})
 
 	default:
@@ -243,6 +276,7 @@ type viewOptions struct {
 	Project   string
 	Workspace string
 	Repo      string
+	Web       bool
 }
 
 type cloneOptions struct {
@@ -250,7 +284,9 @@ type cloneOptions struct {
 	Workspace string
 	Repo      string
 	UseSSH    bool
+	UseHTTPS  bool
 	Dest      string
+	GitArgs   []string
 }
 
 func newViewCmd(f *cmdutil.Factory) *cobra.Command {
@@ -269,6 +305,7 @@ func newViewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "repository")
 	return cmd
 }
 
@@ -303,16 +340,12 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 			return fmt.Errorf("repository slug required; pass --repo or set the context default")
 		}
 
-		client, err := bbdc.New(bbdc.Options{
-			BaseURL:  host.BaseURL,
-			Username: host.Username,
-			Token:    host.Token,
-		})
+		client, err := cmdutil.NewDCClient(host)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		repo, err := client.GetRepository(ctx, projectKey, repoSlug)
@@ -320,44 +353,27 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 			return err
 		}
 
-		type repoDetails struct {
-			Project string   `json:"project"`
-			Slug    string   `json:"slug"`
-			Name    string   `json:"name"`
-			ID      int      `json:"id"`
-			WebURL  string   `json:"web_url,omitempty"`
-			Clone   []string `json:"clone_urls,omitempty"`
+		if opts.Web {
+			return cmdutil.OpenInBrowser(f, ios.Out, "repository", firstLinkDC(*repo, "web"))
 		}
 
-		details := repoDetails{
-			Project: repo.Project.Key,
-			Slug:    repo.Slug,
-			Name:    repo.Name,
-			ID:      repo.ID,
-			WebURL:  firstLinkDC(*repo, "web"),
-			Clone:   cloneLinksDC(*repo),
+		// The default branch is optional metadata; leave it blank when the
+		// repository is empty or the lookup is not permitted.
+		if repo.DefaultBranch == "" {
+			if branch, err := client.GetDefaultBranch(ctx, projectKey, repoSlug); err == nil {
+				repo.DefaultBranch = branch.DisplayID
+			}
 		}
 
+		details := dcRepoDetails(*repo)
+
 		return cmdutil.WriteOutput(cmd, ios.Out, details, func() error {
-			if _, err := fmt.Fprintf(ios.Out, "%s/%s (%d)\n", details.Project, details.Slug, details.ID); err != nil {
-				return err
-			}
-			if _, err := fmt.Fprintf(ios.Out, "Name: %s\n", details.Name); err != nil {
-				return err
-			}
-			if details.WebURL != "" {
-				if _, err := fmt.Fprintf(ios.Out, "Web:  %s\n", details.WebURL); err != nil {
-					return err
-				}
-			}
-			if len(details.Clone) > 0 {
-				for _, url := range details.Clone {
-					if _, err := fmt.Fprintf(ios.Out, "Clone: %s\n", url); err != nil {
-						return err
-					}
-				}
-			}
-			return nil
+			return printRepoDetails(ios.Out, fmt.Sprintf("%s/%s (%d)", details.Project, details.Slug, details.ID), details.Name, []detailField{
+				{"Description", details.Description},
+				{"Default branch", details.DefaultBranch},
+				{"Visibility", details.Visibility},
+				{"Forkable", fmt.Sprintf("%t", details.Forkable)},
+			}, details.WebURL, details.Clone)
 		})
 
 	case "cloud":
@@ -382,7 +398,7 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		repo, err := client.GetRepository(ctx, workspace, repoSlug)
@@ -390,44 +406,22 @@ func runView(cmd *cobra.Command, f *cmdutil.Factory, opts *viewOptions) error {
 			return err
 		}
 
-		type repoDetails struct {
-			Workspace string   `json:"workspace"`
-			Slug      string   `json:"slug"`
-			Name      string   `json:"name"`
-			UUID      string   `json:"uuid"`
-			WebURL    string   `json:"web_url,omitempty"`
-			Clone     []string `json:"clone_urls,omitempty"`
+		if opts.Web {
+			return cmdutil.OpenInBrowser(f, ios.Out, "repository", repo.Links.HTML.Href)
 		}
 
-		details := repoDetails{
-			Workspace: workspace,
-			Slug:      repo.Slug,
-			Name:      repo.Name,
-			UUID:      strings.Trim(repo.UUID, "{}"),
-			WebURL:    firstLinkCloud(*repo),
-			Clone:     cloneLinksCloud(*repo),
-		}
+		details := cloudRepoDetails(workspace, *repo)
 
 		return cmdutil.WriteOutput(cmd, ios.Out, details, func() error {
-			if _, err := fmt.Fprintf(ios.Out, "%s/%s (%s)\n", details.Workspace, details.Slug, details.UUID); err != nil {
-				return err
-			}
-			if _, err := fmt.Fprintf(ios.Out, "Name: %s\n", details.Name); err != nil {
-				return err
-			}
-			if details.WebURL != "" {
-				if _, err := fmt.Fprintf(ios.Out, "Web:  %s\n", details.WebURL); err != nil {
-					return err
-				}
-			}
-			if len(details.Clone) > 0 {
-				for _, url := range details.Clone {
-					if _, err := fmt.Fprintf(ios.Out, "Clone: %s\n", url); err != nil {
-						return err
-					}
-				}
-			}
-			return nil
+			return printRepoDetails(ios.Out, fmt.Sprintf("%s/%s (%s)", details.Workspace, details.Slug, details.UUID), details.Name, []detailField{
+				{"Description", details.Description},
+				{"Default branch", details.DefaultBranch},
+				{"Visibility", details.Visibility},
+				{"Fork policy", details.ForkPolicy},
+				{"Language", details.Language},
+				{"Size", formatSize(details.Size)},
+				{"Updated", formatUpdated(details.UpdatedOn)},
+			}, details.WebURL, details.Clone)
 		})
 
 	default:
@@ -447,20 +441,17 @@ func runClone(cmd *cobra.Command, f *cmdutil.Factory, opts *cloneOptions) error
 		return err
 	}
 
+	owner, repoArg := splitRepoArg(opts.Repo)
+	protocol := resolveCloneProtocol(opts, host)
+
 	switch host.Kind {
 	case "dc":
-		projectKey := strings.TrimSpace(opts.Project)
+		projectKey := cmdutil.FirstNonEmpty(owner, opts.Project, ctxCfg.ProjectKey)
 		if projectKey == "" {
-			projectKey = ctxCfg.ProjectKey
-		}
-		if projectKey == "" {
-			return fmt.Errorf("project key required; set with --project or configure the context default")
+			return fmt.Errorf("project key required; pass PROJECT/repo, set --project, or configure the context default")
 		}
 
-		repoSlug := strings.TrimSpace(opts.Repo)
-		if repoSlug == "" {
-			repoSlug = ctxCfg.DefaultRepo
-		}
+		repoSlug := cmdutil.FirstNonEmpty(repoArg, ctxCfg.DefaultRepo)
 		if repoSlug == "" {
 			return fmt.Errorf("repository slug required; pass argument or set the context default")
 		}
@@ -470,7 +461,7 @@ func runClone(cmd *cobra.Command, f *cmdutil.Factory, opts *cloneOptions) error
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		repo, err := client.GetRepository(ctx, projectKey, repoSlug)
@@ -478,26 +469,25 @@ func runClone(cmd *cobra.Command, f *cmdutil.Factory, opts *cloneOptions) error
 			return err
 		}
 
-		cloneURL, err := selectCloneURLDC(*repo, opts.UseSSH)
+		cloneURL, err := selectCloneURLDC(*repo, protocol == "ssh")
 		if err != nil {
 			return err
 		}
 
-		return runGitClone(cmd, ios.Out, ios.ErrOut, ios.In, cloneURL, opts.Dest)
+		env, err := cloneCredentialEnv(ctx, host, protocol, cloneURL)
+		if err != nil {
+			return err
+		}
+
+		return runGitClone(cmd, ios.Out, ios.ErrOut, ios.In, cloneURL, opts.Dest, opts.GitArgs, env)
 
 	case "cloud":
-		workspace := strings.TrimSpace(opts.Workspace)
+		workspace := cmdutil.FirstNonEmpty(owner, opts.Workspace, ctxCfg.Workspace)
 		if workspace == "" {
-			workspace = ctxCfg.Workspace
-		}
-		if workspace == "" {
-			return fmt.Errorf("workspace required; set with --workspace or configure the context default")
+			return fmt.Errorf("workspace required; pass workspace/repo, set --workspace, or configure the context default")
 		}
 
-		repoSlug := strings.TrimSpace(opts.Repo)
-		if repoSlug == "" {
-			repoSlug = ctxCfg.DefaultRepo
-		}
+		repoSlug := cmdutil.FirstNonEmpty(repoArg, ctxCfg.DefaultRepo)
 		if repoSlug == "" {
 			return fmt.Errorf("repository slug required; pass argument or set the context default")
 		}
@@ -507,7 +497,7 @@ func runClone(cmd *cobra.Command, f *cmdutil.Factory, opts *cloneOptions) error
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		repo, err := client.GetRepository(ctx, workspace, repoSlug)
@@ -515,18 +505,126 @@ func runClone(cmd *cobra.Command, f *cmdutil.Factory, opts *cloneOptions) error
 			return err
 		}
 
-		cloneURL, err := selectCloneURLCloud(*repo, opts.UseSSH)
+		cloneURL, err := selectCloneURLCloud(*repo, protocol == "ssh")
+		if err != nil {
+			return err
+		}
+
+		env, err := cloneCredentialEnv(ctx, host, protocol, cloneURL)
 		if err != nil {
 			return err
 		}
 
-		return runGitClone(cmd, ios.Out, ios.ErrOut, ios.In, cloneURL, opts.Dest)
+		return runGitClone(cmd, ios.Out, ios.ErrOut, ios.In, cloneURL, opts.Dest, opts.GitArgs, env)
 
 	default:
 		return fmt.Errorf("unsupported host kind %q", host.Kind)
 	}
 }
 
+// formatUpdated renders a Bitbucket timestamp as a short local date, falling
+// back to the raw value when it cannot be parsed.
+func formatUpdated(ts string) string {
+	if ts == "" {
+		return ""
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ts
+	}
+	return parsed.Local().Format("2006-01-02 15:04")
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// splitRepoArg separates an OWNER/slug argument into its owner (workspace or
+// project key) and repository slug. Bare slugs return an empty owner.
+func splitRepoArg(arg string) (string, string) {
+	arg = strings.Trim(strings.TrimSpace(arg), "/")
+	if owner, slug, ok := strings.Cut(arg, "/"); ok {
+		return strings.TrimSpace(owner), strings.TrimSpace(slug)
+	}
+	return "", arg
+}
+
+// resolveCloneProtocol picks ssh or https from explicit flags, then the host
+// git_protocol preference, defaulting to https.
+func resolveCloneProtocol(opts *cloneOptions, host *config.Host) string {
+	switch {
+	case opts.UseSSH:
+		return "ssh"
+	case opts.UseHTTPS:
+		return "https"
+	}
+	if host != nil && strings.EqualFold(strings.TrimSpace(host.GitProtocol), "ssh") {
+		return "ssh"
+	}
+	return "https"
+}
+
+// cloneCredentialEnv returns environment variables that inject an HTTP
+// Authorization header for the clone. Using GIT_CONFIG_* keeps the token out of
+// the process arguments and out of the cloned repository's .git/config. The
+// header is scoped to the clone URL's origin so submodules hosted elsewhere
+// never see it, and it is appended after any GIT_CONFIG_* entries already set.
+func cloneCredentialEnv(ctx context.Context, host *config.Host, protocol, cloneURL string) ([]string, error) {
+	if protocol != "https" || host == nil || host.Token == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(cloneURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil, nil
+	}
+	scope := parsed.Scheme + "://" + parsed.Host + "/"
+
+	index := 0
+	if raw := os.Getenv("GIT_CONFIG_COUNT"); raw != "" {
+		if index, err = strconv.Atoi(raw); err != nil || index < 0 {
+			// git rejects the clone with its own error; adding to a bogus
+			// count would only hide it.
+			return nil, nil
+		}
+	}
+
+	username := host.Username
+	if host.AuthMethod == config.AuthMethodOAuth || host.AuthMethod == config.AuthMethodBearer {
+		// OAuth access tokens authenticate git with the x-token-auth user.
+		username = "x-token-auth"
+	} else if host.Kind == "cloud" && strings.Contains(username, "@") {
+		// Atlassian API tokens are used with the account email for the API
+		// but authenticate git over HTTPS with a fixed username. App
+		// passwords and other basic credentials keep the host's username.
+		username = "x-bitbucket-api-token-auth"
+	}
+	if username == "" {
+		return nil, nil
+	}
+
+	// Refreshes an expired OAuth access token, as the API client does.
+	token, err := cmdutil.AccessToken(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := base64.StdEncoding.EncodeToString([]byte(username + ":" + token))
+	return []string{
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", index+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.%s.extraHeader", index, scope),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", index, creds),
+	}, nil
+}
+
 func runBrowse(cmd *cobra.Command, f *cmdutil.Factory, opts *browseOptions) error {
 	ios, err := f.Streams()
 	if err != nil {
@@ -562,7 +660,7 @@ func runBrowse(cmd *cobra.Command, f *cmdutil.Factory, opts *browseOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 		repo, err := client.GetRepository(ctx, projectKey, repoSlug)
 		if err != nil {
@@ -598,7 +696,7 @@ func runBrowse(cmd *cobra.Command, f *cmdutil.Factory, opts *browseOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 		repo, err := client.GetRepository(ctx, workspace, repoSlug)
 		if err != nil {
@@ -669,7 +767,7 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, slug string, opts createO
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		input := bbdc.CreateRepositoryInput{
@@ -715,7 +813,7 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, slug string, opts createO
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
 		defer cancel()
 
 		input := bbcloud.CreateRepositoryInput{
@@ -749,10 +847,31 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, slug string, opts createO
 func newCloneCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &cloneOptions{}
 	cmd := &cobra.Command{
-		Use:   "clone <repository>",
+		Use:   "clone <repository> [-- <git flags>...]",
 		Short: "Clone a repository",
-		Args:  cobra.ExactArgs(1),
+		Long: `Clone a repository, resolving its clone links through the Bitbucket API.
+
+The repository may be given as a bare slug (using the context default
+workspace or project) or as WORKSPACE/slug (Cloud) or PROJECT/slug (Data
+Center). HTTPS is used unless --ssh is passed or the host was logged in with
+--git-protocol ssh. HTTPS clones reuse the stored credentials when available.
+
+Arguments after -- are passed through to git clone.`,
+		Example: `  bkt repo clone my-team/api
+  bkt repo clone api --ssh
+  bkt repo clone my-team/api -- --depth 1 --branch develop`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				opts.GitArgs = args[dash:]
+				args = args[:dash]
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("expected exactly one repository argument before --")
+			}
+			if opts.UseSSH && opts.UseHTTPS {
+				return fmt.Errorf("specify only one of --ssh or --https")
+			}
 			opts.Repo = args[0]
 			return runClone(cmd, f, opts)
 		},
@@ -761,6 +880,7 @@ func newCloneCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().BoolVar(&opts.UseSSH, "ssh", false, "Use SSH clone URL")
+	cmd.Flags().BoolVar(&opts.UseHTTPS, "https", false, "Use HTTPS clone URL")
 	cmd.Flags().StringVar(&opts.Dest, "dest", "", "Destination directory")
 
 	return cmd
@@ -889,8 +1009,10 @@ func selectCloneURLCloud(repo bbcloud.Repository, useSSH bool) (string, error) {
 	return "", fmt.Errorf("no %s clone URL available", desired)
 }
 
-func runGitClone(cmd *cobra.Command, out, errOut io.Writer, in io.Reader, cloneURL, dest string) error {
-	args := []string{"clone", cloneURL}
+func runGitClone(cmd *cobra.Command, out, errOut io.Writer, in io.Reader, cloneURL, dest string, extraArgs, env []string) error {
+	args := []string{"clone"}
+	args = append(args, extraArgs...)
+	args = append(args, cloneURL)
 	if dest != "" {
 		args = append(args, dest)
 	}
@@ -899,6 +1021,9 @@ func runGitClone(cmd *cobra.Command, out, errOut io.Writer, in io.Reader, cloneU
 	gitCmd.Stdout = out
 	gitCmd.Stderr = errOut
 	gitCmd.Stdin = in
+	if len(env) > 0 {
+		gitCmd.Env = append(os.Environ(), env...)
+	}
 
 	return gitCmd.Run()
 }
@@ -1,10 +1,21 @@
 package repo
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/oauth"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
 	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
@@ -124,3 +135,836 @@ func TestBrowseWithoutRepoDefaults(t *testing.T) {
 		t.Fatalf("expected error to mention repository requirement, got %q", err.Error())
 	}
 }
+
+func TestSplitRepoArg(t *testing.T) {
+	tests := []struct {
+		in, owner, slug string
+	}{
+		{"repo", "", "repo"},
+		{"my-team/api", "my-team", "api"},
+		{"/PROJ/repo/", "PROJ", "repo"},
+	}
+	for _, tt := range tests {
+		owner, slug := splitRepoArg(tt.in)
+		if owner != tt.owner || slug != tt.slug {
+			t.Fatalf("splitRepoArg(%q) = %q, %q; want %q, %q", tt.in, owner, slug, tt.owner, tt.slug)
+		}
+	}
+}
+
+func TestResolveCloneProtocol(t *testing.T) {
+	sshHost := &config.Host{Kind: "cloud", GitProtocol: "ssh"}
+
+	if got := resolveCloneProtocol(&cloneOptions{}, &config.Host{Kind: "cloud"}); got != "https" {
+		t.Fatalf("default protocol = %q, want https", got)
+	}
+	if got := resolveCloneProtocol(&cloneOptions{}, sshHost); got != "ssh" {
+		t.Fatalf("configured protocol = %q, want ssh", got)
+	}
+	if got := resolveCloneProtocol(&cloneOptions{UseHTTPS: true}, sshHost); got != "https" {
+		t.Fatalf("--https should override host preference, got %q", got)
+	}
+}
+
+func TestCloneCredentialEnv(t *testing.T) {
+	host := &config.Host{Kind: "dc", Username: "alice", Token: "tok"}
+	if env, err := cloneCredentialEnv(context.Background(), host, "ssh", "ssh://git@bitbucket.example.com:7999/proj/api.git"); err != nil || env != nil {
+		t.Fatalf("expected no credentials for ssh, got %v", env)
+	}
+
+	env, err := cloneCredentialEnv(context.Background(), host, "https", "https://alice@bitbucket.example.com/scm/proj/api.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(env) != 3 || env[0] != "GIT_CONFIG_COUNT=1" || env[1] != "GIT_CONFIG_KEY_0=http.https://bitbucket.example.com/.extraHeader" {
+		t.Fatalf("unexpected env: %v", env)
+	}
+	// base64("alice:tok")
+	if env[2] != "GIT_CONFIG_VALUE_0=Authorization: Basic YWxpY2U6dG9r" {
+		t.Fatalf("unexpected auth header: %q", env[2])
+	}
+
+	encoded, err := oauth.Credentials{Token: oauth.Token{AccessToken: "access", Expiry: time.Now().Add(time.Hour)}}.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oauthHost := &config.Host{Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", AuthMethod: config.AuthMethodOAuth, Token: encoded}
+	env, err = cloneCredentialEnv(context.Background(), oauthHost, "https", "https://bitbucket.org/team/api.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("x-token-auth:access")); len(env) != 3 || env[2] != want {
+		t.Fatalf("unexpected OAuth env: %v", env)
+	}
+
+	// Cloud API tokens belong to an account email and use git's token user;
+	// app passwords keep the Bitbucket username.
+	apiTokenHost := &config.Host{Kind: "cloud", Username: "alice@example.com", Token: "tok"}
+	env, _ = cloneCredentialEnv(context.Background(), apiTokenHost, "https", "https://bitbucket.org/team/api.git")
+	if want := "GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("x-bitbucket-api-token-auth:tok")); len(env) != 3 || env[2] != want {
+		t.Fatalf("unexpected API token env: %v", env)
+	}
+	appPasswordHost := &config.Host{Kind: "cloud", Username: "alice", Token: "app-pass"}
+	env, _ = cloneCredentialEnv(context.Background(), appPasswordHost, "https", "https://bitbucket.org/team/api.git")
+	if want := "GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("alice:app-pass")); len(env) != 3 || env[2] != want {
+		t.Fatalf("unexpected app password env: %v", env)
+	}
+
+	// Entries the user already passes through GIT_CONFIG_* are kept.
+	t.Setenv("GIT_CONFIG_COUNT", "2")
+	env, _ = cloneCredentialEnv(context.Background(), host, "https", "https://bitbucket.example.com/scm/proj/api.git")
+	if len(env) != 3 || env[0] != "GIT_CONFIG_COUNT=3" || !strings.HasPrefix(env[1], "GIT_CONFIG_KEY_2=") || !strings.HasPrefix(env[2], "GIT_CONFIG_VALUE_2=") {
+		t.Fatalf("unexpected env with existing count: %v", env)
+	}
+
+	t.Setenv("GIT_CONFIG_COUNT", "many")
+	if env, _ := cloneCredentialEnv(context.Background(), host, "https", "https://bitbucket.example.com/scm/proj/api.git"); env != nil {
+		t.Fatalf("expected no credentials with an invalid count, got %v", env)
+	}
+}
+
+func TestListCloudRendersTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/other-team" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("role") != "member" {
+			t.Fatalf("expected role filter, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"values": []map[string]any{
+				{"slug": "api", "description": "Public\nAPI", "updated_on": "2026-01-02T03:04:05.000000+00:00", "is_private": true},
+				{"slug": "site", "is_private": false},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newListCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"other-team", "--role", "member"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo list: %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"SLUG", "VISIBILITY", "Public API", "private", "public", "site"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestEditRequiresChanges(t *testing.T) {
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newEditCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "no updates specified") {
+		t.Fatalf("expected no updates error, got %v", err)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		0:           "",
+		512:         "512 B",
+		2048:        "2.0 KiB",
+		5 * 1 << 20: "5.0 MiB",
+	}
+	for in, want := range tests {
+		if got := formatSize(in); got != want {
+			t.Fatalf("formatSize(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDefaultReviewerAddContinuesAfterFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"error","error":{"message":"user not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"uuid":"{u1}","display_name":"Alice"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newDefaultReviewerCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"add", "missing", "557058:alice"})
+
+	err := cmd.Execute()
+	var exitErr *cmdutil.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected partial failure exit error, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Added default reviewer Alice (557058:alice)") {
+		t.Fatalf("expected successful addition in output, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "missing") {
+		t.Fatalf("expected failure summary on stderr, got %q", stderr.String())
+	}
+}
+
+func TestDefaultReviewerAddJSONHasAccountFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uuid":"{u1}","display_name":"Alice"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newDefaultReviewerCmd(f)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"add", "557058:alice", "--json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("default-reviewer add: %v", err)
+	}
+
+	var payload struct {
+		Added []struct {
+			User        string `json:"user"`
+			UUID        string `json:"uuid"`
+			DisplayName string `json:"display_name"`
+		} `json:"added"`
+	}
+	if err := json.Unmarshal([]byte(stdout.String()), &payload); err != nil {
+		t.Fatalf("decode: %v (%s)", err, stdout.String())
+	}
+	if len(payload.Added) != 1 || payload.Added[0].User != "557058:alice" ||
+		payload.Added[0].UUID != "{u1}" || payload.Added[0].DisplayName != "Alice" {
+		t.Fatalf("unexpected payload %+v", payload.Added)
+	}
+}
+
+func TestPermissionGrantDCGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/1.0/projects/PRJ/repos/api/permissions/groups" ||
+			q.Get("name") != "developers" || q.Get("permission") != "REPO_WRITE" {
+			t.Fatalf("unexpected request %s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "dc", ProjectKey: "PRJ", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"dc": {Kind: "dc", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCmdPermission(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"grant", "--group", "developers", "--perm", "REPO_WRITE"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo permission grant: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "✓ Granted write on PRJ/api to group developers") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestPermissionListCloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repositories/team/api/permissions-config/users":
+			_, _ = w.Write([]byte(`{"values":[{"permission":"admin","user":{"display_name":"Alice","account_id":"557058:a"}}]}`))
+		case "/repositories/team/api/permissions-config/groups":
+			_, _ = w.Write([]byte(`{"values":[{"permission":"read","group":{"slug":"qa","name":"QA"}}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCmdPermission(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"list"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo permission list: %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"Alice", "557058:a", "admin", "QA", "group", "read"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestNormalizePermission(t *testing.T) {
+	for in, want := range map[string]string{"read": "read", "REPO_ADMIN": "admin", " Write ": "write"} {
+		got, err := normalizePermission(in)
+		if err != nil || got != want {
+			t.Fatalf("normalizePermission(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := normalizePermission("owner"); err == nil {
+		t.Fatal("expected error for invalid permission")
+	}
+}
+
+type recordingBrowser struct {
+	opened []string
+}
+
+func (b *recordingBrowser) Open(url string) error {
+	b.opened = append(b.opened, url)
+	return nil
+}
+
+func TestViewWebOpensBrowser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/team/api" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"slug":  "api",
+			"links": map[string]any{"html": map[string]any{"href": "https://bitbucket.org/team/api"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	browser := &recordingBrowser{}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Browser: browser,
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newViewCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"api", "--web"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo view --web: %v", err)
+	}
+	if len(browser.opened) != 1 || browser.opened[0] != "https://bitbucket.org/team/api" {
+		t.Fatalf("opened %v, want the repository page", browser.opened)
+	}
+	if got := stdout.String(); got != "Opening https://bitbucket.org/team/api in your browser\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
+
+func TestWatchDCAllReportsFailures(t *testing.T) {
+	var watched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/PLAT/repos":
+			_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"slug":"api"},{"slug":"locked"},{"slug":"web"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/watch"):
+			if strings.Contains(r.URL.Path, "/locked/") {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":[{"message":"no access"}]}`))
+				return
+			}
+			watched = append(watched, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "dc", ProjectKey: "PRJ", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"dc": {Kind: "dc", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newWatchCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--all", "--project", "PLAT"})
+
+	err := cmd.Execute()
+	var exitErr *cmdutil.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected partial failure exit error, got %v", err)
+	}
+	if len(watched) != 2 || watched[1] != "/rest/api/1.0/projects/PLAT/repos/web/watch" {
+		t.Fatalf("unexpected watch requests %v", watched)
+	}
+	if stdout.String() != "✓ Watching PLAT/api\n✓ Watching PLAT/web\n" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "PLAT/locked") {
+		t.Fatalf("expected failure summary on stderr, got %q", stderr.String())
+	}
+}
+
+func TestWatchingCloudListsWatchedRepos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"uuid":"{me}"}`))
+		case "/repositories/team":
+			_, _ = w.Write([]byte(`{"values":[{"slug":"api"},{"slug":"docs"},{"slug":"web"}]}`))
+		case "/repositories/team/api/watchers", "/repositories/team/web/watchers":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{other}"},{"uuid":"{me}"}]}`))
+		case "/repositories/team/docs/watchers":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{other}"}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newWatchingCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo watching: %v", err)
+	}
+	if stdout.String() != "team/api\nteam/web\n" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestWatchingCloudChecksOnlyLimitedRepos(t *testing.T) {
+	var listQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"uuid":"{me}"}`))
+		case "/repositories/team":
+			listQuery = r.URL.Query()
+			_, _ = w.Write([]byte(`{"values":[{"slug":"web"},{"slug":"api"},{"slug":"docs"}]}`))
+		case "/repositories/team/api/watchers", "/repositories/team/web/watchers":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{me}"}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newWatchingCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--limit", "2", "--query", `name ~ "a"`})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo watching: %v", err)
+	}
+	if listQuery.Get("q") != `name ~ "a"` || listQuery.Get("sort") != "-updated_on" {
+		t.Fatalf("unexpected list query %v", listQuery)
+	}
+	if stdout.String() != "team/api\nteam/web\n" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "--limit 0") {
+		t.Fatalf("expected limit notice on stderr, got %q", stderr.String())
+	}
+}
+
+func TestWatchDCAllWritesRepoReports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/PLAT/repos":
+			_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"slug":"api"},{"slug":"locked"}]}`))
+		case strings.Contains(r.URL.Path, "/locked/"):
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"no access"}]}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "dc", ProjectKey: "PLAT"},
+		},
+		Hosts: map[string]*config.Host{
+			"dc": {Kind: "dc", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	dir := t.TempDir()
+	cmd := newWatchCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--all", "--out-dir", dir})
+
+	err := cmd.Execute()
+	var exitErr *cmdutil.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected partial failure exit error, got %v", err)
+	}
+
+	var index struct {
+		Reports []cmdutil.ReportIndexEntry `json:"reports"`
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("decode index: %v", err)
+	}
+	if len(index.Reports) != 2 || index.Reports[0].File != "PLAT+api.json" || index.Reports[0].Summary != "watched" ||
+		index.Reports[1].File != "PLAT+locked.json" || !strings.HasPrefix(index.Reports[1].Summary, "failed: ") {
+		t.Fatalf("unexpected index %+v", index.Reports)
+	}
+
+	var report struct {
+		Repo    string `json:"repo"`
+		Watched bool   `json:"watched"`
+		Error   string `json:"error"`
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "PLAT+locked.json"))
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Repo != "PLAT/locked" || report.Watched || !strings.Contains(report.Error, "no access") {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if !strings.Contains(stdout.String(), "✓ Wrote 2 report(s)") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestWatchingCloudWritesMarkdownReports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"uuid":"{me}"}`))
+		case "/repositories/team":
+			_, _ = w.Write([]byte(`{"values":[{"slug":"api"},{"slug":"docs"}]}`))
+		case "/repositories/team/api/watchers":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{me}"}]}`))
+		case "/repositories/team/docs/watchers":
+			_, _ = w.Write([]byte(`{"values":[]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	dir := t.TempDir()
+	cmd := newWatchingCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--out-dir", dir, "--out-format", "markdown"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo watching: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	for _, want := range []string{
+		"| team/api | watching | [team+api.md](team+api.md) |",
+		"| team/docs | not watching | [team+docs.md](team+docs.md) |",
+	} {
+		if !strings.Contains(string(index), want) {
+			t.Fatalf("index lacks %q:\n%s", want, index)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "team+docs.md")); err != nil {
+		t.Fatalf("missing per-repository report: %v", err)
+	}
+}
+
+func TestWatchRejectsUnknownReportFormatBeforeWatching(t *testing.T) {
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "dc", ProjectKey: "PLAT", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"dc": {Kind: "dc", BaseURL: "http://127.0.0.1:0", Username: "user", Token: "token"},
+		},
+	}
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &strings.Builder{}, ErrOut: &strings.Builder{}},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := newWatchCmd(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--out-dir", t.TempDir(), "--out-format", "xml"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+func newDefaultReviewerCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "default-reviewer",
+		Aliases: []string{"default-reviewers"},
+		Short:   "Manage repository default reviewers (Cloud)",
+		Long: `List, add and remove the default reviewers that Bitbucket Cloud adds to new
+pull requests. Users are identified by account UUID or Atlassian account ID.`,
+	}
+
+	cmd.AddCommand(newDefaultReviewerListCmd(f))
+	cmd.AddCommand(newDefaultReviewerAddCmd(f))
+	cmd.AddCommand(newDefaultReviewerRemoveCmd(f))
+
+	return cmd
+}
+
+type defaultReviewerOptions struct {
+	Workspace string
+	Repo      string
+	Limit     int
+	FailFast  bool
+}
+
+func newDefaultReviewerListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &defaultReviewerOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List default reviewers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDefaultReviewerList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum reviewers to list (0 for all)")
+
+	return cmd
+}
+
+func runDefaultReviewerList(cmd *cobra.Command, f *cmdutil.Factory, opts *defaultReviewerOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	reviewers, err := client.ListDefaultReviewers(ctx, workspace, repoSlug, opts.Limit)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"repo":      repoSlug,
+		"reviewers": reviewers,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(reviewers) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No default reviewers configured for %s/%s.\n", workspace, repoSlug)
+			return err
+		}
+		for _, r := range reviewers {
+			if _, err := fmt.Fprintf(ios.Out, "%s\t%s\t%s\n", r.DisplayName, r.Nickname, r.UUID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func newDefaultReviewerAddCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &defaultReviewerOptions{}
+	cmd := &cobra.Command{
+		Use:   "add <user>...",
+		Short: "Add default reviewers",
+		Example: `  bkt repo default-reviewer add {b3a1c2d4-...}
+  bkt repo default-reviewer add 557058:abcd --repo api`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDefaultReviewerChange(cmd, f, opts, args, true)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+
+	return cmd
+}
+
+func newDefaultReviewerRemoveCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &defaultReviewerOptions{}
+	cmd := &cobra.Command{
+		Use:     "remove <user>...",
+		Aliases: []string{"rm"},
+		Short:   "Remove default reviewers",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDefaultReviewerChange(cmd, f, opts, args, false)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+
+	return cmd
+}
+
+func runDefaultReviewerChange(cmd *cobra.Command, f *cmdutil.Factory, opts *defaultReviewerOptions, users []string, add bool) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, host, err := cmdutil.ResolveCloudRepo(f, cmd, opts.Workspace, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	// defaultReviewerChange identifies a reviewer as given on the command
+	// line, with the account details Bitbucket returns when adding one.
+	type defaultReviewerChange struct {
+		User        string `json:"user"`
+		UUID        string `json:"uuid,omitempty"`
+		DisplayName string `json:"display_name,omitempty"`
+	}
+
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+	changed := []defaultReviewerChange{}
+
+	for _, user := range users {
+		change := defaultReviewerChange{User: strings.TrimSpace(user)}
+		if add {
+			var account *bbcloud.Account
+			account, err = client.AddDefaultReviewer(ctx, workspace, repoSlug, change.User)
+			if err == nil {
+				change.UUID = account.UUID
+				change.DisplayName = account.DisplayName
+			}
+		} else {
+			err = client.RemoveDefaultReviewer(ctx, workspace, repoSlug, change.User)
+		}
+		if err := partial.Record(change.User, err); err != nil {
+			return err
+		}
+		if err == nil {
+			changed = append(changed, change)
+		}
+	}
+
+	action, verb := "added", "Added"
+	if !add {
+		action, verb = "removed", "Removed"
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"repo":      repoSlug,
+		action:      changed,
+	}
+	if failures := partial.Failures(); len(failures) > 0 {
+		payload["errors"] = failures
+	}
+
+	if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		for _, change := range changed {
+			user := change.User
+			if change.DisplayName != "" {
+				user = fmt.Sprintf("%s (%s)", change.DisplayName, change.User)
+			}
+			if _, err := fmt.Fprintf(ios.Out, "✓ %s default reviewer %s on %s/%s\n", verb, user, workspace, repoSlug); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return partial.Err(ios.ErrOut)
+}
@@ -0,0 +1,358 @@
+package repo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// watchConcurrency bounds the watcher lists fetched at once by repo watching.
+const watchConcurrency = 6
+
+type watchOptions struct {
+	Project   string
+	All       bool
+	FailFast  bool
+	OutDir    string
+	OutFormat string
+}
+
+func newWatchCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &watchOptions{}
+	cmd := &cobra.Command{
+		Use:   "watch [<repo>...]",
+		Short: "Watch repositories (Data Center)",
+		Long: `Subscribe to notifications for the pull requests and commits of one or more
+repositories. Repositories are given as slugs in the context project or as
+PROJECT/slug; without arguments the context repository is watched, and --all
+watches every repository of the project.
+
+Bitbucket Cloud offers no API for watching repositories; use the Watch menu on
+the repository page instead.`,
+		Example: `  bkt repo watch
+  bkt repo watch api web DATA/etl
+  bkt repo watch --all --project PLAT`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchChange(cmd, f, opts, args, true)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Watch every repository of the project")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+	cmdutil.AddOutDirFlags(cmd, &opts.OutDir, &opts.OutFormat)
+
+	return cmd
+}
+
+func newUnwatchCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &watchOptions{}
+	cmd := &cobra.Command{
+		Use:   "unwatch [<repo>...]",
+		Short: "Stop watching repositories (Data Center)",
+		Long: `Unsubscribe from the notifications of one or more repositories. Repositories
+are given as for "bkt repo watch"; --all unwatches every repository of the
+project, for example when leaving a team.`,
+		Example: `  bkt repo unwatch legacy-api
+  bkt repo unwatch --all --project OLDTEAM`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchChange(cmd, f, opts, args, false)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Unwatch every repository of the project")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+	cmdutil.AddOutDirFlags(cmd, &opts.OutDir, &opts.OutFormat)
+
+	return cmd
+}
+
+func runWatchChange(cmd *cobra.Command, f *cmdutil.Factory, opts *watchOptions, args []string, watch bool) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if opts.All && len(args) > 0 {
+		return fmt.Errorf("--all cannot be combined with repository arguments")
+	}
+	if opts.OutDir != "" {
+		if err := cmdutil.CheckReportFormat(opts.OutFormat); err != nil {
+			return err
+		}
+	}
+
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+	if host.Kind != "dc" {
+		return fmt.Errorf("repo %s currently supports Data Center contexts only; Bitbucket Cloud has no API for watching repositories", cmd.Name())
+	}
+
+	projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+	if projectKey == "" {
+		return fmt.Errorf("project key required; set with --project or configure the context default")
+	}
+
+	client, err := cmdutil.NewDCClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 60*time.Second)
+	defer cancel()
+
+	var targets []string
+	switch {
+	case opts.All:
+		repos, err := client.ListRepositories(ctx, projectKey, 0)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			targets = append(targets, projectKey+"/"+repo.Slug)
+		}
+	case len(args) > 0:
+		for _, arg := range args {
+			owner, slug := splitRepoArg(arg)
+			targets = append(targets, cmdutil.FirstNonEmpty(owner, projectKey)+"/"+slug)
+		}
+	case ctxCfg.DefaultRepo != "":
+		targets = append(targets, projectKey+"/"+ctxCfg.DefaultRepo)
+	default:
+		return fmt.Errorf("specify repositories, --all, or configure the context default repo")
+	}
+
+	key, verb := "watched", "Watching"
+	if !watch {
+		key, verb = "unwatched", "Stopped watching"
+	}
+
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+	changed := []string{}
+	var reports []cmdutil.RepoReport
+	for _, target := range targets {
+		owner, slug := splitRepoArg(target)
+		if watch {
+			err = client.WatchRepository(ctx, owner, slug)
+		} else {
+			err = client.UnwatchRepository(ctx, owner, slug)
+		}
+		reports = append(reports, watchReport(target, key, err == nil, err))
+		if err := partial.Record(target, err); err != nil {
+			return err
+		}
+		if err == nil {
+			changed = append(changed, target)
+		}
+	}
+
+	if opts.OutDir != "" {
+		if err := cmdutil.WriteReports(ios.Out, opts.OutDir, opts.OutFormat, reports); err != nil {
+			return err
+		}
+		return partial.Err(ios.ErrOut)
+	}
+
+	payload := map[string]any{key: changed}
+	if failures := partial.Failures(); len(failures) > 0 {
+		payload["errors"] = failures
+	}
+
+	if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(targets) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No repositories found in project %s.\n", projectKey)
+			return err
+		}
+		for _, repo := range changed {
+			if _, err := fmt.Fprintf(ios.Out, "✓ %s %s\n", verb, repo); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return partial.Err(ios.ErrOut)
+}
+
+// watchReport is the --out-dir report of one repository: the outcome of a
+// change or check under key, or why it failed.
+func watchReport(repo, key string, value bool, err error) cmdutil.RepoReport {
+	data := map[string]any{"repo": repo, key: value}
+	summary := key
+	switch {
+	case err != nil:
+		data["error"] = err.Error()
+		summary = "failed: " + err.Error()
+	case !value:
+		summary = "not " + key
+	}
+	return cmdutil.RepoReport{Repo: repo, Summary: summary, Data: data}
+}
+
+type watchingOptions struct {
+	Workspace string
+	Query     string
+	Limit     int
+	FailFast  bool
+	OutDir    string
+	OutFormat string
+}
+
+func newWatchingCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &watchingOptions{Limit: 100}
+	cmd := &cobra.Command{
+		Use:   "watching",
+		Short: "List repositories you watch (Cloud)",
+		Long: `List the repositories of a workspace that you are watching. Bitbucket Cloud
+can only list the watchers of a repository, so every repository checked costs
+at least one more request. Only the --limit most recently updated repositories
+are checked (0 checks the whole workspace); narrow them further with --query.
+
+Bitbucket Data Center offers no API to list watched repositories.`,
+		Example: `  bkt repo watching
+  bkt repo watching --query 'project.key = "PLAT"'
+  bkt repo watching --workspace acme --limit 0 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatching(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Query, "query", "", "BBQL filter selecting the repositories to check, e.g. 'name ~ \"api\"'")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum repositories to check (0 for all)")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+	cmdutil.AddOutDirFlags(cmd, &opts.OutDir, &opts.OutFormat)
+
+	return cmd
+}
+
+func runWatching(cmd *cobra.Command, f *cmdutil.Factory, opts *watchingOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	if opts.OutDir != "" {
+		if err := cmdutil.CheckReportFormat(opts.OutFormat); err != nil {
+			return err
+		}
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 2*time.Minute)
+	defer cancel()
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	repos, err := client.ListRepositories(ctx, workspace, bbcloud.ListRepositoriesOptions{
+		Query: opts.Query,
+		Sort:  "-updated_on",
+		Limit: opts.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 && len(repos) == opts.Limit {
+		if _, err := fmt.Fprintf(ios.ErrOut, "! Checked the %d most recently updated repositories; use --limit 0 to check all of them\n", opts.Limit); err != nil {
+			return err
+		}
+	}
+	slices.SortFunc(repos, func(a, b bbcloud.Repository) int { return strings.Compare(a.Slug, b.Slug) })
+
+	// Check every repository concurrently, keeping results in slug order.
+	watched := make([]bool, len(repos))
+	errs := make([]error, len(repos))
+	sem := make(chan struct{}, watchConcurrency)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			watched[i], errs[i] = client.IsWatchingRepository(ctx, workspace, repo.Slug, user.UUID)
+		}()
+	}
+	wg.Wait()
+
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+	type watchedRepo struct {
+		Workspace string `json:"workspace"`
+		Slug      string `json:"slug"`
+		Name      string `json:"name"`
+		WebURL    string `json:"web_url,omitempty"`
+	}
+	result := []watchedRepo{}
+	var reports []cmdutil.RepoReport
+	for i, repo := range repos {
+		reports = append(reports, watchReport(workspace+"/"+repo.Slug, "watching", watched[i], errs[i]))
+		if err := partial.Record(workspace+"/"+repo.Slug, errs[i]); err != nil {
+			return err
+		}
+		if watched[i] {
+			result = append(result, watchedRepo{
+				Workspace: workspace,
+				Slug:      repo.Slug,
+				Name:      repo.Name,
+				WebURL:    repo.Links.HTML.Href,
+			})
+		}
+	}
+
+	if opts.OutDir != "" {
+		if err := cmdutil.WriteReports(ios.Out, opts.OutDir, opts.OutFormat, reports); err != nil {
+			return err
+		}
+		return partial.Err(ios.ErrOut)
+	}
+
+	payload := map[string]any{
+		"workspace":    workspace,
+		"repositories": result,
+	}
+	if failures := partial.Failures(); len(failures) > 0 {
+		payload["errors"] = failures
+	}
+
+	if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(result) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "You are not watching any repositories in %s.\n", workspace)
+			return err
+		}
+		for _, repo := range result {
+			if _, err := fmt.Fprintf(ios.Out, "%s/%s\n", repo.Workspace, repo.Slug); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return partial.Err(ios.ErrOut)
+}
@@ -1,23 +1,40 @@
 package root
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/admin"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/alias"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/api"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/auth"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/branch"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/browse"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/commit"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/compare"
+	configcmd "github.com/alessandro308/bitbucket-cli/pkg/cmd/config"
 	contextcmd "github.com/alessandro308/bitbucket-cli/pkg/cmd/context"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/download"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/extension"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/file"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/issue"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/on"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/perms"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/pipeline"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/pr"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/project"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/repo"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/search"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/snippet"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/sshkey"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/status"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/tag"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/variable"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmd/webhook"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmd/workspace"
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
 )
 
@@ -39,26 +56,58 @@ Common flows:
   bkt status pr 123 --json`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyDebugFlag(cmd); err != nil {
+				return err
+			}
+			if err := cmdutil.ApplyTimeoutFlag(f, cmd); err != nil {
+				return err
+			}
+			if err := cmdutil.ApplyRepoFlag(f, cmd); err != nil {
+				return err
+			}
+			cmdutil.StartPager(f, cmd)
+			return nil
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			_ = cmd.Help()
 		},
 	}
 
 	root.PersistentFlags().StringP("context", "c", "", "Active Bitbucket context name")
-	root.PersistentFlags().Bool("json", false, "Output in JSON format when supported")
+	root.PersistentFlags().String("repo", "", "Target repository as `WORKSPACE/SLUG` (Cloud) or PROJECT/SLUG (Data Center)")
+	root.PersistentFlags().StringVar(&f.Profile, "profile", "", "Credential profile to use for the selected host")
+	root.PersistentFlags().String("debug", "", "Log HTTP requests to stderr; `api` also logs redacted headers and bodies")
+	root.PersistentFlags().Lookup("debug").NoOptDefVal = "1"
+	root.PersistentFlags().Duration("timeout", 0, "Deadline for a command's API calls, e.g. `30s` or 2m (0 disables)")
+	root.PersistentFlags().String("json", "", "Output in JSON format when supported, optionally limited to `fields` (--json id,title)")
+	root.PersistentFlags().Lookup("json").NoOptDefVal = "true"
 	root.PersistentFlags().Bool("yaml", false, "Output in YAML format when supported")
 	root.PersistentFlags().String("jq", "", "Apply a jq expression to JSON output (requires --json)")
 	root.PersistentFlags().String("template", "", "Render output using Go templates")
+	root.PersistentFlags().String("format", "", "Output records as `csv` or tsv with a header row (columns via --json <fields>)")
 
 	root.AddCommand(
 		admin.NewCmdAdmin(f),
 		auth.NewCmdAuth(f),
+		alias.NewCmdAlias(f),
+		configcmd.NewCmdConfig(f),
 		contextcmd.NewCmdContext(f),
+		workspace.NewCmdWorkspace(f),
 		repo.NewCmdRepo(f),
+		sshkey.NewCmdSSHKey(f),
 		project.NewCmdProject(f),
 		pr.NewCmdPR(f),
 		issue.NewCmdIssue(f),
+		snippet.NewCmdSnippet(f),
+		search.NewCmdSearch(f),
 		branch.NewCmdBranch(f),
+		tag.NewCmdTag(f),
+		commit.NewCmdCommit(f),
+		compare.NewCmdCompare(f),
+		file.NewCmdFile(f),
+		browse.NewCmdBrowse(f),
+		download.NewCmdDownload(f),
 		perms.NewCommand(f),
 		webhook.NewCommand(f),
 		status.NewCmdStatus(f),
@@ -66,8 +115,11 @@ Common flows:
 		variable.NewCommand(f),
 		api.NewCmdAPI(f),
 		extension.NewCmdExtension(f),
+		on.NewCmdOn(f),
 	)
 
+	registerRepoCompletion(root, f)
+
 	root.Version = f.AppVersion
 	root.SetIn(ios.In)
 	root.SetOut(ios.Out)
@@ -75,3 +127,30 @@ Common flows:
 
 	return root, nil
 }
+
+// registerRepoCompletion completes every --repo flag in the tree with the
+// repositories of the command's project or workspace.
+func registerRepoCompletion(cmd *cobra.Command, f *cmdutil.Factory) {
+	if cmd.Flags().Lookup("repo") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepos(f))
+	}
+	for _, child := range cmd.Commands() {
+		registerRepoCompletion(child, f)
+	}
+}
+
+// applyDebugFlag exports --debug as BKT_DEBUG, which every API client (and
+// any extension started from this process) reads when it is constructed.
+func applyDebugFlag(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("debug")
+	if flag == nil || !flag.Changed {
+		return nil
+	}
+	value := strings.ToLower(strings.TrimSpace(flag.Value.String()))
+	switch value {
+	case "1", "true", "api", "0", "false":
+	default:
+		return fmt.Errorf("invalid --debug value %q; use --debug or --debug=api", flag.Value.String())
+	}
+	return os.Setenv("BKT_DEBUG", value)
+}
@@ -0,0 +1,188 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// searchConcurrency bounds the workspaces searched at once.
+const searchConcurrency = 6
+
+var repositoryRoles = []string{"member", "contributor", "admin", "owner"}
+
+type reposOptions struct {
+	Workspaces []string
+	Role       string
+	Language   string
+	Limit      int
+	FailFast   bool
+}
+
+func newReposCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &reposOptions{Role: "member", Limit: 30}
+	cmd := &cobra.Command{
+		Use:   "repos <term>",
+		Short: "Find repositories by name across your workspaces (Cloud)",
+		Long: `Find repositories whose name contains <term> in every Bitbucket Cloud
+workspace you belong to, so you can locate a slug without knowing which
+workspace holds it. Narrow the search with --workspace, --role and --language.`,
+		Example: `  bkt search repos billing
+  bkt search repos api --language go --role admin
+  bkt search repos infra --workspace acme --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRepos(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Workspaces, "workspace", nil, "Only search these workspaces (repeatable)")
+	cmd.Flags().StringVar(&opts.Role, "role", opts.Role, "Only repositories where you hold this role: member, contributor, admin, owner")
+	cmd.Flags().StringVar(&opts.Language, "language", "", "Only repositories in this language, e.g. go")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", opts.Limit, "Maximum repositories to list (0 for all)")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+
+	return cmd
+}
+
+type repoMatch struct {
+	Workspace   string `json:"workspace"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Language    string `json:"language,omitempty"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"is_private"`
+	UpdatedOn   string `json:"updated_on,omitempty"`
+	WebURL      string `json:"web_url,omitempty"`
+}
+
+func runRepos(cmd *cobra.Command, f *cmdutil.Factory, term string, opts *reposOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return fmt.Errorf("search term is required")
+	}
+	role := strings.ToLower(strings.TrimSpace(opts.Role))
+	if role != "" && !slices.Contains(repositoryRoles, role) {
+		return fmt.Errorf("invalid --role %q (valid: %s)", opts.Role, strings.Join(repositoryRoles, ", "))
+	}
+
+	_, _, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+	if host.Kind != "cloud" {
+		return fmt.Errorf("repository search is only available on Bitbucket Cloud hosts")
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	workspaces := opts.Workspaces
+	if len(workspaces) == 0 {
+		all, err := client.ListWorkspaces(ctx, 0)
+		if err != nil {
+			return err
+		}
+		for _, ws := range all {
+			workspaces = append(workspaces, ws.Slug)
+		}
+	}
+
+	query := fmt.Sprintf("name ~ %s", bbcloud.BBQLString(term))
+	if lang := strings.TrimSpace(opts.Language); lang != "" {
+		query += fmt.Sprintf(" AND language = %s", bbcloud.BBQLString(strings.ToLower(lang)))
+	}
+
+	// Search every workspace concurrently, keeping results in workspace
+	// order so the output is stable.
+	found := make([][]bbcloud.Repository, len(workspaces))
+	errs := make([]error, len(workspaces))
+	sem := make(chan struct{}, searchConcurrency)
+	var wg sync.WaitGroup
+	for i, workspace := range workspaces {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			found[i], errs[i] = client.ListRepositories(ctx, workspace, bbcloud.ListRepositoriesOptions{
+				Role:  role,
+				Query: query,
+				Sort:  "slug",
+				Limit: opts.Limit,
+			})
+		}()
+	}
+	wg.Wait()
+
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+	matches := []repoMatch{}
+	for i, workspace := range workspaces {
+		if err := partial.Record(workspace, errs[i]); err != nil {
+			return err
+		}
+		for _, repo := range found[i] {
+			matches = append(matches, repoMatch{
+				Workspace:   workspace,
+				Slug:        repo.Slug,
+				Name:        repo.Name,
+				Language:    repo.Language,
+				Description: repo.Description,
+				Private:     repo.IsPrivate,
+				UpdatedOn:   repo.UpdatedOn,
+				WebURL:      repo.Links.HTML.Href,
+			})
+		}
+	}
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+
+	payload := map[string]any{
+		"term":         term,
+		"repositories": matches,
+	}
+	if failures := partial.Failures(); len(failures) > 0 {
+		payload["errors"] = failures
+	}
+
+	if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(matches) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No repositories match %q.\n", term)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, m := range matches {
+			visibility := "public"
+			if m.Private {
+				visibility = "private"
+			}
+			if _, err := fmt.Fprintf(tw, "%s/%s\t%s\t%s\t%s\n", m.Workspace, m.Slug, cmdutil.FirstNonEmpty(m.Language, "-"), visibility, m.Name); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	}); err != nil {
+		return err
+	}
+	return partial.Err(ios.ErrOut)
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdSearch exposes Bitbucket search.
+func NewCmdSearch(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search code and repositories",
+	}
+
+	cmd.AddCommand(newCodeCmd(f))
+	cmd.AddCommand(newReposCmd(f))
+
+	return cmd
+}
+
+type codeOptions struct {
+	Workspace string
+	Limit     int
+}
+
+func newCodeCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &codeOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:   "code <query>",
+		Short: "Search code in a workspace (Cloud)",
+		Long: `Search the code of every repository in a Bitbucket Cloud workspace and print
+each matching file with the matched lines. The query uses Bitbucket's search
+syntax: quote phrases and narrow results with modifiers such as repo:, lang:,
+ext: and path:. Code search must be enabled for the workspace.`,
+		Example: `  bkt search code "TODO lang:go repo:api"
+  bkt search code '"retry budget" path:internal/' --limit 5
+  bkt search code "ext:tf aws_s3_bucket" --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCode(cmd, f, strings.Join(args, " "), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", opts.Limit, "Maximum files to list (0 for all)")
+
+	return cmd
+}
+
+func runCode(cmd *cobra.Command, f *cmdutil.Factory, query string, opts *codeOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	var results []bbcloud.CodeSearchResult
+	for result, err := range client.SearchCodeIter(ctx, workspace, query) {
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		if opts.Limit > 0 && len(results) >= opts.Limit {
+			break
+		}
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"query":     query,
+		"results":   results,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(results) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No code matches %q in %s.\n", query, workspace)
+			return err
+		}
+		return printCodeResults(ios.Out, results, ios.ColorEnabled())
+	})
+}
+
+const (
+	colorReset     = "\033[0m"
+	colorBold      = "\033[1m"
+	colorHighlight = "\033[1;33m"
+)
+
+// printCodeResults prints each file as a "repo:path" header followed by its
+// lines, grep style: "12:" marks a matching line and "13-" a context line.
+func printCodeResults(out io.Writer, results []bbcloud.CodeSearchResult, color bool) error {
+	highlight := func(segments []bbcloud.CodeSearchSegment) string {
+		var b strings.Builder
+		for _, s := range segments {
+			if s.Match && color {
+				b.WriteString(colorHighlight + s.Text + colorReset)
+				continue
+			}
+			b.WriteString(s.Text)
+		}
+		return b.String()
+	}
+
+	for i, result := range results {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+
+		header := result.File.Path
+		if len(result.PathMatches) > 0 {
+			header = highlight(result.PathMatches)
+		}
+		if repo := cmdutil.FirstNonEmpty(result.File.Commit.Repository.FullName, result.File.Commit.Repository.Slug); repo != "" {
+			header = repo + ":" + header
+		}
+		if color {
+			header = colorBold + header + colorReset
+		}
+		if _, err := fmt.Fprintln(out, header); err != nil {
+			return err
+		}
+
+		for j, match := range result.ContentMatches {
+			if j > 0 {
+				if _, err := fmt.Fprintln(out, "  --"); err != nil {
+					return err
+				}
+			}
+			for _, line := range match.Lines {
+				sep := "-"
+				if line.Matched() {
+					sep = ":"
+				}
+				text := strings.TrimRight(highlight(line.Segments), "\r\n")
+				if _, err := fmt.Fprintf(out, "  %d%s %s\n", line.Line, sep, text); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package search
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, baseURL string) (*cmdutil.Factory, *strings.Builder) {
+	t.Helper()
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout
+}
+
+func TestCodePrintsPathLineAndSnippet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/team/search/code" || r.URL.Query().Get("search_query") != "TODO repo:api" {
+			t.Fatalf("unexpected request %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[
+			{"content_matches":[{"lines":[
+				{"line":11,"segments":[{"text":"func retry() {"}]},
+				{"line":12,"segments":[{"text":"\t// "},{"text":"TODO","match":true},{"text":" backoff"}]}
+			]}],"path_matches":[{"text":"internal/retry.go"}],"file":{"path":"internal/retry.go","commit":{"repository":{"full_name":"team/api"}}}},
+			{"content_matches":[],"file":{"path":"README.md","commit":{"repository":{"full_name":"team/api"}}}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t, server.URL)
+	cmd := NewCmdSearch(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"code", "TODO", "repo:api", "--limit", "1"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("search code: %v", err)
+	}
+	want := "team/api:internal/retry.go\n  11- func retry() {\n  12: \t// TODO backoff\n"
+	if stdout.String() != want {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestCodeHighlightsMatchesWithColor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"content_matches":[{"lines":[{"line":1,"segments":[{"text":"TODO","match":true}]}]}],"file":{"path":"a.go"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t, server.URL)
+	f.IOStreams.SetColorEnabled(true)
+	cmd := NewCmdSearch(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"code", "TODO"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("search code: %v", err)
+	}
+	if !strings.Contains(stdout.String(), colorHighlight+"TODO"+colorReset) {
+		t.Fatalf("expected highlighted match, got %q", stdout.String())
+	}
+}
+
+func TestReposSearchesEveryWorkspace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user/permissions/workspaces":
+			_, _ = w.Write([]byte(`{"values":[{"workspace":{"slug":"acme"}},{"workspace":{"slug":"labs"}},{"workspace":{"slug":"locked"}}]}`))
+		case "/repositories/acme":
+			if got := r.URL.Query().Get("q"); got != `name ~ "bill" AND language = "go"` {
+				t.Errorf("unexpected query %q", got)
+			}
+			if got := r.URL.Query().Get("role"); got != "admin" {
+				t.Errorf("unexpected role %q", got)
+			}
+			_, _ = w.Write([]byte(`{"values":[{"slug":"billing-api","name":"Billing API","language":"go","is_private":true}]}`))
+		case "/repositories/labs":
+			_, _ = w.Write([]byte(`{"values":[{"slug":"billing-spike","name":"Billing spike","language":"go"}]}`))
+		case "/repositories/locked":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"message":"no access"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t, server.URL)
+	cmd := NewCmdSearch(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"repos", "bill", "--language", "Go", "--role", "admin"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "results are incomplete") {
+		t.Fatalf("expected a partial results error, got %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "acme/billing-api") || !strings.Contains(out, "labs/billing-spike") {
+		t.Fatalf("expected matches from both workspaces, got %q", out)
+	}
+	if strings.Index(out, "acme/") > strings.Index(out, "labs/") {
+		t.Fatalf("expected results in workspace order, got %q", out)
+	}
+}
+
+func TestReposRejectsUnknownRole(t *testing.T) {
+	f, _ := newTestFactory(t, "http://127.0.0.1:0")
+	cmd := NewCmdSearch(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"repos", "api", "--role", "reader"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --role") {
+		t.Fatalf("expected role validation error, got %v", err)
+	}
+}
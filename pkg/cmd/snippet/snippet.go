@@ -0,0 +1,348 @@
+package snippet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdSnippet exposes Bitbucket Cloud snippets.
+func NewCmdSnippet(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snippet",
+		Short: "Share code snippets (Cloud)",
+		Long: `Create, list, view and delete Bitbucket Cloud snippets: standalone files
+shared from a workspace, similar to gists.`,
+	}
+
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newCreateCmd(f))
+	cmd.AddCommand(newViewCmd(f))
+	cmd.AddCommand(newDeleteCmd(f))
+
+	return cmd
+}
+
+type listOptions struct {
+	Workspace string
+	Role      string
+	Limit     int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List snippets in a workspace",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Role, "role", "", "Only snippets where you are owner, contributor or member")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum snippets to list (0 for all)")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	snippets, err := client.ListSnippets(ctx, workspace, bbcloud.SnippetListOptions{
+		Role:  opts.Role,
+		Limit: opts.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"snippets":  snippets,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(snippets) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No snippets found in %s.\n", workspace)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, s := range snippets {
+			visibility := "public"
+			if s.IsPrivate {
+				visibility = "private"
+			}
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.ID, cmdutil.FirstNonEmpty(s.Title, "(untitled)"), visibility, s.UpdatedOn); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+type createOptions struct {
+	Workspace string
+	Title     string
+	Public    bool
+	Name      string
+}
+
+func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create <file>...",
+		Short: "Create a snippet from local files",
+		Long: `Create a snippet from one or more local files and print its URL. Snippets
+are private unless --public is passed. Use - to read a single file from stdin.`,
+		Example: `  bkt snippet create main.go --public
+  bkt snippet create handler.go handler_test.go --title "Retry example"
+  kubectl logs api-7d9 | bkt snippet create - --name api.log`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(cmd, f, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Snippet title (default: first file name)")
+	cmd.Flags().BoolVar(&opts.Public, "public", false, "Make the snippet public")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "File name when reading from stdin (-)")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, f *cmdutil.Factory, paths []string, opts *createOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	var files []bbcloud.SnippetFileInput
+	for _, path := range paths {
+		if path == "-" {
+			if len(paths) > 1 {
+				return fmt.Errorf("stdin (-) cannot be combined with other files")
+			}
+			if strings.TrimSpace(opts.Name) == "" {
+				return fmt.Errorf("--name is required when reading from stdin")
+			}
+			files = append(files, bbcloud.SnippetFileInput{Name: strings.TrimSpace(opts.Name), Reader: ios.In})
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("cannot access file %s: %w", path, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("cannot create a snippet from directory: %s", path)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+		files = append(files, bbcloud.SnippetFileInput{Name: filepath.Base(path), Reader: file})
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 2*time.Minute)
+	defer cancel()
+
+	snippet, err := client.CreateSnippet(ctx, workspace, bbcloud.CreateSnippetInput{
+		Title:   cmdutil.FirstNonEmpty(opts.Title, files[0].Name),
+		Private: !opts.Public,
+		Files:   files,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, snippet, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Created snippet %s\n%s\n", snippet.ID, snippet.Links.HTML.Href)
+		return err
+	})
+}
+
+type viewOptions struct {
+	Workspace string
+	File      string
+	Web       bool
+}
+
+func newViewCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &viewOptions{}
+	cmd := &cobra.Command{
+		Use:   "view <id>",
+		Short: "Print the raw content of a snippet",
+		Long: `Print the raw content of a snippet. Snippets with several files print each
+one under a "==> name <==" header unless --file selects a single file.`,
+		Example: `  bkt snippet view kypj9
+  bkt snippet view kypj9 --file main.go > main.go
+  bkt snippet view kypj9 --web`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runView(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.File, "file", "", "Only print this file")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "snippet")
+
+	return cmd
+}
+
+func runView(cmd *cobra.Command, f *cmdutil.Factory, id string, opts *viewOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, time.Minute)
+	defer cancel()
+
+	snippet, err := client.GetSnippet(ctx, workspace, id)
+	if err != nil {
+		return err
+	}
+
+	if opts.Web {
+		return cmdutil.OpenInBrowser(f, ios.Out, "snippet", snippet.Links.HTML.Href)
+	}
+
+	names := snippet.FileNames()
+	if opts.File != "" {
+		if _, ok := snippet.Files[opts.File]; !ok {
+			return fmt.Errorf("snippet %s has no file %q (files: %s)", id, opts.File, strings.Join(names, ", "))
+		}
+		names = []string{opts.File}
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, snippet, func() error {
+		for i, name := range names {
+			if len(names) > 1 {
+				if i > 0 {
+					if _, err := fmt.Fprintln(ios.Out); err != nil {
+						return err
+					}
+				}
+				if _, err := fmt.Fprintf(ios.Out, "==> %s <==\n", name); err != nil {
+					return err
+				}
+			}
+			if err := client.GetSnippetFile(ctx, workspace, id, name, ios.Out); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type deleteOptions struct {
+	Workspace string
+	Yes       bool
+}
+
+func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:     "delete <id>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a snippet",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(cmd *cobra.Command, f *cmdutil.Factory, id string, opts *deleteOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Yes {
+		confirmed, err := f.Prompt().Confirm(fmt.Sprintf("Delete snippet %s from %s?", id, workspace), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, err := fmt.Fprintln(ios.Out, "Aborted.")
+			return err
+		}
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	if err := client.DeleteSnippet(ctx, workspace, id); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(ios.Out, "✓ Deleted snippet %s\n", id)
+	return err
+}
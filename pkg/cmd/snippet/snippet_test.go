@@ -0,0 +1,100 @@
+package snippet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, baseURL string) (*cmdutil.Factory, *strings.Builder) {
+	t.Helper()
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout
+}
+
+func TestCreatePublicPrintsURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		if r.FormValue("is_private") != "false" || r.FormValue("title") != "main.go" {
+			t.Fatalf("unexpected fields %v", r.MultipartForm.Value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"kypj9","links":{"html":{"href":"https://bitbucket.org/team/workspace/snippets/kypj9"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte("package main\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, stdout := newTestFactory(t, server.URL)
+	cmd := NewCmdSnippet(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"create", path, "--public"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("snippet create: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "https://bitbucket.org/team/workspace/snippets/kypj9") {
+		t.Fatalf("expected share URL, got %q", stdout.String())
+	}
+}
+
+func TestViewPrintsEachFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/snippets/team/kypj9":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"kypj9","files":{"b.txt":{},"a.txt":{}}}`))
+		case "/snippets/team/kypj9/files/a.txt":
+			_, _ = w.Write([]byte("alpha\n"))
+		case "/snippets/team/kypj9/files/b.txt":
+			_, _ = w.Write([]byte("beta\n"))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	f, stdout := newTestFactory(t, server.URL)
+	cmd := NewCmdSnippet(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"view", "kypj9"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("snippet view: %v", err)
+	}
+	want := "==> a.txt <==\nalpha\n\n==> b.txt <==\nbeta\n"
+	if stdout.String() != want {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
@@ -0,0 +1,451 @@
+package sshkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdSSHKey returns the ssh-key command group.
+func NewCmdSSHKey(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ssh-key",
+		Aliases: []string{"ssh-keys"},
+		Short:   "Manage SSH keys on your Bitbucket account",
+	}
+
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newAddCmd(f))
+	cmd.AddCommand(newDeleteCmd(f))
+	cmd.AddCommand(newSetupCmd(f))
+
+	return cmd
+}
+
+// keyInfo is the host-agnostic view of a registered key used for output.
+type keyInfo struct {
+	ID        string `json:"id"`
+	Label     string `json:"label,omitempty"`
+	Key       string `json:"key"`
+	CreatedOn string `json:"created_on,omitempty"`
+	LastUsed  string `json:"last_used,omitempty"`
+}
+
+// keyStore wraps the Cloud and Data Center SSH key APIs.
+type keyStore interface {
+	List(ctx context.Context) ([]keyInfo, error)
+	Add(ctx context.Context, label, key string) (*keyInfo, error)
+	Delete(ctx context.Context, id string) error
+}
+
+func newKeyStore(ctx context.Context, host *config.Host) (keyStore, error) {
+	switch host.Kind {
+	case "dc":
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return nil, err
+		}
+		return &dcStore{client: client}, nil
+	case "cloud":
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return nil, err
+		}
+		user, err := client.CurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("resolve current user: %w", err)
+		}
+		return &cloudStore{client: client, user: user.UUID}, nil
+	default:
+		return nil, fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+type listOptions struct {
+	Limit int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List SSH keys on your account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum keys to list (0 for all)")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	_, _, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	store, err := newKeyStore(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	keys, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 && len(keys) > opts.Limit {
+		keys = keys[:opts.Limit]
+	}
+
+	payload := map[string]any{
+		"ssh_keys": keys,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(keys) == 0 {
+			_, err := fmt.Fprintln(ios.Out, "No SSH keys registered.")
+			return err
+		}
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		if _, err := fmt.Fprintln(tw, "ID\tLABEL\tKEY"); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", k.ID, cmdutil.FirstNonEmpty(k.Label, "-"), abbreviateKey(k.Key)); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+type addOptions struct {
+	Title string
+}
+
+func newAddCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &addOptions{}
+	cmd := &cobra.Command{
+		Use:   "add <key-file>",
+		Short: "Add an SSH key to your account",
+		Long: `Upload a public SSH key to your Bitbucket account. Pass "-" as the key file
+to read the key from standard input.`,
+		Example: `  bkt ssh-key add ~/.ssh/id_ed25519.pub --title laptop
+  cat key.pub | bkt ssh-key add -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(cmd, f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Label for the key (defaults to the key comment)")
+
+	return cmd
+}
+
+func runAdd(cmd *cobra.Command, f *cmdutil.Factory, opts *addOptions, keyFile string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	key, err := cmdutil.ReadPublicKey(ios.In, keyFile)
+	if err != nil {
+		return err
+	}
+
+	_, _, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	store, err := newKeyStore(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	added, err := store.Add(ctx, cmdutil.FirstNonEmpty(opts.Title, cmdutil.PublicKeyComment(key)), key)
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, added, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Added SSH key %s (%s)\n", added.ID, cmdutil.FirstNonEmpty(added.Label, "unlabelled"))
+		return err
+	})
+}
+
+type deleteOptions struct {
+	Yes bool
+}
+
+func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:     "delete <id>",
+		Aliases: []string{"rm"},
+		Short:   "Delete an SSH key from your account",
+		Long:    `Delete an SSH key by the ID shown in "bkt ssh-key list" (a UUID on Bitbucket Cloud).`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd, f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deleteOptions, id string) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	_, _, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	if !opts.Yes {
+		confirmed, err := f.Prompt().Confirm(fmt.Sprintf("Delete SSH key %s?", id), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, err := fmt.Fprintln(ios.Out, "Aborted.")
+			return err
+		}
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	store, err := newKeyStore(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"id":      id,
+		"deleted": true,
+	}
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		_, err := fmt.Fprintf(ios.Out, "✓ Deleted SSH key %s\n", id)
+		return err
+	})
+}
+
+type setupOptions struct {
+	KeyFile    string
+	Title      string
+	SSHHost    string
+	SkipVerify bool
+}
+
+func newSetupCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &setupOptions{}
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Upload your default SSH key and verify access",
+		Long: `Upload ~/.ssh/id_ed25519.pub (or --key) to your Bitbucket account unless it
+is already registered, then run "ssh -T" against the host to confirm that git
+over SSH works.
+
+Data Center serves SSH on port 7999 by default; use --ssh-host to point at a
+different host or port.`,
+		Example: `  bkt ssh-key setup
+  bkt ssh-key setup --key ~/.ssh/work.pub --ssh-host git.example.com:7999`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetup(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.KeyFile, "key", "", "Public key file (default ~/.ssh/id_ed25519.pub)")
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Label for the key (defaults to the key comment)")
+	cmd.Flags().StringVar(&opts.SSHHost, "ssh-host", "", "SSH endpoint to verify as host[:port]")
+	cmd.Flags().BoolVar(&opts.SkipVerify, "skip-verify", false, "Do not test the SSH connection after uploading")
+
+	return cmd
+}
+
+func runSetup(cmd *cobra.Command, f *cmdutil.Factory, opts *setupOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	keyFile := opts.KeyFile
+	if keyFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("locate home directory: %w", err)
+		}
+		keyFile = filepath.Join(home, ".ssh", "id_ed25519.pub")
+	}
+
+	key, err := cmdutil.ReadPublicKey(ios.In, keyFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found; generate one with: ssh-keygen -t ed25519", keyFile)
+		}
+		return err
+	}
+
+	_, _, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	store, err := newKeyStore(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	existing, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var registered *keyInfo
+	for i := range existing {
+		if cmdutil.SamePublicKey(existing[i].Key, key) {
+			registered = &existing[i]
+			break
+		}
+	}
+
+	uploaded := false
+	if registered == nil {
+		registered, err = store.Add(ctx, cmdutil.FirstNonEmpty(opts.Title, cmdutil.PublicKeyComment(key)), key)
+		if err != nil {
+			return err
+		}
+		uploaded = true
+	}
+
+	status := "✓ %s is already registered as SSH key %s\n"
+	if uploaded {
+		status = "✓ Uploaded %s as SSH key %s\n"
+	}
+	if _, err := fmt.Fprintf(ios.Out, status, keyFile, registered.ID); err != nil {
+		return err
+	}
+
+	if opts.SkipVerify {
+		return nil
+	}
+
+	endpoint := opts.SSHHost
+	if endpoint == "" {
+		endpoint = sshEndpoint(host)
+	}
+	if endpoint == "" {
+		return fmt.Errorf("cannot determine SSH host for %s; pass --ssh-host", host.BaseURL)
+	}
+
+	output, err := verifySSH(cmd.Context(), endpoint)
+	if err != nil {
+		if output != "" {
+			if _, werr := fmt.Fprintln(ios.ErrOut, output); werr != nil {
+				return werr
+			}
+		}
+		return fmt.Errorf("ssh connection to %s failed: %w", endpoint, err)
+	}
+	_, err = fmt.Fprintf(ios.Out, "✓ SSH access to %s verified\n", endpoint)
+	return err
+}
+
+// sshEndpoint derives the git SSH endpoint (host[:port]) for a configured host.
+func sshEndpoint(host *config.Host) string {
+	u, err := url.Parse(host.BaseURL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	hostname := u.Hostname()
+	if host.Kind == "cloud" {
+		return strings.TrimPrefix(hostname, "api.")
+	}
+	return net.JoinHostPort(hostname, "7999")
+}
+
+// sshCommand builds the argument list used to probe an SSH endpoint.
+func sshCommand(endpoint string) []string {
+	hostname, port := endpoint, ""
+	if h, p, err := net.SplitHostPort(endpoint); err == nil {
+		hostname, port = h, p
+	}
+
+	args := []string{"-T", "-o", "BatchMode=yes", "-o", "StrictHostKeyChecking=accept-new", "-o", "ConnectTimeout=10"}
+	if port != "" && port != "22" {
+		args = append(args, "-p", port)
+	}
+	return append(args, "git@"+hostname)
+}
+
+// verifySSH connects to the endpoint with "ssh -T". Bitbucket refuses shell
+// access, so any exit status other than ssh's own failure code 255 means the
+// key was accepted.
+func verifySSH(ctx context.Context, endpoint string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "ssh", sshCommand(endpoint)...).CombinedOutput()
+	output := strings.TrimSpace(string(out))
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() != 255 {
+		return output, nil
+	}
+	return output, err
+}
+
+// abbreviateKey shortens key material for table output.
+func abbreviateKey(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return key
+	}
+	material := fields[1]
+	if len(material) > 16 {
+		material = material[:8] + "..." + material[len(material)-8:]
+	}
+	return fields[0] + " " + material
+}
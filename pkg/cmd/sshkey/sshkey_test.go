@@ -0,0 +1,92 @@
+package sshkey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func TestSSHEndpoint(t *testing.T) {
+	cloud := &config.Host{Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0"}
+	if got := sshEndpoint(cloud); got != "bitbucket.org" {
+		t.Fatalf("cloud endpoint = %q", got)
+	}
+	dc := &config.Host{Kind: "dc", BaseURL: "https://git.example.com/bitbucket"}
+	if got := sshEndpoint(dc); got != "git.example.com:7999" {
+		t.Fatalf("dc endpoint = %q", got)
+	}
+}
+
+func TestSSHCommand(t *testing.T) {
+	got := sshCommand("git.example.com:7999")
+	want := []string{"-T", "-o", "BatchMode=yes", "-o", "StrictHostKeyChecking=accept-new", "-o", "ConnectTimeout=10", "-p", "7999", "git@git.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("sshCommand = %v, want %v", got, want)
+	}
+	if got := sshCommand("bitbucket.org"); got[len(got)-1] != "git@bitbucket.org" || strings.Contains(strings.Join(got, " "), "-p") {
+		t.Fatalf("unexpected args for default port: %v", got)
+	}
+}
+
+func TestSetupSkipsUploadWhenRegistered(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/user":
+			_, _ = w.Write([]byte(`{"uuid":"{u1}","display_name":"Alice"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/users/{u1}/ssh-keys":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{k1}","key":"ssh-ed25519 AAAAC3Nz","label":"laptop"}]}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519.pub")
+	if err := os.WriteFile(keyFile, []byte("ssh-ed25519 AAAAC3Nz alice@laptop\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	}
+
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams: &iostreams.IOStreams{
+			Out:    &stdout,
+			ErrOut: &stderr,
+		},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCmdSSHKey(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"setup", "--key", keyFile, "--skip-verify"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "already registered as SSH key k1") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
@@ -0,0 +1,92 @@
+package sshkey
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+)
+
+type cloudStore struct {
+	client *bbcloud.Client
+	user   string
+}
+
+func (s *cloudStore) List(ctx context.Context) ([]keyInfo, error) {
+	keys, err := s.client.ListSSHKeys(ctx, s.user, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]keyInfo, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, cloudKeyInfo(k))
+	}
+	return out, nil
+}
+
+func (s *cloudStore) Add(ctx context.Context, label, key string) (*keyInfo, error) {
+	added, err := s.client.AddSSHKey(ctx, s.user, label, key)
+	if err != nil {
+		return nil, err
+	}
+	info := cloudKeyInfo(*added)
+	return &info, nil
+}
+
+func (s *cloudStore) Delete(ctx context.Context, id string) error {
+	return s.client.DeleteSSHKey(ctx, s.user, id)
+}
+
+func cloudKeyInfo(k bbcloud.SSHKey) keyInfo {
+	return keyInfo{
+		ID:        strings.Trim(k.UUID, "{}"),
+		Label:     k.Label,
+		Key:       k.Key,
+		CreatedOn: k.CreatedOn,
+		LastUsed:  k.LastUsed,
+	}
+}
+
+type dcStore struct {
+	client *bbdc.Client
+}
+
+func (s *dcStore) List(ctx context.Context) ([]keyInfo, error) {
+	keys, err := s.client.ListSSHKeys(ctx, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]keyInfo, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, dcKeyInfo(k))
+	}
+	return out, nil
+}
+
+func (s *dcStore) Add(ctx context.Context, label, key string) (*keyInfo, error) {
+	added, err := s.client.AddSSHKey(ctx, label, key)
+	if err != nil {
+		return nil, err
+	}
+	info := dcKeyInfo(*added)
+	return &info, nil
+}
+
+func (s *dcStore) Delete(ctx context.Context, id string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		return fmt.Errorf("invalid SSH key id %q", id)
+	}
+	return s.client.DeleteSSHKey(ctx, n)
+}
+
+func dcKeyInfo(k bbdc.SSHKey) keyInfo {
+	return keyInfo{
+		ID:    strconv.Itoa(k.ID),
+		Label: k.Label,
+		Key:   k.Text,
+	}
+}
@@ -0,0 +1,393 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/types"
+)
+
+// dashboardConcurrency bounds the requests the dashboard has in flight.
+const dashboardConcurrency = 6
+
+type dashboardOptions struct {
+	Limit    int
+	FailFast bool
+}
+
+type dashboardPR struct {
+	Repository string `json:"repository"`
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	Author     string `json:"author,omitempty"`
+	URL        string `json:"url,omitempty"`
+
+	commit string
+}
+
+type dashboardBuild struct {
+	Repository  string `json:"repository"`
+	PullRequest int    `json:"pull_request"`
+	Title       string `json:"title"`
+	Build       string `json:"build"`
+	State       string `json:"state"`
+	URL         string `json:"url,omitempty"`
+}
+
+type dashboardPipeline struct {
+	Repository  string `json:"repository"`
+	BuildNumber int    `json:"build_number"`
+	Ref         string `json:"ref"`
+	State       string `json:"state"`
+	CreatedOn   string `json:"created_on"`
+}
+
+type dashboard struct {
+	Repositories    []string                 `json:"repositories,omitempty"`
+	Authored        []dashboardPR            `json:"authored"`
+	ReviewRequested []dashboardPR            `json:"review_requested"`
+	FailingBuilds   []dashboardBuild         `json:"failing_builds"`
+	Pipelines       []dashboardPipeline      `json:"recent_pipelines,omitempty"`
+	Errors          []cmdutil.PartialFailure `json:"errors,omitempty"`
+}
+
+// result is one concurrently fetched piece of the dashboard.
+type result struct {
+	item string
+	err  error
+	fill func(*dashboard)
+}
+
+// fetchAll runs jobs with bounded concurrency and returns their results in
+// job order, so the dashboard renders deterministically.
+func fetchAll(jobs []func() result) []result {
+	results := make([]result, len(jobs))
+	sem := make(chan struct{}, dashboardConcurrency)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = job()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func runDashboard(cmd *cobra.Command, f *cmdutil.Factory, opts *dashboardOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, cmdutil.FlagValue(cmd, "context"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+	var board *dashboard
+	switch host.Kind {
+	case "dc":
+		board, err = dcDashboard(ctx, host, opts, partial)
+	case "cloud":
+		cfg, cfgErr := f.ResolveConfig()
+		if cfgErr != nil {
+			return cfgErr
+		}
+		board, err = cloudDashboard(ctx, host, dashboardRepos(cfg, ctxCfg), opts, partial)
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+	if err != nil {
+		return err
+	}
+	board.Errors = partial.Failures()
+
+	if err := cmdutil.WriteOutput(cmd, ios.Out, board, func() error {
+		return printDashboard(ios.Out, board, host.Kind == "cloud")
+	}); err != nil {
+		return err
+	}
+	return partial.Err(ios.ErrOut)
+}
+
+// dashboardRepos lists the WORKSPACE/SLUG repositories configured as context
+// defaults on the active context's host, starting with the active one.
+func dashboardRepos(cfg *config.Config, active *config.Context) []string {
+	var repos []string
+	seen := make(map[string]bool)
+	add := func(c *config.Context) {
+		if c == nil || c.Host != active.Host || c.Workspace == "" || c.DefaultRepo == "" {
+			return
+		}
+		repo := c.Workspace + "/" + c.DefaultRepo
+		if !seen[repo] {
+			seen[repo] = true
+			repos = append(repos, repo)
+		}
+	}
+
+	add(active)
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(cfg.Contexts[name])
+	}
+	return repos
+}
+
+func cloudDashboard(ctx context.Context, host *config.Host, repos []string, opts *dashboardOptions, partial *cmdutil.PartialResults) (*dashboard, error) {
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories configured; set a default repository on a context (bkt context create --workspace W --repo R) or pass --repo WORKSPACE/SLUG")
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return nil, err
+	}
+	me, err := client.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &dashboard{Repositories: repos}
+	var jobs []func() result
+	for _, repo := range repos {
+		workspace, slug, _ := strings.Cut(repo, "/")
+		listPRs := func(item string, filter bbcloud.PullRequestListOptions, add func(*dashboard, dashboardPR)) func() result {
+			return func() result {
+				filter.State, filter.Limit = "OPEN", opts.Limit
+				prs, err := client.ListPullRequests(ctx, workspace, slug, filter)
+				return result{item: item, err: err, fill: func(d *dashboard) {
+					for _, pr := range prs {
+						add(d, dashboardPR{
+							Repository: repo,
+							ID:         pr.ID,
+							Title:      pr.Title,
+							Author:     cmdutil.FirstNonEmpty(pr.Author.DisplayName, pr.Author.Username),
+							URL:        pr.Links.HTML.Href,
+							commit:     pr.Source.Commit.Hash,
+						})
+					}
+				}}
+			}
+		}
+		jobs = append(jobs,
+			listPRs(repo+" authored", bbcloud.PullRequestListOptions{Query: fmt.Sprintf(`author.uuid="%s"`, me.UUID)}, func(d *dashboard, pr dashboardPR) {
+				d.Authored = append(d.Authored, pr)
+			}),
+			listPRs(repo+" review requests", bbcloud.PullRequestListOptions{ReviewRequested: me.UUID}, func(d *dashboard, pr dashboardPR) {
+				d.ReviewRequested = append(d.ReviewRequested, pr)
+			}),
+			func() result {
+				pipelines, err := client.ListPipelines(ctx, workspace, slug, opts.Limit)
+				return result{item: repo + " pipelines", err: err, fill: func(d *dashboard) {
+					for _, p := range pipelines {
+						d.Pipelines = append(d.Pipelines, dashboardPipeline{
+							Repository:  repo,
+							BuildNumber: p.BuildNumber,
+							Ref:         p.Target.Ref.Name,
+							State:       cmdutil.FirstNonEmpty(p.State.Result.Name, p.State.Name),
+							CreatedOn:   p.CreatedOn,
+						})
+					}
+				}}
+			},
+		)
+	}
+	if err := apply(board, fetchAll(jobs), partial); err != nil {
+		return nil, err
+	}
+
+	err = collectFailingBuilds(board, partial, func(pr dashboardPR) ([]bbcloud.CommitStatus, error) {
+		workspace, slug, _ := strings.Cut(pr.Repository, "/")
+		return client.CommitStatuses(ctx, workspace, slug, pr.commit)
+	})
+	return board, err
+}
+
+func dcDashboard(ctx context.Context, host *config.Host, opts *dashboardOptions, partial *cmdutil.PartialResults) (*dashboard, error) {
+	client, err := cmdutil.NewDCClient(host)
+	if err != nil {
+		return nil, err
+	}
+
+	listPRs := func(item, role string, add func(*dashboard, bbdc.PullRequest, dashboardPR)) func() result {
+		return func() result {
+			prs, err := client.ListDashboardPullRequests(ctx, bbdc.DashboardPullRequestsOptions{State: "OPEN", Role: role, Limit: opts.Limit})
+			return result{item: item, err: err, fill: func(d *dashboard) {
+				for _, pr := range prs {
+					repo := pr.ToRef.Repository.Slug
+					if pr.ToRef.Repository.Project != nil {
+						repo = pr.ToRef.Repository.Project.Key + "/" + repo
+					}
+					url := ""
+					if len(pr.Links.Self) > 0 {
+						url = pr.Links.Self[0].Href
+					}
+					add(d, pr, dashboardPR{
+						Repository: repo,
+						ID:         pr.ID,
+						Title:      pr.Title,
+						Author:     cmdutil.FirstNonEmpty(pr.Author.User.FullName, pr.Author.User.Name),
+						URL:        url,
+						commit:     pr.FromRef.LatestCommit,
+					})
+				}
+			}}
+		}
+	}
+
+	board := &dashboard{}
+	results := fetchAll([]func() result{
+		listPRs("authored pull requests", "AUTHOR", func(d *dashboard, _ bbdc.PullRequest, pr dashboardPR) {
+			d.Authored = append(d.Authored, pr)
+		}),
+		listPRs("review requests", "REVIEWER", func(d *dashboard, raw bbdc.PullRequest, pr dashboardPR) {
+			if !reviewedBy(raw, host.Username) {
+				d.ReviewRequested = append(d.ReviewRequested, pr)
+			}
+		}),
+	})
+	if err := apply(board, results, partial); err != nil {
+		return nil, err
+	}
+
+	err = collectFailingBuilds(board, partial, func(pr dashboardPR) ([]bbdc.CommitStatus, error) {
+		return client.CommitStatuses(ctx, pr.commit)
+	})
+	return board, err
+}
+
+// reviewedBy reports whether username has already approved pr, in which case
+// it no longer awaits their review.
+func reviewedBy(pr bbdc.PullRequest, username string) bool {
+	for _, reviewer := range pr.Reviewers {
+		if strings.EqualFold(reviewer.User.Name, username) || strings.EqualFold(reviewer.User.Slug, username) {
+			return reviewer.Approved || strings.EqualFold(reviewer.Status, "APPROVED")
+		}
+	}
+	return false
+}
+
+// apply merges fetched results into board, recording failures on partial.
+func apply(board *dashboard, results []result, partial *cmdutil.PartialResults) error {
+	for _, r := range results {
+		if r.err != nil {
+			if err := partial.Record(r.item, r.err); err != nil {
+				return err
+			}
+			continue
+		}
+		r.fill(board)
+	}
+	return nil
+}
+
+// collectFailingBuilds looks up the build statuses of each authored pull
+// request's head commit and lists the failed ones.
+func collectFailingBuilds(board *dashboard, partial *cmdutil.PartialResults, statuses func(dashboardPR) ([]types.CommitStatus, error)) error {
+	var jobs []func() result
+	for _, pr := range board.Authored {
+		if pr.commit == "" {
+			continue
+		}
+		jobs = append(jobs, func() result {
+			list, err := statuses(pr)
+			return result{item: fmt.Sprintf("%s#%d builds", pr.Repository, pr.ID), err: err, fill: func(d *dashboard) {
+				for _, status := range list {
+					if !strings.EqualFold(status.State, "FAILED") && !strings.EqualFold(status.State, "STOPPED") {
+						continue
+					}
+					d.FailingBuilds = append(d.FailingBuilds, dashboardBuild{
+						Repository:  pr.Repository,
+						PullRequest: pr.ID,
+						Title:       pr.Title,
+						Build:       cmdutil.FirstNonEmpty(status.Name, status.Key),
+						State:       strings.ToUpper(status.State),
+						URL:         status.URL,
+					})
+				}
+			}}
+		})
+	}
+	return apply(board, fetchAll(jobs), partial)
+}
+
+func printDashboard(out io.Writer, board *dashboard, cloud bool) error {
+	section := func(title string, rows []string) error {
+		if _, err := fmt.Fprintln(out, title); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			rows = []string{"(none)"}
+		}
+		for _, row := range rows {
+			if _, err := fmt.Fprintf(out, "  %s\n", row); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintln(out)
+		return err
+	}
+
+	prRows := func(prs []dashboardPR, withAuthor bool) []string {
+		rows := make([]string, 0, len(prs))
+		for _, pr := range prs {
+			row := fmt.Sprintf("%s#%d  %s", pr.Repository, pr.ID, pr.Title)
+			if withAuthor && pr.Author != "" {
+				row += "  (" + pr.Author + ")"
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	if err := section("Your pull requests", prRows(board.Authored, false)); err != nil {
+		return err
+	}
+	if err := section("Awaiting your review", prRows(board.ReviewRequested, true)); err != nil {
+		return err
+	}
+
+	var builds []string
+	for _, b := range board.FailingBuilds {
+		builds = append(builds, fmt.Sprintf("%s#%d  %s  %s", b.Repository, b.PullRequest, b.Build, b.State))
+	}
+	if err := section("Failing builds on your pull requests", builds); err != nil {
+		return err
+	}
+
+	if cloud {
+		var pipelines []string
+		for _, p := range board.Pipelines {
+			pipelines = append(pipelines, fmt.Sprintf("%s  #%d  %s  %s  %s", p.Repository, p.BuildNumber, p.Ref, p.State, p.CreatedOn))
+		}
+		if err := section("Recent pipelines", pipelines); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package status
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, baseURL string) (*cmdutil.Factory, *strings.Builder, *strings.Builder) {
+	t.Helper()
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+			"web":     {Host: "cloud", Workspace: "team", DefaultRepo: "web"},
+			"dup":     {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+			"other":   {Host: "elsewhere", Workspace: "team", DefaultRepo: "ignored"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: baseURL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout, &stderr
+}
+
+func dashboardServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"uuid":"{me}","username":"user"}`))
+		case "/repositories/team/api/pullrequests":
+			switch q := r.URL.Query().Get("q"); q {
+			case `author.uuid="{me}"`:
+				_, _ = w.Write([]byte(`{"values":[{"id":7,"title":"Add retries","source":{"commit":{"hash":"abc123"}}}]}`))
+			case `reviewers.uuid="{me}"`:
+				_, _ = w.Write([]byte(`{"values":[{"id":9,"title":"Bump deps","author":{"display_name":"Ana"}}]}`))
+			default:
+				t.Errorf("unexpected query %q", q)
+			}
+		case "/repositories/team/web/pullrequests":
+			_, _ = w.Write([]byte(`{"values":[]}`))
+		case "/repositories/team/api/commit/abc123/statuses":
+			_, _ = w.Write([]byte(`{"values":[{"state":"SUCCESSFUL","key":"lint"},{"state":"FAILED","key":"unit","name":"Unit tests"}]}`))
+		case "/repositories/team/api/pipelines/":
+			_, _ = w.Write([]byte(`{"values":[{"build_number":42,"state":{"name":"COMPLETED","result":{"name":"SUCCESSFUL"}},"target":{"ref":{"name":"main"}}}]}`))
+		case "/repositories/team/web/pipelines/":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"message":"pipelines are disabled"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDashboardCloudRendersSections(t *testing.T) {
+	server := dashboardServer(t)
+	t.Cleanup(server.Close)
+
+	f, stdout, stderr := newTestFactory(t, server.URL)
+	cmd := NewCmdStatus(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "results are incomplete") {
+		t.Fatalf("expected a partial results error, got %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"Your pull requests\n  team/api#7  Add retries\n",
+		"Awaiting your review\n  team/api#9  Bump deps  (Ana)\n",
+		"Failing builds on your pull requests\n  team/api#7  Unit tests  FAILED\n",
+		"Recent pipelines\n  team/api  #42",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "ignored") {
+		t.Fatalf("repository from another host leaked into the dashboard:\n%s", out)
+	}
+	if !strings.Contains(stderr.String(), "team/web pipelines") {
+		t.Fatalf("expected a warning for the failed section, got %q", stderr.String())
+	}
+}
+
+func TestDashboardCloudFailFast(t *testing.T) {
+	server := dashboardServer(t)
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, server.URL)
+	cmd := NewCmdStatus(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--fail-fast"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "pipelines are disabled") {
+		t.Fatalf("expected the first failure to abort, got %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no dashboard output, got %q", stdout.String())
+	}
+}
+
+func TestDashboardJSON(t *testing.T) {
+	server := dashboardServer(t)
+	t.Cleanup(server.Close)
+
+	f, stdout, _ := newTestFactory(t, server.URL)
+	cmd := NewCmdStatus(f)
+	cmd.PersistentFlags().Bool("json", false, "")
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--json"})
+
+	_ = cmd.Execute()
+
+	var board dashboard
+	if err := json.Unmarshal([]byte(stdout.String()), &board); err != nil {
+		t.Fatalf("decode: %v\n%s", err, stdout.String())
+	}
+	if got := strings.Join(board.Repositories, ","); got != "team/api,team/web" {
+		t.Fatalf("unexpected repositories %q", got)
+	}
+	if len(board.FailingBuilds) != 1 || board.FailingBuilds[0].PullRequest != 7 {
+		t.Fatalf("unexpected failing builds %+v", board.FailingBuilds)
+	}
+	if len(board.Errors) != 1 {
+		t.Fatalf("expected one recorded error, got %+v", board.Errors)
+	}
+}
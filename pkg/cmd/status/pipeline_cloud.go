@@ -1,7 +1,6 @@
 package status
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -15,6 +14,7 @@ type cloudStatusOptions struct {
 	Workspace string
 	Repo      string
 	UUID      string
+	FailFast  bool
 }
 
 func newCloudPipelineCmd(f *cmdutil.Factory) *cobra.Command {
@@ -31,6 +31,7 @@ func newCloudPipelineCmd(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
 
 	return cmd
 }
@@ -51,7 +52,7 @@ func runCloudPipelineStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *cloudS
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	pipeline, err := client.GetPipeline(ctx, workspace, repo, opts.UUID)
@@ -59,8 +60,10 @@ func runCloudPipelineStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *cloudS
 		return err
 	}
 
+	partial := &cmdutil.PartialResults{FailFast: opts.FailFast}
+
 	steps, err := client.ListPipelineSteps(ctx, workspace, repo, opts.UUID)
-	if err != nil {
+	if err := partial.Record("steps", err); err != nil {
 		return err
 	}
 
@@ -68,8 +71,11 @@ func runCloudPipelineStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *cloudS
 		"pipeline": pipeline,
 		"steps":    steps,
 	}
+	if failures := partial.Failures(); len(failures) > 0 {
+		payload["errors"] = failures
+	}
 
-	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+	if err := cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
 		if _, err := fmt.Fprintf(ios.Out, "%s\t%s\t%s\n", pipeline.UUID, pipeline.State.Name, pipeline.State.Result.Name); err != nil {
 			return err
 		}
@@ -86,6 +92,11 @@ func runCloudPipelineStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *cloudS
 				return err
 			}
 		}
+		if partial.Failed("steps") {
+			if _, err := fmt.Fprintf(ios.Out, "%sSteps: unavailable (request failed)\n", partial.Marker("steps")); err != nil {
+				return err
+			}
+		}
 		if len(steps) > 0 {
 			if _, err := fmt.Fprintln(ios.Out, "Steps:"); err != nil {
 				return err
@@ -97,7 +108,11 @@ func runCloudPipelineStatus(cmd *cobra.Command, f *cmdutil.Factory, opts *cloudS
 			}
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	return partial.Err(ios.ErrOut)
 }
 
 func resolveCloudStatusContext(cmd *cobra.Command, f *cmdutil.Factory, workspaceOverride, repoOverride string) (string, string, *config.Host, error) {
@@ -1,7 +1,6 @@
 package status
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"time"
@@ -34,7 +33,7 @@ func runRateLimit(cmd *cobra.Command, f *cmdutil.Factory) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 5*time.Second)
 	defer cancel()
 
 	switch host.Kind {
@@ -1,7 +1,6 @@
 package status
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"strconv"
@@ -14,13 +13,33 @@ import (
 	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
 )
 
-// NewCmdStatus exposes commit and PR status commands.
+// NewCmdStatus exposes the personal dashboard along with commit and PR
+// status commands.
 func NewCmdStatus(f *cmdutil.Factory) *cobra.Command {
+	opts := &dashboardOptions{Limit: 5}
 	cmd := &cobra.Command{
 		Use:   "status",
-		Short: "Inspect commit and pull request statuses",
+		Short: "Show your dashboard and inspect commit and pull request statuses",
+		Long: `Without a subcommand, show a personal dashboard: open pull requests you
+authored, pull requests awaiting your review, failing builds on your pull
+requests and, on Bitbucket Cloud, recent pipeline runs.
+
+On Cloud the dashboard covers the default repository of every context that
+shares the active context's host. On Data Center it covers all repositories.
+Sections that fail to load are reported as warnings; pass --fail-fast to abort
+instead.`,
+		Example: `  bkt status
+  bkt status --limit 10
+  bkt status --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDashboard(cmd, f, opts)
+		},
 	}
 
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum entries per repository and section (0 for all)")
+	cmdutil.AddFailFastFlag(cmd, &opts.FailFast)
+
 	cmd.AddCommand(newCommitCmd(f))
 	cmd.AddCommand(newPullRequestCmd(f))
 	cmd.AddCommand(newCloudPipelineCmd(f))
@@ -57,16 +76,12 @@ func runCommit(cmd *cobra.Command, f *cmdutil.Factory, sha string) error {
 		return fmt.Errorf("status commit currently supports Data Center contexts only")
 	}
 
-	client, err := bbdc.New(bbdc.Options{
-		BaseURL:  host.BaseURL,
-		Username: host.Username,
-		Token:    host.Token,
-	})
+	client, err := cmdutil.NewDCClient(host)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	statuses, err := client.CommitStatuses(ctx, sha)
@@ -134,16 +149,12 @@ func runPullRequest(cmd *cobra.Command, f *cmdutil.Factory, prID int, opts *prOp
 		return fmt.Errorf("repository slug required; pass --repo or set the context default")
 	}
 
-	client, err := bbdc.New(bbdc.Options{
-		BaseURL:  host.BaseURL,
-		Username: host.Username,
-		Token:    host.Token,
-	})
+	client, err := cmdutil.NewDCClient(host)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 	defer cancel()
 
 	pr, err := client.GetPullRequest(ctx, projectKey, repoSlug, prID)
@@ -0,0 +1,388 @@
+package tag
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdTag exposes tag operations.
+func NewCmdTag(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "List, create and delete tags",
+	}
+
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newCreateCmd(f))
+	cmd.AddCommand(newDeleteCmd(f))
+
+	return cmd
+}
+
+type listOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Filter    string
+	Limit     int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List tags, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter tags by text")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum tags to list (0 for all)")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		tags, err := client.ListTags(ctx, projectKey, repoSlug, bbdc.TagListOptions{Filter: opts.Filter, Limit: opts.Limit})
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"project": projectKey,
+			"repo":    repoSlug,
+			"tags":    tags,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			if len(tags) == 0 {
+				_, err := fmt.Fprintln(ios.Out, "No tags found.")
+				return err
+			}
+
+			tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+			for _, tag := range tags {
+				if _, err := fmt.Fprintf(tw, "%s\t%s\n", tag.DisplayID, shortHash(tag.LatestCommit)); err != nil {
+					return err
+				}
+			}
+			return tw.Flush()
+		})
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		tags, err := client.ListTags(ctx, workspace, repoSlug, bbcloud.TagListOptions{
+			Filter: opts.Filter,
+			Sort:   "-target.date",
+			Limit:  opts.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"workspace": workspace,
+			"repo":      repoSlug,
+			"tags":      tags,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			if len(tags) == 0 {
+				_, err := fmt.Fprintln(ios.Out, "No tags found.")
+				return err
+			}
+
+			tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+			for _, tag := range tags {
+				date := cmdutil.FirstNonEmpty(tag.Date, tag.Target.Date)
+				if t, err := time.Parse(time.RFC3339, date); err == nil {
+					date = t.Format("2006-01-02")
+				}
+				if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", tag.Name, shortHash(tag.Target.Hash), date); err != nil {
+					return err
+				}
+			}
+			return tw.Flush()
+		})
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+type createOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Target    string
+	Message   string
+}
+
+func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create <tag>",
+		Short: "Create a tag",
+		Long: `Create a tag pointing at a commit or branch. Passing --message creates an
+annotated tag.`,
+		Example: `  bkt tag create v1.4.0 --target 3f2a9c1e --message "Release 1.4.0"
+  bkt tag create nightly-2024-05-01 --target main`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Target, "target", "", "Commit hash or branch to tag (required)")
+	cmd.Flags().StringVarP(&opts.Message, "message", "m", "", "Tag message (creates an annotated tag)")
+	_ = cmd.MarkFlagRequired("target")
+	_ = cmd.RegisterFlagCompletionFunc("target", cmdutil.CompleteBranches(f))
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, f *cmdutil.Factory, name string, opts *createOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		tag, err := client.CreateTag(ctx, projectKey, repoSlug, bbdc.CreateTagInput{
+			Name:       name,
+			StartPoint: opts.Target,
+			Message:    opts.Message,
+		})
+		if err != nil {
+			return err
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, tag, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Created tag %s (%s)\n", tag.DisplayID, shortHash(tag.LatestCommit))
+			return err
+		})
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		tag, err := client.CreateTag(ctx, workspace, repoSlug, bbcloud.CreateTagInput{
+			Name:    name,
+			Target:  opts.Target,
+			Message: opts.Message,
+		})
+		if err != nil {
+			return err
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, tag, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Created tag %s (%s)\n", tag.Name, shortHash(tag.Target.Hash))
+			return err
+		})
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
+type deleteOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	Yes       bool
+}
+
+func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:     "delete <tag>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a tag",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(cmd *cobra.Command, f *cmdutil.Factory, name string, opts *deleteOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	confirm := func(location string) (bool, error) {
+		if opts.Yes {
+			return true, nil
+		}
+		confirmed, err := f.Prompt().Confirm(fmt.Sprintf("Delete tag %q from %s?", name, location), false)
+		if err != nil {
+			return false, err
+		}
+		if !confirmed {
+			_, _ = fmt.Fprintln(ios.Out, "Aborted.")
+		}
+		return confirmed, nil
+	}
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+		if ok, err := confirm(projectKey + "/" + repoSlug); err != nil || !ok {
+			return err
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		if err := client.DeleteTag(ctx, projectKey, repoSlug, name); err != nil {
+			return err
+		}
+
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+		if ok, err := confirm(workspace + "/" + repoSlug); err != nil || !ok {
+			return err
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		if err := client.DeleteTag(ctx, workspace, repoSlug, name); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	if _, err := fmt.Fprintf(ios.Out, "✓ Deleted tag %s\n", name); err != nil {
+		return err
+	}
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
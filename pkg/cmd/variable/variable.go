@@ -166,7 +166,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	var variables []bbcloud.PipelineVariable
@@ -343,7 +343,7 @@ func runGet(cmd *cobra.Command, f *cmdutil.Factory, opts *getOptions, variableNa
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	// List all variables and find the one with matching key
@@ -512,7 +512,7 @@ func runDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deleteOptions, vari
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	// List all variables and find the one with matching key
@@ -766,7 +766,7 @@ func runSet(cmd *cobra.Command, f *cmdutil.Factory, opts *setOptions, variableNa
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
 	defer cancel()
 
 	// Check if variable already exists
@@ -983,7 +983,7 @@ func runSetFromEnvFile(cmd *cobra.Command, f *cmdutil.Factory, opts *setOptions)
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 60*time.Second)
+	ctx, cancel := cmdutil.CommandContext(cmd, 60*time.Second)
 	defer cancel()
 
 	// Get existing variables
@@ -0,0 +1,392 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// Events subscribed to by default: pushes, pull request activity and build
+// statuses (Data Center has no build status event).
+var (
+	defaultCloudForwardEvents = []string{
+		"repo:push",
+		"pullrequest:created",
+		"pullrequest:updated",
+		"pullrequest:fulfilled",
+		"pullrequest:rejected",
+		"pullrequest:comment_created",
+		"repo:commit_status_created",
+		"repo:commit_status_updated",
+	}
+	defaultDCForwardEvents = []string{
+		"repo:refs_changed",
+		"pr:opened",
+		"pr:from_ref_updated",
+		"pr:modified",
+		"pr:merged",
+		"pr:declined",
+		"pr:comment:added",
+	}
+)
+
+// maxDeliverySize bounds the webhook payloads accepted by the listener.
+const maxDeliverySize = 10 << 20
+
+type forwardOptions struct {
+	Project   string
+	Workspace string
+	Repo      string
+	To        string
+	URL       string
+	Listen    string
+	Events    []string
+}
+
+func newForwardCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &forwardOptions{Listen: "localhost:8787"}
+	cmd := &cobra.Command{
+		Use:   "forward --to <local-url> --url <public-url>",
+		Short: "Forward webhook deliveries to a local server",
+		Long: `Create a temporary webhook on the repository and forward its deliveries to a
+local server while you develop an integration. The webhook is deleted when the
+command exits (Ctrl+C).
+
+Bitbucket must be able to reach the listener, so expose --listen through a
+tunnel (for example ngrok or cloudflared) and pass the tunnel's public address
+as --url. Deliveries are signed with a random secret; those without a valid
+X-Hub-Signature are rejected. Accepted ones are posted to --to with the
+original headers and body, and the local server's response is returned to
+Bitbucket.
+
+By default the webhook subscribes to pushes, pull request activity and, on
+Cloud, build status changes; use --event to choose others.`,
+		Example: `  ngrok http 8787 &
+  bkt webhook forward --url https://abc123.ngrok.app --to http://localhost:3000/webhooks
+
+  bkt webhook forward --url https://hooks.example.dev --to http://localhost:3000/bb \
+    --listen :9000 --event pullrequest:created --event pullrequest:fulfilled`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runForward(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override (Data Center)")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.To, "to", "", "Local URL to forward deliveries to (required)")
+	cmd.Flags().StringVar(&opts.URL, "url", "", "Public URL that reaches the listener, e.g. a tunnel (required)")
+	cmd.Flags().StringVar(&opts.Listen, "listen", opts.Listen, "Address for the local listener")
+	cmd.Flags().StringSliceVar(&opts.Events, "event", nil, "Events to subscribe to (repeatable; defaults to push, pull request and build events)")
+
+	_ = cmd.MarkFlagRequired("to")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+// forwardHook creates the temporary webhook and returns a description of it
+// along with the function that deletes it.
+type forwardHook func(ctx context.Context, name, url, secret string) (string, func(context.Context) error, error)
+
+func runForward(cmd *cobra.Command, f *cmdutil.Factory, opts *forwardOptions) (err error) {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	for flag, value := range map[string]string{"--to": opts.To, "--url": opts.URL} {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an http(s) URL, got %q", flag, value)
+		}
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	var create forwardHook
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		events := opts.Events
+		if len(events) == 0 {
+			events = defaultDCForwardEvents
+		}
+		create = func(ctx context.Context, name, hookURL, secret string) (string, func(context.Context) error, error) {
+			hook, err := client.CreateWebhook(ctx, projectKey, repoSlug, bbdc.CreateWebhookInput{
+				Name:   name,
+				URL:    hookURL,
+				Events: events,
+				Active: true,
+				Secret: secret,
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "#" + strconv.Itoa(hook.ID), func(ctx context.Context) error {
+				return client.DeleteWebhook(ctx, projectKey, repoSlug, hook.ID)
+			}, nil
+		}
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		events := opts.Events
+		if len(events) == 0 {
+			events = defaultCloudForwardEvents
+		}
+		create = func(ctx context.Context, name, hookURL, secret string) (string, func(context.Context) error, error) {
+			hook, err := client.CreateWebhook(ctx, workspace, repoSlug, bbcloud.WebhookInput{
+				Description: name,
+				URL:         hookURL,
+				Events:      events,
+				Active:      true,
+				Secret:      secret,
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return hook.UUID, func(ctx context.Context) error {
+				return client.DeleteWebhook(ctx, workspace, repoSlug, hook.UUID)
+			}, nil
+		}
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+
+	// Listen before creating the webhook so a busy port fails fast.
+	listener, err := net.Listen("tcp", opts.Listen)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", opts.Listen, err)
+	}
+	defer listener.Close()
+
+	secret, err := randomSecret()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	createCtx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	name := "bkt webhook forward"
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		name += " (" + hostname + ")"
+	}
+	id, remove, err := create(createCtx, name, opts.URL, secret)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	// Delete the webhook however the command ends; the command context may
+	// already be cancelled by then.
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		var werr error
+		if rerr := remove(cleanupCtx); rerr != nil {
+			_, werr = fmt.Fprintf(ios.ErrOut, "! failed to delete webhook %s: %v; remove it with `bkt webhook delete`\n", id, rerr)
+		} else {
+			_, werr = fmt.Fprintf(ios.Out, "✓ Deleted webhook %s\n", id)
+		}
+		err = errors.Join(err, werr)
+	}()
+
+	if _, err := fmt.Fprintf(ios.Out, "✓ Created webhook %s\nForwarding %s → %s → %s\nPress Ctrl+C to stop.\n",
+		id, opts.URL, listener.Addr(), opts.To); err != nil {
+		return err
+	}
+
+	fw := newForwarder(opts.To, secret, ios.Out, ios.ErrOut)
+	server := &http.Server{
+		Handler:           fw,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	var writeErr error
+	select {
+	case <-ctx.Done():
+	case writeErr = <-fw.writeErr:
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("webhook listener: %w", err)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return errors.Join(writeErr, server.Shutdown(shutdownCtx))
+}
+
+// randomSecret returns a hex-encoded 32 byte secret for signing deliveries.
+func randomSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// forwarder verifies webhook deliveries and relays them to a local URL.
+type forwarder struct {
+	target string
+	secret string
+	client *http.Client
+	out    io.Writer
+	errOut io.Writer
+	mu     sync.Mutex
+	// writeErr receives the first failed log write, which stops forwarding.
+	writeErr chan error
+}
+
+func newForwarder(target, secret string, out, errOut io.Writer) *forwarder {
+	// The target is a local service, so deliveries skip the proxy and TLS
+	// settings of the Bitbucket host and any proxy from the environment.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	return &forwarder{
+		target:   target,
+		secret:   secret,
+		client:   &http.Client{Timeout: 30 * time.Second, Transport: transport},
+		out:      out,
+		errOut:   errOut,
+		writeErr: make(chan error, 1),
+	}
+}
+
+func (fw *forwarder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	event := cmdutil.FirstNonEmpty(r.Header.Get("X-Event-Key"), "unknown event")
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDeliverySize+1))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxDeliverySize {
+		fw.logf(fw.errOut, "! %s rejected: payload larger than %d bytes\n", event, maxDeliverySize)
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !validSignature(fw.secret, body, r.Header.Get("X-Hub-Signature")) {
+		fw.logf(fw.errOut, "! %s rejected: missing or invalid signature\n", event)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, fw.target, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Del("Content-Length")
+	req.Header.Del("Connection")
+
+	start := time.Now()
+	resp, err := fw.client.Do(req)
+	if err != nil {
+		fw.logf(fw.errOut, "! %s not delivered to %s: %v\n", event, fw.target, err)
+		http.Error(w, "forwarding failed", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	fw.logf(fw.out, "%s  %-28s  %d  %s\n", start.Format("15:04:05"), event, resp.StatusCode, time.Since(start).Round(time.Millisecond))
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, io.LimitReader(resp.Body, maxDeliverySize))
+}
+
+// logf writes a line, keeping lines from concurrent deliveries intact. A
+// failed write is reported on writeErr once.
+func (fw *forwarder) logf(w io.Writer, format string, args ...any) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if _, err := fmt.Fprintf(w, format, args...); err != nil {
+		select {
+		case fw.writeErr <- err:
+		default:
+		}
+	}
+}
+
+// validSignature checks an X-Hub-Signature header of the form
+// "sha256=<hex hmac>" against the body.
+func validSignature(secret string, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// signBody returns the X-Hub-Signature value Bitbucket sends for body.
+func signBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
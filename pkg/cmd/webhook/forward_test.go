@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestForwarderRelaysSignedDeliveries(t *testing.T) {
+	var gotBody, gotEvent string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		gotBody, gotEvent = string(data), r.Header.Get("X-Event-Key")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("queued"))
+	}))
+	t.Cleanup(target.Close)
+
+	var out, errOut bytes.Buffer
+	fw := newForwarder(target.URL, "s3cret", &out, &errOut)
+
+	body := []byte(`{"pullrequest":{"id":7}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("X-Event-Key", "pullrequest:created")
+	req.Header.Set("X-Hub-Signature", sign("s3cret", body))
+	rec := httptest.NewRecorder()
+	fw.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "queued" {
+		t.Fatalf("unexpected response %d %q", rec.Code, rec.Body.String())
+	}
+	if gotBody != string(body) || gotEvent != "pullrequest:created" {
+		t.Fatalf("target received body %q event %q", gotBody, gotEvent)
+	}
+	if !strings.Contains(out.String(), "pullrequest:created") || !strings.Contains(out.String(), "202") {
+		t.Fatalf("expected delivery log, got %q", out.String())
+	}
+}
+
+func TestForwarderRejectsBadSignatures(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unsigned delivery must not be forwarded")
+	}))
+	t.Cleanup(target.Close)
+
+	var out, errOut bytes.Buffer
+	fw := newForwarder(target.URL, "s3cret", &out, &errOut)
+
+	for _, signature := range []string{"", "sha256=00", sign("other", []byte("{}"))} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+		req.Header.Set("X-Event-Key", "repo:push")
+		if signature != "" {
+			req.Header.Set("X-Hub-Signature", signature)
+		}
+		rec := httptest.NewRecorder()
+		fw.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("signature %q: status = %d, want 401", signature, rec.Code)
+		}
+	}
+	if !strings.Contains(errOut.String(), "repo:push rejected") {
+		t.Fatalf("expected rejection warning, got %q", errOut.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestForwarderDeliversWithoutProxy(t *testing.T) {
+	fw := newForwarder("http://127.0.0.1:0", "s3cret", io.Discard, io.Discard)
+
+	transport, ok := fw.client.Transport.(*http.Transport)
+	if !ok || transport.Proxy != nil {
+		t.Fatalf("forwarder transport %#v uses a proxy", fw.client.Transport)
+	}
+}
+
+func TestForwarderReportsLogWriteErrors(t *testing.T) {
+	var out bytes.Buffer
+	fw := newForwarder("http://127.0.0.1:0", "s3cret", &out, failingWriter{})
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+		fw.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	select {
+	case err := <-fw.writeErr:
+		if err == nil || !strings.Contains(err.Error(), "broken pipe") {
+			t.Fatalf("writeErr = %v", err)
+		}
+	default:
+		t.Fatal("expected the failed write to be reported")
+	}
+}
+
+func TestForwardCreatesAndDeletesWebhook(t *testing.T) {
+	received := make(chan string, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received <- string(data)
+	}))
+	t.Cleanup(target.Close)
+
+	created := make(chan map[string]any, 1)
+	var mu sync.Mutex
+	var deleted bool
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repositories/team/api/hooks":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"uuid":"{h1}","url":"https://tunnel.example.com","active":true}`))
+			created <- body
+		case r.Method == http.MethodDelete && r.URL.Path == "/repositories/team/api/hooks/h1":
+			mu.Lock()
+			deleted = true
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	// Reserve a free port for the listener.
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	listen := probe.Addr().String()
+	_ = probe.Close()
+
+	cfg := &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: api.URL, Username: "user", Token: "token"},
+		},
+	}
+	var stdout, stderr syncBuffer
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCommand(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"forward", "--url", "https://tunnel.example.com", "--to", target.URL, "--listen", listen})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.ExecuteContext(ctx)
+	}()
+
+	var hook map[string]any
+	select {
+	case hook = <-created:
+	case err := <-done:
+		t.Fatalf("forward exited early: %v", err)
+	}
+	secret, _ := hook["secret"].(string)
+	if secret == "" || hook["url"] != "https://tunnel.example.com" {
+		t.Fatalf("unexpected webhook body: %v", hook)
+	}
+	if events, _ := hook["events"].([]any); len(events) != len(defaultCloudForwardEvents) {
+		t.Fatalf("expected default events, got %v", hook["events"])
+	}
+
+	payload := []byte(`{"push":{}}`)
+	req, _ := http.NewRequest(http.MethodPost, "http://"+listen+"/", bytes.NewReader(payload))
+	req.Header.Set("X-Event-Key", "repo:push")
+	req.Header.Set("X-Hub-Signature", sign(secret, payload))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	_ = resp.Body.Close()
+	if got := <-received; got != string(payload) {
+		t.Fatalf("target received %q", got)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("forward: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !deleted {
+		t.Fatal("expected webhook to be deleted on exit")
+	}
+	if out := stdout.String(); !strings.Contains(out, "✓ Created webhook {h1}") || !strings.Contains(out, "✓ Deleted webhook {h1}") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of the
+// listener and the command.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
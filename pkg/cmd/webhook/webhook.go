@@ -1,9 +1,14 @@
 package webhook
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -22,8 +27,10 @@ func NewCommand(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.AddCommand(newListCmd(f))
 	cmd.AddCommand(newCreateCmd(f))
+	cmd.AddCommand(newUpdateCmd(f))
 	cmd.AddCommand(newDeleteCmd(f))
 	cmd.AddCommand(newTestCmd(f))
+	cmd.AddCommand(newForwardCmd(f))
 
 	return cmd
 }
@@ -75,7 +82,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		hooks, err := client.ListWebhooks(ctx, projectKey, repoSlug)
@@ -118,7 +125,7 @@ func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		hooks, err := client.ListWebhooks(ctx, workspace, repoSlug)
@@ -161,6 +168,7 @@ type createOptions struct {
 	URL       string
 	Events    []string
 	Active    bool
+	Secret    string
 }
 
 func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
@@ -180,6 +188,7 @@ func newCreateCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.URL, "url", "", "Webhook callback URL (required)")
 	cmd.Flags().StringSliceVar(&opts.Events, "event", nil, "Events to subscribe to (repeatable)")
 	cmd.Flags().BoolVar(&opts.Active, "active", opts.Active, "Whether the webhook starts active")
+	cmd.Flags().StringVar(&opts.Secret, "secret", "", "Secret used to sign deliveries (X-Hub-Signature)")
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("url")
@@ -213,7 +222,7 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		hook, err := client.CreateWebhook(ctx, projectKey, repoSlug, bbdc.CreateWebhookInput{
@@ -221,6 +230,7 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 			URL:    opts.URL,
 			Events: opts.Events,
 			Active: opts.Active,
+			Secret: opts.Secret,
 		})
 		if err != nil {
 			return err
@@ -242,7 +252,7 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		hook, err := client.CreateWebhook(ctx, workspace, repoSlug, bbcloud.WebhookInput{
@@ -250,6 +260,7 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 			URL:         opts.URL,
 			Events:      opts.Events,
 			Active:      opts.Active,
+			Secret:      opts.Secret,
 		})
 		if err != nil {
 			return err
@@ -264,6 +275,159 @@ func runCreate(cmd *cobra.Command, f *cmdutil.Factory, opts *createOptions) erro
 	}
 }
 
+type updateOptions struct {
+	Project    string
+	Workspace  string
+	Repo       string
+	Identifier string
+	Name       string
+	URL        string
+	Events     []string
+	Active     bool
+	Secret     string
+}
+
+func newUpdateCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &updateOptions{}
+	cmd := &cobra.Command{
+		Use:     "update <id|uuid>",
+		Aliases: []string{"edit"},
+		Short:   "Update an existing webhook",
+		Long: `Update an existing webhook. Only the flags you pass are changed; --event
+replaces the full list of subscribed events and --secret replaces the signing
+secret (pass an empty value to stop signing deliveries).`,
+		Example: `  bkt webhook update 12 --url https://ci.example.com/hook
+  bkt webhook update {uuid} --event repo:push --event pullrequest:created
+  bkt webhook update 12 --active=false`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Identifier = args[0]
+			return runUpdate(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override (Data Center)")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Webhook name (description on Cloud)")
+	cmd.Flags().StringVar(&opts.URL, "url", "", "Webhook callback URL")
+	cmd.Flags().StringSliceVar(&opts.Events, "event", nil, "Events to subscribe to (repeatable; replaces existing events)")
+	cmd.Flags().BoolVar(&opts.Active, "active", true, "Whether the webhook is active")
+	cmd.Flags().StringVar(&opts.Secret, "secret", "", "Secret used to sign deliveries (empty to remove)")
+
+	return cmd
+}
+
+func runUpdate(cmd *cobra.Command, f *cmdutil.Factory, opts *updateOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("name") && !flags.Changed("url") && !flags.Changed("event") && !flags.Changed("active") && !flags.Changed("secret") {
+		return fmt.Errorf("no updates specified: use flags like --url, --event, --active, --secret")
+	}
+
+	var name, hookURL, secret *string
+	var active *bool
+	var events []string
+	if flags.Changed("name") {
+		name = &opts.Name
+	}
+	if flags.Changed("url") {
+		hookURL = &opts.URL
+	}
+	if flags.Changed("event") {
+		if len(opts.Events) == 0 {
+			return fmt.Errorf("at least one event is required")
+		}
+		events = opts.Events
+	}
+	if flags.Changed("active") {
+		active = &opts.Active
+	}
+	if flags.Changed("secret") {
+		secret = &opts.Secret
+	}
+
+	override := cmdutil.FlagValue(cmd, "context")
+	_, ctxCfg, host, err := cmdutil.ResolveContext(f, cmd, override)
+	if err != nil {
+		return err
+	}
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		id, err := strconv.Atoi(opts.Identifier)
+		if err != nil {
+			return fmt.Errorf("invalid webhook id %q", opts.Identifier)
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		hook, err := client.UpdateWebhook(ctx, projectKey, repoSlug, id, bbdc.UpdateWebhookInput{
+			Name:   name,
+			URL:    hookURL,
+			Events: events,
+			Active: active,
+			Secret: secret,
+		})
+		if err != nil {
+			return err
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, hook, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Updated webhook #%d (%s)\n", hook.ID, hook.Name)
+			return err
+		})
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		hook, err := client.UpdateWebhook(ctx, workspace, repoSlug, opts.Identifier, bbcloud.UpdateWebhookInput{
+			Description: name,
+			URL:         hookURL,
+			Events:      events,
+			Active:      active,
+			Secret:      secret,
+		})
+		if err != nil {
+			return err
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, hook, func() error {
+			_, err := fmt.Fprintf(ios.Out, "✓ Updated webhook %s\n", hook.UUID)
+			return err
+		})
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
+	}
+}
+
 type deleteOptions struct {
 	Project    string
 	Workspace  string
@@ -272,9 +436,11 @@ type deleteOptions struct {
 }
 
 type testOptions struct {
-	Project string
-	Repo    string
-	ID      string
+	Project   string
+	Workspace string
+	Repo      string
+	ID        string
+	Secret    string
 }
 
 func newDeleteCmd(f *cmdutil.Factory) *cobra.Command {
@@ -327,7 +493,7 @@ func runDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deleteOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		if err := client.DeleteWebhook(ctx, projectKey, repoSlug, id); err != nil {
@@ -350,7 +516,7 @@ func runDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deleteOptions) erro
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
 		defer cancel()
 
 		if err := client.DeleteWebhook(ctx, workspace, repoSlug, opts.Identifier); err != nil {
@@ -369,17 +535,33 @@ func runDelete(cmd *cobra.Command, f *cmdutil.Factory, opts *deleteOptions) erro
 func newTestCmd(f *cmdutil.Factory) *cobra.Command {
 	opts := &testOptions{}
 	cmd := &cobra.Command{
-		Use:   "test <id>",
-		Short: "Trigger a webhook test delivery",
-		Args:  cobra.ExactArgs(1),
+		Use:     "test <id|uuid>",
+		Aliases: []string{"ping"},
+		Short:   "Trigger a webhook test delivery",
+		Long: `Trigger a test delivery for a webhook.
+
+Data Center asks the server to deliver the test.
+
+Bitbucket Cloud has no test endpoint, so on Cloud this is a local simulation:
+bkt itself posts a sample payload to the webhook URL from this machine, with
+the headers Bitbucket sends, and reports the response status. The request does
+not come from Bitbucket, does not show up in the webhook's request history and
+only proves the endpoint is reachable from here. Cloud never returns a
+webhook's secret, so pass it with --secret to sign the payload; webhooks that
+have a secret cannot be simulated without it.`,
+		Example: `  bkt webhook test 12
+  bkt webhook test {uuid} --secret "$WEBHOOK_SECRET"`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.ID = args[0]
 			return runTest(cmd, f, opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override")
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Bitbucket project key override (Data Center)")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override (Cloud)")
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository slug override")
+	cmd.Flags().StringVar(&opts.Secret, "secret", "", "Secret to sign the simulated Cloud delivery with")
 
 	return cmd
 }
@@ -395,35 +577,119 @@ func runTest(cmd *cobra.Command, f *cmdutil.Factory, opts *testOptions) error {
 	if err != nil {
 		return err
 	}
-	if host.Kind != "dc" {
-		return fmt.Errorf("webhook test is supported for Data Center contexts only")
+
+	switch host.Kind {
+	case "dc":
+		projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if projectKey == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+		}
+
+		id, err := strconv.Atoi(opts.ID)
+		if err != nil {
+			return fmt.Errorf("invalid webhook id %q", opts.ID)
+		}
+
+		client, err := cmdutil.NewDCClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		if err := client.TestWebhook(ctx, projectKey, repoSlug, id); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(ios.Out, "✓ Triggered test delivery for webhook #%d\n", id); err != nil {
+			return err
+		}
+		return nil
+	case "cloud":
+		workspace := cmdutil.FirstNonEmpty(opts.Workspace, ctxCfg.Workspace)
+		repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
+		if workspace == "" || repoSlug == "" {
+			return fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		}
+
+		client, err := cmdutil.NewCloudClient(host)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+		defer cancel()
+
+		hook, err := client.GetWebhook(ctx, workspace, repoSlug, opts.ID)
+		if err != nil {
+			return err
+		}
+
+		if hook.SecretSet && opts.Secret == "" {
+			return fmt.Errorf("webhook %s signs its deliveries; pass its secret with --secret to simulate one", hook.UUID)
+		}
+
+		httpClient, err := cmdutil.NewStdHTTPClient(host)
+		if err != nil {
+			return err
+		}
+
+		status, err := pingWebhook(ctx, httpClient, hook, workspace+"/"+repoSlug, opts.Secret)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(ios.Out, "✓ Delivered a simulated test payload from this machine to %s (HTTP %d)\n", hook.URL, status); err != nil {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported host kind %q", host.Kind)
 	}
+}
 
-	projectKey := cmdutil.FirstNonEmpty(opts.Project, ctxCfg.ProjectKey)
-	repoSlug := cmdutil.FirstNonEmpty(opts.Repo, ctxCfg.DefaultRepo)
-	if projectKey == "" || repoSlug == "" {
-		return fmt.Errorf("context must supply project and repo; use --project/--repo if needed")
+// pingWebhook posts a sample payload to a Cloud webhook URL, mimicking the
+// headers Bitbucket sends and signing the body when secret is set. Non-2xx
+// responses are reported as errors.
+func pingWebhook(ctx context.Context, client *http.Client, hook *bbcloud.Webhook, fullName, secret string) (int, error) {
+	event := "repo:push"
+	if len(hook.Events) > 0 {
+		event = hook.Events[0]
 	}
 
-	id, err := strconv.Atoi(opts.ID)
+	body, err := json.Marshal(map[string]any{
+		"test": true,
+		"repository": map[string]any{
+			"full_name": fullName,
+		},
+	})
 	if err != nil {
-		return fmt.Errorf("invalid webhook id %q", opts.ID)
+		return 0, err
 	}
 
-	client, err := cmdutil.NewDCClient(host)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Bitbucket-Webhooks/2.0")
+	req.Header.Set("X-Event-Key", event)
+	req.Header.Set("X-Hook-UUID", strings.Trim(hook.UUID, "{}"))
+	if secret != "" {
+		req.Header.Set("X-Hub-Signature", signBody(secret, body))
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
-	defer cancel()
-
-	if err := client.TestWebhook(ctx, projectKey, repoSlug, id); err != nil {
-		return err
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("deliver test payload: %w", err)
 	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
 
-	if _, err := fmt.Fprintf(ios.Out, "✓ Triggered test delivery for webhook #%d\n", id); err != nil {
-		return err
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with HTTP %d", resp.StatusCode)
 	}
-	return nil
+	return resp.StatusCode, nil
 }
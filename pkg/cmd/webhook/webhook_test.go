@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+)
+
+func TestPingWebhookSendsBitbucketHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Event-Key") != "pullrequest:created" {
+			t.Fatalf("unexpected event key %q", r.Header.Get("X-Event-Key"))
+		}
+		if r.Header.Get("X-Hook-UUID") != "abc" {
+			t.Fatalf("unexpected hook uuid %q", r.Header.Get("X-Hook-UUID"))
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	status, err := pingWebhook(context.Background(), http.DefaultClient, &bbcloud.Webhook{
+		UUID:   "{abc}",
+		URL:    server.URL,
+		Events: []string{"pullrequest:created"},
+	}, "work/repo", "")
+	if err != nil {
+		t.Fatalf("pingWebhook: %v", err)
+	}
+	if status != http.StatusAccepted {
+		t.Fatalf("status = %d", status)
+	}
+}
+
+func TestPingWebhookReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	if _, err := pingWebhook(context.Background(), http.DefaultClient, &bbcloud.Webhook{URL: server.URL}, "work/repo", ""); err == nil {
+		t.Fatal("expected error for HTTP 500 response")
+	}
+}
+
+func TestPingWebhookSignsPayloadWithSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !validSignature("s3cret", body, r.Header.Get("X-Hub-Signature")) {
+			t.Errorf("invalid signature %q", r.Header.Get("X-Hub-Signature"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	if _, err := pingWebhook(context.Background(), http.DefaultClient, &bbcloud.Webhook{URL: server.URL}, "work/repo", "s3cret"); err != nil {
+		t.Fatalf("pingWebhook: %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+package workspace
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+)
+
+// NewCmdWorkspace exposes Bitbucket Cloud workspace discovery.
+func NewCmdWorkspace(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workspace",
+		Aliases: []string{"ws"},
+		Short:   "Discover Bitbucket Cloud workspaces",
+	}
+
+	cmd.AddCommand(newListCmd(f))
+	cmd.AddCommand(newViewCmd(f))
+	cmd.AddCommand(newMembersCmd(f))
+
+	return cmd
+}
+
+type listOptions struct {
+	Host  string
+	Limit int
+}
+
+func newListCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{Limit: 30}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List workspaces you can access",
+		Long: `List the workspaces the authenticated user belongs to, with their role in each.
+Works before a context exists, so it can be used to find the slug to pass to
+"bkt context create --workspace".`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Host, "host", "", "Host key or base URL override")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Maximum workspaces to list (0 for all)")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, f *cmdutil.Factory, opts *listOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	host, err := resolveCloudHost(f, cmd, opts.Host)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 15*time.Second)
+	defer cancel()
+
+	workspaces, err := client.ListWorkspaces(ctx, opts.Limit)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspaces": workspaces,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(workspaces) == 0 {
+			_, err := fmt.Fprintln(ios.Out, "No workspaces found.")
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, ws := range workspaces {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", ws.Slug, ws.Name, ws.Permission); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+type viewOptions struct {
+	Host string
+	Web  bool
+}
+
+func newViewCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &viewOptions{}
+	cmd := &cobra.Command{
+		Use:   "view [slug]",
+		Short: "Show workspace details",
+		Long:  `Show details for a workspace. Defaults to the active context's workspace.`,
+		Example: `  bkt workspace view
+  bkt workspace view acme --json
+  bkt workspace view acme --web`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug := ""
+			if len(args) > 0 {
+				slug = args[0]
+			}
+			return runView(cmd, f, slug, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Host, "host", "", "Host key or base URL override")
+	cmdutil.AddWebFlag(cmd, &opts.Web, "workspace")
+
+	return cmd
+}
+
+func runView(cmd *cobra.Command, f *cmdutil.Factory, slug string, opts *viewOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	var host *config.Host
+	slug = strings.TrimSpace(slug)
+	if slug == "" && opts.Host == "" {
+		slug, host, err = cmdutil.ResolveCloudWorkspace(f, cmd, "")
+	} else {
+		host, err = resolveCloudHost(f, cmd, opts.Host)
+		if err == nil && slug == "" {
+			err = fmt.Errorf("workspace slug is required with --host")
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 10*time.Second)
+	defer cancel()
+
+	ws, err := client.GetWorkspace(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	if opts.Web {
+		return cmdutil.OpenInBrowser(f, ios.Out, "workspace", ws.Links.HTML.Href)
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, ws, func() error {
+		visibility := "public"
+		if ws.IsPrivate {
+			visibility = "private"
+		}
+		if _, err := fmt.Fprintf(ios.Out, "%s (%s)\n", ws.Name, ws.Slug); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(ios.Out, "UUID: %s\nVisibility: %s\n", ws.UUID, visibility); err != nil {
+			return err
+		}
+		if ws.CreatedOn != "" {
+			if _, err := fmt.Fprintf(ios.Out, "Created: %s\n", ws.CreatedOn); err != nil {
+				return err
+			}
+		}
+		if ws.Links.HTML.Href != "" {
+			if _, err := fmt.Fprintf(ios.Out, "URL: %s\n", ws.Links.HTML.Href); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+var (
+	workspaceRoles  = []string{"owner", "collaborator", "member"}
+	repositoryRoles = []string{"admin", "write", "read"}
+)
+
+type membersOptions struct {
+	Workspace string
+	Repo      string
+	Roles     []string
+	Limit     int
+}
+
+func newMembersCmd(f *cmdutil.Factory) *cobra.Command {
+	opts := &membersOptions{}
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "List workspace members and their access",
+		Long: `List workspace members with their workspace role (owner, collaborator or
+member). With --repo, list effective repository access (admin, write or read)
+instead; pass --repo all to cover every repository in the workspace.`,
+		Example: `  bkt workspace members
+  bkt workspace members --role owner --json
+  bkt workspace members --repo api --role admin
+  bkt workspace members --repo all --role admin --role write`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMembers(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Bitbucket workspace override")
+	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Show repository permissions for this slug (or all)")
+	cmd.Flags().StringSliceVar(&opts.Roles, "role", nil, "Only members with this role (repeatable)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum entries to list (0 for all)")
+
+	return cmd
+}
+
+func runMembers(cmd *cobra.Command, f *cmdutil.Factory, opts *membersOptions) error {
+	ios, err := f.Streams()
+	if err != nil {
+		return err
+	}
+
+	valid := workspaceRoles
+	if opts.Repo != "" {
+		valid = repositoryRoles
+	}
+	roles := make([]string, 0, len(opts.Roles))
+	for _, role := range opts.Roles {
+		role = strings.ToLower(strings.TrimSpace(role))
+		if !slices.Contains(valid, role) {
+			return fmt.Errorf("invalid --role %q (valid: %s)", role, strings.Join(valid, ", "))
+		}
+		roles = append(roles, role)
+	}
+
+	workspace, host, err := cmdutil.ResolveCloudWorkspace(f, cmd, opts.Workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.NewCloudClient(host)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cmdutil.CommandContext(cmd, 30*time.Second)
+	defer cancel()
+
+	listOpts := bbcloud.PermissionListOptions{Permissions: roles, Limit: opts.Limit}
+
+	if opts.Repo != "" {
+		repoSlug := opts.Repo
+		if repoSlug == "all" {
+			repoSlug = ""
+		}
+
+		perms, err := client.ListRepositoryPermissions(ctx, workspace, repoSlug, listOpts)
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]any{
+			"workspace":   workspace,
+			"repo":        opts.Repo,
+			"permissions": perms,
+		}
+
+		return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+			if len(perms) == 0 {
+				_, err := fmt.Fprintln(ios.Out, "No matching permissions.")
+				return err
+			}
+
+			tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+			for _, p := range perms {
+				if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Repository.Name, p.User.DisplayName, p.User.AccountID, p.Permission); err != nil {
+					return err
+				}
+			}
+			return tw.Flush()
+		})
+	}
+
+	perms, err := client.ListWorkspacePermissions(ctx, workspace, listOpts)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"workspace": workspace,
+		"members":   perms,
+	}
+
+	return cmdutil.WriteOutput(cmd, ios.Out, payload, func() error {
+		if len(perms) == 0 {
+			_, err := fmt.Fprintf(ios.Out, "No matching members in %s.\n", workspace)
+			return err
+		}
+
+		tw := tabwriter.NewWriter(ios.Out, 0, 4, 2, ' ', 0)
+		for _, p := range perms {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", p.User.DisplayName, p.User.AccountID, p.Permission); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+// resolveCloudHost picks the Cloud host from --host, the active context or
+// the only configured host, so discovery works before a context exists.
+func resolveCloudHost(f *cmdutil.Factory, cmd *cobra.Command, hostOverride string) (*config.Host, error) {
+	_, host, err := cmdutil.ResolveHost(f, cmdutil.FlagValue(cmd, "context"), hostOverride)
+	if err != nil {
+		return nil, err
+	}
+	if host.Kind != "cloud" {
+		return nil, fmt.Errorf("workspaces are only available on Bitbucket Cloud hosts")
+	}
+	return host, nil
+}
@@ -0,0 +1,105 @@
+package workspace
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud/bbtest"
+	"github.com/alessandro308/bitbucket-cli/pkg/cmdutil"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func newTestFactory(t *testing.T, cfg *config.Config) (*cmdutil.Factory, *strings.Builder) {
+	t.Helper()
+	var stdout, stderr strings.Builder
+	f := &cmdutil.Factory{
+		AppVersion:     "test",
+		ExecutableName: "bkt",
+		IOStreams:      &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr},
+		Config: func() (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return f, &stdout
+}
+
+func execute(f *cmdutil.Factory, args ...string) error {
+	cmd := NewCmdWorkspace(f)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestListWithoutContext(t *testing.T) {
+	server := bbtest.NewServer(t)
+	server.JSON("GET /user/permissions/workspaces", http.StatusOK, `{"values":[{"permission":"owner","workspace":{"slug":"acme","name":"Acme Corp"}}]}`)
+
+	// Only a host is configured: listing must work before any context exists.
+	f, stdout := newTestFactory(t, &config.Config{
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	})
+	if err := execute(f, "list"); err != nil {
+		t.Fatalf("workspace list: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "acme") || !strings.Contains(out, "Acme Corp") || !strings.Contains(out, "owner") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestViewDefaultsToContextWorkspace(t *testing.T) {
+	server := bbtest.NewServer(t)
+	server.JSON("GET /workspaces/team", http.StatusOK, `{"uuid":"{1}","slug":"team","name":"Team","is_private":true,"links":{"html":{"href":"https://bitbucket.org/team/"}}}`)
+
+	f, stdout := newTestFactory(t, &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	})
+	if err := execute(f, "view"); err != nil {
+		t.Fatalf("workspace view: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "Team (team)") || !strings.Contains(out, "Visibility: private") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestMembersRoleFilter(t *testing.T) {
+	server := bbtest.NewServer(t)
+	server.JSON("GET /workspaces/team/permissions", http.StatusOK, `{"values":[{"permission":"owner","user":{"display_name":"Ada Lovelace","account_id":"557058:1"}}]}`)
+
+	f, stdout := newTestFactory(t, &config.Config{
+		ActiveContext: "default",
+		Contexts: map[string]*config.Context{
+			"default": {Host: "cloud", Workspace: "team"},
+		},
+		Hosts: map[string]*config.Host{
+			"cloud": {Kind: "cloud", BaseURL: server.URL, Username: "user", Token: "token"},
+		},
+	})
+	if err := execute(f, "members", "--role", "Owner"); err != nil {
+		t.Fatalf("workspace members: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Ada Lovelace") || !strings.Contains(stdout.String(), "owner") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+	if q := server.Requests()[0].Query.Get("q"); q != `permission="owner"` {
+		t.Fatalf("query = %q", q)
+	}
+
+	if err := execute(f, "members", "--repo", "api", "--role", "owner"); err == nil || !strings.Contains(err.Error(), "admin, write, read") {
+		t.Fatalf("expected repository role validation error, got %v", err)
+	}
+}
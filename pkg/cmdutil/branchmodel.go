@@ -0,0 +1,51 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+)
+
+// BranchModel is the host-agnostic view of a repository branching model.
+type BranchModel struct {
+	Development string `json:"development,omitempty"`
+	Production  string `json:"production,omitempty"`
+	// Prefixes maps lower-case branch kinds (feature, bugfix, release,
+	// hotfix) to their branch prefixes. Disabled kinds are left out.
+	Prefixes map[string]string `json:"prefixes,omitempty"`
+}
+
+// CloudBranchModel flattens a Bitbucket Cloud branching model, dropping a
+// disabled production branch.
+func CloudBranchModel(model *bbcloud.BranchingModel) BranchModel {
+	out := BranchModel{
+		Development: model.Development.BranchName(),
+		Prefixes:    make(map[string]string),
+	}
+	if model.Production != nil && (model.Production.Enabled == nil || *model.Production.Enabled) {
+		out.Production = model.Production.BranchName()
+	}
+	for _, t := range model.BranchTypes {
+		if t.Enabled != nil && !*t.Enabled {
+			continue
+		}
+		out.Prefixes[strings.ToLower(t.Kind)] = t.Prefix
+	}
+	return out
+}
+
+// DCBranchModel flattens a Bitbucket Data Center branch model.
+func DCBranchModel(model *bbdc.BranchModel) BranchModel {
+	out := BranchModel{Prefixes: make(map[string]string)}
+	if model.Development != nil {
+		out.Development = model.Development.DisplayID
+	}
+	if model.Production != nil {
+		out.Production = model.Production.DisplayID
+	}
+	for _, t := range model.Types {
+		out.Prefixes[strings.ToLower(t.ID)] = t.Prefix
+	}
+	return out
+}
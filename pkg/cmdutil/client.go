@@ -1,10 +1,17 @@
 package cmdutil
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/oauth"
+	"github.com/alessandro308/bitbucket-cli/internal/secret"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
 	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
 	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
@@ -19,10 +26,16 @@ func NewDCClient(host *config.Host) (*bbdc.Client, error) {
 		return nil, fmt.Errorf("host %q has no base URL configured", host.Kind)
 	}
 	opts := bbdc.Options{
-		BaseURL:     host.BaseURL,
-		Username:    host.Username,
-		Token:       host.Token,
-		EnableCache: true,
+		BaseURL:            host.BaseURL,
+		Username:           host.Username,
+		Token:              host.Token,
+		TokenSource:        bearerTokenSource(host),
+		Proxy:              host.Proxy,
+		CACertFile:         host.CACert,
+		InsecureSkipVerify: host.InsecureSkipVerify,
+		EnableCache:        true,
+		CacheDir:           httpCacheDir(),
+		CacheIdentity:      httpCacheIdentity(host),
 		Retry: httpx.RetryPolicy{
 			MaxAttempts:    4,
 			InitialBackoff: 250 * time.Millisecond,
@@ -40,11 +53,21 @@ func NewCloudClient(host *config.Host) (*bbcloud.Client, error) {
 	if host.BaseURL == "" {
 		host.BaseURL = "https://api.bitbucket.org/2.0"
 	}
+	tokens, err := cloudTokenSource(host)
+	if err != nil {
+		return nil, err
+	}
 	opts := bbcloud.Options{
-		BaseURL:     host.BaseURL,
-		Username:    host.Username,
-		Token:       host.Token,
-		EnableCache: true,
+		BaseURL:            host.BaseURL,
+		Username:           host.Username,
+		Token:              host.Token,
+		TokenSource:        tokens,
+		Proxy:              host.Proxy,
+		CACertFile:         host.CACert,
+		InsecureSkipVerify: host.InsecureSkipVerify,
+		EnableCache:        true,
+		CacheDir:           httpCacheDir(),
+		CacheIdentity:      httpCacheIdentity(host),
 		Retry: httpx.RetryPolicy{
 			MaxAttempts:    4,
 			InitialBackoff: 250 * time.Millisecond,
@@ -54,6 +77,91 @@ func NewCloudClient(host *config.Host) (*bbcloud.Client, error) {
 	return bbcloud.New(opts)
 }
 
+// httpCacheDir locates the on-disk HTTP response cache, or returns "" when
+// BKT_NO_HTTP_CACHE is set or no cache directory is available.
+func httpCacheDir() string {
+	if os.Getenv("BKT_NO_HTTP_CACHE") != "" {
+		return ""
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "bkt", "http")
+}
+
+// httpCacheIdentity names the account behind a host that authenticates
+// through a token source, so its cached responses survive token refreshes;
+// basic-auth hosts are keyed on their credentials by httpx itself.
+func httpCacheIdentity(host *config.Host) string {
+	switch host.AuthMethod {
+	case config.AuthMethodOAuth:
+		return strings.Join([]string{host.AuthMethod, host.BaseURL, host.Username, host.OAuthClientID}, "\x00")
+	case config.AuthMethodBearer:
+		return strings.Join([]string{host.AuthMethod, host.BaseURL, host.Token}, "\x00")
+	default:
+		return ""
+	}
+}
+
+// cloudTokenSource returns a refreshing token source for hosts that logged in
+// with OAuth, or nil for token-based hosts. Refreshed credentials are written
+// back to the secret store because Bitbucket rotates refresh tokens.
+func cloudTokenSource(host *config.Host) (httpx.TokenSource, error) {
+	if host.AuthMethod == config.AuthMethodBearer {
+		return bearerTokenSource(host), nil
+	}
+	if host.AuthMethod != config.AuthMethodOAuth {
+		return nil, nil
+	}
+
+	creds, err := oauth.DecodeCredentials(host.Token)
+	if err != nil {
+		return nil, fmt.Errorf("%w; run `bkt auth login --oauth` again", err)
+	}
+
+	hostKey, err := HostKeyFromURL(host.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := NewStdHTTPClient(host)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := oauth.Config{ClientID: host.OAuthClientID, ClientSecret: creds.ClientSecret, HTTPClient: httpClient}
+	return oauth.NewRefresher(cfg, creds.Token, func(tok *oauth.Token) error {
+		encoded, err := oauth.Credentials{ClientSecret: creds.ClientSecret, Token: *tok}.Encode()
+		if err != nil {
+			return err
+		}
+		host.Token = encoded
+
+		store, err := OpenCredentialStore(host.AllowInsecureStore)
+		if err != nil {
+			return err
+		}
+		return store.Set(secret.ProfileTokenKey(hostKey, host.Profile), encoded)
+	}), nil
+}
+
+// staticToken is a bearer token that is used as is.
+type staticToken string
+
+func (t staticToken) AccessToken(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// bearerTokenSource returns the token of a host authenticating with a raw
+// bearer token, or nil for any other host.
+func bearerTokenSource(host *config.Host) httpx.TokenSource {
+	if host.AuthMethod != config.AuthMethodBearer {
+		return nil
+	}
+	return staticToken(host.Token)
+}
+
 // NewHTTPClient constructs a raw HTTP client for the configured host.
 func NewHTTPClient(host *config.Host) (*httpx.Client, error) {
 	if host == nil {
@@ -77,3 +185,30 @@ func NewHTTPClient(host *config.Host) (*httpx.Client, error) {
 		return nil, fmt.Errorf("unsupported host kind %q", host.Kind)
 	}
 }
+
+// NewStdHTTPClient returns a plain *http.Client that uses the proxy and TLS
+// settings saved for host, for requests that do not go to its API such as
+// OAuth token exchanges and webhook deliveries.
+func NewStdHTTPClient(host *config.Host) (*http.Client, error) {
+	if host == nil {
+		return nil, fmt.Errorf("missing host configuration")
+	}
+	return httpx.NewStdClient(httpx.Options{
+		Proxy:              host.Proxy,
+		CACertFile:         host.CACert,
+		InsecureSkipVerify: host.InsecureSkipVerify,
+	})
+}
+
+// AccessToken returns the secret used to authenticate API calls for host,
+// refreshing OAuth access tokens when they have expired.
+func AccessToken(ctx context.Context, host *config.Host) (string, error) {
+	tokens, err := cloudTokenSource(host)
+	if err != nil {
+		return "", err
+	}
+	if tokens == nil {
+		return host.Token, nil
+	}
+	return tokens.AccessToken(ctx)
+}
@@ -0,0 +1,248 @@
+package cmdutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbcloud"
+	"github.com/alessandro308/bitbucket-cli/pkg/bbdc"
+)
+
+const (
+	// completionTimeout bounds the API call behind a single <TAB> press.
+	completionTimeout = 2 * time.Second
+	// completionCacheTTL keeps repeated <TAB> presses off the network.
+	completionCacheTTL = time.Minute
+	// completionLimit caps how many candidates are fetched.
+	completionLimit = 100
+)
+
+// CompletionFunc is the signature Cobra uses for argument and flag completion.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// CompletePullRequestIDs completes the first argument with open pull request
+// IDs of the command's repository, described by their titles.
+func CompletePullRequestIDs(f *Factory) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeRepoItems(f, cmd, "prs", toComplete, func(ctx context.Context, t completionTarget) ([]string, error) {
+			var items []string
+			switch t.host.Kind {
+			case "dc":
+				client, err := NewDCClient(t.host)
+				if err != nil {
+					return nil, err
+				}
+				prs, err := client.ListPullRequests(ctx, t.namespace, t.repo, "OPEN", completionLimit)
+				if err != nil {
+					return nil, err
+				}
+				for _, pr := range prs {
+					items = append(items, strconv.Itoa(pr.ID)+"\t"+pr.Title)
+				}
+			case "cloud":
+				client, err := NewCloudClient(t.host)
+				if err != nil {
+					return nil, err
+				}
+				prs, err := client.ListPullRequests(ctx, t.namespace, t.repo, bbcloud.PullRequestListOptions{State: "OPEN", Limit: completionLimit})
+				if err != nil {
+					return nil, err
+				}
+				for _, pr := range prs {
+					items = append(items, strconv.Itoa(pr.ID)+"\t"+pr.Title)
+				}
+			}
+			return items, nil
+		})
+	}
+}
+
+// CompleteBranches completes branch names of the command's repository.
+func CompleteBranches(f *Factory) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeRepoItems(f, cmd, "branches", toComplete, func(ctx context.Context, t completionTarget) ([]string, error) {
+			var items []string
+			switch t.host.Kind {
+			case "dc":
+				client, err := NewDCClient(t.host)
+				if err != nil {
+					return nil, err
+				}
+				branches, err := client.ListBranches(ctx, t.namespace, t.repo, bbdc.BranchListOptions{Limit: completionLimit})
+				if err != nil {
+					return nil, err
+				}
+				for _, b := range branches {
+					items = append(items, b.DisplayID)
+				}
+			case "cloud":
+				client, err := NewCloudClient(t.host)
+				if err != nil {
+					return nil, err
+				}
+				branches, err := client.ListBranches(ctx, t.namespace, t.repo, bbcloud.BranchListOptions{Limit: completionLimit})
+				if err != nil {
+					return nil, err
+				}
+				for _, b := range branches {
+					items = append(items, b.Name)
+				}
+			}
+			return items, nil
+		})
+	}
+}
+
+// CompleteRepos completes repository slugs in the command's project (Data
+// Center) or workspace (Cloud).
+func CompleteRepos(f *Factory) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		t, ok := resolveCompletionTarget(f, cmd)
+		if !ok || t.namespace == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		t.repo = ""
+		items := cachedCompletions(t.cacheKey("repos"), func(ctx context.Context) ([]string, error) {
+			var items []string
+			switch t.host.Kind {
+			case "dc":
+				client, err := NewDCClient(t.host)
+				if err != nil {
+					return nil, err
+				}
+				repos, err := client.ListRepositories(ctx, t.namespace, completionLimit)
+				if err != nil {
+					return nil, err
+				}
+				for _, r := range repos {
+					items = append(items, r.Slug)
+				}
+			case "cloud":
+				client, err := NewCloudClient(t.host)
+				if err != nil {
+					return nil, err
+				}
+				repos, err := client.ListRepositories(ctx, t.namespace, bbcloud.ListRepositoriesOptions{Limit: completionLimit})
+				if err != nil {
+					return nil, err
+				}
+				for _, r := range repos {
+					items = append(items, r.Slug)
+				}
+			}
+			return items, nil
+		})
+		return filterCompletions(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionTarget is the repository a completion request refers to.
+type completionTarget struct {
+	host      *config.Host
+	namespace string // project key (dc) or workspace (cloud)
+	repo      string
+}
+
+func (t completionTarget) cacheKey(kind string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{t.host.BaseURL, t.host.Username, t.host.Profile, t.namespace, t.repo, kind}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func resolveCompletionTarget(f *Factory, cmd *cobra.Command) (completionTarget, bool) {
+	_, ctxCfg, host, err := ResolveContext(f, cmd, FlagValue(cmd, "context"))
+	if err != nil {
+		return completionTarget{}, false
+	}
+	t := completionTarget{host: host, repo: FirstNonEmpty(FlagValue(cmd, "repo"), ctxCfg.DefaultRepo)}
+	switch host.Kind {
+	case "dc":
+		t.namespace = FirstNonEmpty(FlagValue(cmd, "project"), ctxCfg.ProjectKey)
+	case "cloud":
+		t.namespace = FirstNonEmpty(FlagValue(cmd, "workspace"), ctxCfg.Workspace)
+	default:
+		return completionTarget{}, false
+	}
+	return t, true
+}
+
+func completeRepoItems(f *Factory, cmd *cobra.Command, kind, toComplete string, fetch func(context.Context, completionTarget) ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	t, ok := resolveCompletionTarget(f, cmd)
+	if !ok || t.namespace == "" || t.repo == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	items := cachedCompletions(t.cacheKey(kind), func(ctx context.Context) ([]string, error) {
+		return fetch(ctx, t)
+	})
+	return filterCompletions(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func filterCompletions(items []string, prefix string) []string {
+	if prefix == "" {
+		return items
+	}
+	var out []string
+	for _, item := range items {
+		if strings.HasPrefix(item, prefix) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+type completionCacheEntry struct {
+	Created time.Time `json:"created"`
+	Items   []string  `json:"items"`
+}
+
+// cachedCompletions returns fresh cached candidates for key, or calls fetch
+// under completionTimeout and caches its result. Failures yield no
+// candidates: completion must never print errors into the user's shell.
+func cachedCompletions(key string, fetch func(context.Context) ([]string, error)) []string {
+	path, pathErr := completionCachePath(key)
+	if pathErr == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			var entry completionCacheEntry
+			if json.Unmarshal(data, &entry) == nil && time.Since(entry.Created) < completionCacheTTL {
+				return entry.Items
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	items, err := fetch(ctx)
+	if err != nil {
+		return nil
+	}
+
+	if pathErr == nil {
+		if data, err := json.Marshal(completionCacheEntry{Created: time.Now(), Items: items}); err == nil {
+			if err := os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+				_ = os.WriteFile(path, data, 0o600)
+			}
+		}
+	}
+	return items
+}
+
+func completionCachePath(key string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve cache directory: %w", err)
+	}
+	return filepath.Join(dir, "bkt", "completion", key+".json"), nil
+}
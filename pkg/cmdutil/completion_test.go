@@ -0,0 +1,64 @@
+package cmdutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+)
+
+func TestCompletePullRequestIDsCachesResults(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path != "/rest/api/1.0/projects/ABC/repos/api/pull-requests" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":7,"title":"Fix login"},{"id":12,"title":"Add search"}],"isLastPage":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		ActiveContext: "work",
+		Contexts: map[string]*config.Context{
+			"work": {Host: "main", ProjectKey: "ABC", DefaultRepo: "api"},
+		},
+		Hosts: map[string]*config.Host{
+			"main": {Kind: "dc", BaseURL: server.URL, Token: "token"},
+		},
+	}
+	f := newTestFactory(cfg)
+	cmd := &cobra.Command{Use: "view"}
+	cmd.Flags().String("repo", "", "")
+	complete := CompletePullRequestIDs(f)
+
+	got, directive := complete(cmd, nil, "")
+	want := []string{"7\tFix login", "12\tAdd search"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("completions = %q, want %q", got, want)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Fatalf("directive = %v", directive)
+	}
+
+	got, _ = complete(cmd, nil, "1")
+	if !reflect.DeepEqual(got, []string{"12\tAdd search"}) {
+		t.Fatalf("filtered completions = %q", got)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Fatalf("expected cached second lookup, server hit %d times", n)
+	}
+
+	if got, _ := complete(cmd, []string{"7"}, ""); got != nil {
+		t.Fatalf("expected no completions after the ID, got %q", got)
+	}
+}
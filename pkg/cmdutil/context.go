@@ -27,11 +27,32 @@ func ResolveContext(f *Factory, cmd *cobra.Command, override string) (string, *c
 
 	contextName := override
 	if contextName == "" {
+		hostKey, host, ok, err := EnvHost(cfg)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if ok {
+			return resolveEnvContext(f, cfg, hostKey, host)
+		}
 		contextName = cfg.ActiveContext
 	}
 
 	if contextName == "" {
-		return "", nil, nil, fmt.Errorf("no active context; run `%s context use <name>`", f.ExecutableName)
+		if hostKey, ctx, ok := gitContext(cfg); ok {
+			host, err := resolveIdentity(f, hostKey, cfg.Hosts[hostKey], "")
+			if err != nil {
+				return "", nil, nil, err
+			}
+			return "", finishContext(f, cfg, ctx, host), host, nil
+		}
+		if f.RepoOverride != nil && f.RepoOverride.Namespace != "" {
+			hostKey, host, err := ResolveHost(f, "", "")
+			if err != nil {
+				return "", nil, nil, err
+			}
+			return "", finishContext(f, cfg, &config.Context{Host: hostKey}, host), host, nil
+		}
+		return "", nil, nil, fmt.Errorf("no active context; run `%s context use <name>`, pass --repo WORKSPACE/SLUG or run inside a clone of a Bitbucket repository", f.ExecutableName)
 	}
 
 	ctx, err := cfg.Context(contextName)
@@ -48,13 +69,38 @@ func ResolveContext(f *Factory, cmd *cobra.Command, override string) (string, *c
 		return "", nil, nil, err
 	}
 
-	if err := loadHostToken(f.ExecutableName, ctx.Host, host); err != nil {
+	host, err = resolveIdentity(f, ctx.Host, host, ctx.Profile)
+	if err != nil {
 		return "", nil, nil, err
 	}
 
-	applyRemoteDefaults(ctx, host)
+	return contextName, finishContext(f, cfg, ctx, host), host, nil
+}
 
-	return contextName, ctx, host, nil
+// resolveEnvContext builds the context for the host named by BKT_HOST. The
+// active context contributes its defaults when it targets the same host.
+func resolveEnvContext(f *Factory, cfg *config.Config, hostKey string, host *config.Host) (string, *config.Context, *config.Host, error) {
+	ctx := &config.Context{Host: hostKey}
+	if active, err := cfg.Context(cfg.ActiveContext); err == nil && active.Host == hostKey {
+		ctx = active
+	}
+
+	host, err := resolveIdentity(f, hostKey, host, ctx.Profile)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return "", finishContext(f, cfg, ctx, host), host, nil
+}
+
+// finishContext applies the overrides shared by every way of resolving a
+// context to a copy of ctx: BITBUCKET_WORKSPACE, then the git remote, then
+// --repo, each winning over the last. Settings fill keys still empty.
+func finishContext(f *Factory, cfg *config.Config, ctx *config.Context, host *config.Host) *config.Context {
+	resolved := *ctx
+	applyEnvWorkspace(&resolved, host)
+	applyRemoteDefaults(&resolved, host)
+	return applyRepoOverride(f, applySettingDefaults(cfg, &resolved, host), host)
 }
 
 // ResolveHost locates a host configuration using optional context or host overrides.
@@ -69,7 +115,8 @@ func ResolveHost(f *Factory, contextOverride, hostOverride string) (string, *con
 	hostIdentifier := strings.TrimSpace(hostOverride)
 	if hostIdentifier != "" {
 		if host, ok := cfg.Hosts[hostIdentifier]; ok {
-			if err := loadHostToken(f.ExecutableName, hostIdentifier, host); err != nil {
+			host, err := resolveIdentity(f, hostIdentifier, host, "")
+			if err != nil {
 				return "", nil, err
 			}
 			return hostIdentifier, host, nil
@@ -79,7 +126,8 @@ func ResolveHost(f *Factory, contextOverride, hostOverride string) (string, *con
 		if err == nil {
 			if key, err := HostKeyFromURL(baseURL); err == nil {
 				if host, ok := cfg.Hosts[key]; ok {
-					if err := loadHostToken(f.ExecutableName, key, host); err != nil {
+					host, err := resolveIdentity(f, key, host, "")
+					if err != nil {
 						return "", nil, err
 					}
 					return key, host, nil
@@ -87,11 +135,30 @@ func ResolveHost(f *Factory, contextOverride, hostOverride string) (string, *con
 			}
 		}
 
+		if key, host, ok, err := EnvHost(cfg); err == nil && ok && key == hostIdentifier {
+			host, err := resolveIdentity(f, key, host, "")
+			if err != nil {
+				return "", nil, err
+			}
+			return key, host, nil
+		}
+
 		return "", nil, fmt.Errorf("host %q not found; run `%s auth login` first", hostIdentifier, f.ExecutableName)
 	}
 
 	contextName := strings.TrimSpace(contextOverride)
 	if contextName == "" {
+		hostKey, host, ok, err := EnvHost(cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		if ok {
+			host, err = resolveIdentity(f, hostKey, host, "")
+			if err != nil {
+				return "", nil, err
+			}
+			return hostKey, host, nil
+		}
 		contextName = cfg.ActiveContext
 	}
 	if contextName != "" {
@@ -106,18 +173,28 @@ func ResolveHost(f *Factory, contextOverride, hostOverride string) (string, *con
 		if err != nil {
 			return "", nil, err
 		}
-		if err := loadHostToken(f.ExecutableName, ctx.Host, host); err != nil {
+		host, err = resolveIdentity(f, ctx.Host, host, ctx.Profile)
+		if err != nil {
 			return "", nil, err
 		}
 		return ctx.Host, host, nil
 	}
 
+	if hostKey, _, ok := gitContext(cfg); ok {
+		host, err := resolveIdentity(f, hostKey, cfg.Hosts[hostKey], "")
+		if err != nil {
+			return "", nil, err
+		}
+		return hostKey, host, nil
+	}
+
 	switch len(cfg.Hosts) {
 	case 0:
-		return "", nil, fmt.Errorf("no hosts configured; run `%s auth login` first", f.ExecutableName)
+		return "", nil, fmt.Errorf("no hosts configured; run `%s auth login` first or set %s and %s", f.ExecutableName, HostEnv, TokenEnv)
 	case 1:
 		for key, host := range cfg.Hosts {
-			if err := loadHostToken(f.ExecutableName, key, host); err != nil {
+			host, err := resolveIdentity(f, key, host, "")
+			if err != nil {
 				return "", nil, err
 			}
 			return key, host, nil
@@ -143,6 +220,35 @@ func FlagValue(cmd *cobra.Command, name string) string {
 	return flag.Value.String()
 }
 
+// resolveIdentity applies the selected credential profile to a copy of host
+// and loads its token. The --profile flag wins over the context's pinned
+// profile, which wins over the host's active profile; BKT_TOKEN wins over
+// them all.
+func resolveIdentity(f *Factory, hostKey string, host *config.Host, contextProfile string) (*config.Host, error) {
+	if host == nil {
+		return nil, fmt.Errorf("host %q not configured", hostKey)
+	}
+
+	name := FirstNonEmpty(strings.TrimSpace(f.Profile), contextProfile)
+	resolved, err := host.WithProfile(name)
+	if err != nil {
+		if errors.Is(err, config.ErrProfileNotFound) {
+			return nil, fmt.Errorf("%w for host %s; run `%s auth login --profile %s`", err, hostKey, f.ExecutableName, FirstNonEmpty(name, host.ActiveProfile))
+		}
+		return nil, err
+	}
+
+	if ok, err := applyEnvCredentials(hostKey, resolved); err != nil {
+		return nil, err
+	} else if ok {
+		return resolved, nil
+	}
+	if err := loadHostToken(f.ExecutableName, hostKey, resolved); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
 func loadHostToken(executable, hostKey string, host *config.Host) error {
 	if host == nil {
 		return fmt.Errorf("host %q not configured", hostKey)
@@ -152,12 +258,7 @@ func loadHostToken(executable, hostKey string, host *config.Host) error {
 		return nil
 	}
 
-	opts := []secret.Option{}
-	if host.AllowInsecureStore {
-		opts = append(opts, secret.WithAllowFileFallback(true))
-	}
-
-	store, err := secret.Open(opts...)
+	store, err := OpenCredentialStore(host.AllowInsecureStore)
 	if err != nil {
 		if secret.IsNoKeyringError(err) {
 			return fmt.Errorf("no OS keychain backend available for host %q; rerun `%s auth login %s --allow-insecure-store` or set BKT_ALLOW_INSECURE_STORE=1: %w", hostKey, executable, hostKey, err)
@@ -165,13 +266,16 @@ func loadHostToken(executable, hostKey string, host *config.Host) error {
 		return err
 	}
 
-	token, err := store.Get(secret.TokenKey(hostKey))
+	token, err := store.Get(secret.ProfileTokenKey(hostKey, host.Profile))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			target := host.BaseURL
 			if target == "" {
 				target = hostKey
 			}
+			if host.Profile != "" {
+				return fmt.Errorf("credentials for profile %q on host %q not found; run `%s auth login %s --profile %s`", host.Profile, hostKey, executable, target, host.Profile)
+			}
 			return fmt.Errorf("credentials for host %q not found; run `%s auth login %s`", hostKey, executable, target)
 		}
 		return err
@@ -186,16 +290,8 @@ func applyRemoteDefaults(ctx *config.Context, host *config.Host) {
 		return
 	}
 
-	wd, err := os.Getwd()
-	if err != nil {
-		return
-	}
-
-	loc, err := remote.Detect(wd)
-	if err != nil {
-		return
-	}
-	if !locatorMatchesHost(host, loc) {
+	loc, ok := remoteForHost(host)
+	if !ok {
 		return
 	}
 
@@ -212,6 +308,27 @@ func applyRemoteDefaults(ctx *config.Context, host *config.Host) {
 	}
 }
 
+// applySettingDefaults fills a workspace or project key the context leaves
+// empty from the workspace and project settings of the config layers. The
+// stored context is left untouched.
+func applySettingDefaults(cfg *config.Config, ctx *config.Context, host *config.Host) *config.Context {
+	switch {
+	case host.Kind == "cloud" && ctx.Workspace == "":
+		if workspace := cfg.Setting("workspace"); workspace != "" {
+			out := *ctx
+			out.Workspace = workspace
+			return &out
+		}
+	case host.Kind == "dc" && ctx.ProjectKey == "":
+		if project := cfg.Setting("project"); project != "" {
+			out := *ctx
+			out.ProjectKey = project
+			return &out
+		}
+	}
+	return ctx
+}
+
 func locatorMatchesHost(host *config.Host, loc remote.Locator) bool {
 	if host == nil {
 		return false
@@ -274,7 +391,35 @@ func ResolveCloudRepo(f *Factory, cmd *cobra.Command, workspaceOverride, repoOve
 	workspace := FirstNonEmpty(workspaceOverride, ctxCfg.Workspace)
 	repo := FirstNonEmpty(repoOverride, ctxCfg.DefaultRepo)
 	if workspace == "" || repo == "" {
-		return "", "", nil, fmt.Errorf("context must supply workspace and repo; use --workspace/--repo if needed")
+		return "", "", nil, errNoRepository(host)
 	}
 	return workspace, repo, host, nil
 }
+
+// ResolveCloudWorkspace resolves the workspace for workspace-level Cloud commands.
+func ResolveCloudWorkspace(f *Factory, cmd *cobra.Command, workspaceOverride string) (string, *config.Host, error) {
+	_, ctxCfg, host, err := ResolveContext(f, cmd, FlagValue(cmd, "context"))
+	if err != nil {
+		return "", nil, err
+	}
+	if host.Kind != "cloud" {
+		return "", nil, fmt.Errorf("command supports Bitbucket Cloud contexts only")
+	}
+	workspace := FirstNonEmpty(workspaceOverride, ctxCfg.Workspace)
+	if workspace == "" {
+		return "", nil, fmt.Errorf("context must supply workspace; use --workspace if needed")
+	}
+	return workspace, host, nil
+}
+
+// IsBuiltinCommand reports whether name is a top-level command (or command
+// alias) of root, including the help and completion commands Cobra adds at
+// execution time.
+func IsBuiltinCommand(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
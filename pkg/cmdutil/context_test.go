@@ -1,12 +1,14 @@
 package cmdutil
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"strings"
 	"testing"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/secret"
 )
 
 func newTestFactory(cfg *config.Config) *Factory {
@@ -205,6 +207,51 @@ func TestResolveContextOverridesProjectFromRemoteSSH(t *testing.T) {
 	}
 }
 
+func TestResolveContextFromGitRemoteWithoutContext(t *testing.T) {
+	// origin points at another forge; the Bitbucket remote must still win.
+	repoDir := initGitRepo(t, "git@github.com:someone/mirror.git")
+	runGit(t, repoDir, "remote", "add", "bb", "git@bitbucket.org:acme/widgets.git")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Chdir failed: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	cfg := &config.Config{
+		Contexts: map[string]*config.Context{},
+		Hosts: map[string]*config.Host{
+			"bitbucket.example.com": {Kind: "dc", BaseURL: "https://bitbucket.example.com", Token: "dc-token"},
+			"api.bitbucket.org":     {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Token: "cloud-token"},
+		},
+	}
+	f := newTestFactory(cfg)
+
+	_, ctx, host, err := ResolveContext(f, nil, "")
+	if err != nil {
+		t.Fatalf("ResolveContext error: %v", err)
+	}
+	if host.Kind != "cloud" || ctx.Host != "api.bitbucket.org" {
+		t.Fatalf("resolved host %q (%s), want api.bitbucket.org", ctx.Host, host.Kind)
+	}
+	if ctx.Workspace != "acme" || ctx.DefaultRepo != "widgets" {
+		t.Fatalf("workspace/repo = %q/%q, want acme/widgets", ctx.Workspace, ctx.DefaultRepo)
+	}
+
+	key, _, err := ResolveHost(f, "", "")
+	if err != nil {
+		t.Fatalf("ResolveHost error: %v", err)
+	}
+	if key != "api.bitbucket.org" {
+		t.Fatalf("ResolveHost key = %q, want api.bitbucket.org", key)
+	}
+}
+
 func initGitRepo(t *testing.T, remoteURL string) string {
 	t.Helper()
 
@@ -229,3 +276,200 @@ func runGit(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %v failed: %v\n%s", args, err, output)
 	}
 }
+
+func TestResolveHostSelectsProfile(t *testing.T) {
+	t.Setenv("KEYRING_BACKEND", "file")
+	t.Setenv("KEYRING_FILE_DIR", t.TempDir())
+	t.Setenv("BKT_KEYRING_PASSPHRASE", "test")
+	t.Setenv("BKT_ALLOW_INSECURE_STORE", "1")
+
+	store, err := secret.Open()
+	if err != nil {
+		t.Fatalf("open secret store: %v", err)
+	}
+	if err := store.Set(secret.ProfileTokenKey("api.bitbucket.org", "work"), "work-token"); err != nil {
+		t.Fatalf("store profile token: %v", err)
+	}
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			ActiveContext: "personal",
+			Contexts: map[string]*config.Context{
+				"personal": {Host: "api.bitbucket.org", Workspace: "me"},
+				"acme":     {Host: "api.bitbucket.org", Workspace: "acme", Profile: "work"},
+			},
+			Hosts: map[string]*config.Host{
+				"api.bitbucket.org": {
+					Kind:     "cloud",
+					BaseURL:  "https://api.bitbucket.org/2.0",
+					Username: "me@example.com",
+					Token:    "personal-token",
+					Profiles: map[string]*config.Profile{
+						"work": {Username: "me@acme.example"},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		profile   string
+		context   string
+		wantUser  string
+		wantToken string
+	}{
+		{name: "default identity", wantUser: "me@example.com", wantToken: "personal-token"},
+		{name: "profile flag", profile: "work", wantUser: "me@acme.example", wantToken: "work-token"},
+		{name: "context pinned profile", context: "acme", wantUser: "me@acme.example", wantToken: "work-token"},
+		{name: "flag overrides context", profile: "default", context: "acme", wantUser: "me@example.com", wantToken: "personal-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			f := newTestFactory(cfg)
+			f.Profile = tt.profile
+
+			_, host, err := ResolveHost(f, tt.context, "")
+			if err != nil {
+				t.Fatalf("ResolveHost: %v", err)
+			}
+			if host.Username != tt.wantUser || host.Token != tt.wantToken {
+				t.Fatalf("got %s/%s, want %s/%s", host.Username, host.Token, tt.wantUser, tt.wantToken)
+			}
+			if cfg.Hosts["api.bitbucket.org"].Username != "me@example.com" {
+				t.Fatal("profile selection must not modify the stored host")
+			}
+		})
+	}
+
+	f := newTestFactory(newConfig())
+	f.Profile = "missing"
+	if _, _, err := ResolveHost(f, "", ""); err == nil || !strings.Contains(err.Error(), "auth login --profile missing") {
+		t.Fatalf("expected missing profile hint, got %v", err)
+	}
+}
+
+func TestResolveContextFillsWorkspaceFromSettings(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	stored := &config.Context{Host: "bitbucket.org", DefaultRepo: "api"}
+	cfg := &config.Config{
+		ActiveContext: "cloud",
+		Workspace:     "acme",
+		Project:       "IGNORED",
+		Contexts:      map[string]*config.Context{"cloud": stored},
+		Hosts: map[string]*config.Host{
+			"bitbucket.org": {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Token: "test-token"},
+		},
+	}
+
+	_, ctx, _, err := ResolveContext(newTestFactory(cfg), nil, "")
+	if err != nil {
+		t.Fatalf("ResolveContext error: %v", err)
+	}
+	if ctx.Workspace != "acme" || ctx.ProjectKey != "" {
+		t.Fatalf("context = %+v, want workspace from settings only", ctx)
+	}
+	if stored.Workspace != "" {
+		t.Fatalf("stored context modified: %+v", stored)
+	}
+
+	stored.Workspace = "own"
+	if _, ctx, _, err = ResolveContext(newTestFactory(cfg), nil, ""); err != nil || ctx.Workspace != "own" {
+		t.Fatalf("context workspace = %q, %v; want the context's own", ctx.Workspace, err)
+	}
+}
+
+func TestResolveContextFromEnvironment(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(HostEnv, "bitbucket.org")
+	t.Setenv(TokenEnv, "env-token")
+	t.Setenv(UsernameEnv, "ci@example.com")
+	t.Setenv(WorkspaceEnv, "acme")
+
+	_, ctx, host, err := ResolveContext(newTestFactory(&config.Config{}), nil, "")
+	if err != nil {
+		t.Fatalf("ResolveContext error: %v", err)
+	}
+	if host.Kind != "cloud" || host.BaseURL != "https://api.bitbucket.org/2.0" {
+		t.Fatalf("host = %+v, want Cloud", host)
+	}
+	if host.Token != "env-token" || host.Username != "ci@example.com" {
+		t.Fatalf("credentials = %q/%q, want environment values", host.Username, host.Token)
+	}
+	if ctx.Host != "api.bitbucket.org" || ctx.Workspace != "acme" {
+		t.Fatalf("context = %+v", ctx)
+	}
+}
+
+func TestEnvironmentAuthMethod(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(HostEnv, "bitbucket.org")
+	t.Setenv(TokenEnv, "access")
+
+	t.Setenv(AuthMethodEnv, "bearer")
+	_, host, err := ResolveHost(newTestFactory(&config.Config{}), "", "")
+	if err != nil || host.AuthMethod != config.AuthMethodBearer {
+		t.Fatalf("host = %+v, %v; want a bearer token", host, err)
+	}
+	if token, err := AccessToken(context.Background(), host); err != nil || token != "access" {
+		t.Fatalf("AccessToken = %q, %v", token, err)
+	}
+
+	t.Setenv(AuthMethodEnv, "kerberos")
+	if _, _, err := ResolveHost(newTestFactory(&config.Config{}), "", ""); err == nil || !strings.Contains(err.Error(), AuthMethodEnv) {
+		t.Fatalf("error = %v, want invalid %s", err, AuthMethodEnv)
+	}
+}
+
+func TestEnvironmentOverridesConfiguredHost(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	stored := &config.Context{Host: "bitbucket.example.com", ProjectKey: "DEV", DefaultRepo: "api"}
+	cfg := &config.Config{
+		ActiveContext: "dev",
+		Contexts:      map[string]*config.Context{"dev": stored},
+		Hosts: map[string]*config.Host{
+			"bitbucket.example.com": {
+				Kind:          "dc",
+				BaseURL:       "https://bitbucket.example.com",
+				Username:      "alice",
+				Token:         "stored-token",
+				AuthMethod:    config.AuthMethodOAuth,
+				OAuthClientID: "client",
+			},
+			"api.bitbucket.org": {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Token: "cloud-token"},
+		},
+	}
+
+	t.Setenv(TokenEnv, "env-token")
+	_, ctx, host, err := ResolveContext(newTestFactory(cfg), nil, "")
+	if err != nil {
+		t.Fatalf("ResolveContext error: %v", err)
+	}
+	if host.Token != "env-token" || host.AuthMethod != "" || host.Username != "alice" {
+		t.Fatalf("host = %+v, want the environment token", host)
+	}
+	if ctx.ProjectKey != "DEV" || ctx.DefaultRepo != "api" {
+		t.Fatalf("context = %+v, want the active context", ctx)
+	}
+	if cfg.Hosts["bitbucket.example.com"].Token != "stored-token" {
+		t.Fatal("configured host modified")
+	}
+
+	// With BKT_HOST the token only applies to that host.
+	t.Setenv(HostEnv, "https://bitbucket.example.com")
+	_, host, err = ResolveHost(newTestFactory(cfg), "", "api.bitbucket.org")
+	if err != nil || host.Token != "cloud-token" {
+		t.Fatalf("other host = %+v, %v; want its stored token", host, err)
+	}
+	_, ctx, host, err = ResolveContext(newTestFactory(cfg), nil, "")
+	if err != nil {
+		t.Fatalf("ResolveContext with %s: %v", HostEnv, err)
+	}
+	if host.Token != "env-token" || ctx.ProjectKey != "DEV" {
+		t.Fatalf("host %+v, context %+v", host, ctx)
+	}
+}
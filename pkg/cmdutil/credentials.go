@@ -0,0 +1,39 @@
+package cmdutil
+
+import (
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/secret"
+)
+
+// OpenCredentialStore returns the credential backend for a host: the OS
+// keyring, or the encrypted file backend when insecure storage is allowed.
+func OpenCredentialStore(allowInsecure bool) (config.CredentialStore, error) {
+	var opts []secret.Option
+	if allowInsecure {
+		opts = append(opts, secret.WithAllowFileFallback(true))
+	}
+	return secret.Open(opts...)
+}
+
+// MigrateLegacyCredentials moves tokens that older releases wrote into
+// config.yml to the credential store and rewrites the config without them. It
+// returns the migrated host keys; config.yml is left untouched on error.
+func MigrateLegacyCredentials(cfg *config.Config) ([]string, error) {
+	hostKeys := cfg.LegacyTokenHosts()
+	if len(hostKeys) == 0 {
+		return nil, nil
+	}
+
+	allowInsecure := false
+	for _, key := range hostKeys {
+		if cfg.Hosts[key].AllowInsecureStore {
+			allowInsecure = true
+		}
+	}
+
+	store, err := OpenCredentialStore(allowInsecure)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.MigrateCredentials(store, secret.TokenKey)
+}
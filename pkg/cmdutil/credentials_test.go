@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+)
+
+func TestMigrateLegacyCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("BKT_CONFIG_DIR", dir)
+	t.Setenv("KEYRING_BACKEND", "file")
+	t.Setenv("BKT_ALLOW_INSECURE_STORE", "1")
+	t.Setenv("BKT_KEYRING_PASSPHRASE", "test")
+
+	legacy := "version: 1\nhosts:\n  api.bitbucket.org:\n    kind: cloud\n    base_url: https://api.bitbucket.org/2.0\n    token: old\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yml"), []byte(legacy), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	migrated, err := MigrateLegacyCredentials(cfg)
+	if err != nil {
+		t.Fatalf("MigrateLegacyCredentials: %v", err)
+	}
+	if len(migrated) != 1 || migrated[0] != "api.bitbucket.org" {
+		t.Fatalf("migrated = %v", migrated)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "config.yml")); strings.Contains(string(data), "old") {
+		t.Fatalf("config.yml still contains the token:\n%s", data)
+	}
+
+	store, _ := OpenCredentialStore(false)
+	if got, err := store.Get("host/api.bitbucket.org/token"); err != nil || got != "old" {
+		t.Fatalf("migrated token = %q, %v", got, err)
+	}
+
+	// The in-memory host keeps working for the current run.
+	f := newTestFactory(cfg)
+	if _, host, err := ResolveHost(f, "", ""); err != nil || host.Token != "old" {
+		t.Fatalf("ResolveHost after migration: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+)
+
+// Environment variables that let CI jobs and containers use bkt without a
+// config file. They take precedence over config.yml and the credential store;
+// BKT_CONFIG_DIR, which relocates the config directory, is read by the config
+// package itself.
+const (
+	// HostEnv names the Bitbucket instance to use instead of the active
+	// context, e.g. bitbucket.org or https://bitbucket.mycorp.example.
+	HostEnv = "BKT_HOST"
+	// TokenEnv supplies the token, replacing the stored one. With BKT_HOST
+	// set it applies to that host only.
+	TokenEnv = "BKT_TOKEN"
+	// UsernameEnv supplies the username (the account email on Cloud) paired
+	// with the token.
+	UsernameEnv = "BKT_USERNAME"
+	// AuthMethodEnv says how BKT_TOKEN authenticates: "token" (the default)
+	// sends it with BKT_USERNAME as Basic auth, "bearer" sends it as a bearer
+	// token, as OAuth access tokens require.
+	AuthMethodEnv = "BKT_AUTH_METHOD"
+	// WorkspaceEnv overrides the context's Cloud workspace. Bitbucket
+	// Pipelines sets it for every build.
+	WorkspaceEnv = "BITBUCKET_WORKSPACE"
+)
+
+// EnvHost returns the host named by BKT_HOST: the configured entry when one
+// matches, else a host built from the URL, treating bitbucket.org as Cloud
+// and anything else as Data Center. ok is false when BKT_HOST is unset.
+func EnvHost(cfg *config.Config) (key string, host *config.Host, ok bool, err error) {
+	raw := strings.TrimSpace(os.Getenv(HostEnv))
+	if raw == "" {
+		return "", nil, false, nil
+	}
+
+	baseURL, err := NormalizeBaseURL(raw)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("invalid %s %q: %w", HostEnv, raw, err)
+	}
+	key, err = HostKeyFromURL(baseURL)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("invalid %s %q: %w", HostEnv, raw, err)
+	}
+
+	kind := "dc"
+	if hostname := strings.ToLower(strings.Split(key, ":")[0]); hostname == "bitbucket.org" || hostname == "api.bitbucket.org" {
+		kind, key, baseURL = "cloud", "api.bitbucket.org", "https://api.bitbucket.org/2.0"
+	}
+
+	if configured, found := cfg.Hosts[key]; found && configured != nil {
+		return key, configured, true, nil
+	}
+	return key, &config.Host{Kind: kind, BaseURL: baseURL}, true, nil
+}
+
+// applyEnvCredentials replaces the resolved host's credentials with BKT_TOKEN,
+// BKT_USERNAME and BKT_AUTH_METHOD. It reports whether a token was applied so
+// the credential store need not be consulted.
+func applyEnvCredentials(hostKey string, host *config.Host) (bool, error) {
+	token := strings.TrimSpace(os.Getenv(TokenEnv))
+	if token == "" {
+		return false, nil
+	}
+	if key, _, ok, err := EnvHost(&config.Config{}); err != nil || (ok && key != hostKey) {
+		return false, nil
+	}
+
+	// An environment token is always a plain secret, never the encoded OAuth
+	// credentials a stored token may hold.
+	switch method := strings.ToLower(strings.TrimSpace(os.Getenv(AuthMethodEnv))); method {
+	case "", "token":
+		host.AuthMethod = ""
+	case config.AuthMethodBearer:
+		host.AuthMethod = config.AuthMethodBearer
+	default:
+		return false, fmt.Errorf("invalid %s %q: use token or bearer", AuthMethodEnv, method)
+	}
+
+	host.Token = token
+	if username := strings.TrimSpace(os.Getenv(UsernameEnv)); username != "" {
+		host.Username = username
+	}
+	return true, nil
+}
+
+// applyEnvWorkspace overrides the Cloud workspace of a resolved context with
+// BITBUCKET_WORKSPACE.
+func applyEnvWorkspace(ctx *config.Context, host *config.Host) {
+	if host.Kind != "cloud" {
+		return
+	}
+	if workspace := strings.TrimSpace(os.Getenv(WorkspaceEnv)); workspace != "" {
+		ctx.Workspace = workspace
+	}
+}
@@ -1,10 +1,13 @@
 package cmdutil
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
 )
 
 var (
@@ -31,3 +34,21 @@ func (e *ExitError) Error() string {
 func NotImplemented(cmd *cobra.Command) error {
 	return fmt.Errorf("%s not yet implemented", cmd.CommandPath())
 }
+
+// ErrorHint suggests a next step for well-known API failures, or returns ""
+// when err carries no actionable kind.
+func ErrorHint(err error, executable string) string {
+	switch {
+	case errors.Is(err, httpx.ErrUnauthorized):
+		return fmt.Sprintf("credentials were rejected; run `%s auth status` to check them or `%s auth login` to sign in again", executable, executable)
+	case errors.Is(err, httpx.ErrPermission):
+		return fmt.Sprintf("the credentials lack permission for this operation; run `%s auth status` to review granted scopes", executable)
+	case errors.Is(err, httpx.ErrNotFound):
+		return "check the workspace or project and repository; target another one with --repo WORKSPACE/SLUG"
+	case errors.Is(err, httpx.ErrRateLimited):
+		return "Bitbucket rate limit exceeded; wait a moment and try again"
+	case errors.Is(err, httpx.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "Bitbucket did not respond in time; retry with a longer deadline such as --timeout 2m, or set `timeout` in config.yml"
+	}
+	return ""
+}
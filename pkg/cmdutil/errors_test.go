@@ -0,0 +1,33 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/httpx"
+)
+
+func TestErrorHint(t *testing.T) {
+	notFound := fmt.Errorf("get repository: %w", &httpx.APIError{StatusCode: http.StatusNotFound, Status: "404 Not Found"})
+	if hint := ErrorHint(notFound, "bkt"); !strings.Contains(hint, "--repo") {
+		t.Fatalf("unexpected not-found hint %q", hint)
+	}
+
+	unauthorized := &httpx.APIError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}
+	if hint := ErrorHint(unauthorized, "bkt"); !strings.Contains(hint, "bkt auth login") {
+		t.Fatalf("unexpected unauthorized hint %q", hint)
+	}
+
+	timeout := fmt.Errorf("list pull requests: %w", &httpx.TimeoutError{Method: "GET", URL: "https://api.bitbucket.org/2.0/repositories", Err: context.DeadlineExceeded})
+	if hint := ErrorHint(timeout, "bkt"); !strings.Contains(hint, "--timeout") {
+		t.Fatalf("unexpected timeout hint %q", hint)
+	}
+
+	if hint := ErrorHint(errors.New("boom"), "bkt"); hint != "" {
+		t.Fatalf("expected no hint for plain errors, got %q", hint)
+	}
+}
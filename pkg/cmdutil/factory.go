@@ -1,10 +1,12 @@
 package cmdutil
 
 import (
+	"io"
 	"sync"
 
 	"github.com/alessandro308/bitbucket-cli/internal/config"
 	"github.com/alessandro308/bitbucket-cli/pkg/browser"
+	"github.com/alessandro308/bitbucket-cli/pkg/editor"
 	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
 	"github.com/alessandro308/bitbucket-cli/pkg/pager"
 	"github.com/alessandro308/bitbucket-cli/pkg/progress"
@@ -20,8 +22,19 @@ type Factory struct {
 
 	Config func() (*config.Config, error)
 
+	// Profile selects a named credential profile for every host resolved
+	// through this factory (bound to the global --profile flag). When empty,
+	// the context's pinned profile or the host's active profile is used.
+	Profile string
+
+	// RepoOverride is the repository named by a global --repo
+	// WORKSPACE/SLUG (or PROJECT/SLUG) flag. When set it takes precedence
+	// over the active context and git remotes in ResolveContext.
+	RepoOverride *RepoRef
+
 	// Lazy-initialised platform helpers.
 	Browser  browser.Browser
+	Editor   editor.Editor
 	Pager    pager.Manager
 	Prompter prompter.Interface
 	Spinner  progress.Spinner
@@ -33,6 +46,12 @@ type Factory struct {
 	cfgErr error
 	ioOnce sync.Once
 	ios    *iostreams.IOStreams
+
+	// paged, stdout and stderr are set while StartPager has replaced
+	// ios.Out and ios.ErrOut.
+	paged  *pager.Writer
+	stdout io.Writer
+	stderr io.Writer
 }
 
 // ResolveConfig loads configuration, caching the result.
@@ -68,6 +87,21 @@ func (f *Factory) BrowserOpener() browser.Browser {
 	return f.Browser
 }
 
+// TextEditor returns the editor used to compose descriptions and comments,
+// defaulting to the configured editor or the user's $EDITOR bound to the
+// factory streams.
+func (f *Factory) TextEditor() editor.Editor {
+	if f.Editor == nil {
+		ios, _ := f.Streams()
+		var configured string
+		if cfg, err := f.ResolveConfig(); err == nil {
+			configured = cfg.Setting("editor")
+		}
+		f.Editor = editor.NewConfigured(ios, configured)
+	}
+	return f.Editor
+}
+
 // PagerManager returns the pager manager, defaulting to a system-backed
 // instance bound to the factory streams.
 func (f *Factory) PagerManager() pager.Manager {
@@ -78,8 +112,12 @@ func (f *Factory) PagerManager() pager.Manager {
 	return f.Pager
 }
 
-// Prompt returns the prompter helper for interactive input.
+// Prompt returns the prompter helper for interactive input. Any pager started
+// for the command is stopped first so the question is not held back or paged;
+// output printed so far reaches the terminal before it.
 func (f *Factory) Prompt() prompter.Interface {
+	// A pager that fails to exit cleanly must not block the prompt.
+	_ = f.StopPager()
 	if f.Prompter == nil {
 		ios, _ := f.Streams()
 		f.Prompter = prompter.New(ios)
@@ -87,6 +125,13 @@ func (f *Factory) Prompt() prompter.Interface {
 	return f.Prompter
 }
 
+// ProgressBar starts a transfer progress bar for total bytes (zero or less
+// when unknown) on the factory streams.
+func (f *Factory) ProgressBar(label string, total int64) progress.Bar {
+	ios, _ := f.Streams()
+	return progress.NewBar(ios, label, total)
+}
+
 // ProgressSpinner exposes a spinner helper for long-running operations.
 func (f *Factory) ProgressSpinner() progress.Spinner {
 	if f.Spinner == nil {
@@ -0,0 +1,18 @@
+package cmdutil
+
+import (
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+	"github.com/alessandro308/bitbucket-cli/pkg/markdown"
+)
+
+// maxMarkdownWidth keeps rendered text readable on very wide terminals.
+const maxMarkdownWidth = 120
+
+// RenderMarkdown renders a description or comment for ios.Out: styled and
+// wrapped to the terminal when colour is enabled, plain text otherwise.
+func RenderMarkdown(ios *iostreams.IOStreams, text string) string {
+	return markdown.Render(text, markdown.Options{
+		Width: min(ios.TerminalWidth(), maxMarkdownWidth),
+		Color: ios.ColorEnabled(),
+	})
+}
@@ -0,0 +1,233 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Report formats supported by WriteRepoReports.
+const (
+	ReportFormatJSON     = "json"
+	ReportFormatMarkdown = "markdown"
+)
+
+// RepoReport captures the slice of a multi-repository command result that
+// belongs to a single repository.
+type RepoReport struct {
+	// Repo is the repository identifier (e.g. PROJ/repo or workspace/repo).
+	Repo string
+	// Summary is a one-line description rendered in the index.
+	Summary string
+	// Data is serialised verbatim for JSON reports.
+	Data any
+	// Markdown renders the body of a markdown report. When nil a fenced JSON
+	// dump of Data is written instead.
+	Markdown func(w io.Writer) error
+}
+
+// ReportIndexEntry describes one file written by WriteRepoReports.
+type ReportIndexEntry struct {
+	Repo    string `json:"repo"`
+	File    string `json:"file"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// AddOutDirFlags registers the --out-dir/--out-format flags used by commands
+// that fan out across repositories.
+func AddOutDirFlags(cmd *cobra.Command, dir, format *string) {
+	cmd.Flags().StringVar(dir, "out-dir", "", "Write one report per repository plus an index into this directory")
+	cmd.Flags().StringVar(format, "out-format", ReportFormatJSON, "Report format for --out-dir (json, markdown)")
+}
+
+// CheckReportFormat validates the --out-format value so commands can reject
+// it before doing any work.
+func CheckReportFormat(format string) error {
+	_, _, err := reportFormat(format)
+	return err
+}
+
+func reportFormat(format string) (string, string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", ReportFormatJSON:
+		return ReportFormatJSON, ".json", nil
+	case ReportFormatMarkdown, "md":
+		return ReportFormatMarkdown, ".md", nil
+	default:
+		return "", "", fmt.Errorf("unsupported report format %q (expected json or markdown)", format)
+	}
+}
+
+// WriteReports writes reports like WriteRepoReports and tells out where they
+// went.
+func WriteReports(out io.Writer, dir, format string, reports []RepoReport) error {
+	indexPath, err := WriteRepoReports(dir, format, reports)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "✓ Wrote %d report(s) to %s (index: %s)\n", len(reports), dir, indexPath)
+	return err
+}
+
+// WriteRepoReports writes one report per repository into dir followed by an
+// index summarising every file. It returns the index path.
+func WriteRepoReports(dir, format string, reports []RepoReport) (string, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return "", fmt.Errorf("output directory is required")
+	}
+
+	format, ext, err := reportFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create output directory: %w", err)
+	}
+
+	sorted := append([]RepoReport(nil), reports...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Repo < sorted[j].Repo })
+
+	// File names encode the repository one-to-one, so only the same
+	// repository reported twice, or one named like the index, needs a suffix.
+	used := map[string]int{"index": 1}
+	index := make([]ReportIndexEntry, 0, len(sorted))
+	for _, report := range sorted {
+		name := reportFileName(report.Repo)
+		used[name]++
+		if n := used[name]; n > 1 {
+			name = fmt.Sprintf("%s~%d", name, n)
+		}
+		file := name + ext
+
+		if err := writeReportFile(filepath.Join(dir, file), func(w io.Writer) error {
+			if format == ReportFormatJSON {
+				return encodeJSON(w, report.Data)
+			}
+			return writeMarkdownReport(w, report)
+		}); err != nil {
+			return "", err
+		}
+
+		index = append(index, ReportIndexEntry{Repo: report.Repo, File: file, Summary: report.Summary})
+	}
+
+	indexPath := filepath.Join(dir, "index"+ext)
+	if err := writeReportFile(indexPath, func(w io.Writer) error {
+		if format == ReportFormatJSON {
+			return encodeJSON(w, map[string]any{"reports": index})
+		}
+		return writeMarkdownIndex(w, index)
+	}); err != nil {
+		return "", err
+	}
+
+	return indexPath, nil
+}
+
+// reportFileName encodes a repository identifier as a file name: the
+// characters of slugs and keys are kept, "/" becomes "+" and anything else,
+// including "+", "%" and "~", is percent-encoded. Different identifiers
+// therefore never share a name.
+func reportFileName(repo string) string {
+	repo = strings.TrimSpace(repo)
+	if repo == "" {
+		return "unknown"
+	}
+	var b strings.Builder
+	for i := 0; i < len(repo); i++ {
+		c := repo[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.' && i > 0:
+			b.WriteByte(c)
+		case c == '/':
+			b.WriteByte('+')
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// MarkdownText escapes s for use as inline markdown text, such as a table
+// cell or heading: markup characters are backslash-escaped and line breaks
+// become spaces.
+func MarkdownText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '`', '*', '_', '[', ']', '<', '>', '|', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\r':
+		case '\n':
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func writeReportFile(path string, render func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create report: %w", err)
+	}
+	if err := render(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func encodeJSON(w io.Writer, data any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+func writeMarkdownReport(w io.Writer, report RepoReport) error {
+	if _, err := fmt.Fprintf(w, "# %s\n\n", MarkdownText(report.Repo)); err != nil {
+		return err
+	}
+	if report.Summary != "" {
+		if _, err := fmt.Fprintf(w, "%s\n\n", MarkdownText(report.Summary)); err != nil {
+			return err
+		}
+	}
+	if report.Markdown != nil {
+		return report.Markdown(w)
+	}
+	if _, err := fmt.Fprintln(w, "```json"); err != nil {
+		return err
+	}
+	if err := encodeJSON(w, report.Data); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "```")
+	return err
+}
+
+func writeMarkdownIndex(w io.Writer, index []ReportIndexEntry) error {
+	if _, err := fmt.Fprintf(w, "# Report index\n\n| Repository | Summary | Report |\n| --- | --- | --- |\n"); err != nil {
+		return err
+	}
+	for _, entry := range index {
+		if _, err := fmt.Fprintf(w, "| %s | %s | [%s](%s) |\n", MarkdownText(entry.Repo), MarkdownText(entry.Summary), MarkdownText(entry.File), url.PathEscape(entry.File)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteRepoReportsJSON(t *testing.T) {
+	dir := t.TempDir()
+
+	indexPath, err := WriteRepoReports(dir, "json", []RepoReport{
+		{Repo: "ws/b-repo", Summary: "2 items", Data: map[string]any{"count": 2}},
+		{Repo: "ws/a-repo", Summary: "1 item", Data: map[string]any{"count": 1}},
+	})
+	if err != nil {
+		t.Fatalf("WriteRepoReports returned error: %v", err)
+	}
+	if indexPath != filepath.Join(dir, "index.json") {
+		t.Fatalf("index path = %q", indexPath)
+	}
+
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	var index struct {
+		Reports []ReportIndexEntry `json:"reports"`
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("decode index: %v", err)
+	}
+	if len(index.Reports) != 2 {
+		t.Fatalf("expected 2 index entries, got %d", len(index.Reports))
+	}
+	if index.Reports[0].Repo != "ws/a-repo" || index.Reports[0].File != "ws+a-repo.json" {
+		t.Fatalf("unexpected first entry: %+v", index.Reports[0])
+	}
+
+	report, err := os.ReadFile(filepath.Join(dir, "ws+b-repo.json"))
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	if !strings.Contains(string(report), `"count": 2`) {
+		t.Fatalf("unexpected report body: %s", report)
+	}
+}
+
+func TestWriteRepoReportsMarkdown(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := WriteRepoReports(dir, "md", []RepoReport{
+		{Repo: "PROJ/repo", Summary: "a | b", Data: []int{1}},
+		{Repo: "PROJ:repo", Data: []int{2}},
+		{Repo: "PROJ/*new*_repo|x", Summary: "line\nbreak", Data: []int{3}},
+	})
+	if err != nil {
+		t.Fatalf("WriteRepoReports returned error: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	for _, want := range []string{
+		`| PROJ/repo | a \| b | [PROJ+repo.md](PROJ+repo.md) |`,
+		`[PROJ%3Arepo.md](PROJ%253Arepo.md)`,
+		`| PROJ/\*new\*\_repo\|x | line break |`,
+	} {
+		if !strings.Contains(string(index), want) {
+			t.Fatalf("index lacks %q:\n%s", want, index)
+		}
+	}
+
+	report, err := os.ReadFile(filepath.Join(dir, "PROJ+repo.md"))
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	if !strings.HasPrefix(string(report), "# PROJ/repo") || !strings.Contains(string(report), "```json") {
+		t.Fatalf("unexpected markdown report:\n%s", report)
+	}
+}
+
+func TestReportFileNamesDoNotCollide(t *testing.T) {
+	repos := []string{"team/api", "other/api", "ws/a_b", "ws_a/b", "ws/a+b", "ws/a%2Bb", "index", "../up"}
+	seen := make(map[string]string)
+	for _, repo := range repos {
+		name := reportFileName(repo)
+		if prev, ok := seen[name]; ok {
+			t.Fatalf("%q and %q both map to %q", prev, repo, name)
+		}
+		if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+			t.Fatalf("unsafe file name %q for %q", name, repo)
+		}
+		seen[name] = repo
+	}
+
+	dir := t.TempDir()
+	if _, err := WriteRepoReports(dir, "json", []RepoReport{{Repo: "index", Data: 1}}); err != nil {
+		t.Fatalf("WriteRepoReports: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "index~2.json")); err != nil {
+		t.Fatalf("a repository named index must not replace the index: %v", err)
+	}
+}
+
+func TestWriteRepoReportsRejectsUnknownFormat(t *testing.T) {
+	if _, err := WriteRepoReports(t.TempDir(), "xml", nil); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
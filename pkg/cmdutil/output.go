@@ -3,6 +3,11 @@ package cmdutil
 import (
 	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +19,49 @@ type OutputSettings struct {
 	Format   string
 	JQ       string
 	Template string
+	Fields   []string
+}
+
+var jsonFieldList = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(,[A-Za-z_][A-Za-z0-9_]*)+$`)
+
+// ExpandJSONFieldArgs rewrites "--json a,b" into "--json=a,b" so the optional
+// field list of the --json flag can be given as a separate argument. Only
+// comma-separated lists are rewritten; a single field must use --json=field to
+// stay distinguishable from a positional argument.
+func ExpandJSONFieldArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(out, args[i:]...)
+		}
+		if arg == "--json" && i+1 < len(args) && jsonFieldList.MatchString(args[i+1]) {
+			out = append(out, "--json="+args[i+1])
+			i++
+			continue
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+func parseJSONFields(value string) (bool, []string, error) {
+	switch value {
+	case "", "false":
+		return false, nil, nil
+	case "true":
+		return true, nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return false, nil, fmt.Errorf("invalid --json field list %q", value)
+		}
+		fields = append(fields, field)
+	}
+	return true, fields, nil
 }
 
 // OutputSettings extracts flags from the command hierarchy with validation.
@@ -28,10 +76,25 @@ func ResolveOutputSettings(cmd *cobra.Command) (OutputSettings, error) {
 		return flag.Value.String()
 	}
 
-	jsonEnabled := lookup("json") == "true"
+	jsonEnabled, fields, err := parseJSONFields(lookup("json"))
+	if err != nil {
+		return OutputSettings{}, err
+	}
 	yamlEnabled := lookup("yaml") == "true"
 	jqExpr := lookup("jq")
 	tmpl := lookup("template")
+	tabular := strings.ToLower(strings.TrimSpace(lookup("format")))
+
+	switch tabular {
+	case "":
+	case "csv", "tsv":
+		if (jsonEnabled && len(fields) == 0) || yamlEnabled || jqExpr != "" || tmpl != "" {
+			return OutputSettings{}, fmt.Errorf("--format %s cannot be combined with --yaml, --jq, --template or a bare --json; use --json <fields> to pick columns", tabular)
+		}
+		return OutputSettings{Format: tabular, Fields: fields}, nil
+	default:
+		return OutputSettings{}, fmt.Errorf("invalid --format %q (expected csv or tsv)", tabular)
+	}
 
 	if jsonEnabled && yamlEnabled {
 		return OutputSettings{}, fmt.Errorf("cannot use --json and --yaml simultaneously")
@@ -56,6 +119,7 @@ func ResolveOutputSettings(cmd *cobra.Command) (OutputSettings, error) {
 		Format:   format,
 		JQ:       jqExpr,
 		Template: tmpl,
+		Fields:   fields,
 	}, nil
 }
 
@@ -76,6 +140,21 @@ func WriteOutput(cmd *cobra.Command, w io.Writer, data any, fallback func() erro
 	if err != nil {
 		return err
 	}
-	opts := format.Options{Format: settings.Format, JQ: settings.JQ, Template: settings.Template}
+	opts := format.Options{
+		Format:   settings.Format,
+		JQ:       settings.JQ,
+		Template: settings.Template,
+		Fields:   settings.Fields,
+		Color:    colorWriter(w),
+	}
 	return format.Write(w, opts, data, fallback)
 }
+
+// colorWriter reports whether w is a terminal that should receive ANSI colour.
+func colorWriter(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,95 @@
+package cmdutil
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExpandJSONFieldArgs(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want []string
+	}{
+		{[]string{"pr", "list", "--json", "id,title"}, []string{"pr", "list", "--json=id,title"}},
+		{[]string{"repo", "view", "--json", "demo"}, []string{"repo", "view", "--json", "demo"}},
+		{[]string{"pr", "list", "--json", "--jq", ".[]"}, []string{"pr", "list", "--json", "--jq", ".[]"}},
+		{[]string{"api", "--", "--json", "a,b"}, []string{"api", "--", "--json", "a,b"}},
+	}
+	for _, tc := range cases {
+		if got := ExpandJSONFieldArgs(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ExpandJSONFieldArgs(%v) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestWriteOutputSelectsJSONFields(t *testing.T) {
+	root := &cobra.Command{Use: "bkt"}
+	root.PersistentFlags().String("json", "", "")
+	root.PersistentFlags().Lookup("json").NoOptDefVal = "true"
+	root.PersistentFlags().Bool("yaml", false, "")
+	root.PersistentFlags().String("jq", "", "")
+	root.PersistentFlags().String("template", "", "")
+
+	var out bytes.Buffer
+	child := &cobra.Command{
+		Use: "list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payload := map[string]any{
+				"items": []map[string]any{{"id": 7, "title": "demo", "state": "OPEN"}},
+			}
+			return WriteOutput(cmd, &out, payload, nil)
+		},
+	}
+	root.AddCommand(child)
+
+	root.SetArgs(ExpandJSONFieldArgs([]string{"list", "--json", "id,title"}))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	got := strings.Join(strings.Fields(out.String()), "")
+	if got != `[{"id":7,"title":"demo"}]` {
+		t.Fatalf("unexpected output %s", out.String())
+	}
+
+	out.Reset()
+	root.SetArgs([]string{"list", "--json=id,,title"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --json field list") {
+		t.Fatalf("expected invalid field list error, got %v", err)
+	}
+}
+
+func TestResolveOutputSettingsFormat(t *testing.T) {
+	newRoot := func(args ...string) *cobra.Command {
+		root := &cobra.Command{Use: "bkt", RunE: func(*cobra.Command, []string) error { return nil }}
+		root.PersistentFlags().String("json", "", "")
+		root.PersistentFlags().Lookup("json").NoOptDefVal = "true"
+		root.PersistentFlags().Bool("yaml", false, "")
+		root.PersistentFlags().String("jq", "", "")
+		root.PersistentFlags().String("template", "", "")
+		root.PersistentFlags().String("format", "", "")
+		root.SetArgs(args)
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		return root
+	}
+
+	settings, err := ResolveOutputSettings(newRoot("--format", "TSV", "--json=id,title"))
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if settings.Format != "tsv" || !reflect.DeepEqual(settings.Fields, []string{"id", "title"}) {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+
+	if _, err := ResolveOutputSettings(newRoot("--format", "csv", "--yaml")); err == nil {
+		t.Fatal("expected --format/--yaml conflict")
+	}
+	if _, err := ResolveOutputSettings(newRoot("--format", "xml")); err == nil || !strings.Contains(err.Error(), "expected csv or tsv") {
+		t.Fatalf("expected invalid format error, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/pager"
+)
+
+// pagedCommands names the commands whose stdout may be long enough to page.
+var pagedCommands = map[string]bool{
+	"list": true,
+	"view": true,
+	"diff": true,
+}
+
+// unpagedFlags disable paging when set: structured output is meant for
+// other programs, and waiting or watching commands keep running after their
+// first screen.
+var unpagedFlags = []string{"json", "yaml", "jq", "template", "format", "wait", "watch", "follow"}
+
+// StartPager routes the stdout of list, view and diff commands through the
+// pager when stdout is a terminal. Output only reaches the pager once it
+// exceeds the terminal height; `pager: never` in the config turns it off.
+// Prompting through Factory.Prompt stops the pager first. Callers must
+// invoke StopPager once the command has finished.
+func StartPager(f *Factory, cmd *cobra.Command) {
+	if f.paged != nil || !shouldPage(f, cmd) {
+		return
+	}
+	ios, err := f.Streams()
+	if err != nil {
+		return
+	}
+	height := ios.TerminalHeight()
+	if height <= 0 {
+		return
+	}
+	m := f.PagerManager()
+	if !m.Enabled() {
+		return
+	}
+
+	f.stdout, f.stderr = ios.Out, ios.ErrOut
+	f.paged = pager.NewWriter(m, ios.Out, height, ios.TerminalWidth())
+	ios.Out = f.paged
+	ios.ErrOut = f.paged.Stderr(ios.ErrOut)
+}
+
+// shouldPage reports whether cmd produces human-readable output that may be
+// paged.
+func shouldPage(f *Factory, cmd *cobra.Command) bool {
+	if !pagedCommands[cmd.Name()] {
+		return false
+	}
+	for _, name := range unpagedFlags {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return false
+		}
+	}
+	if cfg, err := f.ResolveConfig(); err == nil && strings.EqualFold(cfg.Setting("pager"), "never") {
+		return false
+	}
+	return true
+}
+
+// StopPager flushes output held back by StartPager, waits for the pager to
+// exit and restores stdout and stderr.
+func (f *Factory) StopPager() error {
+	if f.paged == nil {
+		return nil
+	}
+	ios, _ := f.Streams()
+	ios.Out, ios.ErrOut = f.stdout, f.stderr
+	err := f.paged.Close()
+	f.paged, f.stdout, f.stderr = nil, nil, nil
+	return err
+}
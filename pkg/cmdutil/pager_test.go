@@ -0,0 +1,94 @@
+package cmdutil
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+	"github.com/alessandro308/bitbucket-cli/pkg/pager"
+)
+
+func TestShouldPage(t *testing.T) {
+	newCmd := func(name string) *cobra.Command {
+		root := &cobra.Command{Use: "bkt"}
+		root.PersistentFlags().String("json", "", "")
+		root.PersistentFlags().Lookup("json").NoOptDefVal = "true"
+		cmd := &cobra.Command{Use: name, Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().Bool("wait", false, "")
+		root.AddCommand(cmd)
+		return root
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		pager string
+		want  bool
+	}{
+		{name: "list", args: []string{"list"}, want: true},
+		{name: "non-paged command", args: []string{"create"}},
+		{name: "json output", args: []string{"list", "--json"}},
+		{name: "waiting", args: []string{"view", "--wait"}},
+		{name: "pager never", args: []string{"diff"}, pager: "never"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFactory(&config.Config{Pager: tt.pager})
+			root := newCmd(tt.args[0])
+			root.SetArgs(tt.args)
+			var got bool
+			root.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+				got = shouldPage(f, cmd)
+			}
+			if err := root.Execute(); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("shouldPage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type recordingManager struct {
+	paged bytes.Buffer
+}
+
+func (m *recordingManager) Enabled() bool { return true }
+
+func (m *recordingManager) Start() (io.WriteCloser, error) {
+	return nopCloser{&m.paged}, nil
+}
+
+func (m *recordingManager) Stop() error { return nil }
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestPromptStopsPager(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	ios := &iostreams.IOStreams{Out: &stdout, ErrOut: &stderr}
+	f := &Factory{IOStreams: ios}
+
+	f.stdout, f.stderr = ios.Out, ios.ErrOut
+	f.paged = pager.NewWriter(&recordingManager{}, ios.Out, 10, 0)
+	ios.Out = f.paged
+	ios.ErrOut = f.paged.Stderr(ios.ErrOut)
+
+	if _, err := io.WriteString(ios.Out, "#1 held back\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Prompt()
+
+	if f.paged != nil || ios.Out != &stdout || ios.ErrOut != &stderr {
+		t.Fatal("prompting must restore the terminal streams")
+	}
+	if stdout.String() != "#1 held back\n" {
+		t.Fatalf("output before the prompt = %q", stdout.String())
+	}
+}
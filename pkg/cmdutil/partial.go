@@ -0,0 +1,82 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// PartialFailure records one request that failed while a command aggregated
+// several API calls.
+type PartialFailure struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+// PartialResults collects per-item failures so commands that combine many
+// API calls can render whatever data loaded and report the rest at the end.
+type PartialResults struct {
+	// FailFast restores strict behaviour: the first failure is returned as-is.
+	FailFast bool
+
+	failures []PartialFailure
+}
+
+// AddFailFastFlag registers the --fail-fast flag used by aggregating commands.
+func AddFailFastFlag(cmd *cobra.Command, failFast *bool) {
+	cmd.Flags().BoolVar(failFast, "fail-fast", false, "Abort on the first failed request instead of rendering partial results")
+}
+
+// Record notes that item failed with err. It returns err when FailFast is set
+// so callers can abort, and nil otherwise. A nil err is ignored.
+func (p *PartialResults) Record(item string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if p.FailFast {
+		return err
+	}
+	p.failures = append(p.failures, PartialFailure{Item: item, Error: err.Error()})
+	return nil
+}
+
+// Failed reports whether item has a recorded failure.
+func (p *PartialResults) Failed(item string) bool {
+	for _, f := range p.failures {
+		if f.Item == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures returns the recorded failures in the order they occurred.
+func (p *PartialResults) Failures() []PartialFailure {
+	return p.failures
+}
+
+// Marker returns a warning prefix for items that failed to load.
+func (p *PartialResults) Marker(item string) string {
+	if p.Failed(item) {
+		return "! "
+	}
+	return ""
+}
+
+// Err prints one warning line per failure to w and returns an ExitError when
+// anything failed, so partial output still exits non-zero.
+func (p *PartialResults) Err(w io.Writer) error {
+	if len(p.failures) == 0 {
+		return nil
+	}
+	for _, f := range p.failures {
+		if _, err := fmt.Fprintf(w, "! %s: %s\n", f.Item, f.Error); err != nil {
+			return err
+		}
+	}
+	return &ExitError{
+		Code: 1,
+		Msg:  fmt.Sprintf("%d request(s) failed; results are incomplete (use --fail-fast to stop on the first error)", len(p.failures)),
+	}
+}
@@ -0,0 +1,46 @@
+package cmdutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPartialResultsCollectsFailures(t *testing.T) {
+	partial := &PartialResults{}
+
+	if err := partial.Record("ok", nil); err != nil {
+		t.Fatalf("nil error should be ignored, got %v", err)
+	}
+	if err := partial.Record("steps", errors.New("boom")); err != nil {
+		t.Fatalf("expected failure to be recorded, got %v", err)
+	}
+	if !partial.Failed("steps") || partial.Failed("ok") {
+		t.Fatalf("unexpected failure state: %+v", partial.Failures())
+	}
+	if partial.Marker("steps") == "" || partial.Marker("ok") != "" {
+		t.Fatal("expected warning marker only for failed item")
+	}
+
+	var out strings.Builder
+	err := partial.Err(&out)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 1 {
+		t.Fatalf("expected ExitError, got %v", err)
+	}
+	if !strings.Contains(out.String(), "steps: boom") {
+		t.Fatalf("expected warning line, got %q", out.String())
+	}
+}
+
+func TestPartialResultsFailFast(t *testing.T) {
+	partial := &PartialResults{FailFast: true}
+	boom := errors.New("boom")
+
+	if err := partial.Record("steps", boom); !errors.Is(err, boom) {
+		t.Fatalf("expected original error in fail-fast mode, got %v", err)
+	}
+	if err := partial.Err(&strings.Builder{}); err != nil {
+		t.Fatalf("no failures should be recorded in fail-fast mode, got %v", err)
+	}
+}
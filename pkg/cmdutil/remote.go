@@ -0,0 +1,99 @@
+package cmdutil
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+	"github.com/alessandro308/bitbucket-cli/internal/remote"
+)
+
+// gitRemotes memoises remote detection for the working directory; a single
+// invocation may resolve its context several times.
+var gitRemotes = struct {
+	sync.Mutex
+	dir      string
+	locators []remote.Locator
+}{}
+
+// detectGitRemotes returns the Bitbucket locators of the working directory's
+// git remotes, or nil outside a clone.
+func detectGitRemotes() []remote.Locator {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+
+	gitRemotes.Lock()
+	defer gitRemotes.Unlock()
+	if gitRemotes.dir == wd {
+		return gitRemotes.locators
+	}
+
+	locators, err := remote.DetectAll(wd)
+	if err != nil {
+		locators = nil
+	}
+	gitRemotes.dir, gitRemotes.locators = wd, locators
+	return locators
+}
+
+// remoteForHost returns the first git remote of the working directory that
+// points at host.
+func remoteForHost(host *config.Host) (remote.Locator, bool) {
+	for _, loc := range detectGitRemotes() {
+		if locatorMatchesHost(host, loc) {
+			return loc, true
+		}
+	}
+	return remote.Locator{}, false
+}
+
+// InRepoClone reports whether a git remote of the working directory points
+// at the repository namespace/slug on host, where namespace is the Cloud
+// workspace or Data Center project key.
+func InRepoClone(host *config.Host, namespace, slug string) bool {
+	for _, loc := range detectGitRemotes() {
+		if !locatorMatchesHost(host, loc) || !strings.EqualFold(loc.RepoSlug, slug) {
+			continue
+		}
+		remoteNamespace := loc.Workspace
+		if host.Kind == "dc" {
+			remoteNamespace = loc.ProjectKey
+		}
+		if strings.EqualFold(remoteNamespace, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitContext derives a context from the working directory's git remotes when
+// no context is active: the first remote (origin first) that points at a
+// configured host supplies the host, workspace or project, and repository.
+func gitContext(cfg *config.Config) (string, *config.Context, bool) {
+	keys := make([]string, 0, len(cfg.Hosts))
+	for key := range cfg.Hosts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, loc := range detectGitRemotes() {
+		for _, key := range keys {
+			host := cfg.Hosts[key]
+			if host == nil || !locatorMatchesHost(host, loc) {
+				continue
+			}
+			ctx := &config.Context{Host: key, DefaultRepo: loc.RepoSlug}
+			if host.Kind == "cloud" {
+				ctx.Workspace = loc.Workspace
+			} else {
+				ctx.ProjectKey = loc.ProjectKey
+			}
+			return key, ctx, true
+		}
+	}
+	return "", nil, false
+}
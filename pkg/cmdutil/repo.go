@@ -0,0 +1,101 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+)
+
+// RepoRef identifies a repository by its namespace (Cloud workspace or Data
+// Center project key) and slug.
+type RepoRef struct {
+	Namespace string
+	Slug      string
+}
+
+func (r RepoRef) String() string {
+	if r.Namespace == "" {
+		return r.Slug
+	}
+	return r.Namespace + "/" + r.Slug
+}
+
+// ParseRepoRef parses a WORKSPACE/SLUG or PROJECT/SLUG reference. A bare slug
+// yields a RepoRef without namespace.
+func ParseRepoRef(value string) (RepoRef, error) {
+	value = strings.TrimSpace(value)
+	namespace, slug, found := strings.Cut(value, "/")
+	if !found {
+		return RepoRef{Slug: value}, nil
+	}
+	namespace, slug = strings.TrimSpace(namespace), strings.TrimSpace(slug)
+	if namespace == "" || slug == "" || strings.Contains(slug, "/") {
+		return RepoRef{}, fmt.Errorf("invalid repository %q; expected WORKSPACE/SLUG or PROJECT/SLUG", value)
+	}
+	return RepoRef{Namespace: namespace, Slug: slug}, nil
+}
+
+// ApplyRepoFlag splits a namespaced --repo value on cmd so commands keep
+// receiving a bare slug through their own flag. The namespace fills unset
+// --workspace/--project flags and is recorded on the factory for
+// ResolveContext. It is a no-op for bare slugs.
+func ApplyRepoFlag(f *Factory, cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("repo")
+	if flag == nil || !flag.Changed || !strings.Contains(flag.Value.String(), "/") {
+		return nil
+	}
+
+	ref, err := ParseRepoRef(flag.Value.String())
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"workspace", "project"} {
+		nsFlag := cmd.Flags().Lookup(name)
+		if nsFlag == nil {
+			continue
+		}
+		if nsFlag.Changed {
+			if !strings.EqualFold(nsFlag.Value.String(), ref.Namespace) {
+				return fmt.Errorf("--repo %s conflicts with --%s %s", ref, name, nsFlag.Value.String())
+			}
+			continue
+		}
+		if err := nsFlag.Value.Set(ref.Namespace); err != nil {
+			return err
+		}
+	}
+	if err := flag.Value.Set(ref.Slug); err != nil {
+		return err
+	}
+
+	f.RepoOverride = &ref
+	return nil
+}
+
+func errNoRepository(host *config.Host) error {
+	example := "WORKSPACE/SLUG"
+	if host != nil && host.Kind == "dc" {
+		example = "PROJECT/SLUG"
+	}
+	return fmt.Errorf("could not determine the repository; pass --repo %s or run inside a clone with a Bitbucket remote", example)
+}
+
+// applyRepoOverride returns a copy of ctx targeting the factory's --repo
+// override, leaving the stored context untouched.
+func applyRepoOverride(f *Factory, ctx *config.Context, host *config.Host) *config.Context {
+	if f.RepoOverride == nil {
+		return ctx
+	}
+	out := *ctx
+	out.DefaultRepo = f.RepoOverride.Slug
+	if host.Kind == "dc" {
+		out.ProjectKey = f.RepoOverride.Namespace
+	} else {
+		out.Workspace = f.RepoOverride.Namespace
+	}
+	return &out
+}
@@ -0,0 +1,125 @@
+package cmdutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+)
+
+func TestParseRepoRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    RepoRef
+		wantErr bool
+	}{
+		{in: "api", want: RepoRef{Slug: "api"}},
+		{in: "acme/api", want: RepoRef{Namespace: "acme", Slug: "api"}},
+		{in: "/api", wantErr: true},
+		{in: "acme/", wantErr: true},
+		{in: "acme/api/extra", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRepoRef(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRepoRef(%q) expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRepoRef(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func newRepoFlagCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "view"}
+	cmd.Flags().String("repo", "", "")
+	cmd.Flags().String("workspace", "", "")
+	cmd.Flags().String("context", "", "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestApplyRepoFlagOverridesContext(t *testing.T) {
+	cfg := &config.Config{
+		ActiveContext: "team",
+		Contexts: map[string]*config.Context{
+			"team": {Host: "api.bitbucket.org", Workspace: "acme", DefaultRepo: "web"},
+		},
+		Hosts: map[string]*config.Host{
+			"api.bitbucket.org": {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Token: "token"},
+		},
+	}
+	f := newTestFactory(cfg)
+	cmd := newRepoFlagCommand(t, "--repo", "other/api")
+
+	if err := ApplyRepoFlag(f, cmd); err != nil {
+		t.Fatalf("ApplyRepoFlag: %v", err)
+	}
+	if got := FlagValue(cmd, "repo"); got != "api" {
+		t.Fatalf("repo flag = %q, want bare slug", got)
+	}
+	if got := FlagValue(cmd, "workspace"); got != "other" {
+		t.Fatalf("workspace flag = %q, want other", got)
+	}
+
+	workspace, repo, _, err := ResolveCloudRepo(f, cmd, FlagValue(cmd, "workspace"), FlagValue(cmd, "repo"))
+	if err != nil {
+		t.Fatalf("ResolveCloudRepo: %v", err)
+	}
+	if workspace != "other" || repo != "api" {
+		t.Fatalf("unexpected repo %s/%s", workspace, repo)
+	}
+	if ctx := cfg.Contexts["team"]; ctx.Workspace != "acme" || ctx.DefaultRepo != "web" {
+		t.Fatalf("stored context was modified: %+v", ctx)
+	}
+}
+
+func TestApplyRepoFlagRejectsConflictingWorkspace(t *testing.T) {
+	f := newTestFactory(&config.Config{})
+	cmd := newRepoFlagCommand(t, "--repo", "other/api", "--workspace", "acme")
+
+	err := ApplyRepoFlag(f, cmd)
+	if err == nil || !strings.Contains(err.Error(), "conflicts with --workspace") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestRepoFlagWithoutContext(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{
+		Hosts: map[string]*config.Host{
+			"bitbucket.example.com": {Kind: "dc", BaseURL: "https://bitbucket.example.com", Token: "token"},
+		},
+	}
+
+	f := newTestFactory(cfg)
+	cmd := newRepoFlagCommand(t, "--repo", "ABC/api")
+	if err := ApplyRepoFlag(f, cmd); err != nil {
+		t.Fatal(err)
+	}
+	_, ctxCfg, host, err := ResolveContext(f, cmd, "")
+	if err != nil {
+		t.Fatalf("ResolveContext: %v", err)
+	}
+	if ctxCfg.ProjectKey != "ABC" || ctxCfg.DefaultRepo != "api" || host.Kind != "dc" {
+		t.Fatalf("unexpected resolution %s/%s on %s", ctxCfg.ProjectKey, ctxCfg.DefaultRepo, host.Kind)
+	}
+
+	cloud := &config.Config{
+		Hosts: map[string]*config.Host{
+			"api.bitbucket.org": {Kind: "cloud", BaseURL: "https://api.bitbucket.org/2.0", Token: "token"},
+		},
+	}
+	_, _, _, err = ResolveCloudRepo(newTestFactory(cloud), newRepoFlagCommand(t), "", "")
+	if err == nil || !strings.Contains(err.Error(), "--repo") {
+		t.Fatalf("expected guidance to pass --repo, got %v", err)
+	}
+}
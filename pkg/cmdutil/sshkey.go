@@ -0,0 +1,53 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadPublicKey loads an OpenSSH public key from path, or from in when path
+// is "-". Private keys are rejected so they are never uploaded by mistake.
+func ReadPublicKey(in io.Reader, path string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read public key: %w", err)
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("public key is empty")
+	}
+	if strings.Contains(key, "PRIVATE KEY") {
+		return "", fmt.Errorf("refusing to upload a private key; pass the .pub file instead")
+	}
+	return key, nil
+}
+
+// PublicKeyComment returns the trailing comment of an OpenSSH public key, if any.
+func PublicKeyComment(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) < 3 {
+		return ""
+	}
+	return strings.Join(fields[2:], " ")
+}
+
+// SamePublicKey reports whether two OpenSSH public keys share the same type and
+// key material, ignoring comments.
+func SamePublicKey(a, b string) bool {
+	fa, fb := strings.Fields(a), strings.Fields(b)
+	if len(fa) < 2 || len(fb) < 2 {
+		return false
+	}
+	return fa[0] == fb[0] && fa[1] == fb[1]
+}
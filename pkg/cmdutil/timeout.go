@@ -0,0 +1,55 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type timeoutKey struct{}
+
+// ApplyTimeoutFlag records the global --timeout flag, or the config file's
+// timeout default, on the command's context for CommandContext to use.
+func ApplyTimeoutFlag(f *Factory, cmd *cobra.Command) error {
+	var value string
+	if flag := cmd.Root().PersistentFlags().Lookup("timeout"); flag != nil && flag.Changed {
+		value = flag.Value.String()
+	} else if cfg, err := f.ResolveConfig(); err == nil {
+		value = cfg.Setting("timeout")
+	}
+	if value == "" {
+		return nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return fmt.Errorf("invalid timeout %q; use a duration such as 30s or 2m (0 disables)", value)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(context.WithValue(ctx, timeoutKey{}, timeout))
+	return nil
+}
+
+// CommandContext derives the context for a command's API calls. The deadline
+// is the --timeout (or configured) override when present, else fallback; a
+// zero override disables the deadline.
+func CommandContext(cmd *cobra.Command, fallback time.Duration) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := fallback
+	if override, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
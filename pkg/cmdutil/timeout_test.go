@@ -0,0 +1,92 @@
+package cmdutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alessandro308/bitbucket-cli/internal/config"
+)
+
+func newTimeoutCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	root := &cobra.Command{Use: "bkt"}
+	root.PersistentFlags().Duration("timeout", 0, "")
+	child := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(child)
+	if err := root.PersistentFlags().Parse(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	child.SetContext(context.Background())
+	return child
+}
+
+func remaining(t *testing.T, ctx context.Context) time.Duration {
+	t.Helper()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline")
+	}
+	return time.Until(deadline)
+}
+
+func TestCommandContextUsesFallback(t *testing.T) {
+	cmd := newTimeoutCommand(t)
+	if err := ApplyTimeoutFlag(newTestFactory(&config.Config{}), cmd); err != nil {
+		t.Fatalf("ApplyTimeoutFlag: %v", err)
+	}
+
+	ctx, cancel := CommandContext(cmd, 15*time.Second)
+	defer cancel()
+	if got := remaining(t, ctx); got > 15*time.Second || got < 14*time.Second {
+		t.Fatalf("deadline in %s, want about 15s", got)
+	}
+}
+
+func TestCommandContextPrefersFlagOverConfig(t *testing.T) {
+	cmd := newTimeoutCommand(t, "--timeout", "2m")
+	if err := ApplyTimeoutFlag(newTestFactory(&config.Config{Timeout: "45s"}), cmd); err != nil {
+		t.Fatalf("ApplyTimeoutFlag: %v", err)
+	}
+
+	ctx, cancel := CommandContext(cmd, 10*time.Second)
+	defer cancel()
+	if got := remaining(t, ctx); got <= time.Minute {
+		t.Fatalf("deadline in %s, want about 2m", got)
+	}
+}
+
+func TestCommandContextConfigDefault(t *testing.T) {
+	cmd := newTimeoutCommand(t)
+	if err := ApplyTimeoutFlag(newTestFactory(&config.Config{Timeout: "45s"}), cmd); err != nil {
+		t.Fatalf("ApplyTimeoutFlag: %v", err)
+	}
+
+	ctx, cancel := CommandContext(cmd, 10*time.Second)
+	defer cancel()
+	if got := remaining(t, ctx); got <= 30*time.Second {
+		t.Fatalf("deadline in %s, want about 45s", got)
+	}
+}
+
+func TestCommandContextZeroDisablesDeadline(t *testing.T) {
+	cmd := newTimeoutCommand(t, "--timeout", "0")
+	if err := ApplyTimeoutFlag(newTestFactory(&config.Config{}), cmd); err != nil {
+		t.Fatalf("ApplyTimeoutFlag: %v", err)
+	}
+
+	ctx, cancel := CommandContext(cmd, 10*time.Second)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("expected no deadline with --timeout 0")
+	}
+}
+
+func TestApplyTimeoutFlagRejectsInvalidConfig(t *testing.T) {
+	cmd := newTimeoutCommand(t)
+	if err := ApplyTimeoutFlag(newTestFactory(&config.Config{Timeout: "soon"}), cmd); err == nil {
+		t.Fatal("expected invalid config timeout to be rejected")
+	}
+}
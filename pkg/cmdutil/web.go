@@ -0,0 +1,29 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AddWebFlag registers the shared --web/-w flag on a view command; what names
+// the resource in the help text, e.g. "pull request".
+func AddWebFlag(cmd *cobra.Command, web *bool, what string) {
+	cmd.Flags().BoolVarP(web, "web", "w", false, fmt.Sprintf("Open the %s in the browser", what))
+}
+
+// OpenInBrowser opens a resource's HTML link for --web and notes it on out in
+// place of the usual output.
+func OpenInBrowser(f *Factory, out io.Writer, what, link string) error {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return fmt.Errorf("%s does not expose a web URL", what)
+	}
+	if err := f.BrowserOpener().Open(link); err != nil {
+		return fmt.Errorf("open browser: %w", err)
+	}
+	_, err := fmt.Fprintf(out, "Opening %s in your browser\n", link)
+	return err
+}
@@ -0,0 +1,128 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+// Scissors separates the text being edited from the instructions appended
+// below it; everything from this line on is removed before submission.
+const Scissors = "# ------------------------ >8 ------------------------"
+
+// ErrNotInteractive is returned when no terminal is available to run the
+// editor in.
+var ErrNotInteractive = errors.New("cannot open an editor without a terminal")
+
+// Editor opens text in the user's editor.
+type Editor interface {
+	// Edit writes initial to a temporary file called name, followed by the
+	// instruction lines as comments, and returns the text the user saved
+	// with the instructions removed.
+	Edit(name, initial string, instructions ...string) (string, error)
+}
+
+type system struct {
+	ios        *iostreams.IOStreams
+	configured string
+}
+
+// NewSystem returns an Editor that runs the program named by BKT_EDITOR,
+// GIT_EDITOR, VISUAL or EDITOR, falling back to vi (notepad on Windows).
+func NewSystem(ios *iostreams.IOStreams) Editor {
+	return &system{ios: ios}
+}
+
+// NewConfigured is like NewSystem but runs configured, the editor setting of
+// the bkt config, unless BKT_EDITOR is set.
+func NewConfigured(ios *iostreams.IOStreams, configured string) Editor {
+	return &system{ios: ios, configured: configured}
+}
+
+func (e *system) Edit(name, initial string, instructions ...string) (string, error) {
+	if e.ios == nil || !e.ios.CanPrompt() || !e.ios.IsStdoutTTY() {
+		return "", ErrNotInteractive
+	}
+
+	dir, err := os.MkdirTemp("", "bkt-edit-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(Template(initial, instructions...)), 0o600); err != nil {
+		return "", err
+	}
+
+	args := strings.Fields(CommandWith(e.configured))
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = e.ios.In
+	cmd.Stdout = e.ios.Out
+	cmd.Stderr = e.ios.ErrOut
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s: %w", args[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return Strip(string(data)), nil
+}
+
+// Command returns the editor command line to run.
+func Command() string {
+	return CommandWith("")
+}
+
+// CommandWith returns the editor command line to run when the bkt config
+// names configured; BKT_EDITOR still takes precedence over it.
+func CommandWith(configured string) string {
+	if value := strings.TrimSpace(os.Getenv("BKT_EDITOR")); value != "" {
+		return value
+	}
+	if value := strings.TrimSpace(configured); value != "" {
+		return value
+	}
+	for _, env := range []string{"GIT_EDITOR", "VISUAL", "EDITOR"} {
+		if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+			return value
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Template renders initial followed by the scissors line and instructions,
+// each as a "# " comment.
+func Template(initial string, instructions ...string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(initial, "\n"))
+	b.WriteString("\n\n")
+	b.WriteString(Scissors)
+	b.WriteString("\n# Do not modify or remove the line above.\n")
+	for _, line := range instructions {
+		b.WriteString("# " + line + "\n")
+	}
+	return b.String()
+}
+
+// Strip removes the scissors line and everything below it, along with
+// leading blank lines and trailing whitespace. Lines starting with "#" above
+// the scissors line, such as Markdown headings, are kept.
+func Strip(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if i := strings.Index(text, Scissors); i >= 0 && (i == 0 || text[i-1] == '\n') {
+		text = text[:i]
+	}
+	return strings.TrimLeft(strings.TrimRight(text, " \t\n"), "\n")
+}
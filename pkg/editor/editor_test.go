@@ -0,0 +1,134 @@
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func TestTemplateStripRoundTrip(t *testing.T) {
+	text := "## Summary\n\nFixes the retry loop.\n"
+	rendered := Template(text, "Write the description above.", "Lines below the scissors are ignored.")
+
+	if !strings.Contains(rendered, Scissors) {
+		t.Fatalf("expected scissors line in template:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "# Write the description above.\n") {
+		t.Fatalf("expected instructions as comments:\n%s", rendered)
+	}
+
+	if got, want := Strip(rendered), "## Summary\n\nFixes the retry loop."; got != want {
+		t.Fatalf("Strip = %q, want %q", got, want)
+	}
+}
+
+func TestStripWithoutScissors(t *testing.T) {
+	if got, want := Strip("\n\n# Heading\r\nbody  \n\n"), "# Heading\nbody"; got != want {
+		t.Fatalf("Strip = %q, want %q", got, want)
+	}
+}
+
+func TestCommandPrecedence(t *testing.T) {
+	for _, env := range []string{"BKT_EDITOR", "GIT_EDITOR", "VISUAL", "EDITOR"} {
+		t.Setenv(env, "")
+	}
+
+	t.Setenv("EDITOR", "nano")
+	t.Setenv("VISUAL", "code --wait")
+	if got := Command(); got != "code --wait" {
+		t.Fatalf("Command = %q, want VISUAL", got)
+	}
+
+	t.Setenv("GIT_EDITOR", "vim")
+	if got := Command(); got != "vim" {
+		t.Fatalf("Command = %q, want GIT_EDITOR", got)
+	}
+
+	t.Setenv("BKT_EDITOR", "hx")
+	if got := Command(); got != "hx" {
+		t.Fatalf("Command = %q, want BKT_EDITOR", got)
+	}
+}
+
+func TestEditRequiresTerminal(t *testing.T) {
+	ios := &iostreams.IOStreams{
+		In:     io.NopCloser(strings.NewReader("")),
+		Out:    &bytes.Buffer{},
+		ErrOut: &bytes.Buffer{},
+	}
+
+	_, err := NewSystem(ios).Edit("MSG.md", "hello")
+	if !errors.Is(err, ErrNotInteractive) {
+		t.Fatalf("expected ErrNotInteractive, got %v", err)
+	}
+}
+
+func TestEditRunsEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+
+	// The fake editor keeps the template but replaces the first line, as a
+	// user typing a new description would.
+	script := filepath.Join(t.TempDir(), "fake-editor")
+	body := "#!/bin/sh\n{ echo 'Rewritten'; tail -n +2 \"$1\"; } > \"$1.new\" && mv \"$1.new\" \"$1\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	t.Setenv("BKT_EDITOR", script)
+
+	ios := &iostreams.IOStreams{
+		In:     io.NopCloser(strings.NewReader("")),
+		Out:    &bytes.Buffer{},
+		ErrOut: &bytes.Buffer{},
+	}
+	forceTTY(ios)
+
+	got, err := NewSystem(ios).Edit("MSG.md", "Original\n\nSecond paragraph.", "Instructions.")
+	if err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	if want := "Rewritten\n\nSecond paragraph."; got != want {
+		t.Fatalf("Edit = %q, want %q", got, want)
+	}
+}
+
+func forceTTY(ios *iostreams.IOStreams) {
+	setBoolField := func(name string) {
+		field := reflect.ValueOf(ios).Elem().FieldByName(name)
+		ptr := unsafe.Pointer(field.UnsafeAddr())
+		reflect.NewAt(field.Type(), ptr).Elem().SetBool(true)
+	}
+
+	setBoolField("isStdinTTY")
+	setBoolField("isStdoutTTY")
+	setBoolField("isStderrTTY")
+}
+
+func TestCommandWithConfiguredEditor(t *testing.T) {
+	for _, env := range []string{"BKT_EDITOR", "GIT_EDITOR", "VISUAL", "EDITOR"} {
+		t.Setenv(env, "")
+	}
+
+	t.Setenv("GIT_EDITOR", "vim")
+	if got := CommandWith("code --wait"); got != "code --wait" {
+		t.Fatalf("CommandWith = %q, want the configured editor", got)
+	}
+	if got := CommandWith(""); got != "vim" {
+		t.Fatalf("CommandWith = %q, want GIT_EDITOR", got)
+	}
+
+	t.Setenv("BKT_EDITOR", "hx")
+	if got := CommandWith("code --wait"); got != "hx" {
+		t.Fatalf("CommandWith = %q, want BKT_EDITOR", got)
+	}
+}
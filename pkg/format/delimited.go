@@ -0,0 +1,129 @@
+package format
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// writeDelimited renders data as CSV or TSV with a header row. Records are
+// found the same way as for SelectFields: a list, the single list inside an
+// envelope object, or the object itself. Without explicit fields the columns
+// are the union of record keys in alphabetical order.
+func writeDelimited(w io.Writer, format string, data any, fields []string) error {
+	value, err := normaliseForJQ(data)
+	if err != nil {
+		return err
+	}
+	records, err := delimitedRecords(value)
+	if err != nil {
+		return err
+	}
+
+	columns := fields
+	if len(columns) == 0 {
+		seen := make(map[string]bool)
+		for _, record := range records {
+			for key := range record {
+				if !seen[key] {
+					seen[key] = true
+					columns = append(columns, key)
+				}
+			}
+		}
+		sort.Strings(columns)
+	} else if len(records) > 0 {
+		available := make(map[string]bool)
+		for _, record := range records {
+			for key := range record {
+				available[key] = true
+			}
+		}
+		if err := checkFields(available, columns); err != nil {
+			return err
+		}
+	}
+
+	rows := make([][]string, 0, len(records)+1)
+	rows = append(rows, columns)
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			cell, err := cellValue(record[column])
+			if err != nil {
+				return err
+			}
+			row[i] = cell
+		}
+		rows = append(rows, row)
+	}
+
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.WriteAll(rows); err != nil {
+			return fmt.Errorf("encode csv: %w", err)
+		}
+		return nil
+	case "tsv":
+		escaper := strings.NewReplacer("\\", `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+		for _, row := range rows {
+			for i := range row {
+				row[i] = escaper.Replace(row[i])
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func delimitedRecords(value any) ([]map[string]any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		records := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			record, ok := item.(map[string]any)
+			if !ok {
+				record = map[string]any{"value": item}
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	case map[string]any:
+		if nested, ok := soleValue(v, func(x any) bool { _, ok := x.([]any); return ok }); ok {
+			return delimitedRecords(nested)
+		}
+		return []map[string]any{v}, nil
+	default:
+		return []map[string]any{{"value": v}}, nil
+	}
+}
+
+// cellValue renders scalars as plain text and nested values as compact JSON.
+func cellValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("encode cell: %w", err)
+		}
+		return string(data), nil
+	}
+}
@@ -0,0 +1,127 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectFields reduces data to the named top-level fields of its records.
+//
+// Commands usually wrap their records in an envelope such as
+// {"workspace": "acme", "pull_requests": [...]}. Fields are matched against
+// the envelope first; when none of them is an envelope key, the single list
+// (or, failing that, the single object) inside it is treated as the records,
+// so `--json id,title` on a list command yields an array of {id, title}.
+func SelectFields(data any, fields []string) (any, error) {
+	value, err := normaliseForJQ(data)
+	if err != nil {
+		return nil, err
+	}
+	return selectFields(value, fields)
+}
+
+func selectFields(value any, fields []string) (any, error) {
+	switch v := value.(type) {
+	case []any:
+		return selectFromList(v, fields)
+	case map[string]any:
+		if hasAnyField(v, fields) {
+			return pickFields(v, fields)
+		}
+		if nested, ok := soleValue(v, func(x any) bool { _, ok := x.([]any); return ok }); ok {
+			return selectFromList(nested.([]any), fields)
+		}
+		if nested, ok := soleValue(v, func(x any) bool { _, ok := x.(map[string]any); return ok }); ok {
+			if obj := nested.(map[string]any); hasAnyField(obj, fields) {
+				return pickFields(obj, fields)
+			}
+		}
+		return pickFields(v, fields)
+	default:
+		return nil, fmt.Errorf("--json fields require object output")
+	}
+}
+
+func selectFromList(items []any, fields []string) (any, error) {
+	available := make(map[string]bool)
+	for _, item := range items {
+		if obj, ok := item.(map[string]any); ok {
+			for key := range obj {
+				available[key] = true
+			}
+		}
+	}
+	if len(items) > 0 {
+		if err := checkFields(available, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("--json fields require object output")
+		}
+		picked := make(map[string]any, len(fields))
+		for _, field := range fields {
+			picked[field] = obj[field]
+		}
+		out = append(out, picked)
+	}
+	return out, nil
+}
+
+func pickFields(obj map[string]any, fields []string) (any, error) {
+	available := make(map[string]bool, len(obj))
+	for key := range obj {
+		available[key] = true
+	}
+	if err := checkFields(available, fields); err != nil {
+		return nil, err
+	}
+
+	picked := make(map[string]any, len(fields))
+	for _, field := range fields {
+		picked[field] = obj[field]
+	}
+	return picked, nil
+}
+
+func checkFields(available map[string]bool, fields []string) error {
+	for _, field := range fields {
+		if available[field] {
+			continue
+		}
+		names := make([]string, 0, len(available))
+		for key := range available {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown JSON field %q; available fields: %s", field, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func hasAnyField(obj map[string]any, fields []string) bool {
+	for _, field := range fields {
+		if _, ok := obj[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// soleValue returns the only value in obj that satisfies match.
+func soleValue(obj map[string]any, match func(any) bool) (any, bool) {
+	var found any
+	count := 0
+	for _, v := range obj {
+		if match(v) {
+			found = v
+			count++
+		}
+	}
+	return found, count == 1
+}
@@ -17,6 +17,10 @@ type Options struct {
 	Format   string
 	JQ       string
 	Template string
+	// Fields limits output to the named fields; see SelectFields.
+	Fields []string
+	// Color enables ANSI output from the autocolor template helper.
+	Color bool
 }
 
 // Write serializes data according to the chosen options. When no structured
@@ -30,8 +34,20 @@ func Write(w io.Writer, opts Options, data any, fallback func() error) error {
 		return fallback()
 	}
 
+	if opts.Format == "csv" || opts.Format == "tsv" {
+		return writeDelimited(w, opts.Format, data, opts.Fields)
+	}
+
 	value := data
 
+	if len(opts.Fields) > 0 {
+		var err error
+		value, err = SelectFields(value, opts.Fields)
+		if err != nil {
+			return err
+		}
+	}
+
 	if opts.JQ != "" {
 		var err error
 		value, err = applyJQ(opts.JQ, value)
@@ -41,7 +57,7 @@ func Write(w io.Writer, opts Options, data any, fallback func() error) error {
 	}
 
 	if opts.Template != "" {
-		tmpl, err := template.New("output").Parse(opts.Template)
+		tmpl, err := template.New("output").Funcs(templateFuncs(opts.Color)).Parse(opts.Template)
 		if err != nil {
 			return fmt.Errorf("parse template: %w", err)
 		}
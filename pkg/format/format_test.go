@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 type sample struct {
@@ -94,3 +95,119 @@ func TestWriteJQPreservesLargeIntegersInStructs(t *testing.T) {
 		t.Fatalf("expected jq to preserve large integer 18446744073709551615 from struct, got %q", output)
 	}
 }
+
+func TestWriteSelectsFieldsFromEnvelopeList(t *testing.T) {
+	buf := new(bytes.Buffer)
+	data := map[string]any{
+		"workspace": "acme",
+		"pull_requests": []map[string]any{
+			{"id": 1, "title": "First", "state": "OPEN", "author": "ana"},
+			{"id": 2, "title": "Second", "state": "MERGED", "author": "bo"},
+		},
+	}
+
+	if err := Write(buf, Options{Format: "json", Fields: []string{"id", "state"}}, data, nil); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode JSON output: %v", err)
+	}
+	if len(got) != 2 || len(got[0]) != 2 || got[0]["state"] != "OPEN" || got[1]["id"] != float64(2) {
+		t.Fatalf("unexpected selection: %v", got)
+	}
+}
+
+func TestSelectFieldsTopLevelAndUnknown(t *testing.T) {
+	data := sample{Name: "demo", Count: 3}
+
+	got, err := SelectFields(data, []string{"name"})
+	if err != nil {
+		t.Fatalf("SelectFields returned error: %v", err)
+	}
+	if m := got.(map[string]any); len(m) != 1 || m["name"] != "demo" {
+		t.Fatalf("unexpected selection: %v", got)
+	}
+
+	_, err = SelectFields(data, []string{"name", "missing"})
+	if err == nil || err.Error() != `unknown JSON field "missing"; available fields: count, name` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWriteTemplateHelpers(t *testing.T) {
+	fixed := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	t.Cleanup(func() { now = time.Now })
+
+	data := map[string]any{
+		"items": []map[string]any{
+			{"id": 1, "title": "A rather long pull request title", "updated": "2026-03-10T09:00:00Z"},
+			{"id": 2, "title": "Short", "updated": json.Number("1772928000000")},
+		},
+	}
+	tmpl := `{{range .items}}{{.id}} {{truncate 10 .title}} {{timeago .updated}} {{autocolor "red" "x"}}{{"\n"}}{{end}}` +
+		`{{join "," (pluck "id" .items)}} {{color "green+bold" "ok"}}`
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, Options{Template: tmpl}, data, nil); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	want := "1 A rathe... about 3 hours ago x\n" +
+		"2 Short about 2 days ago x\n" +
+		"1,2 \x1b[32;1mok\x1b[0m"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected template output:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestWriteTemplateRejectsUnknownColor(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, Options{Template: `{{color "plaid" "x"}}`}, nil, nil)
+	if err == nil {
+		t.Fatal("expected unknown color error")
+	}
+}
+
+func TestWriteCSVFromEnvelope(t *testing.T) {
+	data := map[string]any{
+		"workspace": "acme",
+		"repositories": []map[string]any{
+			{"slug": "api", "description": "Core, \"public\" API", "private": true},
+			{"slug": "web", "private": false, "owner": map[string]any{"name": "ana"}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, Options{Format: "csv"}, data, nil); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	want := "description,owner,private,slug\n" +
+		"\"Core, \"\"public\"\" API\",,true,api\n" +
+		",\"{\"\"name\"\":\"\"ana\"\"}\",false,web\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected csv:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestWriteTSVSelectsColumnsAndEscapes(t *testing.T) {
+	data := []map[string]any{
+		{"id": 1, "title": "tab\there", "state": "OPEN"},
+		{"id": 2, "title": "line\nbreak", "state": "MERGED"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, Options{Format: "tsv", Fields: []string{"id", "title"}}, data, nil); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	want := "id\ttitle\n1\ttab\\there\n2\tline\\nbreak\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected tsv:\n got %q\nwant %q", got, want)
+	}
+
+	if err := Write(new(bytes.Buffer), Options{Format: "tsv", Fields: []string{"nope"}}, data, nil); err == nil {
+		t.Fatal("expected unknown column error")
+	}
+}
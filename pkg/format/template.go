@@ -0,0 +1,216 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// now is swapped out in tests.
+var now = time.Now
+
+var ansiStyles = map[string]string{
+	"bold":    "1",
+	"dim":     "2",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"gray":    "90",
+}
+
+// templateFuncs returns the helpers available to --template. autocolor only
+// emits escape codes when color is true; color always does.
+func templateFuncs(color bool) template.FuncMap {
+	return template.FuncMap{
+		"color": colorize,
+		"autocolor": func(style string, value any) (string, error) {
+			if !color {
+				return fmt.Sprint(value), nil
+			}
+			return colorize(style, value)
+		},
+		"timeago":  timeAgo,
+		"timefmt":  timeFormat,
+		"truncate": truncate,
+		"join":     join,
+		"pluck":    pluck,
+	}
+}
+
+// colorize wraps value in ANSI codes; style may combine names with "+", as
+// in "red+bold".
+func colorize(style string, value any) (string, error) {
+	var codes []string
+	for _, name := range strings.Split(style, "+") {
+		code, ok := ansiStyles[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return "", fmt.Errorf("unknown color %q", name)
+		}
+		codes = append(codes, code)
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m" + fmt.Sprint(value) + "\x1b[0m", nil
+}
+
+func timeAgo(value any) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	if t.IsZero() {
+		return "", nil
+	}
+
+	d := now().Sub(t)
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return "less than a minute ago", nil
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute"), nil
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour"), nil
+	case d < 30*24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day"), nil
+	case d < 365*24*time.Hour:
+		return plural(int(d/(30*24*time.Hour)), "month"), nil
+	default:
+		return plural(int(d/(365*24*time.Hour)), "year"), nil
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("about 1 %s ago", unit)
+	}
+	return fmt.Sprintf("about %d %ss ago", n, unit)
+}
+
+func timeFormat(layout string, value any) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	if t.IsZero() {
+		return "", nil
+	}
+	return t.Format(layout), nil
+}
+
+// toTime accepts time values, RFC 3339 strings (Cloud) and millisecond epoch
+// numbers (Data Center).
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, nil
+		}
+		return *v, nil
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse time %q: %w", v, err)
+		}
+		return t, nil
+	case json.Number:
+		ms, err := v.Int64()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse time %q: %w", v, err)
+		}
+		return time.UnixMilli(ms), nil
+	case float64:
+		return time.UnixMilli(int64(v)), nil
+	case int:
+		return time.UnixMilli(int64(v)), nil
+	case int64:
+		return time.UnixMilli(v), nil
+	default:
+		return time.Time{}, fmt.Errorf("cannot interpret %T as a time", value)
+	}
+}
+
+// truncate shortens text to at most length runes, ending with "...".
+func truncate(length int, value any) string {
+	text := fmt.Sprint(value)
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	if length <= 3 {
+		return string([]rune(text)[:length])
+	}
+	return string([]rune(text)[:length-3]) + "..."
+}
+
+func join(sep string, list any) (string, error) {
+	items, err := listItems(list)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = fmt.Sprint(item)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// pluck collects field from every element of list. Elements may be maps
+// keyed by JSON names or structs with exported fields.
+func pluck(field string, list any) ([]any, error) {
+	items, err := listItems(list)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		v := reflect.Indirect(reflect.ValueOf(item))
+		switch v.Kind() {
+		case reflect.Map:
+			elem := v.MapIndex(reflect.ValueOf(field))
+			if !elem.IsValid() {
+				out = append(out, nil)
+				continue
+			}
+			out = append(out, elem.Interface())
+		case reflect.Struct:
+			elem := v.FieldByName(field)
+			if !elem.IsValid() {
+				return nil, fmt.Errorf("pluck: %s has no field %q", v.Type(), field)
+			}
+			out = append(out, elem.Interface())
+		default:
+			return nil, fmt.Errorf("pluck: cannot read %q from %T", field, item)
+		}
+	}
+	return out, nil
+}
+
+func listItems(list any) ([]any, error) {
+	if list == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %T", list)
+	}
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, nil
+}
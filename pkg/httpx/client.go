@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,20 +24,25 @@ type Client struct {
 	baseURL   *url.URL
 	username  string
 	password  string
+	tokens    TokenSource
 	userAgent string
 
 	httpClient *http.Client
 
-	enableCache bool
-	cacheMu     sync.RWMutex
-	cache       map[string]*cacheEntry
+	enableCache   bool
+	cacheDir      string
+	cacheIdentity string
+	pruneOnce     sync.Once
+	cacheMu       sync.RWMutex
+	cache         map[string]*cacheEntry
 
 	rateMu sync.RWMutex
 	rate   RateLimit
 
 	retry RetryPolicy
 
-	debug bool
+	debug    DebugLevel
+	debugOut io.Writer
 }
 
 // Options configures a Client.
@@ -44,17 +52,60 @@ type Options struct {
 	Password  string
 	UserAgent string
 	Timeout   time.Duration
+	// TokenSource, when set, supplies a bearer token for every request and
+	// takes precedence over Username/Password.
+	TokenSource TokenSource
+
+	// Proxy is an explicit proxy URL; when empty the standard
+	// HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables apply.
+	Proxy string
+	// CACertFile names a PEM bundle trusted in addition to the system roots,
+	// for private CAs and TLS-intercepting proxies.
+	CACertFile string
+	// InsecureSkipVerify disables TLS certificate verification entirely.
+	InsecureSkipVerify bool
 
 	EnableCache bool
-	Retry       RetryPolicy
-	Debug       bool
+	// CacheDir, when set together with EnableCache, persists ETag-validated
+	// GET responses across invocations so later runs can revalidate them
+	// with If-None-Match and reuse the body on 304 Not Modified.
+	CacheDir string
+	// CacheIdentity names the account whose responses are cached on disk,
+	// such as the host and user behind a TokenSource whose tokens rotate.
+	// It defaults to the Username/Password credentials; with a TokenSource
+	// and no identity, responses are only cached in memory.
+	CacheIdentity string
+	Retry         RetryPolicy
+
+	// Debug enables DebugRequests tracing; DebugLevel selects a level
+	// explicitly. Without either, BKT_DEBUG decides (see DebugLevelFromEnv).
+	Debug      bool
+	DebugLevel DebugLevel
+	// DebugOutput receives traces; it defaults to os.Stderr.
+	DebugOutput io.Writer
+}
+
+// TokenSource supplies OAuth access tokens, refreshing them as needed.
+type TokenSource interface {
+	AccessToken(ctx context.Context) (string, error)
 }
 
 // RetryPolicy defines exponential backoff characteristics for retries.
+//
+// Idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried after
+// network errors and transient 5xx responses; any request is retried after a
+// 429 since the server rejected it without processing. Delays double from
+// InitialBackoff up to MaxBackoff with random jitter, unless the response
+// carries a Retry-After header.
 type RetryPolicy struct {
+	// MaxAttempts bounds the total number of attempts, including the first;
+	// 1 disables retries.
 	MaxAttempts    int
 	InitialBackoff time.Duration
 	MaxBackoff     time.Duration
+	// MaxRetryAfter caps how long a Retry-After header may delay a retry;
+	// longer server-requested waits fail immediately instead.
+	MaxRetryAfter time.Duration
 }
 
 // RateLimit captures headers advertised by Bitbucket for throttling.
@@ -89,10 +140,16 @@ func New(opts Options) (*Client, error) {
 		timeout = 30 * time.Second
 	}
 
+	transport, err := newTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	client := &Client{
 		baseURL:  base,
 		username: strings.TrimSpace(opts.Username),
 		password: opts.Password,
+		tokens:   opts.TokenSource,
 		userAgent: func() string {
 			if opts.UserAgent != "" {
 				return opts.UserAgent
@@ -100,14 +157,27 @@ func New(opts Options) (*Client, error) {
 			return "bkt-cli"
 		}(),
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: transport,
 		},
 		enableCache: opts.EnableCache,
 		cache:       make(map[string]*cacheEntry),
 	}
+	if identity := cacheIdentityHash(opts.CacheIdentity, client.username, client.password, opts.TokenSource); identity != "" {
+		client.cacheDir = opts.CacheDir
+		client.cacheIdentity = identity
+	}
 
-	if opts.Debug || os.Getenv("BKT_HTTP_DEBUG") != "" {
-		client.debug = true
+	client.debug = opts.DebugLevel
+	if client.debug == DebugOff && opts.Debug {
+		client.debug = DebugRequests
+	}
+	if client.debug == DebugOff {
+		client.debug = DebugLevelFromEnv()
+	}
+	client.debugOut = opts.DebugOutput
+	if client.debugOut == nil {
+		client.debugOut = os.Stderr
 	}
 
 	policy := opts.Retry
@@ -120,6 +190,9 @@ func New(opts Options) (*Client, error) {
 	if policy.MaxBackoff == 0 {
 		policy.MaxBackoff = 2 * time.Second
 	}
+	if policy.MaxRetryAfter == 0 {
+		policy.MaxRetryAfter = time.Minute
+	}
 	client.retry = policy
 
 	return client, nil
@@ -204,7 +277,7 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
 
-	if c.username != "" || c.password != "" {
+	if c.tokens == nil && (c.username != "" || c.password != "") {
 		req.SetBasicAuth(c.username, c.password)
 	}
 
@@ -212,10 +285,15 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 }
 
 // Do executes the HTTP request and decodes the response into v when provided.
+// A request that runs out of time fails with a *TimeoutError.
 func (c *Client) Do(req *http.Request, v any) error {
 	if req == nil {
 		return fmt.Errorf("request is nil")
 	}
+	return asTimeout(req, c.do(req, v))
+}
+
+func (c *Client) do(req *http.Request, v any) error {
 
 	attempts := 0
 	for {
@@ -224,22 +302,42 @@ func (c *Client) Do(req *http.Request, v any) error {
 			return err
 		}
 
+		if c.tokens != nil {
+			// Resolve the token per attempt so a refresh during a retry loop
+			// is picked up.
+			token, err := c.tokens.AccessToken(attemptReq.Context())
+			if err != nil {
+				return err
+			}
+			attemptReq.Header.Set("Authorization", "Bearer "+token)
+		}
+
 		if c.enableCache && attemptReq.Method == http.MethodGet {
 			if etag := c.cachedETag(attemptReq); etag != "" {
 				attemptReq.Header.Set("If-None-Match", etag)
 			}
 		}
 
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "--> %s %s\n", attemptReq.Method, attemptReq.URL.String())
+		if err := c.logRequest(attemptReq); err != nil {
+			return err
 		}
 
-		resp, err := c.httpClient.Do(attemptReq)
+		// A caller deadline governs the request in place of the client's
+		// default per-request timeout, so --timeout can also extend it.
+		httpClient := c.httpClient
+		if _, hasDeadline := attemptReq.Context().Deadline(); hasDeadline || isUnbounded(attemptReq) {
+			unbounded := *c.httpClient
+			unbounded.Timeout = 0
+			httpClient = &unbounded
+		}
+
+		started := time.Now()
+		resp, err := httpClient.Do(attemptReq)
 		if err != nil {
-			if !c.shouldRetry(attempts, 0) {
-				if c.debug {
-					fmt.Fprintf(os.Stderr, "<-- network error: %v\n", err)
-				}
+			if logErr := c.logError(err, time.Since(started)); logErr != nil {
+				return errors.Join(err, logErr)
+			}
+			if req.Context().Err() != nil || !c.shouldRetry(attempts, req.Method, 0) {
 				return err
 			}
 			attempts++
@@ -248,9 +346,6 @@ func (c *Client) Do(req *http.Request, v any) error {
 				return waitErr
 			}
 			if !continueRetry {
-				if c.debug {
-					fmt.Fprintf(os.Stderr, "<-- retry abort after error: %v\n", err)
-				}
 				return err
 			}
 			continue
@@ -259,8 +354,13 @@ func (c *Client) Do(req *http.Request, v any) error {
 		c.updateRateLimit(resp)
 		c.applyAdaptiveThrottle()
 
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "<-- %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+		if dst := capturedHeaders(attemptReq); dst != nil {
+			*dst = resp.Header.Clone()
+		}
+
+		if err := c.logResponse(resp, time.Since(started)); err != nil {
+			_ = resp.Body.Close()
+			return err
 		}
 
 		if resp.StatusCode == http.StatusNotModified && c.enableCache && attemptReq.Method == http.MethodGet {
@@ -275,7 +375,7 @@ func (c *Client) Do(req *http.Request, v any) error {
 			// Read body for retry logic; errors are intentionally ignored as we'll retry anyway
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			_ = resp.Body.Close()
-			if !c.shouldRetry(attempts, resp.StatusCode) {
+			if !c.shouldRetry(attempts, req.Method, resp.StatusCode) {
 				if len(bodyBytes) > 0 {
 					resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 				}
@@ -313,6 +413,9 @@ func (c *Client) Do(req *http.Request, v any) error {
 		}
 
 		if writer, ok := v.(io.Writer); ok {
+			if receiver, ok := writer.(ContentLengthReceiver); ok && resp.ContentLength >= 0 {
+				receiver.SetContentLength(resp.ContentLength)
+			}
 			_, err := io.Copy(writer, resp.Body)
 			_ = resp.Body.Close()
 			return err
@@ -339,52 +442,6 @@ func (c *Client) Do(req *http.Request, v any) error {
 	}
 }
 
-func decodeError(resp *http.Response) error {
-	type apiErrEntry struct {
-		Message       string `json:"message"`
-		ExceptionName string `json:"exceptionName"`
-	}
-	type apiErr struct {
-		Errors []apiErrEntry `json:"errors"`
-	}
-
-	var payload apiErr
-	data, err := io.ReadAll(resp.Body)
-	if err == nil && len(data) > 0 {
-		// Attempt to parse structured error; intentionally ignore unmarshal errors and fall back to raw text
-		_ = json.Unmarshal(data, &payload)
-	}
-
-	if len(payload.Errors) > 0 {
-		// Prioritize user-actionable errors like CAPTCHA over generic ones
-		bestErr := payload.Errors[0]
-		for _, e := range payload.Errors {
-			if isCaptchaException(e.ExceptionName) {
-				bestErr = e
-				break
-			}
-		}
-
-		msg := bestErr.Message
-		// Add hint for CAPTCHA-locked accounts
-		if isCaptchaException(bestErr.ExceptionName) && !strings.Contains(strings.ToLower(msg), "captcha") {
-			msg = "CAPTCHA verification required: " + msg
-		}
-		return fmt.Errorf("%s: %s", resp.Status, msg)
-	}
-
-	if err == nil && len(data) > 0 {
-		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
-	}
-
-	return fmt.Errorf("%s", resp.Status)
-}
-
-// isCaptchaException checks if the exception name indicates a CAPTCHA-locked account.
-func isCaptchaException(exceptionName string) bool {
-	return strings.Contains(strings.ToLower(exceptionName), "captcharequired")
-}
-
 func cloneRequest(req *http.Request) (*http.Request, error) {
 	newReq := req.Clone(req.Context())
 	newReq.Header = req.Header.Clone()
@@ -401,15 +458,48 @@ func cloneRequest(req *http.Request) (*http.Request, error) {
 	return newReq, nil
 }
 
+// shouldRetryStatus reports whether a response status is worth retrying:
+// rate limiting and the 5xx codes that signal a transient condition.
 func shouldRetryStatus(code int) bool {
-	if code == http.StatusTooManyRequests {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether repeating a request with method cannot apply
+// its effect twice.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
 		return true
 	}
-	return code >= 500 && code <= 599
+	return false
+}
+
+// shouldRetry reports whether another attempt is allowed after a failure
+// with status (0 for network errors).
+func (c *Client) shouldRetry(attempts int, method string, status int) bool {
+	if attempts+1 >= c.retry.MaxAttempts {
+		return false
+	}
+	return status == http.StatusTooManyRequests || isIdempotent(method)
 }
 
-func (c *Client) shouldRetry(attempts int, status int) bool {
-	return attempts+1 < c.retry.MaxAttempts
+// jitter randomises a backoff delay; replaced in tests for determinism.
+var jitter = func(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	// Equal jitter: keep half the delay and randomise the rest so parallel
+	// clients hitting the same limit do not retry in lockstep.
+	half := d / 2
+	return half + rand.N(d-half+1)
 }
 
 func (c *Client) backoff(ctx context.Context, attempts int, resp *http.Response) (bool, error) {
@@ -424,12 +514,14 @@ func (c *Client) backoff(ctx context.Context, attempts int, resp *http.Response)
 	if delay > c.retry.MaxBackoff {
 		delay = c.retry.MaxBackoff
 	}
+	delay = jitter(delay)
 
 	if resp != nil {
-		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-			if secs, err := strconv.Atoi(retryAfter); err == nil {
-				delay = time.Duration(secs) * time.Second
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if retryAfter > c.retry.MaxRetryAfter {
+				return false, nil
 			}
+			delay = retryAfter
 		}
 	}
 
@@ -442,6 +534,12 @@ func (c *Client) backoff(ctx context.Context, attempts int, resp *http.Response)
 		}
 	}
 
+	if c.debug != DebugOff {
+		if _, err := fmt.Fprintf(c.debugOut, "... retrying in %s (attempt %d of %d)\n", delay.Round(time.Millisecond), attempts+1, c.retry.MaxAttempts); err != nil {
+			return false, err
+		}
+	}
+
 	timer := time.NewTimer(delay)
 	defer timer.Stop()
 
@@ -453,14 +551,40 @@ func (c *Client) backoff(ctx context.Context, attempts int, resp *http.Response)
 	}
 }
 
+// parseRetryAfter interprets a Retry-After header given either as delay
+// seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
 func (c *Client) cacheKey(req *http.Request) string {
 	return req.Method + " " + req.URL.String()
 }
 
 func (c *Client) cachedETag(req *http.Request) string {
 	c.cacheMu.RLock()
-	defer c.cacheMu.RUnlock()
-	if entry, ok := c.cache[c.cacheKey(req)]; ok {
+	entry, ok := c.cache[c.cacheKey(req)]
+	c.cacheMu.RUnlock()
+	if ok {
+		return entry.etag
+	}
+	if entry, ok := c.loadDiskCache(req); ok {
 		return entry.etag
 	}
 	return ""
@@ -470,9 +594,11 @@ func (c *Client) storeCache(req *http.Request, body []byte, etag string) {
 	if etag == "" || len(body) == 0 {
 		return
 	}
+	entry := &cacheEntry{etag: etag, body: append([]byte(nil), body...), storedAt: time.Now()}
 	c.cacheMu.Lock()
-	c.cache[c.cacheKey(req)] = &cacheEntry{etag: etag, body: append([]byte(nil), body...), storedAt: time.Now()}
+	c.cache[c.cacheKey(req)] = entry
 	c.cacheMu.Unlock()
+	c.storeDiskCache(req, entry)
 }
 
 func (c *Client) applyCachedResponse(req *http.Request, v any) error {
@@ -496,6 +622,26 @@ func (c *Client) applyCachedResponse(req *http.Request, v any) error {
 	return json.Unmarshal(entry.body, v)
 }
 
+type headersKey struct{}
+
+// ContentLengthReceiver is implemented by writers passed to Do that want the
+// response size before the body is streamed into them, e.g. progress bars.
+type ContentLengthReceiver interface {
+	SetContentLength(n int64)
+}
+
+// CaptureHeaders arranges for the response headers of req to be copied into
+// dst when Do completes, for callers that need metadata such as granted
+// scopes alongside the decoded body.
+func CaptureHeaders(req *http.Request, dst *http.Header) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), headersKey{}, dst))
+}
+
+func capturedHeaders(req *http.Request) *http.Header {
+	dst, _ := req.Context().Value(headersKey{}).(*http.Header)
+	return dst
+}
+
 // RateLimitState returns the last observed rate limit headers.
 func (c *Client) RateLimitState() RateLimit {
 	c.rateMu.RLock()
@@ -591,6 +737,12 @@ type MultipartFile struct {
 // NewMultipartRequest builds a multipart/form-data request for file uploads.
 // The request body is buffered in memory to support retries.
 func (c *Client) NewMultipartRequest(ctx context.Context, method, path string, files []MultipartFile) (*http.Request, error) {
+	return c.NewMultipartFormRequest(ctx, method, path, nil, files)
+}
+
+// NewMultipartFormRequest is like NewMultipartRequest but also writes plain
+// form fields, in key order, ahead of the files.
+func (c *Client) NewMultipartFormRequest(ctx context.Context, method, path string, fields map[string]string, files []MultipartFile) (*http.Request, error) {
 	if strings.TrimSpace(path) == "" {
 		return nil, fmt.Errorf("path is required")
 	}
@@ -641,6 +793,17 @@ func (c *Client) NewMultipartRequest(ctx context.Context, method, path string, f
 		return nil, fmt.Errorf("at least one file is required")
 	}
 
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := mw.WriteField(k, fields[k]); err != nil {
+			return nil, fmt.Errorf("write form field: %w", err)
+		}
+	}
+
 	for _, f := range files {
 		if f.Reader == nil {
 			return nil, fmt.Errorf("reader is nil for file %q", f.FileName)
@@ -674,7 +837,7 @@ func (c *Client) NewMultipartRequest(ctx context.Context, method, path string, f
 		return io.NopCloser(bytes.NewReader(payload)), nil
 	}
 
-	if c.username != "" || c.password != "" {
+	if c.tokens == nil && (c.username != "" || c.password != "") {
 		req.SetBasicAuth(c.username, c.password)
 	}
 
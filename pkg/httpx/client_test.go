@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -410,3 +412,423 @@ func TestDecodeErrorPrioritizesCaptchaException(t *testing.T) {
 		})
 	}
 }
+
+type countingTokenSource struct {
+	calls int32
+}
+
+func (s *countingTokenSource) AccessToken(ctx context.Context) (string, error) {
+	n := atomic.AddInt32(&s.calls, 1)
+	if n == 1 {
+		return "first", nil
+	}
+	return "second", nil
+}
+
+func TestClientTokenSourceSetsBearerPerAttempt(t *testing.T) {
+	var seen []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		attempt := len(seen)
+		mu.Unlock()
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(payload{Message: "ok"})
+	}))
+	t.Cleanup(server.Close)
+
+	tokens := &countingTokenSource{}
+	client, err := New(Options{
+		BaseURL:     server.URL,
+		Username:    "ignored",
+		Password:    "ignored",
+		TokenSource: tokens,
+		Retry:       RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	var out payload
+	if err := client.Do(req, &out); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if len(seen) != 2 || seen[0] != "Bearer first" || seen[1] != "Bearer second" {
+		t.Fatalf("unexpected Authorization headers: %v", seen)
+	}
+}
+
+func TestClientDoesNotRetryNonIdempotentOnServerError(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{
+		BaseURL: server.URL,
+		Retry:   RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/pullrequests", map[string]string{"title": "x"})
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := client.Do(req, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if hits != 1 {
+		t.Fatalf("POST must not be retried after a 503, got %d attempts", hits)
+	}
+}
+
+func TestClientRetriesRateLimitHonoringRetryAfter(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{
+		BaseURL: server.URL,
+		// A backoff this long would time the test out; Retry-After wins.
+		Retry: RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour, MaxBackoff: time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/pullrequests", map[string]string{"title": "x"})
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if hits != 2 {
+		t.Fatalf("expected the 429 to be retried, got %d attempts", hits)
+	}
+}
+
+func TestClientGivesUpWhenRetryAfterExceedsCap(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{
+		BaseURL: server.URL,
+		Retry:   RetryPolicy{MaxAttempts: 3, MaxRetryAfter: time.Second},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/repositories", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := client.Do(req, nil); err == nil {
+		t.Fatal("expected rate limit error")
+	}
+	if hits != 1 {
+		t.Fatalf("expected no retry beyond MaxRetryAfter, got %d attempts", hits)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "5", want: 5 * time.Second, ok: true},
+		{value: "-1", ok: false},
+		{value: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second, ok: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestJitterStaysWithinBounds(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if got := jitter(time.Second); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("jitter(1s) = %v, want within [500ms, 1s]", got)
+		}
+	}
+}
+
+func TestClientDiskCacheRevalidatesAcrossClients(t *testing.T) {
+	var hits, revalidated int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&revalidated, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(payload{Message: "fresh"})
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	get := func(username string) payload {
+		t.Helper()
+		client, err := New(Options{BaseURL: server.URL, Username: username, Password: "secret", EnableCache: true, CacheDir: dir})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/repos", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		var out payload
+		if err := client.Do(req, &out); err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		return out
+	}
+
+	if out := get("alice"); out.Message != "fresh" {
+		t.Fatalf("unexpected first response %q", out.Message)
+	}
+	if out := get("alice"); out.Message != "fresh" {
+		t.Fatalf("expected cached body on 304, got %q", out.Message)
+	}
+	if revalidated != 1 {
+		t.Fatalf("expected the second client to revalidate, got %d revalidations", revalidated)
+	}
+
+	get("bob")
+	if revalidated != 1 {
+		t.Fatal("a cached response must not be revalidated for different credentials")
+	}
+	if hits != 3 {
+		t.Fatalf("expected 3 requests, got %d", hits)
+	}
+}
+
+func TestDecodeErrorReturnsTypedCloudError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"message":"Bad request","detail":"Invalid pull request","fields":{"title":["This field is required."]}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL, Retry: RetryPolicy{MaxAttempts: 1}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/pullrequests", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	err = client.Do(req, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "Bad request" || apiErr.Detail != "Invalid pull request" {
+		t.Fatalf("unexpected error fields: %+v", apiErr)
+	}
+	want := "400 Bad Request: Bad request (Invalid pull request); title: This field is required."
+	if err.Error() != want {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestAPIErrorMatchesSentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrPermission},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("list pull requests: %w", &APIError{StatusCode: tt.status, Status: http.StatusText(tt.status)})
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("status %d should match %v", tt.status, tt.sentinel)
+		}
+		if tt.sentinel != ErrNotFound && errors.Is(err, ErrNotFound) {
+			t.Errorf("status %d must not match ErrNotFound", tt.status)
+		}
+	}
+}
+
+func TestDebugAPILogsRedactedTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = w.Write([]byte(`{"message":"ok","access_token":"xyz"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var trace strings.Builder
+	client, err := New(Options{
+		BaseURL:     server.URL,
+		Username:    "me",
+		Password:    "secret",
+		DebugLevel:  DebugAPI,
+		DebugOutput: &trace,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/login", map[string]string{"user": "me", "password": "hunter2"})
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	var out payload
+	if err := client.Do(req, &out); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if out.Message != "ok" {
+		t.Fatalf("response body must survive tracing, got %q", out.Message)
+	}
+
+	got := trace.String()
+	for _, want := range []string{"--> POST " + server.URL + "/login", "<-- 200 OK (", "--> Authorization: [redacted]", "<-- Set-Cookie: [redacted]", `"user": "me"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trace missing %q:\n%s", want, got)
+		}
+	}
+	for _, secret := range []string{"hunter2", "xyz", "session=abc"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("trace leaked %q:\n%s", secret, got)
+		}
+	}
+}
+
+func TestDebugLevelFromEnv(t *testing.T) {
+	t.Setenv("BKT_HTTP_DEBUG", "")
+	for value, want := range map[string]DebugLevel{"": DebugOff, "0": DebugOff, "1": DebugRequests, "API": DebugAPI} {
+		t.Setenv("BKT_DEBUG", value)
+		if got := DebugLevelFromEnv(); got != want {
+			t.Errorf("BKT_DEBUG=%q: got %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestDoReportsDeadlineAsTimeoutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := client.NewRequest(ctx, http.MethodGet, "/slow?page=2", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	err = client.Do(req, nil)
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *TimeoutError, got %v", err)
+	}
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrTimeout and context.DeadlineExceeded, got %v", err)
+	}
+	if timeoutErr.URL != server.URL+"/slow" {
+		t.Fatalf("URL = %q, want query stripped", timeoutErr.URL)
+	}
+}
+
+func TestDoContextDeadlineOverridesClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL, Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := client.NewRequest(ctx, http.MethodGet, "/slow", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+}
+
+func TestDebugBodyRedactsLargeJSONBeforeTruncating(t *testing.T) {
+	var large strings.Builder
+	large.WriteString(`{"access_token":"xyz","items":[`)
+	for i := range 400 {
+		if i > 0 {
+			large.WriteString(",")
+		}
+		large.WriteString(`{"name":"item"}`)
+	}
+	large.WriteString(`]}`)
+
+	var trace strings.Builder
+	if err := writeDebugBody(&trace, "<--", "application/json", []byte(large.String())); err != nil {
+		t.Fatalf("writeDebugBody: %v", err)
+	}
+	if !strings.Contains(trace.String(), "[body truncated after") {
+		t.Fatalf("expected truncated trace:\n%s", trace.String())
+	}
+	if strings.Contains(trace.String(), "xyz") {
+		t.Fatalf("trace leaked token")
+	}
+
+	trace.Reset()
+	if err := writeDebugBody(&trace, "<--", "application/json", []byte(`{"password":"hunter2"`)); err != nil {
+		t.Fatalf("writeDebugBody: %v", err)
+	}
+	if strings.Contains(trace.String(), "hunter2") || !strings.Contains(trace.String(), "body omitted") {
+		t.Fatalf("unparsable JSON must not be echoed:\n%s", trace.String())
+	}
+}
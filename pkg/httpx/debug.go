@@ -0,0 +1,240 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DebugLevel controls request tracing.
+type DebugLevel int
+
+const (
+	// DebugOff disables tracing.
+	DebugOff DebugLevel = iota
+	// DebugRequests logs method, URL, status and timing of each request.
+	DebugRequests
+	// DebugAPI additionally logs redacted headers and bodies.
+	DebugAPI
+)
+
+// debugBodyLimit caps how much of a body is echoed in DebugAPI mode.
+const debugBodyLimit = 4096
+
+const redacted = "[redacted]"
+
+// DebugLevelFromEnv reads BKT_DEBUG (or the older BKT_HTTP_DEBUG): "api"
+// selects DebugAPI, any other value except "0" and "false" DebugRequests.
+func DebugLevelFromEnv() DebugLevel {
+	value := strings.TrimSpace(os.Getenv("BKT_DEBUG"))
+	if value == "" {
+		value = strings.TrimSpace(os.Getenv("BKT_HTTP_DEBUG"))
+	}
+	switch strings.ToLower(value) {
+	case "", "0", "false":
+		return DebugOff
+	case "api":
+		return DebugAPI
+	default:
+		return DebugRequests
+	}
+}
+
+// logRequest traces req. An error means the trace could not be written.
+func (c *Client) logRequest(req *http.Request) error {
+	if c.debug == DebugOff {
+		return nil
+	}
+	if _, err := fmt.Fprintf(c.debugOut, "--> %s %s\n", req.Method, req.URL.String()); err != nil {
+		return err
+	}
+	if c.debug < DebugAPI {
+		return nil
+	}
+	if err := writeDebugHeaders(c.debugOut, "-->", req.Header); err != nil {
+		return err
+	}
+	if req.GetBody == nil {
+		return nil
+	}
+	if isUnbounded(req) {
+		_, err := fmt.Fprintf(c.debugOut, "--> [streamed body omitted]\n")
+		return err
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = body.Close() }()
+	contentType := req.Header.Get("Content-Type")
+	// JSON is read whole so it can be redacted before it is truncated.
+	var reader io.Reader = body
+	if !isJSON(contentType) {
+		reader = io.LimitReader(body, debugBodyLimit+1)
+	}
+	data, _ := io.ReadAll(reader)
+	return writeDebugBody(c.debugOut, "-->", contentType, data)
+}
+
+// logResponse traces resp. In DebugAPI mode textual bodies are read and
+// replaced with an in-memory copy so the caller can still consume them.
+func (c *Client) logResponse(resp *http.Response, elapsed time.Duration) error {
+	if c.debug == DebugOff {
+		return nil
+	}
+	if _, err := fmt.Fprintf(c.debugOut, "<-- %s (%s)\n", resp.Status, elapsed.Round(time.Millisecond)); err != nil {
+		return err
+	}
+	if c.debug < DebugAPI {
+		return nil
+	}
+	if err := writeDebugHeaders(c.debugOut, "<--", resp.Header); err != nil {
+		return err
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !isTextual(contentType) {
+		if resp.ContentLength != 0 {
+			_, err := fmt.Fprintf(c.debugOut, "<-- [%s body omitted]\n", contentType)
+			return err
+		}
+		return nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return writeDebugBody(c.debugOut, "<--", contentType, data)
+}
+
+func (c *Client) logError(err error, elapsed time.Duration) error {
+	if c.debug == DebugOff {
+		return nil
+	}
+	_, werr := fmt.Fprintf(c.debugOut, "<-- network error after %s: %v\n", elapsed.Round(time.Millisecond), err)
+	return werr
+}
+
+func writeDebugHeaders(w io.Writer, prefix string, header http.Header) error {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if isSensitiveHeader(name) {
+			value = redacted
+		}
+		if _, err := fmt.Fprintf(w, "%s %s: %s\n", prefix, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDebugBody echoes a body, redacting JSON before it is truncated. JSON
+// that cannot be parsed, and so cannot be redacted, is not echoed at all.
+func writeDebugBody(w io.Writer, prefix, contentType string, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if isJSON(contentType) {
+		masked, ok := redactJSON(data)
+		if !ok {
+			_, err := fmt.Fprintf(w, "%s [unparsable JSON body omitted]\n", prefix)
+			return err
+		}
+		data = masked
+	}
+	truncated := len(data) > debugBodyLimit
+	if truncated {
+		data = data[:debugBodyLimit]
+	}
+	if _, err := fmt.Fprintf(w, "%s\n%s\n", prefix, data); err != nil {
+		return err
+	}
+	if truncated {
+		_, err := fmt.Fprintf(w, "%s [body truncated after %d bytes]\n", prefix, debugBodyLimit)
+		return err
+	}
+	return nil
+}
+
+func isSensitiveHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+		return true
+	}
+	return false
+}
+
+// isSensitiveKey reports whether a JSON object key likely holds a secret.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range []string{"password", "secret", "token", "private_key"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON masks the values of secret-looking keys. It reports false for
+// invalid JSON, which cannot be redacted.
+func redactJSON(data []byte) ([]byte, bool) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+	out, err := json.MarshalIndent(redactValue(v), "", "  ")
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, val := range t {
+			if isSensitiveKey(key) {
+				t[key] = redacted
+				continue
+			}
+			t[key] = redactValue(val)
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+	}
+	return v
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isTextual(contentType string) bool {
+	if isJSON(contentType) {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/x-www-form-urlencoded"
+}
@@ -0,0 +1,152 @@
+package httpx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// diskCacheMaxAge bounds how long an on-disk entry is offered for
+// revalidation; older entries are discarded on read and when pruning.
+const diskCacheMaxAge = 7 * 24 * time.Hour
+
+// diskCacheMaxSize bounds the total size of the cache directory; pruning
+// removes the least recently written entries beyond it.
+const diskCacheMaxSize = 64 << 20
+
+type diskCacheEntry struct {
+	ETag     string    `json:"etag"`
+	Body     []byte    `json:"body"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// cacheIdentityHash digests the credential identity that scopes on-disk
+// entries: identity when set, otherwise the basic-auth credentials. It
+// returns "" when a token source supplies credentials without an identity,
+// as there is then nothing stable to key the entries on.
+func cacheIdentityHash(identity, username, password string, tokens TokenSource) string {
+	if identity == "" {
+		if tokens != nil {
+			return ""
+		}
+		identity = username + "\x00" + password
+	}
+	sum := sha256.Sum256([]byte(identity))
+	return hex.EncodeToString(sum[:])
+}
+
+// diskCachePath names the cache file for req. The credential identity is part
+// of the key so responses are never replayed to a different account.
+func (c *Client) diskCachePath(req *http.Request) string {
+	sum := sha256.Sum256([]byte(c.cacheKey(req) + "\x00" + c.cacheIdentity))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadDiskCache reads the entry for req into the in-memory cache, reporting
+// whether one was found.
+func (c *Client) loadDiskCache(req *http.Request) (*cacheEntry, bool) {
+	if c.cacheDir == "" {
+		return nil, false
+	}
+	path := c.diskCachePath(req)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var stored diskCacheEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.ETag == "" || time.Since(stored.StoredAt) > diskCacheMaxAge {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	entry := &cacheEntry{etag: stored.ETag, body: stored.Body, storedAt: stored.StoredAt}
+	c.cacheMu.Lock()
+	c.cache[c.cacheKey(req)] = entry
+	c.cacheMu.Unlock()
+	return entry, true
+}
+
+// storeDiskCache persists entry for req. Failures are ignored: the cache is
+// an optimisation and must never fail a request.
+func (c *Client) storeDiskCache(req *http.Request, entry *cacheEntry) {
+	if c.cacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(diskCacheEntry{ETag: entry.etag, Body: entry.body, StoredAt: entry.storedAt})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.cacheDir, 0o700); err != nil {
+		return
+	}
+
+	// Write through a temporary file so concurrent invocations never read a
+	// partially written entry.
+	tmp, err := os.CreateTemp(c.cacheDir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.diskCachePath(req)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	c.pruneOnce.Do(func() { pruneDiskCache(c.cacheDir, time.Now()) })
+}
+
+// pruneDiskCache removes entries, and temporary files left by interrupted
+// writes, older than diskCacheMaxAge, then the oldest remaining entries until
+// the directory fits in diskCacheMaxSize. It runs on the first write of each
+// client so the cache stays bounded without scanning it on every response.
+func pruneDiskCache(dir string, now time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type file struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		files []file
+		total int64
+	)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if now.Sub(info.ModTime()) > diskCacheMaxAge {
+			_ = os.Remove(path)
+			continue
+		}
+		files = append(files, file{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= diskCacheMaxSize {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fixedToken string
+
+func (t fixedToken) AccessToken(context.Context) (string, error) {
+	return string(t), nil
+}
+
+func TestDiskCacheKeysOnCredentialIdentity(t *testing.T) {
+	var revalidated int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&revalidated, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(payload{Message: "fresh"})
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	get := func(identity, token string) {
+		t.Helper()
+		client, err := New(Options{BaseURL: server.URL, TokenSource: fixedToken(token), CacheIdentity: identity, EnableCache: true, CacheDir: dir})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/repos", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		var out payload
+		if err := client.Do(req, &out); err != nil || out.Message != "fresh" {
+			t.Fatalf("Do = %q, %v", out.Message, err)
+		}
+	}
+
+	// A refreshed access token still belongs to the same account.
+	get("oauth alice", "token-1")
+	get("oauth alice", "token-2")
+	if revalidated != 1 {
+		t.Fatalf("expected the refreshed token to revalidate, got %d revalidations", revalidated)
+	}
+
+	get("oauth bob", "token-2")
+	if revalidated != 1 {
+		t.Fatal("a cached response must not be revalidated for a different identity")
+	}
+
+	// Without an identity a token source leaves nothing on disk.
+	empty := t.TempDir()
+	client, err := New(Options{BaseURL: server.URL, TokenSource: fixedToken("t"), EnableCache: true, CacheDir: empty})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/other", nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if entries, _ := os.ReadDir(empty); len(entries) != 0 {
+		t.Fatalf("expected no disk entries, got %d", len(entries))
+	}
+}
+
+func TestPruneDiskCacheDropsOldAndExcessEntries(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	write := func(name string, size int, age time.Duration) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("expired.json", 10, diskCacheMaxAge+time.Hour)
+	write(".entry-stale", 10, diskCacheMaxAge+time.Hour)
+	write("oldest.json", diskCacheMaxSize/2, 3*time.Hour)
+	write("older.json", diskCacheMaxSize/2, 2*time.Hour)
+	write("newest.json", diskCacheMaxSize/2, time.Hour)
+
+	pruneDiskCache(dir, now)
+
+	var names []string
+	for _, entry := range mustReadDir(t, dir) {
+		names = append(names, entry.Name())
+	}
+	if len(names) != 2 || names[0] != "newest.json" || names[1] != "older.json" {
+		t.Fatalf("remaining entries = %v, want [newest.json older.json]", names)
+	}
+}
+
+func mustReadDir(t *testing.T, dir string) []os.DirEntry {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read cache dir: %v", err)
+	}
+	return entries
+}
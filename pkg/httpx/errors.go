@@ -0,0 +1,173 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors matched by APIError through errors.Is, so callers can branch
+// on the kind of failure without inspecting status codes.
+var (
+	ErrUnauthorized = errors.New("authentication failed")
+	ErrPermission   = errors.New("permission denied")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrTimeout      = errors.New("timed out")
+)
+
+// TimeoutError reports a request that did not complete before its deadline
+// or the client timeout. It matches ErrTimeout and unwraps to the underlying
+// context or network error.
+type TimeoutError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for Bitbucket to respond to %s %s", e.Method, e.URL)
+}
+
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// Is matches ErrTimeout.
+func (e *TimeoutError) Is(target error) bool { return target == ErrTimeout }
+
+// asTimeout converts deadline and network timeout failures of req into a
+// *TimeoutError; other errors, including cancellation, pass through.
+func asTimeout(req *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+	var netErr net.Error
+	if !errors.Is(err, context.DeadlineExceeded) && !(errors.As(err, &netErr) && netErr.Timeout()) {
+		return err
+	}
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = ""
+	return &TimeoutError{Method: req.Method, URL: u.String(), Err: err}
+}
+
+// APIError describes a non-2xx response from Bitbucket.
+type APIError struct {
+	StatusCode int
+	// Status is the HTTP status line text, e.g. "404 Not Found".
+	Status string
+	// Message is the primary error message reported by Bitbucket, if any.
+	Message string
+	// Detail carries Bitbucket Cloud's error.detail explanation.
+	Detail string
+	// Fields maps request fields to validation messages (Cloud error.fields).
+	Fields map[string][]string
+	// Body holds the raw response body when it could not be parsed.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		if e.Body != "" {
+			return fmt.Sprintf("%s: %s", e.Status, e.Body)
+		}
+		return e.Status
+	}
+
+	msg := e.Message
+	if e.Detail != "" && e.Detail != e.Message {
+		msg += " (" + e.Detail + ")"
+	}
+	if len(e.Fields) > 0 {
+		names := make([]string, 0, len(e.Fields))
+		for name := range e.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			msg += fmt.Sprintf("; %s: %s", name, strings.Join(e.Fields[name], ", "))
+		}
+	}
+	return fmt.Sprintf("%s: %s", e.Status, msg)
+}
+
+// Is reports whether target is the sentinel matching the status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPermission:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// decodeError builds an APIError from a failed response, understanding both
+// the Data Center ({"errors": [...]}) and Cloud ({"error": {...}}) formats.
+func decodeError(resp *http.Response) error {
+	type apiErrEntry struct {
+		Message       string `json:"message"`
+		ExceptionName string `json:"exceptionName"`
+	}
+	type apiErr struct {
+		Errors []apiErrEntry `json:"errors"`
+		Error  *struct {
+			Message string              `json:"message"`
+			Detail  string              `json:"detail"`
+			Fields  map[string][]string `json:"fields"`
+		} `json:"error"`
+	}
+
+	apiError := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+
+	var payload apiErr
+	data, err := io.ReadAll(resp.Body)
+	if err == nil && len(data) > 0 {
+		// Attempt to parse structured error; intentionally ignore unmarshal errors and fall back to raw text
+		_ = json.Unmarshal(data, &payload)
+	}
+
+	switch {
+	case len(payload.Errors) > 0:
+		// Prioritize user-actionable errors like CAPTCHA over generic ones
+		bestErr := payload.Errors[0]
+		for _, e := range payload.Errors {
+			if isCaptchaException(e.ExceptionName) {
+				bestErr = e
+				break
+			}
+		}
+
+		msg := bestErr.Message
+		// Add hint for CAPTCHA-locked accounts
+		if isCaptchaException(bestErr.ExceptionName) && !strings.Contains(strings.ToLower(msg), "captcha") {
+			msg = "CAPTCHA verification required: " + msg
+		}
+		apiError.Message = msg
+	case payload.Error != nil && payload.Error.Message != "":
+		apiError.Message = payload.Error.Message
+		apiError.Detail = payload.Error.Detail
+		apiError.Fields = payload.Error.Fields
+	case err == nil && len(data) > 0:
+		apiError.Body = strings.TrimSpace(string(data))
+	}
+
+	return apiError
+}
+
+// isCaptchaException checks if the exception name indicates a CAPTCHA-locked account.
+func isCaptchaException(exceptionName string) bool {
+	return strings.Contains(strings.ToLower(exceptionName), "captcharequired")
+}
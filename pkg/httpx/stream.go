@@ -0,0 +1,159 @@
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+)
+
+type unboundedKey struct{}
+
+// Unbounded marks a request as a long-running transfer that is exempt from
+// the client-wide timeout. Callers bound such requests with a context
+// deadline instead, so large uploads and downloads are not cut off mid-stream.
+func Unbounded(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), unboundedKey{}, true))
+}
+
+func isUnbounded(req *http.Request) bool {
+	v, _ := req.Context().Value(unboundedKey{}).(bool)
+	return v
+}
+
+// StreamFile is a file streamed into a multipart request body. Open is called
+// once per attempt, so retries re-read the file from the start.
+type StreamFile struct {
+	FieldName string
+	FileName  string
+	// Size is the exact content length, or -1 when unknown. When every file
+	// has a known size the request carries a Content-Length header; otherwise
+	// it is sent with chunked transfer encoding.
+	Size int64
+	Open func() (io.ReadCloser, error)
+}
+
+// NewStreamingMultipartRequest builds a multipart/form-data request whose
+// body is produced on demand through a pipe rather than buffered in memory.
+// The request is marked Unbounded.
+func (c *Client) NewStreamingMultipartRequest(ctx context.Context, method, path string, fields map[string]string, files []StreamFile) (*http.Request, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("at least one file is required")
+	}
+	for _, f := range files {
+		if f.Open == nil {
+			return nil, fmt.Errorf("open func is nil for file %q", f.FileName)
+		}
+	}
+
+	req, err := c.NewRequest(ctx, method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	writeParts := func(w io.Writer, withContent bool) error {
+		mw := multipart.NewWriter(w)
+		if err := mw.SetBoundary(boundary); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := mw.WriteField(k, fields[k]); err != nil {
+				return fmt.Errorf("write form field: %w", err)
+			}
+		}
+		for _, f := range files {
+			part, err := mw.CreateFormFile(f.FieldName, f.FileName)
+			if err != nil {
+				return fmt.Errorf("create form file: %w", err)
+			}
+			if !withContent {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("open %s: %w", f.FileName, err)
+			}
+			_, err = io.Copy(part, rc)
+			_ = rc.Close()
+			if err != nil {
+				return fmt.Errorf("copy %s: %w", f.FileName, err)
+			}
+		}
+		return mw.Close()
+	}
+
+	// Multipart framing is deterministic for a fixed boundary, so the exact
+	// body length is the framing overhead plus the file sizes.
+	counter := &countingWriter{}
+	if err := writeParts(counter, false); err != nil {
+		return nil, err
+	}
+	length := counter.n
+	for _, f := range files {
+		if f.Size < 0 {
+			length = -1
+			break
+		}
+		length += f.Size
+	}
+
+	getBody := func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeParts(pw, true))
+		}()
+		return pr, nil
+	}
+
+	req.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+	req.ContentLength = length
+	req.GetBody = getBody
+	// Do always replays the body through GetBody, so the initial body only
+	// starts streaming if the request is sent directly.
+	req.Body = &lazyBody{open: getBody}
+
+	return Unbounded(req), nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// lazyBody defers opening a request body until it is first read.
+type lazyBody struct {
+	open func() (io.ReadCloser, error)
+	rc   io.ReadCloser
+}
+
+func (b *lazyBody) Read(p []byte) (int, error) {
+	if b.rc == nil {
+		rc, err := b.open()
+		if err != nil {
+			return 0, err
+		}
+		b.rc = rc
+	}
+	return b.rc.Read(p)
+}
+
+func (b *lazyBody) Close() error {
+	if b.rc == nil {
+		return nil
+	}
+	return b.rc.Close()
+}
@@ -0,0 +1,132 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStreamingMultipartRequest(t *testing.T) {
+	const content = "artifact-bytes"
+	var hits, opens int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		if r.ContentLength <= int64(len(content)) {
+			t.Errorf("expected exact content length, got %d", r.ContentLength)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if int64(len(body)) != r.ContentLength {
+			t.Errorf("content length %d does not match body %d", r.ContentLength, len(body))
+		}
+		if n == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		if r.FormValue("note") != "hi" {
+			t.Errorf("unexpected field %q", r.FormValue("note"))
+		}
+		f, header, err := r.FormFile("files")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		data, _ := io.ReadAll(f)
+		if header.Filename != "app.tar.gz" || string(data) != content {
+			t.Errorf("unexpected file %q: %q", header.Filename, data)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{
+		BaseURL: server.URL,
+		Timeout: time.Nanosecond,
+		Retry:   RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	req, err := client.NewStreamingMultipartRequest(context.Background(), "POST", "/upload", map[string]string{"note": "hi"}, []StreamFile{{
+		FieldName: "files",
+		FileName:  "app.tar.gz",
+		Size:      int64(len(content)),
+		Open: func() (io.ReadCloser, error) {
+			atomic.AddInt32(&opens, 1)
+			return io.NopCloser(strings.NewReader(content)), nil
+		},
+	}})
+	if err != nil {
+		t.Fatalf("NewStreamingMultipartRequest: %v", err)
+	}
+
+	// The nanosecond client timeout would fail any bounded request.
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if hits != 2 || opens != 2 {
+		t.Fatalf("expected a retry that re-opened the file, got hits=%d opens=%d", hits, opens)
+	}
+}
+
+func TestStreamingMultipartUnknownSizeIsChunked(t *testing.T) {
+	client, err := New(Options{BaseURL: "http://example.invalid"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	req, err := client.NewStreamingMultipartRequest(context.Background(), "POST", "/upload", nil, []StreamFile{{
+		FieldName: "files",
+		FileName:  "stdin",
+		Size:      -1,
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("x")), nil
+		},
+	}})
+	if err != nil {
+		t.Fatalf("NewStreamingMultipartRequest: %v", err)
+	}
+	if req.ContentLength != -1 {
+		t.Fatalf("expected unknown length, got %d", req.ContentLength)
+	}
+}
+
+type sizedBuffer struct {
+	bytes.Buffer
+	size int64
+}
+
+func (b *sizedBuffer) SetContentLength(n int64) { b.size = n }
+
+func TestDoReportsContentLengthToWriter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("artifact"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/file", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	var dst sizedBuffer
+	if err := client.Do(req, &dst); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if dst.String() != "artifact" || dst.size != 8 {
+		t.Fatalf("body %q, size %d", dst.String(), dst.size)
+	}
+}
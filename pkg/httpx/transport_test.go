@@ -0,0 +1,107 @@
+package httpx
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClientUsesExplicitProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := New(Options{BaseURL: "http://bitbucket.internal.example", Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/rest/api/1.0/projects", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if proxied != "http://bitbucket.internal.example/rest/api/1.0/projects" {
+		t.Fatalf("request did not go through the proxy, got %q", proxied)
+	}
+
+	if _, err := New(Options{BaseURL: "https://example.com", Proxy: "not a url"}); err == nil {
+		t.Fatal("expected invalid proxy URL to be rejected")
+	}
+}
+
+func TestClientTrustsCustomCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(opts Options) error {
+		t.Helper()
+		opts.BaseURL = server.URL
+		opts.Retry = RetryPolicy{MaxAttempts: 1}
+		client, err := New(opts)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		return client.Do(req, nil)
+	}
+
+	if err := do(Options{}); err == nil {
+		t.Fatal("expected an unknown certificate authority to be rejected")
+	}
+	if err := do(Options{CACertFile: caFile}); err != nil {
+		t.Fatalf("expected the CA bundle to be trusted: %v", err)
+	}
+	if err := do(Options{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("expected verification to be skipped: %v", err)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("nothing"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(Options{BaseURL: server.URL, CACertFile: empty}); err == nil {
+		t.Fatal("expected a bundle without certificates to be rejected")
+	}
+}
+
+func TestNewStdClientUsesExplicitProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := NewStdClient(Options{Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewStdClient: %v", err)
+	}
+	resp, err := client.Get("http://bitbucket.org/site/oauth2/access_token")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if proxied != "http://bitbucket.org/site/oauth2/access_token" {
+		t.Fatalf("request did not go through the proxy, got %q", proxied)
+	}
+}
@@ -75,6 +75,15 @@ func (s *IOStreams) SetColorEnabled(enabled bool) {
 	s.colorEnabled = enabled
 }
 
+// SetStdinTTY allows callers (e.g. tests) to force whether prompts are
+// possible.
+func (s *IOStreams) SetStdinTTY(isTTY bool) {
+	if s == nil {
+		return
+	}
+	s.isStdinTTY = isTTY
+}
+
 // IsStdoutTTY reports whether stdout is attached to a terminal.
 func (s *IOStreams) IsStdoutTTY() bool {
 	return s != nil && s.isStdoutTTY
@@ -85,6 +94,37 @@ func (s *IOStreams) IsStderrTTY() bool {
 	return s != nil && s.isStderrTTY
 }
 
+// TerminalHeight returns the number of rows of the terminal attached to
+// stdout, or 0 when it cannot be determined.
+func (s *IOStreams) TerminalHeight() int {
+	if s == nil || !s.isStdoutTTY {
+		return 0
+	}
+	f, ok := s.Out.(*os.File)
+	if !ok {
+		return 0
+	}
+	_, height, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// TerminalWidth returns the number of columns of the terminal attached to
+// stdout, or 0 when it cannot be determined. Unlike TerminalHeight it asks
+// the process stdout directly, as Out may have been replaced by a pager.
+func (s *IOStreams) TerminalWidth() int {
+	if s == nil || !s.isStdoutTTY {
+		return 0
+	}
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 // ANSI escape sequences for alternate screen buffer
 const (
 	enterAltScreen = "\x1b[?1049h"
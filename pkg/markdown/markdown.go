@@ -0,0 +1,778 @@
+// Package markdown renders the Markdown used in Bitbucket descriptions and
+// comments for display in a terminal.
+package markdown
+
+import (
+	"cmp"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options controls how Markdown is rendered.
+type Options struct {
+	// Width wraps paragraphs, lists and quotes to this many columns and
+	// shrinks tables to fit; 0 disables wrapping.
+	Width int
+	// Color enables ANSI styling. Without it markup is reduced to plain
+	// text, keeping heading markers, bullets and backticks so the structure
+	// survives in logs and pipes.
+	Color bool
+}
+
+// Render converts Markdown to text for the terminal. Code blocks keep their
+// content verbatim, indented by four spaces, and tables are aligned in
+// columns. The result has no trailing newline.
+func Render(src string, opts Options) string {
+	r := &renderer{color: opts.Color}
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	return strings.Join(r.blocks(strings.Split(src, "\n"), opts.Width), "\n\n")
+}
+
+const (
+	styleBold      = "1"
+	styleDim       = "2"
+	styleItalic    = "3"
+	styleUnderline = "4"
+	styleStrike    = "9"
+	styleCode      = "36"
+	styleHeading   = "1;35"
+
+	reset = "\033[0m"
+)
+
+var (
+	headingRe = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*))?$`)
+	setextRe  = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	fenceRe   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})")
+	listRe    = regexp.MustCompile(`^([ \t]*)([-*+]|\d{1,9}[.)])(?:[ \t]+(.*))?$`)
+	tableSep  = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+)
+
+type renderer struct {
+	color bool
+}
+
+// blocks renders the block structure of lines, returning one entry per
+// block.
+func (r *renderer) blocks(lines []string, width int) []string {
+	var out, para []string
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, r.paragraph(para, width))
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+			i++
+		case len(para) > 0 && setextRe.MatchString(line):
+			level := 1
+			if trimmed[0] == '-' {
+				level = 2
+			}
+			out = append(out, r.heading(level, strings.TrimSpace(strings.Join(para, " "))))
+			para = nil
+			i++
+		case fenceRe.MatchString(line):
+			flush()
+			block, n := r.codeFence(lines[i:])
+			out = append(out, block)
+			i += n
+		case headingRe.MatchString(line):
+			flush()
+			m := headingRe.FindStringSubmatch(line)
+			out = append(out, r.heading(len(m[1]), trimClosingHashes(m[2])))
+			i++
+		case isRule(trimmed):
+			flush()
+			out = append(out, r.rule(width))
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var inner []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				quoted := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				inner = append(inner, strings.TrimPrefix(quoted, " "))
+				i++
+			}
+			out = append(out, r.quote(inner, width))
+		case isListItem(line):
+			flush()
+			block, n := r.list(lines[i:], width)
+			out = append(out, block)
+			i += n
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableSep.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			flush()
+			block, n := r.table(lines[i:], width)
+			out = append(out, block)
+			i += n
+		case len(para) == 0 && indentWidth(line) >= 4:
+			block, n := r.indentedCode(lines[i:])
+			out = append(out, block)
+			i += n
+		default:
+			para = append(para, line)
+			i++
+		}
+	}
+	flush()
+	return out
+}
+
+// paragraph joins lines into wrapped text. A line ending in two spaces or a
+// backslash forces a break.
+func (r *renderer) paragraph(lines []string, width int) string {
+	var b strings.Builder
+	for i, line := range lines {
+		hard := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`)
+		line = strings.TrimSuffix(strings.TrimSpace(line), `\`)
+		b.WriteString(line)
+		if i < len(lines)-1 {
+			if hard {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+	return wrap(r.inline(b.String()), width, "", "")
+}
+
+func (r *renderer) heading(level int, text string) string {
+	text = r.inline(text)
+	if !r.color {
+		return strings.Repeat("#", level) + " " + text
+	}
+	if level == 1 {
+		return r.style(text, styleHeading+";"+styleUnderline)
+	}
+	return r.style(text, styleHeading)
+}
+
+func (r *renderer) rule(width int) string {
+	if width <= 0 || width > 80 {
+		width = 40
+	}
+	if !r.color {
+		return strings.Repeat("-", width)
+	}
+	return r.style(strings.Repeat("─", width), styleDim)
+}
+
+func (r *renderer) quote(lines []string, width int) string {
+	bar := "> "
+	if r.color {
+		bar = r.style("│", styleDim) + " "
+	}
+	inner := width
+	if inner > 0 {
+		inner -= 2
+	}
+
+	rendered := strings.Split(strings.Join(r.blocks(lines, inner), "\n\n"), "\n")
+	for i, line := range rendered {
+		if line == "" {
+			rendered[i] = strings.TrimRight(bar, " ")
+			continue
+		}
+		rendered[i] = bar + line
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// codeFence renders a fenced code block starting at lines[0] and returns the
+// number of lines it spans.
+func (r *renderer) codeFence(lines []string) (string, int) {
+	m := fenceRe.FindStringSubmatch(lines[0])
+	indent, fence := len(m[1]), m[2]
+
+	var code []string
+	n := 1
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			n++
+			break
+		}
+		line := lines[n]
+		for j := 0; j < indent && strings.HasPrefix(line, " "); j++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+	return r.code(code), n
+}
+
+// indentedCode renders a code block indented by four spaces.
+func (r *renderer) indentedCode(lines []string) (string, int) {
+	var code []string
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		if strings.TrimSpace(line) != "" && indentWidth(line) < 4 {
+			break
+		}
+		if strings.HasPrefix(line, "\t") {
+			line = line[1:]
+		} else {
+			line = strings.TrimPrefix(line, "    ")
+		}
+		code = append(code, line)
+	}
+	for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+		code = code[:len(code)-1]
+	}
+	return r.code(code), n
+}
+
+func (r *renderer) code(lines []string) string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			continue
+		}
+		if r.color {
+			line = "\033[" + styleCode + "m" + line + reset
+		}
+		out[i] = "    " + line
+	}
+	return strings.Join(out, "\n")
+}
+
+// list renders consecutive list items starting at lines[0], nesting them by
+// indentation, and returns the number of lines consumed.
+func (r *renderer) list(lines []string, width int) (string, int) {
+	type item struct {
+		level  int
+		marker string
+		text   []string
+	}
+
+	var items []*item
+	var indents []int
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		if strings.TrimSpace(line) == "" {
+			// A blank line only continues the list when another item of
+			// the same kind follows.
+			next := n + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next < len(lines) && isListItem(lines[next]) &&
+				isOrdered(listRe.FindStringSubmatch(lines[next])[2]) == isOrdered(items[0].marker) {
+				n = next - 1
+				continue
+			}
+			break
+		}
+
+		if isListItem(line) {
+			m := listRe.FindStringSubmatch(line)
+			indent := indentWidth(m[1])
+			for len(indents) > 0 && indent < indents[len(indents)-1] {
+				indents = indents[:len(indents)-1]
+			}
+			if len(indents) == 0 || indent > indents[len(indents)-1] {
+				indents = append(indents, indent)
+			}
+			items = append(items, &item{level: len(indents) - 1, marker: m[2], text: []string{m[3]}})
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if fenceRe.MatchString(line) || headingRe.MatchString(line) || isRule(trimmed) || strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		last := items[len(items)-1]
+		last.text = append(last.text, trimmed)
+	}
+
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		bullet := it.marker
+		if !isOrdered(bullet) {
+			bullet = "-"
+			if r.color {
+				bullet = "•"
+			}
+		}
+		text := strings.Join(it.text, " ")
+		for _, box := range []string{"[ ] ", "[x] ", "[X] "} {
+			if strings.HasPrefix(text, box) {
+				bullet += " " + strings.ToLower(box[:3])
+				text = text[len(box):]
+				break
+			}
+		}
+		prefix := strings.Repeat("  ", it.level) + bullet + " "
+		out = append(out, wrap(r.inline(text), width, prefix, strings.Repeat(" ", visibleWidth(prefix))))
+	}
+	return strings.Join(out, "\n"), n
+}
+
+// table renders a pipe table whose header is lines[0] and returns the number
+// of lines consumed.
+func (r *renderer) table(lines []string, width int) (string, int) {
+	header := splitRow(lines[0])
+	aligns := make([]byte, len(header))
+	for i, cell := range splitRow(lines[1]) {
+		if i >= len(aligns) {
+			break
+		}
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns[i] = 'c'
+		case strings.HasSuffix(cell, ":"):
+			aligns[i] = 'r'
+		}
+	}
+
+	rows := [][]string{header}
+	n := 2
+	for ; n < len(lines); n++ {
+		if strings.TrimSpace(lines[n]) == "" || !strings.Contains(lines[n], "|") {
+			break
+		}
+		rows = append(rows, splitRow(lines[n]))
+	}
+
+	widths := make([]int, len(header))
+	for i, row := range rows {
+		cells := make([]string, len(header))
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = r.inline(row[j])
+			}
+			if i == 0 {
+				cells[j] = r.style(cells[j], styleBold)
+			}
+			widths[j] = max(widths[j], visibleWidth(cells[j]))
+		}
+		rows[i] = cells
+	}
+
+	// Shrink the widest columns until the table fits.
+	if width > 0 {
+		total := 2 * (len(widths) - 1)
+		for _, w := range widths {
+			total += w
+		}
+		for total > width {
+			widest := 0
+			for j, w := range widths {
+				if w > widths[widest] {
+					widest = j
+				}
+			}
+			if widths[widest] <= 5 {
+				break
+			}
+			widths[widest]--
+			total--
+		}
+	}
+
+	sep := "-"
+	if r.color {
+		sep = "─"
+	}
+	rules := make([]string, len(widths))
+	for j, w := range widths {
+		rules[j] = strings.Repeat(sep, w)
+	}
+	rule := strings.Join(rules, "  ")
+	if r.color {
+		rule = "\033[" + styleDim + "m" + rule + reset
+	}
+
+	out := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = pad(truncate(cell, widths[j]), widths[j], aligns[j])
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, "  "), " "))
+		if i == 0 {
+			out = append(out, rule)
+		}
+	}
+	return strings.Join(out, "\n"), n
+}
+
+// inline renders emphasis, code spans, links and images within s.
+func (r *renderer) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '`':
+			n := 1
+			for i+n < len(s) && s[i+n] == '`' {
+				n++
+			}
+			fence := s[i : i+n]
+			if end := strings.Index(s[i+n:], fence); end >= 0 {
+				b.WriteString(r.codeSpan(s[i+n : i+n+end]))
+				i += end + 2*n
+				continue
+			}
+			b.WriteString(fence)
+			i += n
+			continue
+		case c == '!' && strings.HasPrefix(s[i+1:], "["):
+			if text, url, n, ok := parseLink(s[i+1:]); ok {
+				b.WriteString(r.link(r.inline(cmp.Or(text, "image")), url))
+				i += 1 + n
+				continue
+			}
+		case c == '[':
+			if text, url, n, ok := parseLink(s[i:]); ok {
+				b.WriteString(r.link(r.inline(text), url))
+				i += n
+				continue
+			}
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				target := s[i+1 : i+end]
+				if isAutolink(target) {
+					b.WriteString(r.link("", target))
+					i += end + 1
+					continue
+				}
+			}
+		case c == '~' && strings.HasPrefix(s[i:], "~~"):
+			if end, ok := closing(s, i, "~~"); ok {
+				b.WriteString(r.style(r.inline(s[i+2:end]), styleStrike))
+				i = end + 2
+				continue
+			}
+		case c == '*' || c == '_':
+			if i+1 < len(s) && s[i+1] == c {
+				if end, ok := closing(s, i, s[i:i+2]); ok {
+					b.WriteString(r.style(r.inline(s[i+2:end]), styleBold))
+					i = end + 2
+					continue
+				}
+			} else if end, ok := closing(s, i, s[i:i+1]); ok {
+				b.WriteString(r.style(r.inline(s[i+1:end]), styleItalic))
+				i = end + 1
+				continue
+			}
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String()
+}
+
+func (r *renderer) codeSpan(code string) string {
+	if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+		code = code[1 : len(code)-1]
+	}
+	if !r.color {
+		return "`" + code + "`"
+	}
+	return r.style(code, styleCode)
+}
+
+func (r *renderer) link(text, url string) string {
+	if text == "" || text == url {
+		return r.style(url, styleUnderline)
+	}
+	if !r.color {
+		return text + " (" + url + ")"
+	}
+	return r.style(text, styleUnderline) + " " + r.style("("+url+")", styleDim)
+}
+
+// style applies an SGR style to each word of text separately so wrapping
+// never carries a style into indentation, re-applying it after any reset
+// from nested styles.
+func (r *renderer) style(text, sgr string) string {
+	if !r.color || text == "" {
+		return text
+	}
+	start := "\033[" + sgr + "m"
+	words := strings.Split(text, " ")
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		words[i] = start + strings.ReplaceAll(word, reset, reset+start) + reset
+	}
+	return strings.Join(words, " ")
+}
+
+// closing finds the delimiter that closes the one opening at s[open:], or
+// reports false. Emphasis cannot start or end next to a space, and
+// underscores only count at word boundaries.
+func closing(s string, open int, delim string) (int, bool) {
+	start := open + len(delim)
+	if start >= len(s) || s[start] == ' ' {
+		return 0, false
+	}
+	if delim[0] == '_' && open > 0 && isAlnum(s[open-1]) {
+		return 0, false
+	}
+	for j := start + 1; j+len(delim) <= len(s); j++ {
+		if s[j:j+len(delim)] != delim || s[j-1] == ' ' {
+			continue
+		}
+		after := j + len(delim)
+		// A single delimiter must not be half of a double one.
+		if len(delim) == 1 && (s[j-1] == delim[0] || (after < len(s) && s[after] == delim[0])) {
+			continue
+		}
+		if delim[0] == '_' && after < len(s) && isAlnum(s[after]) {
+			continue
+		}
+		return j, true
+	}
+	return 0, false
+}
+
+// parseLink parses "[text](url "title")" at the start of s, returning the
+// text, the URL and the length consumed.
+func parseLink(s string) (text, url string, n int, ok bool) {
+	depth := 0
+	close := -1
+	for i := 0; i < len(s) && close < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				close = i
+			}
+		}
+	}
+	if close < 0 || close+1 >= len(s) || s[close+1] != '(' {
+		return "", "", 0, false
+	}
+
+	depth = 0
+	for i := close + 1; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				target := strings.TrimSpace(s[close+2 : i])
+				if fields := strings.Fields(target); len(fields) > 0 {
+					target = fields[0]
+				}
+				target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+				return s[1:close], target, i + 1, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// splitRow splits a table row into trimmed cells, honouring escaped pipes.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// wrap breaks each line of text at spaces so it fits width, starting the
+// first line with first and the others with rest.
+func wrap(text string, width int, first, rest string) string {
+	var out []string
+	prefix := first
+	for _, line := range strings.Split(text, "\n") {
+		if width <= 0 {
+			out = append(out, prefix+line)
+			prefix = rest
+			continue
+		}
+
+		avail := max(width-visibleWidth(prefix), 10)
+		var cur strings.Builder
+		curWidth := 0
+		for _, word := range strings.Split(line, " ") {
+			if word == "" {
+				continue
+			}
+			w := visibleWidth(word)
+			if curWidth > 0 && curWidth+1+w > avail {
+				out = append(out, prefix+cur.String())
+				prefix = rest
+				cur.Reset()
+				curWidth = 0
+			}
+			if curWidth > 0 {
+				cur.WriteByte(' ')
+				curWidth++
+			}
+			cur.WriteString(word)
+			curWidth += w
+		}
+		out = append(out, prefix+cur.String())
+		prefix = rest
+	}
+	return strings.Join(out, "\n")
+}
+
+// visibleWidth counts the runes of s, skipping ANSI escape sequences.
+func visibleWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if end := escapeEnd(s, i); end > i {
+			i = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		n++
+	}
+	return n
+}
+
+// truncate shortens s to width visible runes, ending with an ellipsis.
+func truncate(s string, width int) string {
+	if visibleWidth(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	styled := false
+	n := 0
+	for i := 0; i < len(s); {
+		if end := escapeEnd(s, i); end > i {
+			b.WriteString(s[i:end])
+			styled = true
+			i = end
+			continue
+		}
+		if n == width-1 {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		i += size
+		n++
+	}
+	b.WriteString("…")
+	if styled {
+		b.WriteString(reset)
+	}
+	return b.String()
+}
+
+func pad(s string, width int, align byte) string {
+	gap := width - visibleWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	switch align {
+	case 'r':
+		return strings.Repeat(" ", gap) + s
+	case 'c':
+		return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+	}
+	return s + strings.Repeat(" ", gap)
+}
+
+// escapeEnd returns the end of the ANSI CSI sequence starting at s[i], or i
+// when there is none.
+func escapeEnd(s string, i int) int {
+	if !strings.HasPrefix(s[i:], "\033[") {
+		return i
+	}
+	for j := i + 2; j < len(s); j++ {
+		if s[j] >= '@' && s[j] <= '~' {
+			return j + 1
+		}
+	}
+	return i
+}
+
+func trimClosingHashes(text string) string {
+	text = strings.TrimSpace(text)
+	trimmed := strings.TrimRight(text, "#")
+	if trimmed == "" || strings.HasSuffix(trimmed, " ") {
+		return strings.TrimSpace(trimmed)
+	}
+	return text
+}
+
+func isRule(trimmed string) bool {
+	compact := strings.ReplaceAll(trimmed, " ", "")
+	if len(compact) < 3 || !strings.ContainsAny(compact[:1], "-*_") {
+		return false
+	}
+	return strings.Trim(compact, compact[:1]) == ""
+}
+
+func isListItem(line string) bool {
+	m := listRe.FindStringSubmatch(line)
+	return m != nil && m[3] != ""
+}
+
+func isOrdered(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+func isAutolink(target string) bool {
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "mailto:")
+}
+
+func indentWidth(s string) int {
+	n := 0
+	for _, c := range s {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4 - n%4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
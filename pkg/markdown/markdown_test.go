@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPlain(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		width int
+		want  string
+	}{
+		{
+			name: "inline markup",
+			src:  "Some **bold**, *italic*, ~~gone~~ and `code` in snake_case_name.",
+			want: "Some bold, italic, gone and `code` in snake_case_name.",
+		},
+		{
+			name: "links and images",
+			src:  "See [the docs](https://example.com \"Docs\"), <https://x.io> and ![diagram](img.png).",
+			want: "See the docs (https://example.com), https://x.io and diagram (img.png).",
+		},
+		{
+			name: "escapes",
+			src:  `Not \*emphasis\* and a literal \[bracket\].`,
+			want: "Not *emphasis* and a literal [bracket].",
+		},
+		{
+			name: "headings",
+			src:  "# Title #\n\nSubtitle\n--------\n\n### Notes",
+			want: "# Title\n\n## Subtitle\n\n### Notes",
+		},
+		{
+			name:  "paragraph wrapping",
+			src:   "The quick brown fox jumps over\nthe lazy dog.  \nNew line.",
+			width: 20,
+			want:  "The quick brown fox\njumps over the lazy\ndog.\nNew line.",
+		},
+		{
+			name:  "lists",
+			src:   "- one\n- two is a longer item\n  - nested\n- [x] done\n\n1. first\n2. second",
+			width: 16,
+			want:  "- one\n- two is a\n  longer item\n  - nested\n- [x] done\n\n1. first\n2. second",
+		},
+		{
+			name: "code fence",
+			src:  "```go\nfunc main() {\n\tprintln(\"**x**\")\n}\n```\nafter",
+			want: "    func main() {\n    \tprintln(\"**x**\")\n    }\n\nafter",
+		},
+		{
+			name: "unterminated fence runs to the end",
+			src:  "~~~\ncode",
+			want: "    code",
+		},
+		{
+			name: "indented code",
+			src:  "Intro\n\n    $ bkt pr list\n\nOutro",
+			want: "Intro\n\n    $ bkt pr list\n\nOutro",
+		},
+		{
+			name: "quote",
+			src:  "> quoted *text*\n>\n> second",
+			want: "> quoted text\n>\n> second",
+		},
+		{
+			name: "rule",
+			src:  "above\n\n***\n\nbelow",
+			want: "above\n\n" + strings.Repeat("-", 40) + "\n\nbelow",
+		},
+		{
+			name: "table",
+			src:  "| Name | Count | Note |\n|:-----|------:|:----:|\n| a | 1 | x |\n| longer \\| name | 200 | `y` |",
+			want: "Name           Count  Note\n-------------  -----  ----\na                  1   x\nlonger | name    200  `y`",
+		},
+		{
+			name:  "table shrinks to width",
+			src:   "| Key | Description |\n|---|---|\n| A | a very long description |",
+			width: 20,
+			want:  "Key  Description\n---  ---------------\nA    a very long de…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(tt.src, Options{Width: tt.width})
+			if got != tt.want {
+				t.Fatalf("Render mismatch\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	got := Render("## Intro\n\nSome **bold `x` text** and [docs](https://x.io)\n\n- item", Options{Color: true})
+
+	for _, want := range []string{
+		"\033[1;35mIntro\033[0m",
+		"\033[1mbold\033[0m",
+		"\033[1m\033[36mx\033[0m\033[1m\033[0m",
+		"\033[4mdocs\033[0m \033[2m(https://x.io)\033[0m",
+		"• item",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%q", want, got)
+		}
+	}
+	if strings.Contains(got, "**") || strings.Contains(got, "##") {
+		t.Fatalf("expected markup to be removed:\n%q", got)
+	}
+}
+
+func TestWrapIgnoresEscapes(t *testing.T) {
+	got := Render("**aaaa** **bbbb** **cccc**", Options{Width: 10, Color: true})
+	if lines := strings.Split(got, "\n"); len(lines) != 2 {
+		t.Fatalf("expected styled words to wrap by visible width, got %q", got)
+	}
+}
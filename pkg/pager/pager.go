@@ -18,18 +18,21 @@ type Manager interface {
 }
 
 type system struct {
-	ios    *iostreams.IOStreams
+	out    io.Writer
+	errOut io.Writer
 	cmd    *exec.Cmd
 	writer io.WriteCloser
 }
 
 // NewSystem returns a pager manager backed by the user's $PAGER when stdout is
-// a TTY. When stdout is redirected a no-op manager is returned instead.
+// a TTY. When stdout is redirected a no-op manager is returned instead. The
+// pager writes to the streams as they are at construction, so ios.Out may
+// later be replaced with a Writer feeding it.
 func NewSystem(ios *iostreams.IOStreams) Manager {
 	if ios == nil || !ios.IsStdoutTTY() {
 		return noop{}
 	}
-	return &system{ios: ios}
+	return &system{out: ios.Out, errOut: ios.ErrOut}
 }
 
 func (p *system) Enabled() bool { return true }
@@ -41,8 +44,8 @@ func (p *system) Start() (io.WriteCloser, error) {
 
 	pagerCmd := strings.Fields(resolvePager())
 	cmd := exec.Command(pagerCmd[0], pagerCmd[1:]...)
-	cmd.Stdout = p.ios.Out
-	cmd.Stderr = p.ios.ErrOut
+	cmd.Stdout = p.out
+	cmd.Stderr = p.errOut
 
 	in, err := cmd.StdinPipe()
 	if err != nil {
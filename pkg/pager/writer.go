@@ -0,0 +1,150 @@
+package pager
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Writer holds output back until it grows past one screen, then starts the
+// pager and streams through it. Output that fits on the screen is written to
+// the terminal directly on Close, so short results never open a pager.
+type Writer struct {
+	manager Manager
+	out     io.Writer
+	height  int
+	width   int
+
+	mu sync.Mutex
+
+	buf     bytes.Buffer
+	lines   int
+	column  int
+	escape  bool
+	pager   io.WriteCloser
+	started bool
+}
+
+// NewWriter returns a Writer paging through m once the output takes more
+// than height-1 terminal rows (leaving room for the shell prompt); out
+// receives output that fits or that cannot be paged. Lines longer than width
+// count as the rows they wrap onto; a width of zero or less counts lines.
+func NewWriter(m Manager, out io.Writer, height, width int) *Writer {
+	return &Writer{manager: m, out: out, height: height, width: width}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		if w.pager == nil {
+			return w.out.Write(p)
+		}
+		if _, err := w.pager.Write(p); err != nil {
+			// The user quit the pager; drop the rest of the output rather
+			// than failing the command with a broken pipe.
+			w.pager = nopWriteCloser{Writer: io.Discard}
+		}
+		return len(p), nil
+	}
+
+	w.buf.Write(p)
+	w.countRows(p)
+	if w.lines < w.height {
+		return len(p), nil
+	}
+
+	w.started = true
+	if !w.manager.Enabled() {
+		return len(p), w.flush(w.out)
+	}
+	pager, err := w.manager.Start()
+	if err != nil {
+		return len(p), w.flush(w.out)
+	}
+	w.pager = pager
+	_ = w.flush(pager)
+	return len(p), nil
+}
+
+// Close writes buffered output that never reached a screenful, or waits for
+// the user to quit the pager.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started {
+		return w.flush(w.out)
+	}
+	if w.pager != nil {
+		return w.manager.Stop()
+	}
+	return nil
+}
+
+// Stderr wraps errOut so diagnostics stay in order with the paged output.
+// Output held back before the pager starts is written to the terminal first,
+// giving up paging for the rest of the command; once the pager runs,
+// diagnostics go through it.
+func (w *Writer) Stderr(errOut io.Writer) io.Writer {
+	return stderrWriter{w: w, errOut: errOut}
+}
+
+type stderrWriter struct {
+	w      *Writer
+	errOut io.Writer
+}
+
+func (s stderrWriter) Write(p []byte) (int, error) {
+	w := s.w
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started && w.buf.Len() > 0 {
+		w.started = true
+		if err := w.flush(w.out); err != nil {
+			return 0, err
+		}
+	}
+	if w.pager != nil {
+		if _, err := w.pager.Write(p); err != nil {
+			w.pager = nopWriteCloser{Writer: io.Discard}
+		}
+		return len(p), nil
+	}
+	return s.errOut.Write(p)
+}
+
+// countRows adds the terminal rows taken by p to w.lines. Characters are
+// counted as one column each and ANSI escape sequences as none.
+func (w *Writer) countRows(p []byte) {
+	if w.width <= 0 {
+		w.lines += bytes.Count(p, []byte{'\n'})
+		return
+	}
+	for _, r := range string(p) {
+		switch {
+		case w.escape:
+			// CSI sequences end with a letter.
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				w.escape = false
+			}
+		case r == '\x1b':
+			w.escape = true
+		case r == '\n':
+			w.lines++
+			w.column = 0
+		case r == '\r':
+			w.column = 0
+		default:
+			if w.column == w.width {
+				w.lines++
+				w.column = 0
+			}
+			w.column++
+		}
+	}
+}
+
+func (w *Writer) flush(dst io.Writer) error {
+	_, err := w.buf.WriteTo(dst)
+	return err
+}
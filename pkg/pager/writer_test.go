@@ -0,0 +1,141 @@
+package pager
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type fakeManager struct {
+	started  int
+	stopped  int
+	startErr error
+	paged    bytes.Buffer
+}
+
+func (m *fakeManager) Enabled() bool { return true }
+
+func (m *fakeManager) Start() (io.WriteCloser, error) {
+	if m.startErr != nil {
+		return nil, m.startErr
+	}
+	m.started++
+	return nopWriteCloser{Writer: &m.paged}, nil
+}
+
+func (m *fakeManager) Stop() error {
+	m.stopped++
+	return nil
+}
+
+func TestWriterShortOutputBypassesPager(t *testing.T) {
+	m := &fakeManager{}
+	var out bytes.Buffer
+	w := NewWriter(m, &out, 5, 0)
+
+	_, _ = io.WriteString(w, "one\ntwo\n")
+	if out.Len() != 0 {
+		t.Fatalf("output written before Close: %q", out.String())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if out.String() != "one\ntwo\n" || m.started != 0 {
+		t.Fatalf("out = %q, pager started %d times", out.String(), m.started)
+	}
+}
+
+func TestWriterPagesOutputTallerThanScreen(t *testing.T) {
+	m := &fakeManager{}
+	var out bytes.Buffer
+	w := NewWriter(m, &out, 3, 0)
+
+	for i := 0; i < 4; i++ {
+		_, _ = io.WriteString(w, "line\n")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if m.started != 1 || m.stopped != 1 {
+		t.Fatalf("pager started %d, stopped %d times", m.started, m.stopped)
+	}
+	if got := strings.Count(m.paged.String(), "line\n"); got != 4 || out.Len() != 0 {
+		t.Fatalf("paged %d lines, direct output %q", got, out.String())
+	}
+}
+
+func TestWriterFallsBackWhenPagerFails(t *testing.T) {
+	m := &fakeManager{startErr: errors.New("no less")}
+	var out bytes.Buffer
+	w := NewWriter(m, &out, 2, 0)
+
+	_, _ = io.WriteString(w, "a\nb\nc\n")
+	_, _ = io.WriteString(w, "d\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if out.String() != "a\nb\nc\nd\n" {
+		t.Fatalf("out = %q", out.String())
+	}
+}
+
+func TestWriterKeepsStderrInOrder(t *testing.T) {
+	m := &fakeManager{}
+	var out bytes.Buffer
+	w := NewWriter(m, &out, 3, 0)
+	errOut := w.Stderr(&out)
+
+	// Diagnostics before any output leave paging possible.
+	_, _ = io.WriteString(errOut, "fetching\n")
+	_, _ = io.WriteString(w, "row\n")
+	_, _ = io.WriteString(errOut, "! warning\n")
+	_, _ = io.WriteString(w, "a\nb\nc\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if out.String() != "fetching\nrow\n! warning\na\nb\nc\n" || m.started != 0 {
+		t.Fatalf("out = %q, pager started %d times", out.String(), m.started)
+	}
+
+	m = &fakeManager{}
+	out.Reset()
+	w = NewWriter(m, &out, 2, 0)
+	errOut = w.Stderr(&out)
+	_, _ = io.WriteString(w, "a\nb\n")
+	_, _ = io.WriteString(errOut, "! warning\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if m.paged.String() != "a\nb\n! warning\n" || out.Len() != 0 {
+		t.Fatalf("paged %q, direct output %q", m.paged.String(), out.String())
+	}
+}
+
+func TestWriterCountsWrappedRows(t *testing.T) {
+	m := &fakeManager{}
+	var out bytes.Buffer
+	w := NewWriter(m, &out, 3, 10)
+
+	// Two lines, but the first wraps onto two rows of a 10-column screen;
+	// colour codes take no room.
+	_, _ = io.WriteString(w, "\x1b[1m"+strings.Repeat("x", 15)+"\x1b[0m\n")
+	if m.started != 0 {
+		t.Fatal("pager started before the output filled the screen")
+	}
+	_, _ = io.WriteString(w, "short\n")
+	if m.started != 1 {
+		t.Fatalf("pager started %d times, want once the rows exceed the screen", m.started)
+	}
+
+	m = &fakeManager{}
+	w = NewWriter(m, &out, 3, 10)
+	_, _ = io.WriteString(w, "\x1b[31m"+strings.Repeat("y", 10)+"\x1b[0m\nshort\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if m.started != 0 {
+		t.Fatal("a line exactly as wide as the screen must not wrap")
+	}
+}
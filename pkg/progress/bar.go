@@ -0,0 +1,192 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+// Bar reports the progress of a byte transfer. Bytes written to it count as
+// transferred; NewReader and NewWriter wire it into uploads and downloads.
+type Bar interface {
+	io.Writer
+	// SetTotal sets the expected size; zero or less means unknown.
+	SetTotal(total int64)
+	// Reset restarts the count, e.g. when a transfer is retried.
+	Reset()
+	Stop(msg string)
+	Fail(msg string)
+}
+
+// NewBar returns a progress bar on stderr for a transfer of total bytes
+// (zero or less when unknown). Without a TTY it falls back to the spinner's
+// line-based output; with an unknown size it spins while counting bytes.
+func NewBar(ios *iostreams.IOStreams, label string, total int64) Bar {
+	if ios == nil || !ios.IsStderrTTY() {
+		s := NewSpinner(ios)
+		s.Start(label)
+		return &spinnerBar{spinner: s}
+	}
+	return &ttyBar{out: ios.ErrOut, label: label, total: total, started: time.Now(), now: time.Now}
+}
+
+type spinnerBar struct {
+	spinner Spinner
+}
+
+func (b *spinnerBar) Write(p []byte) (int, error) { return len(p), nil }
+func (b *spinnerBar) SetTotal(int64)              {}
+func (b *spinnerBar) Reset()                      {}
+func (b *spinnerBar) Stop(msg string)             { b.spinner.Stop(msg) }
+func (b *spinnerBar) Fail(msg string)             { b.spinner.Fail(msg) }
+
+// renderInterval throttles redraws so fast transfers do not flood the
+// terminal.
+const renderInterval = 100 * time.Millisecond
+
+const barWidth = 24
+
+type ttyBar struct {
+	out   io.Writer
+	label string
+	now   func() time.Time
+
+	mu       sync.Mutex
+	total    int64
+	current  int64
+	started  time.Time
+	rendered time.Time
+	frame    int
+}
+
+func (b *ttyBar) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current += int64(len(p))
+	if now := b.now(); now.Sub(b.rendered) >= renderInterval {
+		b.rendered = now
+		b.render(now)
+	}
+	return len(p), nil
+}
+
+func (b *ttyBar) SetTotal(total int64) {
+	b.mu.Lock()
+	b.total = total
+	b.mu.Unlock()
+}
+
+func (b *ttyBar) Reset() {
+	b.mu.Lock()
+	b.current = 0
+	b.started = b.now()
+	b.mu.Unlock()
+}
+
+func (b *ttyBar) Stop(msg string) { b.end("[OK]", msg) }
+func (b *ttyBar) Fail(msg string) { b.end("[ERR]", msg) }
+
+func (b *ttyBar) end(prefix, msg string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, _ = fmt.Fprint(b.out, "\r\x1b[K")
+	if msg != "" {
+		_, _ = fmt.Fprintf(b.out, "%s %s\n", prefix, msg)
+	}
+}
+
+func (b *ttyBar) render(now time.Time) {
+	_, _ = fmt.Fprintf(b.out, "\r%s\x1b[K", b.line(now))
+}
+
+// line formats the current state: a bar with percentage, size, throughput
+// and ETA when the total is known, otherwise a spinner with the byte count.
+func (b *ttyBar) line(now time.Time) string {
+	elapsed := now.Sub(b.started)
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(b.current) / elapsed.Seconds()
+	}
+	speed := ""
+	if rate > 0 {
+		speed = fmt.Sprintf("  %s/s", formatBytes(int64(rate)))
+	}
+
+	if b.total <= 0 {
+		frames := []rune{'|', '/', '-', '\\'}
+		b.frame = (b.frame + 1) % len(frames)
+		return fmt.Sprintf("%c %s  %s%s", frames[b.frame], b.label, formatBytes(b.current), speed)
+	}
+
+	current := min(b.current, b.total)
+	fraction := float64(current) / float64(b.total)
+	filled := int(fraction * barWidth)
+	eta := ""
+	if rate > 0 && current < b.total {
+		remaining := time.Duration(float64(b.total-current) / rate * float64(time.Second))
+		eta = "  ETA " + remaining.Round(time.Second).String()
+	}
+	return fmt.Sprintf("%s [%s%s] %3d%%  %s / %s%s%s",
+		b.label,
+		strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled),
+		int(fraction*100),
+		formatBytes(current), formatBytes(b.total), speed, eta)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// NewWriter returns a writer that copies to w and counts the bytes on bar.
+// It also takes the response size from the HTTP client (see
+// httpx.ContentLengthReceiver), so downloads learn their total.
+func NewWriter(w io.Writer, bar Bar) io.Writer {
+	return &barWriter{w: w, bar: bar}
+}
+
+type barWriter struct {
+	w   io.Writer
+	bar Bar
+}
+
+func (b *barWriter) Write(p []byte) (int, error) {
+	n, err := b.w.Write(p)
+	_, _ = b.bar.Write(p[:n])
+	return n, err
+}
+
+// SetContentLength implements httpx.ContentLengthReceiver.
+func (b *barWriter) SetContentLength(n int64) {
+	b.bar.SetTotal(n)
+}
+
+// NewReader returns rc with every byte read counted on bar, for uploads.
+func NewReader(rc io.ReadCloser, bar Bar) io.ReadCloser {
+	return &barReader{rc: rc, bar: bar}
+}
+
+type barReader struct {
+	rc  io.ReadCloser
+	bar Bar
+}
+
+func (b *barReader) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	_, _ = b.bar.Write(p[:n])
+	return n, err
+}
+
+func (b *barReader) Close() error { return b.rc.Close() }
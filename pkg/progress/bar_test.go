@@ -0,0 +1,55 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBarLineWithKnownTotal(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &ttyBar{out: io.Discard, label: "Downloading app.tar.gz", total: 4 << 20, started: start, now: func() time.Time { return start }}
+	b.current = 1 << 20
+
+	line := b.line(start.Add(time.Second))
+	for _, want := range []string{"Downloading app.tar.gz", " 25%", "1.0 MiB / 4.0 MiB", "1.0 MiB/s", "ETA 3s", "[######------------------]"} {
+		if !strings.Contains(line, want) {
+			t.Fatalf("expected %q in %q", want, line)
+		}
+	}
+}
+
+func TestBarLineWithUnknownTotalSpins(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &ttyBar{out: io.Discard, label: "Uploading notes.txt", started: start, now: func() time.Time { return start }}
+	b.current = 2048
+
+	line := b.line(start.Add(2 * time.Second))
+	if strings.Contains(line, "%") || strings.Contains(line, "ETA") {
+		t.Fatalf("unknown size should not show a percentage or ETA: %q", line)
+	}
+	if !strings.Contains(line, "Uploading notes.txt  2.0 KiB  1.0 KiB/s") {
+		t.Fatalf("unexpected line %q", line)
+	}
+}
+
+func TestNewWriterCountsBytesAndTakesContentLength(t *testing.T) {
+	var out bytes.Buffer
+	b := &ttyBar{out: io.Discard, now: time.Now, started: time.Now()}
+	w := NewWriter(&out, b)
+
+	w.(interface{ SetContentLength(int64) }).SetContentLength(10)
+	_, _ = io.WriteString(w, "hello")
+
+	if out.String() != "hello" || b.current != 5 || b.total != 10 {
+		t.Fatalf("out=%q current=%d total=%d", out.String(), b.current, b.total)
+	}
+}
+
+func TestBarFallsBackWithoutTTY(t *testing.T) {
+	if _, ok := NewBar(nil, "Downloading", 10).(*spinnerBar); !ok {
+		t.Fatal("expected the spinner fallback without a terminal")
+	}
+}
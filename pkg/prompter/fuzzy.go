@@ -0,0 +1,83 @@
+package prompter
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyScore reports whether every rune of query appears in candidate in
+// order (case-insensitively) and how well it matches: consecutive runes and
+// matches at word boundaries score higher, leading gaps lower.
+func fuzzyScore(query, candidate string) (int, bool) {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	if len(q) == 0 {
+		return 0, true
+	}
+	c := []rune(candidate)
+
+	score, qi, streak := 0, 0, 0
+	first := -1
+	for ci := 0; ci < len(c) && qi < len(q); ci++ {
+		if unicode.ToLower(c[ci]) != q[qi] {
+			streak = 0
+			continue
+		}
+		if first < 0 {
+			first = ci
+		}
+		score++
+		if streak > 0 {
+			score += 4 * streak
+		}
+		if ci == 0 || isBoundary(c[ci-1], c[ci]) {
+			score += 6
+		}
+		streak++
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score - min(first, 10), true
+}
+
+func isBoundary(prev, cur rune) bool {
+	switch prev {
+	case '/', '-', '_', '.', ' ', ':', '@':
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// fuzzyFilter returns the indexes of options matching query, best first. An
+// empty query keeps every option in its original order.
+func fuzzyFilter(query string, options []string) []int {
+	if strings.TrimSpace(query) == "" {
+		indexes := make([]int, len(options))
+		for i := range options {
+			indexes[i] = i
+		}
+		return indexes
+	}
+
+	type match struct{ index, score int }
+	var matches []match
+	for i, option := range options {
+		if score, ok := fuzzyScore(query, option); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return len(options[matches[a].index]) < len(options[matches[b].index])
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+	return indexes
+}
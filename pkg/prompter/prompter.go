@@ -16,6 +16,12 @@ type Interface interface {
 	Input(prompt, defaultValue string) (string, error)
 	Password(prompt string) (string, error)
 	Confirm(prompt string, defaultYes bool) (bool, error)
+	// Select asks for one of options with a type-to-filter fuzzy finder and
+	// returns its index; defaultIndex (or -1) is highlighted initially.
+	Select(prompt string, options []string, defaultIndex int) (int, error)
+	// MultiSelect asks for any number of options and returns their indexes
+	// in order; defaults are marked initially.
+	MultiSelect(prompt string, options []string, defaults []int) ([]int, error)
 }
 
 type system struct {
@@ -101,8 +107,10 @@ func (p *system) Confirm(prompt string, defaultYes bool) (bool, error) {
 		suffix = "[y/N]"
 	}
 
+	// The question goes to stderr with the retry hint, so confirming a
+	// command whose results are piped leaves stdout clean.
 	for {
-		if _, err := fmt.Fprintf(p.ios.Out, "%s %s: ", prompt, suffix); err != nil {
+		if _, err := fmt.Fprintf(p.ios.ErrOut, "%s %s: ", prompt, suffix); err != nil {
 			return false, err
 		}
 
@@ -0,0 +1,367 @@
+package prompter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+// ErrCancelled is returned when the user aborts a selection with Esc or
+// Ctrl-C.
+var ErrCancelled = errors.New("prompt cancelled")
+
+// maxVisible caps how many options a selection shows at once.
+const maxVisible = 10
+
+// selector holds the state of a fuzzy selection independent of the terminal.
+type selector struct {
+	options []string
+	multi   bool
+
+	query   []rune
+	matches []int
+	cursor  int
+	offset  int
+	chosen  map[int]bool
+}
+
+func newSelector(options []string, multi bool, preselected []int) *selector {
+	s := &selector{options: options, multi: multi, chosen: make(map[int]bool)}
+	s.filter()
+	for _, i := range preselected {
+		if i < 0 || i >= len(options) {
+			continue
+		}
+		if multi {
+			s.chosen[i] = true
+			continue
+		}
+		s.move(i)
+	}
+	return s
+}
+
+func (s *selector) filter() {
+	s.matches = fuzzyFilter(string(s.query), s.options)
+	s.cursor, s.offset = 0, 0
+}
+
+func (s *selector) typeRune(r rune) {
+	s.query = append(s.query, r)
+	s.filter()
+}
+
+func (s *selector) backspace() {
+	if len(s.query) == 0 {
+		return
+	}
+	s.query = s.query[:len(s.query)-1]
+	s.filter()
+}
+
+func (s *selector) move(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.cursor = (s.cursor + delta + len(s.matches)) % len(s.matches)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+maxVisible {
+		s.offset = s.cursor - maxVisible + 1
+	}
+}
+
+func (s *selector) toggle() {
+	if len(s.matches) == 0 {
+		return
+	}
+	i := s.matches[s.cursor]
+	s.chosen[i] = !s.chosen[i]
+}
+
+// result returns the chosen option indexes in their original order. A
+// multi-select with nothing marked takes the highlighted option.
+func (s *selector) result() ([]int, bool) {
+	var picked []int
+	if s.multi {
+		for i, ok := range s.chosen {
+			if ok {
+				picked = append(picked, i)
+			}
+		}
+		sort.Ints(picked)
+	}
+	if len(picked) == 0 {
+		if len(s.matches) == 0 {
+			return nil, false
+		}
+		picked = []int{s.matches[s.cursor]}
+	}
+	return picked, true
+}
+
+// render draws the prompt and visible options, returning the line count.
+func (s *selector) render(w io.Writer, prompt string) int {
+	hint := "type to filter, ↑/↓ to move, Enter to choose"
+	if s.multi {
+		hint = "type to filter, ↑/↓ to move, Space to mark, Enter to accept"
+	}
+	_, _ = fmt.Fprintf(w, "? %s %s\x1b[K\r\n", prompt, string(s.query))
+	if len(s.query) == 0 {
+		_, _ = fmt.Fprintf(w, "  [%s]\x1b[K\r\n", hint)
+	} else {
+		_, _ = fmt.Fprintf(w, "  [%d of %d match]\x1b[K\r\n", len(s.matches), len(s.options))
+	}
+	lines := 2
+
+	end := min(s.offset+maxVisible, len(s.matches))
+	for pos := s.offset; pos < end; pos++ {
+		i := s.matches[pos]
+		marker := "  "
+		if pos == s.cursor {
+			marker = "> "
+		}
+		box := ""
+		if s.multi {
+			box = "[ ] "
+			if s.chosen[i] {
+				box = "[x] "
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s%s%s\x1b[K\r\n", marker, box, s.options[i])
+		lines++
+	}
+	return lines
+}
+
+type key int
+
+const (
+	keyRune key = iota
+	keyEnter
+	keyBackspace
+	keyUp
+	keyDown
+	keyCancel
+	keyNone
+)
+
+func readKey(r *bufio.Reader) (key, rune, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return keyNone, 0, err
+	}
+	switch ch {
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case 127, 8:
+		return keyBackspace, 0, nil
+	case 3:
+		return keyCancel, 0, nil
+	case 16: // Ctrl-P
+		return keyUp, 0, nil
+	case 14: // Ctrl-N
+		return keyDown, 0, nil
+	case 27:
+		if r.Buffered() == 0 {
+			return keyCancel, 0, nil
+		}
+		if next, _ := r.ReadByte(); next != '[' && next != 'O' {
+			return keyNone, 0, nil
+		}
+		switch code, _ := r.ReadByte(); code {
+		case 'A':
+			return keyUp, 0, nil
+		case 'B':
+			return keyDown, 0, nil
+		}
+		return keyNone, 0, nil
+	}
+	if unicode.IsPrint(ch) {
+		return keyRune, ch, nil
+	}
+	return keyNone, 0, nil
+}
+
+func (p *system) Select(prompt string, options []string, defaultIndex int) (int, error) {
+	picked, err := p.choose(prompt, options, false, []int{defaultIndex})
+	if err != nil {
+		return -1, err
+	}
+	return picked[0], nil
+}
+
+func (p *system) MultiSelect(prompt string, options []string, defaults []int) ([]int, error) {
+	return p.choose(prompt, options, true, defaults)
+}
+
+func (p *system) choose(prompt string, options []string, multi bool, preselected []int) ([]int, error) {
+	if p.ios == nil || !p.ios.CanPrompt() {
+		return nil, errors.New("interactive prompts require a TTY")
+	}
+	if len(options) == 0 {
+		return nil, errors.New("no options to choose from")
+	}
+
+	if in, ok := p.ios.In.(*os.File); ok && term.IsTerminal(int(in.Fd())) {
+		return p.chooseInteractive(in, prompt, newSelector(options, multi, preselected))
+	}
+	return p.chooseByLine(prompt, options, multi, preselected)
+}
+
+// chooseInteractive runs the fuzzy finder with the terminal in raw mode,
+// redrawing in place after every key.
+func (p *system) chooseInteractive(in *os.File, prompt string, s *selector) ([]int, error) {
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = term.Restore(int(in.Fd()), state) }()
+
+	out := p.ios.Out
+	r := bufio.NewReader(in)
+	drawn := 0
+	clear := func() {
+		if drawn > 0 {
+			_, _ = fmt.Fprintf(out, "\x1b[%dA\r\x1b[J", drawn)
+		}
+	}
+
+	for {
+		clear()
+		drawn = s.render(out, prompt)
+
+		k, ch, err := readKey(r)
+		if err != nil {
+			clear()
+			return nil, err
+		}
+		switch k {
+		case keyRune:
+			if ch == ' ' && s.multi {
+				s.toggle()
+			} else {
+				s.typeRune(ch)
+			}
+		case keyBackspace:
+			s.backspace()
+		case keyUp:
+			s.move(-1)
+		case keyDown:
+			s.move(1)
+		case keyCancel:
+			clear()
+			return nil, ErrCancelled
+		case keyEnter:
+			picked, ok := s.result()
+			if !ok {
+				continue
+			}
+			clear()
+			names := make([]string, len(picked))
+			for i, idx := range picked {
+				names[i] = s.options[idx]
+			}
+			_, _ = fmt.Fprintf(out, "? %s %s\r\n", prompt, strings.Join(names, ", "))
+			return picked, nil
+		}
+	}
+}
+
+// chooseByLine is the fallback for prompt-capable input that is not a raw
+// terminal: it lists numbered options and reads either numbers or a filter.
+func (p *system) chooseByLine(prompt string, options []string, multi bool, preselected []int) ([]int, error) {
+	r, err := p.reader()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := fuzzyFilter("", options)
+	for {
+		shown := matches[:min(len(matches), 20)]
+		for n, i := range shown {
+			if _, err := fmt.Fprintf(p.ios.Out, "%3d. %s\n", n+1, options[i]); err != nil {
+				return nil, err
+			}
+		}
+		if rest := len(matches) - len(shown); rest > 0 {
+			_, _ = fmt.Fprintf(p.ios.Out, "     ... %d more; type text to filter\n", rest)
+		}
+
+		question := "Choose a number or type to filter"
+		if multi {
+			question = "Choose numbers (e.g. 1,3) or type to filter"
+		}
+		if _, err := fmt.Fprintf(p.ios.Out, "%s (%s): ", prompt, question); err != nil {
+			return nil, err
+		}
+
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			if defaults := validIndexes(preselected, len(options)); len(defaults) > 0 {
+				return defaults, nil
+			}
+			continue
+		}
+		if picked, ok := parseChoices(line, shown, multi); ok {
+			return picked, nil
+		}
+
+		filtered := fuzzyFilter(line, options)
+		if len(filtered) == 0 {
+			_, _ = fmt.Fprintf(p.ios.ErrOut, "No options match %q.\n", line)
+			continue
+		}
+		if len(filtered) == 1 && !multi {
+			return filtered, nil
+		}
+		matches = filtered
+	}
+}
+
+// parseChoices reads 1-based numbers separated by commas or spaces against
+// the listed options.
+func parseChoices(line string, shown []int, multi bool) ([]int, bool) {
+	fields := strings.FieldsFunc(line, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	if len(fields) == 0 || (!multi && len(fields) > 1) {
+		return nil, false
+	}
+	seen := make(map[int]bool)
+	var picked []int
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > len(shown) {
+			return nil, false
+		}
+		if i := shown[n-1]; !seen[i] {
+			seen[i] = true
+			picked = append(picked, i)
+		}
+	}
+	sort.Ints(picked)
+	return picked, true
+}
+
+func validIndexes(indexes []int, count int) []int {
+	var valid []int
+	for _, i := range indexes {
+		if i >= 0 && i < count {
+			valid = append(valid, i)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,103 @@
+package prompter
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alessandro308/bitbucket-cli/pkg/iostreams"
+)
+
+func TestFuzzyFilterRanksBoundaryMatchesFirst(t *testing.T) {
+	options := []string{"platform-api", "feature/payment-api", "pay", "docs"}
+
+	got := fuzzyFilter("pa", options)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 matches, got %v", got)
+	}
+	if options[got[0]] != "pay" {
+		t.Fatalf("expected the prefix match first, got %q", options[got[0]])
+	}
+	if got := fuzzyFilter("fpa", options); len(got) != 1 || options[got[0]] != "feature/payment-api" {
+		t.Fatalf("unexpected matches for fpa: %v", got)
+	}
+	if got := fuzzyFilter("", options); !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("empty query should keep order, got %v", got)
+	}
+}
+
+func TestSelectorMultiSelect(t *testing.T) {
+	s := newSelector([]string{"alice", "bob", "carol", "dave"}, true, []int{3})
+	for _, r := range "ca" {
+		s.typeRune(r)
+	}
+	s.toggle()
+	s.backspace()
+	s.backspace()
+
+	got, ok := s.result()
+	if !ok || !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Fatalf("result = %v, want [2 3]", got)
+	}
+}
+
+func TestSelectorScrollsToDefault(t *testing.T) {
+	options := make([]string, 30)
+	for i := range options {
+		options[i] = strings.Repeat("x", i+1)
+	}
+	s := newSelector(options, false, []int{25})
+
+	var out bytes.Buffer
+	s.render(&out, "Pick")
+	if !strings.Contains(out.String(), "> "+options[25]) {
+		t.Fatalf("default option not highlighted:\n%s", out.String())
+	}
+	if got, _ := s.result(); got[0] != 25 {
+		t.Fatalf("result = %v, want 25", got)
+	}
+}
+
+func TestSelectByLineFiltersThenPicksNumber(t *testing.T) {
+	ios := &iostreams.IOStreams{
+		In:     io.NopCloser(strings.NewReader("main\n2\n")),
+		Out:    &bytes.Buffer{},
+		ErrOut: &bytes.Buffer{},
+	}
+	forceTTY(ios)
+
+	options := []string{"develop", "main", "release/main-2", "feature/x"}
+	got, err := New(ios).Select("Branch", options, -1)
+	if err != nil {
+		t.Fatalf("Select returned error: %v", err)
+	}
+	if options[got] != "release/main-2" {
+		t.Fatalf("picked %q, want release/main-2", options[got])
+	}
+}
+
+func TestMultiSelectByLineDefaults(t *testing.T) {
+	ios := &iostreams.IOStreams{
+		In:     io.NopCloser(strings.NewReader("\n")),
+		Out:    &bytes.Buffer{},
+		ErrOut: &bytes.Buffer{},
+	}
+	forceTTY(ios)
+
+	got, err := New(ios).MultiSelect("Reviewers", []string{"alice", "bob", "carol"}, []int{2, 0})
+	if err != nil {
+		t.Fatalf("MultiSelect returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{2, 0}) {
+		t.Fatalf("got %v, want defaults", got)
+	}
+}
+
+func TestSelectRequiresTTY(t *testing.T) {
+	ios := &iostreams.IOStreams{In: io.NopCloser(strings.NewReader("")), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	if _, err := New(ios).Select("Pick", []string{"a"}, 0); err == nil {
+		t.Fatal("expected an error without a TTY")
+	}
+}
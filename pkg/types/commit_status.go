@@ -9,3 +9,16 @@ type CommitStatus struct {
 	URL         string `json:"url"`
 	Description string `json:"description"`
 }
+
+// BuildStatusInput is the request body for publishing a build status. Cloud
+// and Data Center accept the same key, state, url, name and description
+// fields, so both clients send this type unchanged.
+type BuildStatusInput struct {
+	// Key identifies the build; publishing again with the same key replaces
+	// the previous status.
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}